@@ -18,6 +18,7 @@ import (
 	"hash"
 
 	"github.com/scionproto/scion/go/beacon_srv/internal/ifstate"
+	"github.com/scionproto/scion/go/beacon_srv/internal/staticinfo"
 	"github.com/scionproto/scion/go/lib/infra"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/spath"
@@ -42,6 +43,9 @@ type ExtenderConf struct {
 	IfidSize uint8
 	// GetMaxExpTime returns the maximum relative expiration time.
 	GetMaxExpTime func() spath.ExpTimeType
+	// StaticInfo is the static info configuration used to attach the static
+	// info extension to the AS entries. If nil, no extension is attached.
+	StaticInfo *staticinfo.Config
 	// task contains an identifier specific to the task that uses the extender.
 	task string
 }
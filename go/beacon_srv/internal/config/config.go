@@ -146,6 +146,10 @@ type BSConfig struct {
 	RevOverlap util.DurWrap
 	// Policies contains the policy files.
 	Policies Policies
+	// StaticInfoConfig contains the file path for the static info
+	// configuration. If this is the empty string, no static info extension
+	// is attached to the AS entries.
+	StaticInfoConfig string
 }
 
 // InitDefaults the default values for the durations that are equal to zero.
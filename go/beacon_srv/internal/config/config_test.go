@@ -65,6 +65,7 @@ func InitTestConfig(cfg *Config) {
 }
 
 func InitTestBSConfig(cfg *BSConfig) {
+	cfg.StaticInfoConfig = "test"
 	InitTestPolicies(&cfg.Policies)
 }
 
@@ -92,6 +93,7 @@ func CheckTestBSConfig(t *testing.T, cfg *BSConfig) {
 	assert.Equal(t, DefaultExpiredCheckInterval, cfg.ExpiredCheckInterval.Duration)
 	assert.Equal(t, DefaultRevTTL, cfg.RevTTL.Duration)
 	assert.Equal(t, DefaultRevOverlap, cfg.RevOverlap.Duration)
+	assert.Empty(t, cfg.StaticInfoConfig)
 	CheckTestPolicies(t, &cfg.Policies)
 }
 
@@ -42,6 +42,12 @@ RevTTL = "10s"
 # The amount of time before the expiry of an existing revocation where the revoker can reissue a
 # new revocation. (default 5s)
 RevOverlap = "5s"
+
+# The file path for the static info configuration. It contains the latency,
+# bandwidth, link type and geographic location of the interfaces, which are
+# attached to the AS entries. In case of the empty string, no static info is
+# attached. (default "")
+StaticInfoConfig = ""
 `
 
 const policiesSample = `
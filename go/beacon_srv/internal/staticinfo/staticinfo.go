@@ -0,0 +1,202 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package staticinfo contains the static metadata configuration of the AS that
+// is attached to the AS entries created by the beacon server.
+//
+// The configuration is a JSON file keyed by interface ID:
+//  {
+//    "Latency": {
+//      "1": {"Inter": "5ms", "Intra": {"2": "1ms", "3": "2ms"}}
+//    },
+//    "Bandwidth": {
+//      "1": {"Inter": 1000000, "Intra": {"2": 10000000}}
+//    },
+//    "LinkType": {"1": "direct", "2": "opennet"},
+//    "Geo": {
+//      "1": {"Latitude": 47.3769, "Longitude": 8.5417, "Address": "Zurich"}
+//    }
+//  }
+// Intra-AS values are symmetric, it is sufficient to specify them for one of
+// the two interfaces. Bandwidths are in Kbit/s.
+package staticinfo
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"sort"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// Latency contains the latencies of an interface.
+type Latency struct {
+	// Inter is the latency of the inter-AS link attached to the interface.
+	Inter util.DurWrap `json:"Inter"`
+	// Intra contains the latencies from the interface to the other interfaces
+	// of the AS.
+	Intra map[common.IFIDType]util.DurWrap `json:"Intra"`
+}
+
+// Bandwidth contains the bandwidths of an interface in Kbit/s.
+type Bandwidth struct {
+	// Inter is the bandwidth of the inter-AS link attached to the interface.
+	Inter uint64 `json:"Inter"`
+	// Intra contains the bandwidths from the interface to the other interfaces
+	// of the AS.
+	Intra map[common.IFIDType]uint64 `json:"Intra"`
+}
+
+// Geo is the geographic location of an interface.
+type Geo struct {
+	Latitude  float32 `json:"Latitude"`
+	Longitude float32 `json:"Longitude"`
+	Address   string  `json:"Address"`
+}
+
+// Config is the static info configuration of the AS.
+type Config struct {
+	Latency   map[common.IFIDType]Latency   `json:"Latency"`
+	Bandwidth map[common.IFIDType]Bandwidth `json:"Bandwidth"`
+	LinkType  map[common.IFIDType]string    `json:"LinkType"`
+	Geo       map[common.IFIDType]Geo       `json:"Geo"`
+}
+
+// ParseConfig parses the static info configuration in JSON format.
+func ParseConfig(b common.RawBytes) (*Config, error) {
+	cfg := &Config{}
+	if err := json.Unmarshal(b, cfg); err != nil {
+		return nil, common.NewBasicError("Unable to parse static info config", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// LoadConfig loads the static info configuration from a JSON file.
+func LoadConfig(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, common.NewBasicError("Unable to read static info config", err, "path", path)
+	}
+	return ParseConfig(b)
+}
+
+// Validate checks that all link types are known.
+func (cfg *Config) Validate() error {
+	for ifid, t := range cfg.LinkType {
+		if _, err := seg.ParseLinkType(t); err != nil {
+			return common.NewBasicError("Invalid link type", err, "ifid", ifid)
+		}
+	}
+	return nil
+}
+
+// Generate creates the static info extension for an AS entry with the given
+// egress interface. A nil config generates no extension.
+func (cfg *Config) Generate(egress common.IFIDType) *seg.StaticInfoExtn {
+	if cfg == nil {
+		return nil
+	}
+	ext := &seg.StaticInfoExtn{}
+	for _, ifid := range sortedIfids(cfg.Latency) {
+		l := seg.LatencyInfo{
+			IfID:  ifid,
+			Inter: micros(cfg.Latency[ifid].Inter.Duration),
+			Intra: micros(cfg.intraLatency(egress, ifid)),
+		}
+		if l.Inter != 0 || l.Intra != 0 {
+			ext.Latency = append(ext.Latency, l)
+		}
+	}
+	for _, ifid := range sortedIfids(cfg.Bandwidth) {
+		b := seg.BandwidthInfo{
+			IfID:  ifid,
+			Inter: cfg.Bandwidth[ifid].Inter,
+			Intra: cfg.intraBandwidth(egress, ifid),
+		}
+		if b.Inter != 0 || b.Intra != 0 {
+			ext.Bandwidth = append(ext.Bandwidth, b)
+		}
+	}
+	for _, ifid := range sortedIfids(cfg.LinkType) {
+		// The link types are validated when the config is loaded.
+		t, _ := seg.ParseLinkType(cfg.LinkType[ifid])
+		ext.LinkType = append(ext.LinkType, seg.LinkTypeInfo{IfID: ifid, LinkType: t})
+	}
+	for _, ifid := range sortedIfids(cfg.Geo) {
+		g := cfg.Geo[ifid]
+		ext.Geo = append(ext.Geo, seg.GeoInfo{
+			IfID:      ifid,
+			Latitude:  g.Latitude,
+			Longitude: g.Longitude,
+			Address:   g.Address,
+		})
+	}
+	return ext
+}
+
+func (cfg *Config) intraLatency(a, b common.IFIDType) time.Duration {
+	if a == 0 || a == b {
+		return 0
+	}
+	if d, ok := cfg.Latency[a].Intra[b]; ok {
+		return d.Duration
+	}
+	return cfg.Latency[b].Intra[a].Duration
+}
+
+func (cfg *Config) intraBandwidth(a, b common.IFIDType) uint64 {
+	if a == 0 || a == b {
+		return 0
+	}
+	if bw, ok := cfg.Bandwidth[a].Intra[b]; ok {
+		return bw
+	}
+	return cfg.Bandwidth[b].Intra[a]
+}
+
+func micros(d time.Duration) uint32 {
+	return uint32(d / time.Microsecond)
+}
+
+// sortedIfids returns the keys of the map in increasing order. m must be a map
+// keyed by interface ID.
+func sortedIfids(m interface{}) []common.IFIDType {
+	var ifids []common.IFIDType
+	switch v := m.(type) {
+	case map[common.IFIDType]Latency:
+		for ifid := range v {
+			ifids = append(ifids, ifid)
+		}
+	case map[common.IFIDType]Bandwidth:
+		for ifid := range v {
+			ifids = append(ifids, ifid)
+		}
+	case map[common.IFIDType]string:
+		for ifid := range v {
+			ifids = append(ifids, ifid)
+		}
+	case map[common.IFIDType]Geo:
+		for ifid := range v {
+			ifids = append(ifids, ifid)
+		}
+	}
+	sort.Slice(ifids, func(i, j int) bool { return ifids[i] < ifids[j] })
+	return ifids
+}
@@ -0,0 +1,82 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staticinfo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/beacon_srv/internal/staticinfo"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cfg, err := staticinfo.LoadConfig("testdata/staticinfo.json")
+	require.NoError(t, err)
+	assert.Len(t, cfg.Latency, 3)
+	assert.Len(t, cfg.Bandwidth, 2)
+	assert.Equal(t, "opennet", cfg.LinkType[2])
+	assert.Equal(t, "Bern", cfg.Geo[2].Address)
+}
+
+func TestParseConfigInvalidLinkType(t *testing.T) {
+	_, err := staticinfo.ParseConfig([]byte(`{"LinkType": {"1": "wireless"}}`))
+	assert.Error(t, err)
+}
+
+func TestGenerate(t *testing.T) {
+	cfg, err := staticinfo.LoadConfig("testdata/staticinfo.json")
+	require.NoError(t, err)
+
+	t.Run("egress 2", func(t *testing.T) {
+		ext := cfg.Generate(2)
+		expected := &seg.StaticInfoExtn{
+			Latency: []seg.LatencyInfo{
+				{IfID: 1, Inter: 5000, Intra: 1000},
+				{IfID: 2, Inter: 10000},
+				{IfID: 3, Inter: 1000, Intra: 3000},
+			},
+			Bandwidth: []seg.BandwidthInfo{
+				{IfID: 1, Inter: 1000000, Intra: 10000000},
+				{IfID: 2, Inter: 400000},
+			},
+			LinkType: []seg.LinkTypeInfo{
+				{IfID: 1, LinkType: seg.LinkTypeDirect},
+				{IfID: 2, LinkType: seg.LinkTypeOpenNet},
+				{IfID: 3, LinkType: seg.LinkTypeMultiHop},
+			},
+			Geo: []seg.GeoInfo{
+				{IfID: 1, Latitude: 47.3769, Longitude: 8.5417, Address: "Zurich"},
+				{IfID: 2, Latitude: 46.948, Longitude: 7.4474, Address: "Bern"},
+			},
+		}
+		assert.Equal(t, expected, ext)
+	})
+	t.Run("terminated segment has no intra values", func(t *testing.T) {
+		ext := cfg.Generate(0)
+		for _, l := range ext.Latency {
+			assert.Zero(t, l.Intra)
+		}
+		for _, b := range ext.Bandwidth {
+			assert.Zero(t, b.Intra)
+		}
+	})
+	t.Run("nil config", func(t *testing.T) {
+		var cfg *staticinfo.Config
+		assert.Nil(t, cfg.Generate(1))
+	})
+}
@@ -39,6 +39,7 @@ import (
 	"github.com/scionproto/scion/go/beacon_srv/internal/keepalive"
 	"github.com/scionproto/scion/go/beacon_srv/internal/onehop"
 	"github.com/scionproto/scion/go/beacon_srv/internal/revocation"
+	"github.com/scionproto/scion/go/beacon_srv/internal/staticinfo"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/discovery"
@@ -140,6 +141,8 @@ func realMain() int {
 			Address:  cfg.QUIC.Address,
 			CertFile: cfg.QUIC.CertFile,
 			KeyFile:  cfg.QUIC.KeyFile,
+			UsePKI:   cfg.QUIC.UsePKI,
+			KeyDir:   filepath.Join(cfg.General.ConfigDir, "keys"),
 		},
 		SVCResolutionFraction: cfg.QUIC.ResolutionFraction,
 		TrustStore:            trustStore,
@@ -177,6 +180,12 @@ func realMain() int {
 		}),
 	)
 
+	env.StatusPages{
+		Config:   &cfg,
+		ID:       cfg.General.ID,
+		Features: cfg.Features,
+		Health:   env.HealthChecks(env.TopologyHealth, env.DispatcherHealth("")),
+	}.Register()
 	cfg.Metrics.StartPrometheus()
 	go func() {
 		defer log.LogPanicAndExit()
@@ -218,6 +227,12 @@ func realMain() int {
 		log.Crit(infraenv.ErrAppUnableToInitMessenger.Error(), "err", err)
 		return 1
 	}
+	if cfg.BS.StaticInfoConfig != "" {
+		if tasks.staticInfo, err = staticinfo.LoadConfig(cfg.BS.StaticInfoConfig); err != nil {
+			log.Crit("Unable to load static info config", "err", err)
+			return 1
+		}
+	}
 	msgr.UpdateSigner(signer, []infra.MessageType{infra.Seg})
 
 	if tasks.genMac, err = macGenFactory(); err != nil {
@@ -225,7 +240,7 @@ func realMain() int {
 		return 1
 	}
 	discoRunners, err := idiscovery.StartRunners(cfg.Discovery, discovery.Full,
-		idiscovery.TopoHandlers{}, nil, "bs")
+		idiscovery.TopoHandlers{}, trustStore.NewVerifier(), nil, "bs")
 	if err != nil {
 		log.Crit("Unable to start topology fetcher", "err", err)
 		return 1
@@ -268,6 +283,7 @@ type periodicTasks struct {
 	topoProvider    itopo.ProviderI
 	allowIsdLoop    bool
 	addressRewriter *messenger.AddressRewriter
+	staticInfo      *staticinfo.Config
 
 	keepalive  *periodic.Runner
 	originator *periodic.Runner
@@ -388,6 +404,7 @@ func (t *periodicTasks) startOriginator(a *net.UDPAddr) (*periodic.Runner, error
 			MTU:           topo.MTU(),
 			Signer:        signer,
 			GetMaxExpTime: maxExpTimeFactory(t.store, beacon.PropPolicy),
+			StaticInfo:    t.staticInfo,
 		},
 		Period: cfg.BS.OriginationInterval.Duration,
 	}.New()
@@ -424,6 +441,7 @@ func (t *periodicTasks) startPropagator(a *net.UDPAddr) (*periodic.Runner, error
 			MTU:           topo.MTU(),
 			Signer:        signer,
 			GetMaxExpTime: maxExpTimeFactory(t.store, beacon.PropPolicy),
+			StaticInfo:    t.staticInfo,
 		},
 		Period: cfg.BS.PropagationInterval.Duration,
 	}.New()
@@ -475,6 +493,7 @@ func (t *periodicTasks) startRegistrar(topo itopo.Topology, segType proto.PathSe
 			MTU:           topo.MTU(),
 			Signer:        signer,
 			GetMaxExpTime: maxExpTimeFactory(t.store, policyType),
+			StaticInfo:    t.staticInfo,
 		},
 	}.New()
 	if err != nil {
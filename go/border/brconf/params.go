@@ -21,6 +21,7 @@ import (
 	"github.com/scionproto/scion/go/lib/config"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/infra/modules/idiscovery"
+	"github.com/scionproto/scion/go/lib/serrors"
 )
 
 var _ config.Config = (*Config)(nil)
@@ -110,6 +111,15 @@ type Discovery struct {
 	AllowSemiMutable bool
 }
 
+// Validate validates the discovery configuration. The border router does not
+// have access to the trust store, thus it cannot verify signed topologies.
+func (cfg *Discovery) Validate() error {
+	if cfg.Static.Signed || cfg.Dynamic.Signed {
+		return serrors.New("Signed topologies are not supported by the border router")
+	}
+	return cfg.Config.Validate()
+}
+
 func (cfg *Discovery) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
 	config.WriteString(dst, discoverySample)
 	cfg.Config.Sample(dst, path, ctx)
@@ -20,6 +20,7 @@ import (
 
 	"github.com/BurntSushi/toml"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/env/envtest"
 	"github.com/scionproto/scion/go/lib/infra/modules/idiscovery/idiscoverytest"
@@ -66,3 +67,11 @@ func CheckTestBRConfig(t *testing.T, cfg *BR) {
 	assert.False(t, cfg.Profile)
 	assert.Equal(t, FailActionFatal, cfg.RollbackFailAction)
 }
+
+func TestDiscoveryValidateSigned(t *testing.T) {
+	var cfg Discovery
+	cfg.InitDefaults()
+	require.NoError(t, cfg.Validate())
+	cfg.Dynamic.Signed = true
+	assert.Error(t, cfg.Validate())
+}
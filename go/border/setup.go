@@ -32,11 +32,13 @@ import (
 	"github.com/scionproto/scion/go/border/rpkt"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/discovery"
+	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/fatal"
 	"github.com/scionproto/scion/go/lib/infra/modules/idiscovery"
 	"github.com/scionproto/scion/go/lib/infra/modules/itopo"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/ringbuf"
+	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/topology"
 	"github.com/scionproto/scion/go/proto"
 )
@@ -88,10 +90,24 @@ func (r *Router) setup() error {
 	if err = r.clearCapabilities(); err != nil {
 		return err
 	}
+	env.StatusPages{
+		Config:   &cfg,
+		ID:       cfg.General.ID,
+		Features: cfg.Features,
+		Health:   env.HealthChecks(env.TopologyHealth, contextHealth),
+	}.Register()
 	cfg.Metrics.StartPrometheus()
 	return nil
 }
 
+// contextHealth fails if the router context is not set up.
+func contextHealth() error {
+	if rctx.Get() == nil {
+		return serrors.New("router context not set up")
+	}
+	return nil
+}
+
 // clearCapabilities drops unnecessary capabilities after startup
 func (r *Router) clearCapabilities() error {
 	caps, err := capability.NewPid(0)
@@ -295,7 +311,7 @@ func (r *Router) startDiscovery() error {
 		Dynamic: r.setupCtxFromDynamic,
 	}
 	_, err = idiscovery.StartRunners(cfg.Discovery.Config, discovery.Full,
-		handlers, client, "border")
+		handlers, nil, client, "border")
 	if err != nil {
 		return common.NewBasicError("Unable to start discovery runners", err)
 	}
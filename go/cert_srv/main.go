@@ -94,6 +94,12 @@ func realMain() int {
 	}()
 	// Cleanup when the CS exits.
 	defer stop()
+	env.StatusPages{
+		Config:   &cfg,
+		ID:       cfg.General.ID,
+		Features: cfg.Features,
+		Health:   env.HealthChecks(env.TopologyHealth, env.DispatcherHealth("")),
+	}.Register()
 	cfg.Metrics.StartPrometheus()
 	select {
 	case <-fatal.ShutdownChan():
@@ -157,7 +163,7 @@ func startReissRunner() {
 func startDiscovery() {
 	var err error
 	discRunners, err = idiscovery.StartRunners(cfg.Discovery, discovery.Full,
-		idiscovery.TopoHandlers{}, nil, "cs")
+		idiscovery.TopoHandlers{}, state.Store.NewVerifier(), nil, "cs")
 	if err != nil {
 		fatal.Fatal(common.NewBasicError("Unable to start dynamic topology fetcher", err))
 	}
@@ -172,6 +172,8 @@ func setMessenger(cfg *config.Config, router snet.Router) error {
 			Address:  cfg.QUIC.Address,
 			CertFile: cfg.QUIC.CertFile,
 			KeyFile:  cfg.QUIC.KeyFile,
+			UsePKI:   cfg.QUIC.UsePKI,
+			KeyDir:   filepath.Join(cfg.General.ConfigDir, "keys"),
 		},
 		SVCResolutionFraction: cfg.QUIC.ResolutionFraction,
 		TrustStore:            state.Store,
@@ -0,0 +1,144 @@
+// Copyright 2019 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package config contains the configuration of the discovery service.
+package config
+
+import (
+	"io"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/config"
+	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/truststorage"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+var (
+	// DefaultAdminAddr is the default address of the admin API.
+	DefaultAdminAddr = "127.0.0.1:30490"
+	// DefaultReloadInterval is the default interval between two checks
+	// whether the topology file has changed.
+	DefaultReloadInterval = time.Second
+	// DefaultACL is the default list of networks that are allowed to fetch
+	// the full topology.
+	DefaultACL = []string{"127.0.0.0/8", "::1/128"}
+)
+
+var _ config.Config = (*Config)(nil)
+
+type Config struct {
+	General env.General
+	Logging env.Logging
+	Metrics env.Metrics
+	TrustDB truststorage.TrustDBConf
+	DS      DSConfig
+}
+
+func (cfg *Config) InitDefaults() {
+	config.InitAll(
+		&cfg.General,
+		&cfg.Logging,
+		&cfg.Metrics,
+		&cfg.TrustDB,
+		&cfg.DS,
+	)
+}
+
+func (cfg *Config) Validate() error {
+	return config.ValidateAll(
+		&cfg.General,
+		&cfg.Logging,
+		&cfg.Metrics,
+		&cfg.TrustDB,
+		&cfg.DS,
+	)
+}
+
+func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
+	config.WriteSample(dst, path, config.CtxMap{config.ID: idSample},
+		&cfg.General,
+		&cfg.Logging,
+		&cfg.Metrics,
+		&cfg.TrustDB,
+		&cfg.DS,
+	)
+}
+
+func (cfg *Config) ConfigName() string {
+	return "ds_config"
+}
+
+var _ config.Config = (*DSConfig)(nil)
+
+type DSConfig struct {
+	// AdminAddr is the address the admin API listens on. The admin API is
+	// used to set and delete the dynamic topology.
+	AdminAddr string
+	// ReloadInterval is the interval between two checks whether the
+	// topology file has changed.
+	ReloadInterval util.DurWrap
+	// ACL is the list of networks that are allowed to fetch the full
+	// topology.
+	ACL []string
+	// Sign indicates whether the served topologies are signed with the AS
+	// signing key.
+	Sign bool
+}
+
+func (cfg *DSConfig) InitDefaults() {
+	if cfg.AdminAddr == "" {
+		cfg.AdminAddr = DefaultAdminAddr
+	}
+	if cfg.ReloadInterval.Duration == 0 {
+		cfg.ReloadInterval.Duration = DefaultReloadInterval
+	}
+	if cfg.ACL == nil {
+		cfg.ACL = append([]string(nil), DefaultACL...)
+	}
+}
+
+func (cfg *DSConfig) Validate() error {
+	if cfg.ReloadInterval.Duration == 0 {
+		return serrors.New("ReloadInterval must not be zero")
+	}
+	if _, err := cfg.Networks(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Networks returns the parsed ACL.
+func (cfg *DSConfig) Networks() ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(cfg.ACL))
+	for _, entry := range cfg.ACL {
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, common.NewBasicError("Invalid ACL entry", err, "entry", entry)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+func (cfg *DSConfig) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
+	config.WriteString(dst, dsSample)
+}
+
+func (cfg *DSConfig) ConfigName() string {
+	return "ds"
+}
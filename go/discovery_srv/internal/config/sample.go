@@ -0,0 +1,34 @@
+// Copyright 2019 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+const idSample = "ds-1"
+
+const dsSample = `
+# The address the admin API listens on. The admin API allows to set and delete
+# the dynamic topology. (default "127.0.0.1:30490")
+AdminAddr = "127.0.0.1:30490"
+
+# The interval between two checks whether the topology file has changed.
+# (default 1s)
+ReloadInterval = "1s"
+
+# The networks that are allowed to fetch the full topology.
+# (default ["127.0.0.0/8", "::1/128"])
+ACL = ["127.0.0.0/8", "::1/128"]
+
+# Sign the served topologies with the AS signing key. (default false)
+Sign = false
+`
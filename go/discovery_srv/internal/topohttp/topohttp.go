@@ -0,0 +1,137 @@
+// Copyright 2019 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package topohttp implements the HTTP handlers of the discovery service.
+//
+// The discovery handler serves the topology files with the layout described
+// in lib/discovery. The full topology is only served to privileged
+// requesters, i.e., requesters with an address in the ACL. For the default
+// topology, the full version is served to privileged requesters and the
+// endhost version to everyone else.
+//
+// The admin handler allows to set and delete the dynamic topology:
+//  PUT    /dynamic: Set the dynamic topology to the request body.
+//  DELETE /dynamic: Delete the dynamic topology.
+package topohttp
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+
+	"github.com/scionproto/scion/go/discovery_srv/internal/topostore"
+	"github.com/scionproto/scion/go/lib/discovery"
+	"github.com/scionproto/scion/go/lib/log"
+)
+
+// NewDiscoveryHandler creates the handler that serves the topology files.
+// Requesters with an address in acl are considered privileged.
+func NewDiscoveryHandler(store *topostore.Store, acl []*net.IPNet) http.Handler {
+	mux := http.NewServeMux()
+	for _, mode := range []discovery.Mode{discovery.Static, discovery.Dynamic} {
+		for _, file := range []discovery.File{discovery.Full, discovery.Endhost,
+			discovery.Default} {
+
+			h := &topoHandler{store: store, acl: acl, mode: mode, file: file}
+			mux.Handle("/"+discovery.Path(mode, file), h)
+		}
+	}
+	return mux
+}
+
+type topoHandler struct {
+	store *topostore.Store
+	acl   []*net.IPNet
+	mode  discovery.Mode
+	file  discovery.File
+}
+
+func (h *topoHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	privileged := h.privileged(r)
+	var full bool
+	switch h.file {
+	case discovery.Full:
+		if !privileged {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		full = true
+	case discovery.Default:
+		full = privileged
+	}
+	f, err := h.store.Get(h.mode, full)
+	if err != nil {
+		log.Error("[topohttp] Unable to get topology", "mode", h.mode, "file", h.file,
+			"err", err)
+		http.Error(w, "topology not available", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if f.Signature != "" {
+		w.Header().Set(discovery.SignatureHeader, f.Signature)
+	}
+	w.Write(f.Raw)
+}
+
+// privileged checks whether the requester address is contained in the ACL.
+func (h *topoHandler) privileged(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	for _, network := range h.acl {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewAdminHandler creates the handler for the admin API.
+func NewAdminHandler(store *topostore.Store) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/dynamic", &dynamicHandler{store: store})
+	return mux
+}
+
+type dynamicHandler struct {
+	store *topostore.Store
+}
+
+func (h *dynamicHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPut:
+		raw, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("unable to read body: %s", err), http.StatusBadRequest)
+			return
+		}
+		if err := h.store.SetDynamic(raw); err != nil {
+			http.Error(w, fmt.Sprintf("unable to set topology: %s", err), http.StatusBadRequest)
+			return
+		}
+		log.Info("[topohttp] Dynamic topology set")
+	case http.MethodDelete:
+		h.store.DeleteDynamic()
+		log.Info("[topohttp] Dynamic topology deleted")
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2019 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topohttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/discovery_srv/internal/topostore"
+	"github.com/scionproto/scion/go/lib/discovery"
+	"github.com/scionproto/scion/go/lib/topology"
+)
+
+const testTopo = "../topostore/testdata/topology.json"
+
+func newStore(t *testing.T) *topostore.Store {
+	raw, err := ioutil.ReadFile(testTopo)
+	require.NoError(t, err)
+	s := topostore.New(nil)
+	require.NoError(t, s.SetStatic(raw))
+	return s
+}
+
+func TestDiscoveryHandler(t *testing.T) {
+	_, privileged, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(t, err)
+	h := NewDiscoveryHandler(newStore(t), []*net.IPNet{privileged})
+
+	tests := map[string]struct {
+		File         discovery.File
+		RemoteAddr   string
+		ExpectedCode int
+		ExpectedFull bool
+	}{
+		"full privileged": {
+			File:         discovery.Full,
+			RemoteAddr:   "10.0.0.1:4000",
+			ExpectedCode: http.StatusOK,
+			ExpectedFull: true,
+		},
+		"full unprivileged": {
+			File:         discovery.Full,
+			RemoteAddr:   "192.168.0.1:4000",
+			ExpectedCode: http.StatusForbidden,
+		},
+		"endhost privileged": {
+			File:         discovery.Endhost,
+			RemoteAddr:   "10.0.0.1:4000",
+			ExpectedCode: http.StatusOK,
+		},
+		"default privileged": {
+			File:         discovery.Default,
+			RemoteAddr:   "10.0.0.1:4000",
+			ExpectedCode: http.StatusOK,
+			ExpectedFull: true,
+		},
+		"default unprivileged": {
+			File:         discovery.Default,
+			RemoteAddr:   "192.168.0.1:4000",
+			ExpectedCode: http.StatusOK,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet,
+				"/"+discovery.Path(discovery.Static, test.File), nil)
+			req.RemoteAddr = test.RemoteAddr
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			require.Equal(t, test.ExpectedCode, rec.Code)
+			if test.ExpectedCode != http.StatusOK {
+				return
+			}
+			rt, err := topology.LoadRaw(rec.Body.Bytes())
+			require.NoError(t, err)
+			assert.Equal(t, test.ExpectedFull, len(rt.BeaconService) != 0)
+		})
+	}
+}
+
+func TestAdminHandler(t *testing.T) {
+	s := newStore(t)
+	h := NewAdminHandler(s)
+
+	rt, err := topology.LoadRawFromFile(testTopo)
+	require.NoError(t, err)
+	rt.Timestamp = time.Now().Unix()
+	rt.TTL = 3600
+	rt.MTU = 1000
+	raw, err := json.Marshal(rt)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/dynamic", bytes.NewReader(raw)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	f, err := s.Get(discovery.Dynamic, true)
+	require.NoError(t, err)
+	dynamic, err := topology.LoadRaw(f.Raw)
+	require.NoError(t, err)
+	assert.Equal(t, 1000, dynamic.MTU)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/dynamic",
+		bytes.NewReader([]byte("garbage"))))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/dynamic", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	f, err = s.Get(discovery.Dynamic, true)
+	require.NoError(t, err)
+	dynamic, err = topology.LoadRaw(f.Raw)
+	require.NoError(t, err)
+	assert.NotEqual(t, 1000, dynamic.MTU)
+}
@@ -0,0 +1,75 @@
+// Copyright 2019 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topostore
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/periodic"
+)
+
+var _ periodic.Task = (*Reloader)(nil)
+
+// Reloader is a periodic task that sets the static topology in the store
+// whenever the topology file has changed.
+type Reloader struct {
+	// Filename is the topology file.
+	Filename string
+	// Store is the store the static topology is set in.
+	Store *Store
+
+	modTime time.Time
+	size    int64
+}
+
+// Name returns the tasks name.
+func (r *Reloader) Name() string {
+	return "ds_topo_reloader"
+}
+
+// Run reloads the topology file if its modification time or size changed.
+func (r *Reloader) Run(ctx context.Context) {
+	if _, err := r.Reload(); err != nil {
+		log.FromCtx(ctx).Error("[topostore] Unable to reload topology", "err", err,
+			"file", r.Filename)
+	}
+}
+
+// Reload sets the static topology if the topology file has changed since the
+// last successful reload. It returns whether the topology was reloaded.
+func (r *Reloader) Reload() (bool, error) {
+	info, err := os.Stat(r.Filename)
+	if err != nil {
+		return false, common.NewBasicError("Unable to stat topology", err)
+	}
+	if info.ModTime().Equal(r.modTime) && info.Size() == r.size {
+		return false, nil
+	}
+	raw, err := ioutil.ReadFile(r.Filename)
+	if err != nil {
+		return false, common.NewBasicError("Unable to read topology", err)
+	}
+	if err := r.Store.SetStatic(raw); err != nil {
+		return false, err
+	}
+	r.modTime, r.size = info.ModTime(), info.Size()
+	log.Info("[topostore] Static topology loaded", "file", r.Filename)
+	return true, nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2019 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package topostore keeps the topologies served by the discovery service.
+//
+// The store keeps a static and an optional dynamic topology. For both, the
+// full and the endhost version are rendered when the topology is set. The
+// endhost version is stripped of bind addresses and services that are not
+// relevant for end hosts. If the store is created with a signer, every
+// rendered file is signed.
+//
+// If no dynamic topology is set, or the dynamic topology has expired, the
+// static topology is served for dynamic requests.
+package topostore
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/discovery"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/topology"
+)
+
+// File is a rendered topology file.
+type File struct {
+	// Raw is the JSON encoded topology.
+	Raw common.RawBytes
+	// Signature is the encoded signature for the discovery.SignatureHeader.
+	// It is empty if the store does not sign topologies.
+	Signature string
+}
+
+// entry contains the rendered versions of a topology.
+type entry struct {
+	ia      string
+	full    File
+	endhost File
+	topo    *topology.Topo
+}
+
+// Store keeps the topologies served by the discovery service.
+type Store struct {
+	mtx     sync.RWMutex
+	signer  infra.Signer
+	static  *entry
+	dynamic *entry
+}
+
+// New creates a new store. If signer is nil, the topologies are not signed.
+func New(signer infra.Signer) *Store {
+	return &Store{signer: signer}
+}
+
+// SetStatic sets the static topology.
+func (s *Store) SetStatic(raw common.RawBytes) error {
+	e, err := s.render(raw)
+	if err != nil {
+		return err
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.dynamic != nil && s.dynamic.ia != e.ia {
+		s.dynamic = nil
+	}
+	s.static = e
+	return nil
+}
+
+// SetDynamic sets the dynamic topology. The static topology must be set, and
+// the dynamic topology must be for the same AS.
+func (s *Store) SetDynamic(raw common.RawBytes) error {
+	e, err := s.render(raw)
+	if err != nil {
+		return err
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.static == nil {
+		return serrors.New("Static topology not set")
+	}
+	if s.static.ia != e.ia {
+		return common.NewBasicError("Dynamic topology for different AS", nil,
+			"expected", s.static.ia, "actual", e.ia)
+	}
+	s.dynamic = e
+	return nil
+}
+
+// DeleteDynamic deletes the dynamic topology.
+func (s *Store) DeleteDynamic() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.dynamic = nil
+}
+
+// Get returns the requested version of the topology. If full is not set, the
+// endhost version is returned.
+func (s *Store) Get(mode discovery.Mode, full bool) (File, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	e := s.static
+	if mode == discovery.Dynamic && s.dynamic != nil && s.dynamic.topo.Active(time.Now()) {
+		e = s.dynamic
+	}
+	if e == nil {
+		return File{}, serrors.New("Topology not set")
+	}
+	if full {
+		return e.full, nil
+	}
+	return e.endhost, nil
+}
+
+// render parses the raw topology and renders the full and endhost versions.
+func (s *Store) render(raw common.RawBytes) (*entry, error) {
+	rt, err := topology.LoadRaw(raw)
+	if err != nil {
+		return nil, err
+	}
+	topo, err := topology.TopoFromRaw(rt)
+	if err != nil {
+		return nil, common.NewBasicError(topology.ErrConvert, err)
+	}
+	e := &entry{ia: rt.ISD_AS, topo: topo}
+	if e.full, err = s.file(rt); err != nil {
+		return nil, err
+	}
+	topology.StripBind(rt)
+	topology.StripServices(rt)
+	if e.endhost, err = s.file(rt); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// file encodes and signs the topology.
+func (s *Store) file(rt *topology.RawTopo) (File, error) {
+	raw, err := json.MarshalIndent(rt, "", "    ")
+	if err != nil {
+		return File{}, common.NewBasicError("Unable to encode topology", err)
+	}
+	f := File{Raw: raw}
+	if s.signer == nil {
+		return f, nil
+	}
+	sign, err := s.signer.Sign(raw)
+	if err != nil {
+		return File{}, common.NewBasicError("Unable to sign topology", err)
+	}
+	if f.Signature, err = discovery.EncodeSignature(sign); err != nil {
+		return File{}, common.NewBasicError("Unable to encode signature", err)
+	}
+	return f, nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2019 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topostore
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/discovery"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/proto"
+)
+
+const testTopo = "testdata/topology.json"
+
+type testSigner struct{}
+
+func (testSigner) Sign(msg common.RawBytes) (*proto.SignS, error) {
+	sign := proto.NewSignS(proto.SignType_ed25519, []byte("src"))
+	sign.Signature = append([]byte("signed:"), msg[:8]...)
+	return sign, nil
+}
+
+func (testSigner) Meta() infra.SignerMeta {
+	return infra.SignerMeta{}
+}
+
+// loadTopo loads the test topology and applies modify before encoding it.
+func loadTopo(t *testing.T, modify func(*topology.RawTopo)) common.RawBytes {
+	rt, err := topology.LoadRawFromFile(testTopo)
+	require.NoError(t, err)
+	if modify != nil {
+		modify(rt)
+	}
+	raw, err := json.Marshal(rt)
+	require.NoError(t, err)
+	return raw
+}
+
+func TestStoreGet(t *testing.T) {
+	s := New(nil)
+	_, err := s.Get(discovery.Static, true)
+	assert.Error(t, err)
+
+	require.NoError(t, s.SetStatic(loadTopo(t, nil)))
+	full, err := s.Get(discovery.Static, true)
+	require.NoError(t, err)
+	endhost, err := s.Get(discovery.Static, false)
+	require.NoError(t, err)
+	assert.Empty(t, full.Signature)
+
+	fullTopo, err := topology.LoadRaw(full.Raw)
+	require.NoError(t, err)
+	assert.NotEmpty(t, fullTopo.BeaconService)
+	endhostTopo, err := topology.LoadRaw(endhost.Raw)
+	require.NoError(t, err)
+	assert.Empty(t, endhostTopo.BeaconService)
+	for _, svc := range endhostTopo.PathService {
+		for _, a := range svc.Addrs {
+			assert.Nil(t, a.Bind)
+		}
+	}
+
+	// Without dynamic topology, the static topology is served.
+	dynamic, err := s.Get(discovery.Dynamic, true)
+	require.NoError(t, err)
+	assert.Equal(t, full, dynamic)
+}
+
+func TestStoreDynamic(t *testing.T) {
+	s := New(nil)
+	dynamicRaw := loadTopo(t, func(rt *topology.RawTopo) {
+		rt.Timestamp = time.Now().Unix()
+		rt.TTL = 3600
+		rt.MTU = 1000
+	})
+	assert.Error(t, s.SetDynamic(dynamicRaw), "static topology not set")
+
+	require.NoError(t, s.SetStatic(loadTopo(t, nil)))
+	otherAS := loadTopo(t, func(rt *topology.RawTopo) {
+		rt.ISD_AS = "1-ff00:0:312"
+	})
+	assert.Error(t, s.SetDynamic(otherAS), "different AS")
+
+	require.NoError(t, s.SetDynamic(dynamicRaw))
+	f, err := s.Get(discovery.Dynamic, true)
+	require.NoError(t, err)
+	rt, err := topology.LoadRaw(f.Raw)
+	require.NoError(t, err)
+	assert.Equal(t, 1000, rt.MTU)
+
+	s.DeleteDynamic()
+	f, err = s.Get(discovery.Dynamic, true)
+	require.NoError(t, err)
+	rt, err = topology.LoadRaw(f.Raw)
+	require.NoError(t, err)
+	assert.NotEqual(t, 1000, rt.MTU)
+
+	expired := loadTopo(t, func(rt *topology.RawTopo) {
+		rt.Timestamp = time.Now().Add(-time.Hour).Unix()
+		rt.TTL = 60
+		rt.MTU = 1000
+	})
+	require.NoError(t, s.SetDynamic(expired))
+	f, err = s.Get(discovery.Dynamic, true)
+	require.NoError(t, err)
+	rt, err = topology.LoadRaw(f.Raw)
+	require.NoError(t, err)
+	assert.NotEqual(t, 1000, rt.MTU)
+}
+
+func TestStoreSigned(t *testing.T) {
+	s := New(testSigner{})
+	require.NoError(t, s.SetStatic(loadTopo(t, nil)))
+	f, err := s.Get(discovery.Static, false)
+	require.NoError(t, err)
+	sign, err := discovery.DecodeSignature(f.Signature)
+	require.NoError(t, err)
+	assert.Equal(t, append([]byte("signed:"), f.Raw[:8]...), []byte(sign.Signature))
+}
+
+func TestReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "topostore")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "topology.json")
+	require.NoError(t, ioutil.WriteFile(file, loadTopo(t, nil), 0644))
+
+	r := &Reloader{Filename: file, Store: New(nil)}
+	reloaded, err := r.Reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	reloaded, err = r.Reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+
+	require.NoError(t, ioutil.WriteFile(file, loadTopo(t, func(rt *topology.RawTopo) {
+		rt.MTU = 1000
+	}), 0644))
+	require.NoError(t, os.Chtimes(file, time.Now(), time.Now().Add(time.Second)))
+	reloaded, err = r.Reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	f, err := r.Store.Get(discovery.Static, true)
+	require.NoError(t, err)
+	rt, err := topology.LoadRaw(f.Raw)
+	require.NoError(t, err)
+	assert.Equal(t, 1000, rt.MTU)
+}
@@ -0,0 +1,214 @@
+// Copyright 2019 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	_ "net/http/pprof"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/scionproto/scion/go/discovery_srv/internal/config"
+	"github.com/scionproto/scion/go/discovery_srv/internal/topohttp"
+	"github.com/scionproto/scion/go/discovery_srv/internal/topostore"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/fatal"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/modules/itopo"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/periodic"
+	"github.com/scionproto/scion/go/lib/prom"
+	"github.com/scionproto/scion/go/proto"
+)
+
+const (
+	ShutdownWaitTimeout = 5 * time.Second
+)
+
+var (
+	cfg config.Config
+)
+
+func init() {
+	flag.Usage = env.Usage
+}
+
+// main initializes the discovery service and starts serving topologies.
+func main() {
+	os.Exit(realMain())
+}
+
+func realMain() int {
+	fatal.Init()
+	env.AddFlags()
+	flag.Parse()
+	if v, ok := env.CheckFlags(&cfg); !ok {
+		return v
+	}
+	if err := setupBasic(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer log.Flush()
+	defer env.LogAppStopped(common.DS, cfg.General.ID)
+	defer log.LogPanicAndExit()
+	if err := setup(); err != nil {
+		log.Crit("Setup failed", "err", err)
+		return 1
+	}
+	acl, err := cfg.DS.Networks()
+	if err != nil {
+		log.Crit("Unable to parse ACL", "err", err)
+		return 1
+	}
+	var signer infra.Signer
+	if cfg.DS.Sign {
+		if signer, err = createSigner(); err != nil {
+			log.Crit("Unable to create signer", "err", err)
+			return 1
+		}
+	}
+	store := topostore.New(signer)
+	reloader := &topostore.Reloader{Filename: cfg.General.Topology, Store: store}
+	if _, err := reloader.Reload(); err != nil {
+		log.Crit("Unable to load topology", "err", err)
+		return 1
+	}
+	reloadRunner := periodic.Start(reloader, cfg.DS.ReloadInterval.Duration,
+		cfg.DS.ReloadInterval.Duration)
+	defer reloadRunner.Kill()
+	public, err := publicAddr()
+	if err != nil {
+		log.Crit("Unable to determine public address", "err", err)
+		return 1
+	}
+	discoverySrv := &http.Server{
+		Addr:    public,
+		Handler: topohttp.NewDiscoveryHandler(store, acl),
+	}
+	defer shutdown(discoverySrv)
+	startServer("DiscoveryServer", discoverySrv)
+	adminSrv := &http.Server{
+		Addr:    cfg.DS.AdminAddr,
+		Handler: topohttp.NewAdminHandler(store),
+	}
+	defer shutdown(adminSrv)
+	startServer("AdminServer", adminSrv)
+	cfg.Metrics.StartPrometheus()
+	select {
+	case <-fatal.ShutdownChan():
+		// Whenever we receive a SIGINT or SIGTERM we exit without an error.
+		return 0
+	case <-fatal.FatalChan():
+		return 1
+	}
+}
+
+func setupBasic() error {
+	if _, err := toml.DecodeFile(env.ConfigFile(), &cfg); err != nil {
+		return err
+	}
+	cfg.InitDefaults()
+	if err := env.InitLogging(&cfg.Logging); err != nil {
+		return err
+	}
+	prom.ExportElementID(cfg.General.ID)
+	return env.LogAppStarted(common.DS, cfg.General.ID)
+}
+
+func setup() error {
+	if err := cfg.Validate(); err != nil {
+		return common.NewBasicError("Unable to validate config", err)
+	}
+	itopo.Init("", proto.ServiceType_unset, itopo.Callbacks{})
+	topo, err := itopo.LoadFromFile(cfg.General.Topology)
+	if err != nil {
+		return common.NewBasicError("Unable to load topology", err)
+	}
+	if _, _, err := itopo.SetStatic(topo, false); err != nil {
+		return common.NewBasicError("Unable to set initial static topology", err)
+	}
+	return nil
+}
+
+// publicAddr returns the address the discovery service serves the topology
+// on, as specified in the topology file.
+func publicAddr() (string, error) {
+	topo := itopo.Get()
+	topoAddr, ok := topo.DS()[cfg.General.ID]
+	if !ok {
+		return "", common.NewBasicError("Unable to find topo address", nil,
+			"id", cfg.General.ID)
+	}
+	pub := topoAddr.PublicAddr(topo.Overlay())
+	if pub == nil {
+		return "", common.NewBasicError("No public address", nil, "id", cfg.General.ID)
+	}
+	return net.JoinHostPort(pub.L3.IP().String(), strconv.Itoa(int(pub.L4))), nil
+}
+
+// createSigner creates a signer with the AS signing key.
+func createSigner() (infra.Signer, error) {
+	trustDB, err := cfg.TrustDB.New()
+	if err != nil {
+		return nil, common.NewBasicError("Unable to initialize trustDB", err)
+	}
+	defer trustDB.Close()
+	topo := itopo.Get()
+	trustConf := trust.Config{TopoProvider: itopo.Provider()}
+	trustStore := trust.NewStore(trustDB, topo.IA(), trustConf, log.Root())
+	err = trustStore.LoadAuthoritativeCrypto(filepath.Join(cfg.General.ConfigDir, "certs"))
+	if err != nil {
+		return nil, common.NewBasicError("Unable to load local crypto", err)
+	}
+	keys, err := keyconf.Load(filepath.Join(cfg.General.ConfigDir, "keys"),
+		false, false, false, false)
+	if err != nil {
+		return nil, common.NewBasicError("Unable to load key config", err)
+	}
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+	meta, err := trust.CreateSignMeta(ctx, topo.IA(), trustDB)
+	if err != nil {
+		return nil, common.NewBasicError("Unable to create sign meta", err)
+	}
+	return trust.NewBasicSigner(keys.SignKey, meta)
+}
+
+func startServer(name string, server *http.Server) {
+	go func() {
+		defer log.LogPanicAndExit()
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			fatal.Fatal(common.NewBasicError("ListenAndServe error", err, "name", name))
+		}
+	}()
+}
+
+func shutdown(server *http.Server) {
+	ctx, cancelF := context.WithTimeout(context.Background(), ShutdownWaitTimeout)
+	defer cancelF()
+	server.Shutdown(ctx)
+}
@@ -0,0 +1,175 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the Go representation of the static info extension, which
+// carries static metadata about an AS (latency, bandwidth, link types and
+// geographic location of its interfaces).
+
+package seg
+
+import (
+	"fmt"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ proto.Cerealizable = (*StaticInfoExtn)(nil)
+
+// StaticInfoExtn contains static metadata about the AS that created the AS
+// entry. All entries are keyed by the egress interface of the AS entry.
+type StaticInfoExtn struct {
+	Latency   []LatencyInfo   `capnp:"latency"`
+	Bandwidth []BandwidthInfo `capnp:"bandwidth"`
+	LinkType  []LinkTypeInfo  `capnp:"linkType"`
+	Geo       []GeoInfo       `capnp:"geo"`
+}
+
+func (ext *StaticInfoExtn) ProtoId() proto.ProtoIdType {
+	return proto.StaticInfoExtn_TypeID
+}
+
+// LatencyFor returns the latency information for the interface.
+func (ext *StaticInfoExtn) LatencyFor(ifid common.IFIDType) (LatencyInfo, bool) {
+	if ext == nil {
+		return LatencyInfo{}, false
+	}
+	for _, l := range ext.Latency {
+		if l.IfID == ifid {
+			return l, true
+		}
+	}
+	return LatencyInfo{}, false
+}
+
+// BandwidthFor returns the bandwidth information for the interface.
+func (ext *StaticInfoExtn) BandwidthFor(ifid common.IFIDType) (BandwidthInfo, bool) {
+	if ext == nil {
+		return BandwidthInfo{}, false
+	}
+	for _, b := range ext.Bandwidth {
+		if b.IfID == ifid {
+			return b, true
+		}
+	}
+	return BandwidthInfo{}, false
+}
+
+// LinkTypeFor returns the link type of the interface.
+func (ext *StaticInfoExtn) LinkTypeFor(ifid common.IFIDType) (LinkType, bool) {
+	if ext == nil {
+		return LinkTypeUnset, false
+	}
+	for _, l := range ext.LinkType {
+		if l.IfID == ifid {
+			return l.LinkType, true
+		}
+	}
+	return LinkTypeUnset, false
+}
+
+// GeoFor returns the geographic location of the interface.
+func (ext *StaticInfoExtn) GeoFor(ifid common.IFIDType) (GeoInfo, bool) {
+	if ext == nil {
+		return GeoInfo{}, false
+	}
+	for _, g := range ext.Geo {
+		if g.IfID == ifid {
+			return g, true
+		}
+	}
+	return GeoInfo{}, false
+}
+
+func (ext *StaticInfoExtn) String() string {
+	if ext == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Latency: %v Bandwidth: %v LinkType: %v Geo: %v",
+		ext.Latency, ext.Bandwidth, ext.LinkType, ext.Geo)
+}
+
+// LatencyInfo contains the latency in microseconds from the ingress interface
+// of the AS entry to the interface (intra) and the latency of the link attached
+// to the interface (inter).
+type LatencyInfo struct {
+	IfID  common.IFIDType `capnp:"ifID"`
+	Intra uint32
+	Inter uint32
+}
+
+// BandwidthInfo contains the bandwidth in Kbit/s from the ingress interface of
+// the AS entry to the interface (intra) and of the link attached to the
+// interface (inter).
+type BandwidthInfo struct {
+	IfID  common.IFIDType `capnp:"ifID"`
+	Intra uint64
+	Inter uint64
+}
+
+// LinkTypeInfo contains the type of the link attached to the interface.
+type LinkTypeInfo struct {
+	IfID     common.IFIDType `capnp:"ifID"`
+	LinkType LinkType
+}
+
+// GeoInfo contains the geographic location of the interface.
+type GeoInfo struct {
+	IfID      common.IFIDType `capnp:"ifID"`
+	Latitude  float32
+	Longitude float32
+	Address   string
+}
+
+// LinkType is the type of an inter-domain link.
+type LinkType uint16
+
+const (
+	LinkTypeUnset LinkType = iota
+	// LinkTypeDirect is a direct physical connection.
+	LinkTypeDirect
+	// LinkTypeMultiHop is a connection with local routing or switching.
+	LinkTypeMultiHop
+	// LinkTypeOpenNet is a connection overlaid over the public internet.
+	LinkTypeOpenNet
+)
+
+func (t LinkType) String() string {
+	switch t {
+	case LinkTypeUnset:
+		return "unset"
+	case LinkTypeDirect:
+		return "direct"
+	case LinkTypeMultiHop:
+		return "multihop"
+	case LinkTypeOpenNet:
+		return "opennet"
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", t)
+}
+
+// ParseLinkType parses the string representation of a link type.
+func ParseLinkType(s string) (LinkType, error) {
+	switch s {
+	case "unset", "":
+		return LinkTypeUnset, nil
+	case "direct":
+		return LinkTypeDirect, nil
+	case "multihop":
+		return LinkTypeMultiHop, nil
+	case "opennet":
+		return LinkTypeOpenNet, nil
+	}
+	return LinkTypeUnset, common.NewBasicError("Unknown link type", nil, "type", s)
+}
@@ -0,0 +1,63 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/spath"
+)
+
+func TestASEntryStaticInfoRoundTrip(t *testing.T) {
+	ase := &ASEntry{
+		RawIA:    as110.IAInt(),
+		MTU:      1500,
+		IfIDSize: 12,
+		HopEntries: []*HopEntry{
+			{
+				RawOutIA:    as111.IAInt(),
+				RemoteOutIF: 23,
+				RawHopField: make(common.RawBytes, spath.HopFieldLength),
+			},
+		},
+	}
+	ase.Exts.StaticInfo = &StaticInfoExtn{
+		Latency:   []LatencyInfo{{IfID: 1, Intra: 100, Inter: 2000}},
+		Bandwidth: []BandwidthInfo{{IfID: 1, Intra: 10000000, Inter: 1000000}},
+		LinkType:  []LinkTypeInfo{{IfID: 1, LinkType: LinkTypeOpenNet}},
+		Geo:       []GeoInfo{{IfID: 1, Latitude: 47.5, Longitude: 8.5, Address: "Zurich"}},
+	}
+	raw, err := ase.Pack()
+	require.NoError(t, err)
+	parsed, err := NewASEntryFromRaw(raw)
+	require.NoError(t, err)
+	assert.Equal(t, ase.Exts.StaticInfo, parsed.Exts.StaticInfo)
+}
+
+func TestParseLinkType(t *testing.T) {
+	for _, lt := range []LinkType{LinkTypeUnset, LinkTypeDirect, LinkTypeMultiHop,
+		LinkTypeOpenNet} {
+
+		parsed, err := ParseLinkType(lt.String())
+		assert.NoError(t, err)
+		assert.Equal(t, lt, parsed)
+	}
+	_, err := ParseLinkType("wireless")
+	assert.Error(t, err)
+}
@@ -60,10 +60,18 @@
 //  dynamic && default:  /discovery/v1/dynamic/default.json
 //  dynamic && endhost:  /discovery/v1/dynamic/endhost.json
 //  dynamic && full:     /discovery/v1/dynamic/full.json
+//
+// Signatures
+//
+// The discovery service can sign the topology files with the AS signing key.
+// The signature is carried in the SignatureHeader of the response. It covers
+// the raw response body, and can be verified against the AS certificate
+// chain from the trust store.
 package discovery
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -74,6 +82,7 @@ import (
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/proto"
 )
 
 // FetchParams contains the parameters for fetching the topology from
@@ -108,6 +117,13 @@ const (
 	Default File = "default.json"
 )
 
+const (
+	// SignatureHeader is the HTTP header that carries the signature of the
+	// topology file. The value is the base64 encoded, capnp packed
+	// signature metadata (see EncodeSignature).
+	SignatureHeader = "Scion-Topology-Signature"
+)
+
 const (
 	// Base is the base path for the topology file url. It is supposed to be used
 	// as Base/<mode>/<file>. For example, the dynamic and full topology has the url
@@ -121,27 +137,72 @@ const (
 func FetchTopoRaw(ctx context.Context, params FetchParams, ds *net.UDPAddr,
 	client *http.Client) (*topology.Topo, common.RawBytes, error) {
 
+	topo, raw, _, err := FetchSignedTopoRaw(ctx, params, ds, client)
+	return topo, raw, err
+}
+
+// FetchSignedTopoRaw fetches the topology with the specified parameters from
+// the discovery service. If client is nil, the default http client is used.
+// The topology, the raw response body and the signature are returned. If the
+// response does not contain a signature, the returned signature is nil. The
+// signature is not verified.
+func FetchSignedTopoRaw(ctx context.Context, params FetchParams, ds *net.UDPAddr,
+	client *http.Client) (*topology.Topo, common.RawBytes, *proto.SignS, error) {
+
 	url, err := createURL(params, ds)
 	if err != nil {
-		return nil, nil, common.NewBasicError("Unable to create URL", err)
+		return nil, nil, nil, common.NewBasicError("Unable to create URL", err)
 	}
 	rep, err := ctxhttp.Get(ctx, client, url)
 	if err != nil {
-		return nil, nil, common.NewBasicError("HTTP request failed", err)
+		return nil, nil, nil, common.NewBasicError("HTTP request failed", err)
 	}
 	defer rep.Body.Close()
 	if rep.StatusCode != http.StatusOK {
-		return nil, nil, common.NewBasicError("Status not OK", nil, "status", rep.Status)
+		return nil, nil, nil, common.NewBasicError("Status not OK", nil, "status", rep.Status)
 	}
 	raw, err := ioutil.ReadAll(rep.Body)
 	if err != nil {
-		return nil, nil, common.NewBasicError("Unable to read body", err)
+		return nil, nil, nil, common.NewBasicError("Unable to read body", err)
 	}
 	topo, err := topology.Load(raw)
 	if err != nil {
-		return nil, nil, common.NewBasicError("Unable to parse topo", err)
+		return nil, nil, nil, common.NewBasicError("Unable to parse topo", err)
+	}
+	var sign *proto.SignS
+	if header := rep.Header.Get(SignatureHeader); header != "" {
+		if sign, err = DecodeSignature(header); err != nil {
+			return nil, nil, nil, common.NewBasicError("Unable to parse signature", err)
+		}
+	}
+	return topo, raw, sign, nil
+}
+
+// EncodeSignature encodes the signature metadata for the SignatureHeader.
+// The signature is packed as a signed blob without blob, since the signed
+// content is the response body.
+func EncodeSignature(sign *proto.SignS) (string, error) {
+	raw, err := proto.PackRoot(&proto.SignedBlobS{Sign: sign})
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(raw), nil
+}
+
+// DecodeSignature decodes the signature metadata from the SignatureHeader.
+func DecodeSignature(header string) (*proto.SignS, error) {
+	raw, err := base64.StdEncoding.DecodeString(header)
+	if err != nil {
+		return nil, err
+	}
+	blob := &proto.SignedBlobS{}
+	if err := proto.ParseFromRaw(blob, raw); err != nil {
+		return nil, err
+	}
+	if blob.Sign == nil {
+		return nil, serrors.New("Signature missing")
 	}
-	return topo, raw, nil
+	return blob.Sign, nil
 }
 
 // createURL builds the url to the topology file.
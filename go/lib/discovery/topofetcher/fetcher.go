@@ -18,12 +18,17 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/discovery"
 	"github.com/scionproto/scion/go/lib/discovery/discoverypool"
+	"github.com/scionproto/scion/go/lib/infra"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/proto"
 )
 
 var _ discovery.Fetcher = (*Fetcher)(nil)
@@ -49,7 +54,18 @@ type Fetcher struct {
 	Callbacks Callbacks
 	// Client is the http Client. If nil, the default Client is used.
 	Client *http.Client
-	caller string
+	// Verifier verifies the signature of the fetched topologies. If set,
+	// topologies without a valid signature are rejected. The verifier should
+	// be bound to the local AS, such that only signatures created by the
+	// local AS are accepted. If nil, topologies are not authenticated.
+	Verifier infra.Verifier
+	caller   string
+
+	// lastMtx protects last.
+	lastMtx sync.Mutex
+	// last is the timestamp of the most recent accepted topology. Topologies
+	// with an older timestamp are rejected to prevent rollbacks.
+	last time.Time
 }
 
 // New initializes a fetcher with the given values. Topo is provided to
@@ -99,11 +115,19 @@ func (f *Fetcher) run(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	topo, raw, err := discovery.FetchTopoRaw(ctx, f.Params, ds.Addr(), f.Client)
+	topo, raw, sign, err := discovery.FetchSignedTopoRaw(ctx, f.Params, ds.Addr(), f.Client)
 	if err != nil {
 		ds.Fail()
 		return err
 	}
+	if err := f.verify(ctx, raw, sign); err != nil {
+		ds.Fail()
+		return common.NewBasicError("Unable to verify topology", err, "ds", ds)
+	}
+	if err := f.checkTimestamp(topo.Timestamp); err != nil {
+		ds.Fail()
+		return err
+	}
 	// Update DS server entries based on new topo.
 	err = f.Pool.Update(topo.DS)
 	if err != nil {
@@ -118,3 +142,36 @@ func (f *Fetcher) run(ctx context.Context) error {
 	}
 	return nil
 }
+
+// SetLastTimestamp sets the timestamp of the most recent accepted topology.
+// Callers should set it to the timestamp of the topology currently in use,
+// such that the first fetched topology cannot roll it back.
+func (f *Fetcher) SetLastTimestamp(ts time.Time) {
+	f.lastMtx.Lock()
+	defer f.lastMtx.Unlock()
+	f.last = ts
+}
+
+// verify verifies the signature of the raw topology, if a verifier is set.
+func (f *Fetcher) verify(ctx context.Context, raw common.RawBytes, sign *proto.SignS) error {
+	if f.Verifier == nil {
+		return nil
+	}
+	if sign == nil {
+		return serrors.New("Topology is not signed")
+	}
+	return f.Verifier.Verify(ctx, raw, sign)
+}
+
+// checkTimestamp ensures the timestamp is not older than the timestamp of the
+// most recent accepted topology, and records it.
+func (f *Fetcher) checkTimestamp(ts time.Time) error {
+	f.lastMtx.Lock()
+	defer f.lastMtx.Unlock()
+	if ts.Before(f.last) {
+		return common.NewBasicError("Topology older than last accepted topology", nil,
+			"ts", util.TimeToString(ts), "last", util.TimeToString(f.last))
+	}
+	f.last = ts
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2019 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topofetcher
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/discovery"
+	"github.com/scionproto/scion/go/lib/discovery/discoveryinfo"
+	"github.com/scionproto/scion/go/lib/infra/mock_infra"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/proto"
+)
+
+type staticPool struct {
+	info *discoveryinfo.Info
+}
+
+func (p staticPool) Update(topology.IDAddrMap) error {
+	return nil
+}
+
+func (p staticPool) Choose() (discovery.InstanceInfo, error) {
+	return p.info, nil
+}
+
+func topoJSON(ts int64) string {
+	return fmt.Sprintf(`{"ISD_AS": "1-ff00:0:111", "Overlay": "UDP/IPv4", "Timestamp": %d}`, ts)
+}
+
+func TestFetcherRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var body, signature string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if signature != "" {
+			w.Header().Set(discovery.SignatureHeader, signature)
+		}
+		fmt.Fprint(w, body)
+	}))
+	defer srv.Close()
+	srvAddr := srv.Listener.Addr().(*net.TCPAddr)
+
+	sign := proto.NewSignS(proto.SignType_ed25519, []byte("src"))
+	sign.Signature = []byte("signature")
+	encoded, err := discovery.EncodeSignature(sign)
+	require.NoError(t, err)
+
+	newFetcher := func() (*Fetcher, *[]*topology.Topo, *[]error) {
+		var topos []*topology.Topo
+		var errs []error
+		f := &Fetcher{
+			Pool: staticPool{info: discoveryinfo.New("ds",
+				&net.UDPAddr{IP: srvAddr.IP, Port: srvAddr.Port})},
+			Params: discovery.FetchParams{Mode: discovery.Static, File: discovery.Full},
+			Callbacks: Callbacks{
+				Update: func(_ context.Context, topo *topology.Topo) {
+					topos = append(topos, topo)
+				},
+				Error: func(_ context.Context, err error) {
+					errs = append(errs, err)
+				},
+			},
+		}
+		return f, &topos, &errs
+	}
+
+	t.Run("older topologies are rejected", func(t *testing.T) {
+		f, topos, errs := newFetcher()
+		body, signature = topoJSON(1000), ""
+		f.Run(context.Background())
+		body = topoJSON(2000)
+		f.Run(context.Background())
+		body = topoJSON(1500)
+		f.Run(context.Background())
+		assert.Len(t, *topos, 2)
+		assert.Len(t, *errs, 1)
+	})
+	t.Run("topologies older than the last timestamp are rejected", func(t *testing.T) {
+		f, topos, errs := newFetcher()
+		f.SetLastTimestamp(time.Unix(2000, 0))
+		body, signature = topoJSON(1000), ""
+		f.Run(context.Background())
+		body = topoJSON(2000)
+		f.Run(context.Background())
+		assert.Len(t, *topos, 1)
+		assert.Len(t, *errs, 1)
+	})
+	t.Run("unsigned topologies are rejected if verifier is set", func(t *testing.T) {
+		f, topos, errs := newFetcher()
+		f.Verifier = mock_infra.NewMockVerifier(ctrl)
+		body, signature = topoJSON(1000), ""
+		f.Run(context.Background())
+		assert.Empty(t, *topos)
+		assert.Len(t, *errs, 1)
+	})
+	t.Run("signed topologies are verified", func(t *testing.T) {
+		f, topos, errs := newFetcher()
+		verifier := mock_infra.NewMockVerifier(ctrl)
+		f.Verifier = verifier
+		body, signature = topoJSON(1000), encoded
+		verifier.EXPECT().Verify(gomock.Any(), common.RawBytes(body), sign).Return(nil)
+		f.Run(context.Background())
+		verifier.EXPECT().Verify(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+			serrors.New("invalid signature"))
+		f.Run(context.Background())
+		assert.Len(t, *topos, 1)
+		assert.Len(t, *errs, 1)
+	})
+}
@@ -207,7 +207,9 @@ type Metrics struct {
 	config.NoDefaulter
 	config.NoValidator
 	// Prometheus contains the address to export prometheus metrics on. If
-	// not set, metrics are not exported.
+	// not set, metrics are not exported. The /loglevel endpoint to get and set
+	// the log levels at runtime, and the registered StatusPages are served on
+	// the same address.
 	Prometheus string
 }
 
@@ -223,6 +225,7 @@ func (cfg *Metrics) StartPrometheus() {
 	fatal.Check()
 	if cfg.Prometheus != "" {
 		http.Handle("/metrics", promhttp.Handler())
+		http.Handle("/loglevel", log.NewLevelHTTPHandler())
 		log.Info("Exporting prometheus metrics", "addr", cfg.Prometheus)
 		go func() {
 			defer log.LogPanicAndExit()
@@ -287,6 +290,10 @@ type QUIC struct {
 	Address            string
 	CertFile           string
 	KeyFile            string
+	// UsePKI indicates that the TLS credentials are derived from the AS
+	// certificate chain and peers are authenticated with the control-plane
+	// PKI. CertFile and KeyFile are only used if there is no AS signing key.
+	UsePKI bool
 }
 
 func (cfg *QUIC) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
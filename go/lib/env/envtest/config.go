@@ -90,11 +90,13 @@ func CheckTestGeneral(t *testing.T, cfg *env.General, id string) {
 func CheckTestLogging(t *testing.T, cfg *env.Logging, id string) {
 	assert.Equal(t, fmt.Sprintf("/var/log/scion/%s.log", id), cfg.File.Path)
 	assert.Equal(t, log.DefaultFileLevel, cfg.File.Level)
+	assert.Equal(t, log.DefaultFormat, cfg.File.Format)
 	assert.Equal(t, log.DefaultFileSizeMiB, int(cfg.File.Size))
 	assert.Equal(t, log.DefaultFileMaxAgeDays, int(cfg.File.MaxAge))
 	assert.Equal(t, log.DefaultFileMaxBackups, int(cfg.File.MaxBackups))
 	assert.Equal(t, log.DefaultFileFlushSeconds, *cfg.File.FlushInterval)
 	assert.Equal(t, log.DefaultConsoleLevel, cfg.Console.Level)
+	assert.Equal(t, log.DefaultFormat, cfg.Console.Format)
 }
 
 func CheckTestMetrics(t *testing.T, cfg *env.Metrics) {
@@ -42,6 +42,8 @@ type Logging struct {
 		Path string
 		// Level of file logging (defaults to lib/log default).
 		Level string
+		// Format of file logging, human or json (defaults to lib/log default).
+		Format string
 		// Size is the max size of log file in MiB (defaults to lib/log default).
 		Size uint
 		// MaxAge is the max age of log file in days (defaults to lib/log default).
@@ -56,6 +58,9 @@ type Logging struct {
 	Console struct {
 		// Level of console logging (defaults to lib/log default).
 		Level string
+		// Format of console logging, human or json (defaults to lib/log
+		// default).
+		Format string
 	}
 }
 
@@ -65,9 +70,15 @@ func (cfg *Logging) InitDefaults() {
 	if cfg.Console.Level == "" {
 		cfg.Console.Level = log.DefaultConsoleLevel
 	}
+	if cfg.Console.Format == "" {
+		cfg.Console.Format = log.DefaultFormat
+	}
 	if cfg.File.Level == "" {
 		cfg.File.Level = log.DefaultFileLevel
 	}
+	if cfg.File.Format == "" {
+		cfg.File.Format = log.DefaultFormat
+	}
 	if cfg.File.Size == 0 {
 		cfg.File.Size = log.DefaultFileSizeMiB
 	}
@@ -106,7 +117,7 @@ func InitLogging(cfg *Logging) error {
 	if err := setupFileLogging(cfg); err != nil {
 		return err
 	}
-	if err := log.SetupLogConsole(cfg.Console.Level); err != nil {
+	if err := log.SetupLogConsole(cfg.Console.Level, cfg.Console.Format); err != nil {
 		return err
 	}
 	return nil
@@ -118,6 +129,7 @@ func setupFileLogging(cfg *Logging) error {
 			filepath.Base(cfg.File.Path),
 			filepath.Dir(cfg.File.Path),
 			cfg.File.Level,
+			cfg.File.Format,
 			int(cfg.File.Size),
 			int(cfg.File.MaxAge),
 			int(cfg.File.MaxBackups),
@@ -128,8 +140,9 @@ func setupFileLogging(cfg *Logging) error {
 }
 
 // LogAppStarted should be called by applications as soon as logging is
-// initialized.
+// initialized. Subsequent JSON log records are tagged with elemId.
 func LogAppStarted(svcType, elemId string) error {
+	log.SetService(elemId)
 	inDocker, err := util.RunsInDocker()
 	if err != nil {
 		return common.NewBasicError("Unable to determine if running in docker", err)
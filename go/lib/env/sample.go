@@ -48,6 +48,9 @@ Path = "/var/log/scion/%s.log"
 # File logging level. (trace|debug|info|warn|error|crit) (default debug)
 Level = "debug"
 
+# File logging format. (human|json) (default human)
+Format = "human"
+
 # Max size of log file in MiB. (default 50)
 Size = 50
 
@@ -66,11 +69,16 @@ FlushInterval = 5
 const loggingConsoleSample = `
 # Console logging level (trace|debug|info|warn|error|crit) (default crit)
 Level = "crit"
+
+# Console logging format. (human|json) (default human)
+Format = "human"
 `
 
 const metricsSample = `
 # The address to export prometheus metrics on (host:port or ip:port or :port).
-# If not set, metrics are not exported. (default "")
+# If not set, metrics are not exported. The log levels can be changed at runtime
+# with the /loglevel endpoint on the same address. The /config, /topology, /info
+# and /healthz endpoints show the state of the service. (default "")
 Prometheus = ""
 `
 
@@ -95,6 +103,14 @@ CertFile = "/etc/scion/quic/tls.pem"
 # Key file to use for authenticating QUIC connections.
 KeyFile = "/etc/scion/quic/tls.key"
 
+# UsePKI derives the TLS credentials from the AS certificate chain and the AS
+# signing key, and authenticates peers with their AS certificate chain which
+# is verified against the TRCs in the trust store. If set, CertFile and
+# KeyFile are only used by hosts without an AS signing key, e.g., end hosts.
+# These authenticate servers, but do not present a client certificate.
+# (default false)
+UsePKI = false
+
 # SVCResolutionFraction enables SVC resolution for traffic to SVC
 # destinations in a way that is also compatible with control plane servers
 # that do not implement the SVC Resolution Mechanism. The value represents
@@ -0,0 +1,304 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/infra/modules/itopo"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/lib/topology"
+)
+
+// redacted replaces the values of secrets on the /config status page.
+const redacted = "REDACTED"
+
+var (
+	startTime = time.Now()
+
+	// secretKeys are substrings of config keys with secret values. They are
+	// matched case insensitively.
+	secretKeys = []string{"password", "passwd", "secret", "token", "credential", "privatekey"}
+	// dsnPassword matches passwords in connection strings of the form
+	// "key=value key=value".
+	dsnPassword = regexp.MustCompile(`(?i)\b(password|passwd|pwd)=(\S+)`)
+)
+
+// StatusPages are HTTP endpoints to inspect a running service. They serve the
+// following:
+//
+//	/config:    The effective config as TOML, with secrets redacted.
+//	/topology:  The static and dynamic topology of itopo as JSON.
+//	/info:      The version, start time, IA, ID and feature flags as JSON.
+//	/healthz:   ok, if the service is healthy.
+type StatusPages struct {
+	// Config is the effective config of the service.
+	Config interface{}
+	// ID is the ID of the service.
+	ID string
+	// IA is the IA of the service. If it is the zero value, the IA of the
+	// itopo topology is used.
+	IA addr.IA
+	// Features are the feature flags of the service.
+	Features Features
+	// Health returns an error if the service is unhealthy. If it is nil, the
+	// service is healthy as long as it serves HTTP.
+	Health func() error
+}
+
+// Register registers the endpoints on the default HTTP mux, which is served by
+// Metrics.StartPrometheus.
+func (p StatusPages) Register() {
+	p.register(http.DefaultServeMux)
+}
+
+func (p StatusPages) register(mux *http.ServeMux) {
+	mux.HandleFunc("/config", p.config)
+	mux.HandleFunc("/topology", p.topology)
+	mux.HandleFunc("/info", p.info)
+	mux.HandleFunc("/healthz", p.healthz)
+}
+
+func (p StatusPages) config(w http.ResponseWriter, _ *http.Request) {
+	raw, err := redactedTOML(p.Config)
+	if err != nil {
+		log.Error("[status] Unable to encode config", "err", err)
+		http.Error(w, "unable to encode config", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write(raw)
+}
+
+type topologyStatus struct {
+	// Active is the topology in use, either static or dynamic.
+	Active  string
+	Static  *topology.Topo
+	Dynamic *topology.Topo `json:",omitempty"`
+}
+
+func (p StatusPages) topology(w http.ResponseWriter, _ *http.Request) {
+	static, dynamic := itopo.Topologies()
+	if static == nil {
+		http.Error(w, "no topology", http.StatusNotFound)
+		return
+	}
+	s := topologyStatus{Active: "static", Static: static, Dynamic: dynamic}
+	if dynamic != nil && dynamic.Active(time.Now()) {
+		s.Active = "dynamic"
+	}
+	writeJSON(w, s)
+}
+
+type infoStatus struct {
+	Version   string
+	StartTime time.Time
+	IA        addr.IA
+	ID        string
+	Features  Features
+}
+
+func (p StatusPages) info(w http.ResponseWriter, _ *http.Request) {
+	ia := p.IA
+	if static, _ := itopo.Topologies(); ia.IsZero() && static != nil {
+		ia = static.ISD_AS
+	}
+	writeJSON(w, infoStatus{
+		Version:   StartupVersion,
+		StartTime: startTime,
+		IA:        ia,
+		ID:        p.ID,
+		Features:  p.Features,
+	})
+}
+
+func (p StatusPages) healthz(w http.ResponseWriter, _ *http.Request) {
+	if p.Health != nil {
+		if err := p.Health(); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+	}
+	fmt.Fprintln(w, "ok")
+}
+
+// HealthChecks returns a health check that fails with the error of the first
+// failing check.
+func HealthChecks(checks ...func() error) func() error {
+	return func() error {
+		for _, check := range checks {
+			if err := check(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// TopologyHealth fails if no topology is loaded, or if neither the static nor
+// the dynamic topology is active.
+func TopologyHealth() error {
+	static, dynamic := itopo.Topologies()
+	if static == nil {
+		return serrors.New("no topology loaded")
+	}
+	now := time.Now()
+	if !static.Active(now) && (dynamic == nil || !dynamic.Active(now)) {
+		return serrors.New("topology expired", "expiry", static.Expiry())
+	}
+	return nil
+}
+
+// DispatcherHealth returns a health check that fails if the dispatcher socket
+// at path does not exist. If path is empty, the default socket is checked.
+func DispatcherHealth(path string) func() error {
+	if path == "" {
+		path = reliable.DefaultDispPath
+	}
+	return func() error {
+		return socketHealth("dispatcher", path)
+	}
+}
+
+// SciondHealth returns a health check that fails if the sciond socket at path
+// does not exist. If path is empty, the default socket is checked.
+func SciondHealth(path string) func() error {
+	if path == "" {
+		path = sciond.DefaultSCIONDPath
+	}
+	return func() error {
+		return socketHealth("sciond", path)
+	}
+}
+
+// socketHealth checks that the socket of the service at path exists. The
+// socket is not dialed, such that health checks do not open connections to
+// the service.
+func socketHealth(name, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return serrors.WrapStr(name+" socket not available", err, "path", path)
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return serrors.New(name+" socket is not a socket", "path", path)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	raw, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		log.Error("[status] Unable to encode response", "err", err)
+		http.Error(w, "unable to encode response", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(raw)
+}
+
+// redactedTOML encodes cfg as TOML. The values of keys that indicate secrets,
+// and passwords in URLs and connection strings are redacted.
+func redactedTOML(cfg interface{}) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := toml.NewEncoder(buf).Encode(cfg); err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if _, err := toml.Decode(buf.String(), &m); err != nil {
+		return nil, err
+	}
+	redact(m)
+	buf.Reset()
+	if err := toml.NewEncoder(buf).Encode(m); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func redact(m map[string]interface{}) {
+	for k, v := range m {
+		if isSecretKey(k) {
+			m[k] = redacted
+			continue
+		}
+		switch v := v.(type) {
+		case map[string]interface{}:
+			redact(v)
+		case []map[string]interface{}:
+			for _, e := range v {
+				redact(e)
+			}
+		case []interface{}:
+			redactSlice(v)
+		case string:
+			m[k] = redactPasswords(v)
+		}
+	}
+}
+
+func redactSlice(s []interface{}) {
+	for i, v := range s {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			redact(v)
+		case []map[string]interface{}:
+			for _, e := range v {
+				redact(e)
+			}
+		case []interface{}:
+			redactSlice(v)
+		case string:
+			s[i] = redactPasswords(v)
+		}
+	}
+}
+
+func isSecretKey(k string) bool {
+	k = strings.ToLower(k)
+	for _, s := range secretKeys {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactPasswords redacts the password of URLs and of connection strings of
+// the form "key=value key=value".
+func redactPasswords(s string) string {
+	if strings.Contains(s, "://") {
+		if u, err := url.Parse(s); err == nil && u.User != nil {
+			if _, ok := u.User.Password(); ok {
+				u.User = url.UserPassword(u.User.Username(), redacted)
+				return u.String()
+			}
+		}
+	}
+	return dsnPassword.ReplaceAllString(s, "${1}="+redacted)
+}
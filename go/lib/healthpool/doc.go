@@ -32,8 +32,18 @@
 // failed for a specified amount of time. The fail count is divided by two
 // every expire interval starting from that point.
 //
+// Algorithms
+//
+// MinFailCount always returns an info with the minimum fail count. Since
+// ties are broken arbitrarily, it does not spread load. RoundRobin and
+// Random spread the load across all infos with the minimum fail count.
+// LatencyEWMA selects the info with the lowest moving average latency
+// among the infos with the minimum fail count.
+//
 // Info
 //
 // The info keeps track of the failures for a given key. The client should
-// call the Fail method to increase the fail count.
+// call the Fail method to increase the fail count. Clients of a pool with
+// the LatencyEWMA algorithm should call ReportLatency after each successful
+// request.
 package healthpool
@@ -23,6 +23,10 @@ import (
 // MaxFailCount is the maximum fail count for a health info.
 const MaxFailCount = math.MaxUint16
 
+// LatencyEWMAWeight is the weight of a newly reported latency sample in the
+// exponentially weighted moving average kept by the info.
+const LatencyEWMAWeight = 0.25
+
 // Info keeps track of the fails for a key. Implementations that want to
 // use healthpool should embed this interface and initialize it with the
 // constructor NewInfo. See healthpool/svcinstance for an example.
@@ -33,6 +37,11 @@ type Info interface {
 	FailCount() int
 	// ResetCount resets the fail count to zero.
 	ResetCount()
+	// ReportLatency adds a latency sample to the moving average.
+	ReportLatency(latency time.Duration)
+	// Latency returns the moving average of the reported latencies. The
+	// boolean indicates whether any latency has been reported yet.
+	Latency() (time.Duration, bool)
 	// expireFails reduces the fail count.
 	expireFails(now time.Time, opts ExpireOptions)
 }
@@ -45,6 +54,8 @@ type info struct {
 	lastFail time.Time
 	lastExp  time.Time
 	fails    uint16
+	latency  time.Duration
+	measured bool
 }
 
 // NewInfo creates a new health info.
@@ -76,6 +87,24 @@ func (c *info) ResetCount() {
 	c.fails = 0
 }
 
+func (c *info) ReportLatency(latency time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if !c.measured {
+		c.latency = latency
+		c.measured = true
+		return
+	}
+	c.latency = time.Duration(LatencyEWMAWeight*float64(latency) +
+		(1-LatencyEWMAWeight)*float64(c.latency))
+}
+
+func (c *info) Latency() (time.Duration, bool) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return c.latency, c.measured
+}
+
 // expireFails exponentially reduces the fail count.
 func (c *info) expireFails(now time.Time, opts ExpireOptions) {
 	c.mtx.Lock()
@@ -100,3 +100,18 @@ func TestExpireFails(t *testing.T) {
 		}
 	})
 }
+
+func TestReportLatency(t *testing.T) {
+	Convey("The latency should be averaged correctly", t, func() {
+		info := info{}
+		_, ok := info.Latency()
+		SoMsg("Initially not measured", ok, ShouldBeFalse)
+		info.ReportLatency(100 * time.Millisecond)
+		latency, ok := info.Latency()
+		SoMsg("Measured", ok, ShouldBeTrue)
+		SoMsg("First sample", latency, ShouldEqual, 100*time.Millisecond)
+		info.ReportLatency(200 * time.Millisecond)
+		latency, _ = info.Latency()
+		SoMsg("Second sample", latency, ShouldEqual, 125*time.Millisecond)
+	})
+}
@@ -31,6 +31,12 @@ func (opts PoolOptions) algorithm(p *Pool) func() (Info, error) {
 	switch opts.Algorithm {
 	case "", MinFailCount:
 		return p.chooseMinFails
+	case RoundRobin:
+		return p.chooseRoundRobin
+	case Random:
+		return p.chooseRandom
+	case LatencyEWMA:
+		return p.chooseLatencyEWMA
 	default:
 		return nil
 	}
@@ -39,6 +45,17 @@ func (opts PoolOptions) algorithm(p *Pool) func() (Info, error) {
 const (
 	// MinFailCount selects a pool entry with the minimum fail count.
 	MinFailCount Algorithm = "MinFailCount"
+	// RoundRobin cycles through the pool entries with the minimum fail
+	// count.
+	RoundRobin Algorithm = "RoundRobin"
+	// Random selects a random pool entry among the ones with the minimum
+	// fail count.
+	Random Algorithm = "Random"
+	// LatencyEWMA selects the pool entry with the lowest moving average
+	// latency among the ones with the minimum fail count. Entries without
+	// any reported latency are preferred, such that every entry is
+	// measured eventually.
+	LatencyEWMA Algorithm = "LatencyEWMA"
 )
 
 // Algorithm is the choosing algorithm of the pool.
@@ -16,7 +16,9 @@ package healthpool
 
 import (
 	"context"
+	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/scionproto/scion/go/lib/common"
@@ -45,6 +47,8 @@ func (s InfoSet) List() []Info {
 type Pool struct {
 	infosMtx sync.RWMutex
 	infos    map[Info]Info
+	order    []Info // infos in insertion order, used by RoundRobin
+	next     uint64
 	choose   func() (Info, error)
 	opts     PoolOptions
 	expirer  *periodic.Runner
@@ -83,6 +87,9 @@ func (p *Pool) Update(infos map[Info]struct{}) error {
 		return common.NewBasicError(ErrPoolClosed, nil)
 	}
 	for info := range infos {
+		if _, ok := p.infos[info]; !ok {
+			p.order = append(p.order, info)
+		}
 		p.infos[info] = info
 	}
 	// Remove infos that are no longer present.
@@ -91,6 +98,13 @@ func (p *Pool) Update(infos map[Info]struct{}) error {
 			delete(p.infos, info)
 		}
 	}
+	order := p.order[:0]
+	for _, info := range p.order {
+		if _, ok := p.infos[info]; ok {
+			order = append(order, info)
+		}
+	}
+	p.order = order
 	return nil
 }
 
@@ -146,6 +160,67 @@ func (p *Pool) chooseMinFails() (Info, error) {
 	return best, nil
 }
 
+// chooseRoundRobin is a choosing algorithm which cycles through the infos
+// with minimum fail count.
+func (p *Pool) chooseRoundRobin() (Info, error) {
+	candidates := p.minFailInfos()
+	if len(candidates) == 0 {
+		return nil, serrors.New("Unable to find an info instance")
+	}
+	next := atomic.AddUint64(&p.next, 1) - 1
+	return candidates[next%uint64(len(candidates))], nil
+}
+
+// chooseRandom is a choosing algorithm which returns a random info among the
+// infos with minimum fail count.
+func (p *Pool) chooseRandom() (Info, error) {
+	candidates := p.minFailInfos()
+	if len(candidates) == 0 {
+		return nil, serrors.New("Unable to find an info instance")
+	}
+	return candidates[rand.Intn(len(candidates))], nil
+}
+
+// chooseLatencyEWMA is a choosing algorithm which returns the info with the
+// lowest latency among the infos with minimum fail count. Infos without any
+// latency report are returned first.
+func (p *Pool) chooseLatencyEWMA() (Info, error) {
+	var best Info
+	var minLatency time.Duration
+	for _, info := range p.minFailInfos() {
+		latency, ok := info.Latency()
+		if !ok {
+			return info, nil
+		}
+		if best == nil || latency < minLatency {
+			best = info
+			minLatency = latency
+		}
+	}
+	if best == nil {
+		return nil, serrors.New("Unable to find an info instance")
+	}
+	return best, nil
+}
+
+// minFailInfos returns all infos with the minimum fail count in insertion
+// order.
+func (p *Pool) minFailInfos() []Info {
+	var infos []Info
+	var minFail = -1
+	for _, info := range p.order {
+		failCount := info.FailCount()
+		switch {
+		case minFail == -1 || failCount < minFail:
+			infos = append(infos[:0], info)
+			minFail = failCount
+		case failCount == minFail:
+			infos = append(infos, info)
+		}
+	}
+	return infos
+}
+
 // expirer is a wrapper to implement period.Task.
 type expirer Pool
 
@@ -117,6 +117,67 @@ func TestPoolChoose(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestPoolChooseRoundRobin(t *testing.T) {
+	one, two, infos := testInfoSet()
+	three := newTestInfo("three")
+	infos[three] = struct{}{}
+	p, err := NewPool(infos, PoolOptions{Algorithm: RoundRobin})
+	require.NoError(t, err)
+
+	chosen := make(map[Info]int)
+	for i := 0; i < 6; i++ {
+		info, err := p.Choose()
+		require.NoError(t, err)
+		chosen[info]++
+	}
+	assert.Equal(t, map[Info]int{one: 2, two: 2, three: 2}, chosen)
+
+	three.Fail()
+	chosen = make(map[Info]int)
+	for i := 0; i < 4; i++ {
+		info, err := p.Choose()
+		require.NoError(t, err)
+		chosen[info]++
+	}
+	assert.Equal(t, map[Info]int{one: 2, two: 2}, chosen)
+}
+
+func TestPoolChooseRandom(t *testing.T) {
+	one, two, infos := testInfoSet()
+	p, err := NewPool(infos, PoolOptions{Algorithm: Random})
+	require.NoError(t, err)
+
+	two.Fail()
+	for i := 0; i < 10; i++ {
+		info, err := p.Choose()
+		require.NoError(t, err)
+		assert.Equal(t, one, info)
+	}
+}
+
+func TestPoolChooseLatencyEWMA(t *testing.T) {
+	one, two, infos := testInfoSet()
+	p, err := NewPool(infos, PoolOptions{Algorithm: LatencyEWMA})
+	require.NoError(t, err)
+
+	// Infos without latency reports are preferred.
+	one.ReportLatency(10 * time.Millisecond)
+	i, err := p.Choose()
+	require.NoError(t, err)
+	assert.Equal(t, two, i)
+
+	two.ReportLatency(20 * time.Millisecond)
+	i, err = p.Choose()
+	require.NoError(t, err)
+	assert.Equal(t, one, i)
+
+	// The fail count takes precedence over the latency.
+	one.Fail()
+	i, err = p.Choose()
+	require.NoError(t, err)
+	assert.Equal(t, two, i)
+}
+
 func TestPoolChooseEmpty(t *testing.T) {
 	p, err := NewPool(nil, PoolOptions{AllowEmpty: true})
 	require.NoError(t, err)
@@ -16,6 +16,7 @@ package svcinstance
 
 import (
 	"sync"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/healthpool"
@@ -32,6 +33,12 @@ func (i Info) Fail() {
 	i.info.Fail()
 }
 
+// ReportLatency reports the latency of a successful request to the service
+// instance.
+func (i Info) ReportLatency(latency time.Duration) {
+	i.info.ReportLatency(latency)
+}
+
 // Addr returns the service instance address.
 func (i Info) Addr() *addr.AppAddr {
 	return i.info.addrCopy()
@@ -20,7 +20,12 @@
 // Instantiate the pool with a set of service instances. Use choose to
 // select the best info according to the specified choosing algorithm. The
 // caller should keep a reference to the returned Info and call Fail if an
-// error is encountered during the request.
+// error is encountered during the request. If the pool uses the
+// LatencyEWMA algorithm, the caller should call ReportLatency after a
+// successful request.
+//
+// Unless specified otherwise, the pool uses the RoundRobin algorithm, such
+// that the load is spread across all healthy service instances.
 package svcinstance
 
 import (
@@ -48,8 +53,15 @@ type Pool struct {
 	hpool *healthpool.Pool
 }
 
+// DefaultAlgorithm is the choosing algorithm used if none is specified in
+// the pool options.
+const DefaultAlgorithm = healthpool.RoundRobin
+
 // NewPool initializes the pool with the provided service instances and pool options.
 func NewPool(svcInfo topology.IDAddrMap, opts healthpool.PoolOptions) (*Pool, error) {
+	if opts.Algorithm == "" {
+		opts.Algorithm = DefaultAlgorithm
+	}
 	p := &Pool{
 		infos: createMap(svcInfo, nil),
 	}
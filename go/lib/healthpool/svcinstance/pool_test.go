@@ -130,6 +130,21 @@ func TestPoolChoose(t *testing.T) {
 	})
 }
 
+func TestPoolChooseSpreadsLoad(t *testing.T) {
+	Convey("Given a pool with the default algorithm", t, func() {
+		p := mustLoadPool(t)
+		Convey("All healthy instances should be chosen", func() {
+			names := make(map[string]int)
+			for i := 0; i < 4; i++ {
+				i, err := p.Choose()
+				SoMsg("err", err, ShouldBeNil)
+				names[i.Name()]++
+			}
+			SoMsg("Chosen", names, ShouldResemble, map[string]int{ds1: 2, ds2: 2})
+		})
+	})
+}
+
 func TestPoolClose(t *testing.T) {
 	Convey("Given a closed pool", t, func() {
 		p := mustLoadPool(t)
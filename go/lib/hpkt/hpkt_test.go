@@ -131,6 +131,50 @@ func TestScnPktWrite(t *testing.T) {
 	assert.Equal(t, s.Pld, c.Pld, "Payloads must match")
 }
 
+func TestScnPktWriteTCP(t *testing.T) {
+	s := &spkt.ScnPkt{}
+	s.DstIA, _ = addr.IAFromString("42-ff00:0:300")
+	s.SrcIA, _ = addr.IAFromString("42-ff00:0:300")
+	s.DstHost = addr.HostFromIP(net.IPv4(1, 2, 3, 4))
+	s.SrcHost = addr.HostFromIP(net.IPv4(10, 0, 0, 1))
+	s.L4 = &l4.TCP{
+		SrcPort: 1280,
+		DstPort: 80,
+		Seq:     1,
+		Flags:   l4.TCPFlagSYN,
+		Window:  1024,
+		Options: []l4.TCPOption{
+			{Kind: l4.TCPOptMSS, Data: common.RawBytes{0x05, 0xb4}},
+			{Kind: l4.TCPOptNOP},
+			{Kind: l4.TCPOptWindowScale, Data: common.RawBytes{7}},
+		},
+	}
+	s.Pld = common.RawBytes("scion123")
+
+	b := make(common.RawBytes, 1024)
+	n, err := WriteScnPkt(s, b)
+	require.NoError(t, err, "Write error")
+
+	c := &spkt.ScnPkt{}
+	err = ParseScnPkt(c, b[:n])
+	require.NoError(t, err, "Read error")
+	tcp, ok := c.L4.(*l4.TCP)
+	require.True(t, ok, "L4Hdr - Bad header")
+	assert.Equal(t, s.L4, tcp)
+	assert.Equal(t, s.Pld, c.Pld, "Payloads must match")
+
+	// The payload starts after the padding that follows the end of option list.
+	s.L4.(*l4.TCP).Padding = common.RawBytes{0, 0xaa, 0xbb, 0xcc}
+	n, err = WriteScnPkt(s, b)
+	require.NoError(t, err, "Write error")
+	c = &spkt.ScnPkt{}
+	err = ParseScnPkt(c, b[:n])
+	require.NoError(t, err, "Read error")
+	assert.Equal(t, s.L4, c.L4)
+	assert.Equal(t, 32, c.L4.L4Len())
+	assert.Equal(t, s.Pld, c.Pld, "Payloads must match")
+}
+
 func TestParseMalformedPkts(t *testing.T) {
 
 	makeCmnHdr := func(total, header, actual, ltype int) []byte {
@@ -236,6 +236,10 @@ func (p *parseCtx) DefaultL4Parser() error {
 		if p.s.L4, err = scmp.HdrFromRaw(p.b[p.offset : p.offset+scmp.HdrLen]); err != nil {
 			return common.NewBasicError("Unable to parse SCMP header", err)
 		}
+	case common.L4TCP:
+		if p.s.L4, err = l4.TCPFromRaw(p.b[p.offset:]); err != nil {
+			return common.NewBasicError("Unable to parse TCP header", err)
+		}
 	default:
 		return common.NewBasicError("Unsupported NextHdr value", nil,
 			"expected", common.L4UDP, "actual", p.nextHdr)
@@ -250,7 +254,7 @@ func (p *parseCtx) DefaultL4Parser() error {
 		return common.NewBasicError("L4 validation failed", err)
 	}
 	switch p.nextHdr {
-	case common.L4UDP:
+	case common.L4UDP, common.L4TCP:
 		p.s.Pld = common.RawBytes(p.b[p.offset : p.offset+pldLen])
 	case common.L4SCMP:
 		hdr, ok := p.s.L4.(*scmp.Hdr)
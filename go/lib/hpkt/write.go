@@ -88,6 +88,8 @@ func WriteScnPkt(s *spkt.ScnPkt, b common.RawBytes) (int, error) {
 		buffer.PushLayer(layers.LayerTypeSCIONUDP)
 	case common.L4SCMP:
 		buffer.PushLayer(layers.LayerTypeSCMP)
+	case common.L4TCP:
+		buffer.PushLayer(layers.LayerTypeSCIONTCP)
 	default:
 		return 0, common.NewBasicError("Unsupported L4", nil, "type", s.L4.L4Type())
 	}
@@ -21,6 +21,8 @@ import (
 	"context"
 	"crypto/tls"
 	"net"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
@@ -30,9 +32,13 @@ import (
 	"github.com/scionproto/scion/go/lib/infra/disp"
 	"github.com/scionproto/scion/go/lib/infra/messenger"
 	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/keyconf"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
 	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/squic"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
 	"github.com/scionproto/scion/go/lib/sock/reliable/reconnect"
 	"github.com/scionproto/scion/go/lib/svc"
@@ -42,7 +48,14 @@ const (
 	ErrAppUnableToInitMessenger common.ErrMsg = "Unable to initialize SCION Infra Messenger"
 )
 
-var resolutionRequestPayload = []byte{0x00, 0x00, 0x00, 0x00}
+// CertificateRenewalInterval is the interval in which the QUIC server checks
+// whether the local AS certificate chain changed, if UsePKI is set.
+const CertificateRenewalInterval = time.Minute
+
+// ResolutionRequestPayload is the payload of SVC resolution requests. Legacy
+// control payloads have a 4-byte length prefix that is never 0, so servers
+// use it to tell resolution requests apart from legacy SVC traffic.
+var ResolutionRequestPayload = []byte{0x00, 0x00, 0x00, 0x00}
 
 // QUIC contains the QUIC configuration for control-plane speakers.
 type QUIC struct {
@@ -52,6 +65,16 @@ type QUIC struct {
 	CertFile string
 	// KeyFile is the private key to use for QUIC authentication.
 	KeyFile string
+	// UsePKI indicates that the TLS credentials are derived from the AS
+	// certificate chain and signing key instead of CertFile and KeyFile.
+	// Peers are authenticated with their AS certificate chain, which is
+	// verified against the TRCs in the trust store. If there is no AS signing
+	// key in KeyDir, e.g., on end hosts, servers are authenticated, but no
+	// client certificate is presented.
+	UsePKI bool
+	// KeyDir is the directory that contains the AS signing key. It is only
+	// used if UsePKI is set.
+	KeyDir string
 }
 
 // NetworkConfig describes the networking configuration of a SCION
@@ -149,26 +172,31 @@ func (nc *NetworkConfig) AddressRewriter(
 	return &messenger.AddressRewriter{
 		Router:    router,
 		SVCRouter: nc.SVCRouter,
-		Resolver: &svc.Resolver{
-			LocalIA:     nc.IA,
-			ConnFactory: connFactory,
-			Machine:     buildLocalMachine(nc.Bind, nc.Public),
-			// Legacy control payloads have a 4-byte length prefix. A
-			// 0-value for the prefix is invalid, so SVC resolution-aware
-			// servers can use this to detect that the client is attempting
-			// SVC resolution. Legacy SVC traffic sent by legacy clients
-			// will have a non-0 value, and thus not trigger resolution
-			// logic.
-			Payload: resolutionRequestPayload,
+		// The caching resolver spreads requests across all instances of the
+		// remote service and fails over to the next instance without a
+		// fresh resolution.
+		Resolver: &svc.CachingResolver{
+			Resolver: &svc.Resolver{
+				LocalIA:     nc.IA,
+				ConnFactory: connFactory,
+				Machine:     buildLocalMachine(nc.Bind, nc.Public),
+				// Legacy control payloads have a 4-byte length prefix. A
+				// 0-value for the prefix is invalid, so SVC resolution-aware
+				// servers can use this to detect that the client is
+				// attempting SVC resolution. Legacy SVC traffic sent by
+				// legacy clients will have a non-0 value, and thus not
+				// trigger resolution logic.
+				Payload: ResolutionRequestPayload,
+			},
 		},
 		SVCResolutionFraction: nc.SVCResolutionFraction,
 	}
 }
 
-// initUDPSocket creates the main control-plane UDP socket. SVC anycasts will
-// be delivered to this socket, which can be configured to reply to SVC
-// resolution requests. If argument address is not the empty string, it will be
-// included as the QUIC address in SVC resolution replies.
+// initUDPSocket creates the main control-plane UDP socket. SVC anycasts and
+// multicasts will be delivered to this socket, which can be configured to
+// reply to SVC resolution requests. If argument address is not the empty
+// string, it will be included as the QUIC address in SVC resolution replies.
 func (nc *NetworkConfig) initUDPSocket(quicAddress string) (net.PacketConn, error) {
 	reply := messenger.BuildReply(nc.Public.Host)
 	if quicAddress != "" {
@@ -184,7 +212,7 @@ func (nc *NetworkConfig) initUDPSocket(quicAddress string) (net.PacketConn, erro
 	if nc.ReconnectToDispatcher {
 		dispatcherService = reconnect.NewDispatcherService(dispatcherService)
 	}
-	packetDispatcher := svc.NewResolverPacketDispatcher(
+	packetDispatcher := svc.NewMulticastResolverPacketDispatcher(
 		&snet.DefaultPacketDispatcherService{
 			Dispatcher: dispatcherService,
 		},
@@ -192,7 +220,7 @@ func (nc *NetworkConfig) initUDPSocket(quicAddress string) (net.PacketConn, erro
 			BaseHandler: &svc.BaseHandler{
 				Message: udpAddressStr.Bytes(),
 			},
-			ExpectedPayload: resolutionRequestPayload,
+			ExpectedPayload: ResolutionRequestPayload,
 		},
 	)
 	network := snet.NewCustomNetworkWithPR(nc.IA, packetDispatcher)
@@ -235,6 +263,9 @@ func (nc *NetworkConfig) initQUICSocket() (net.PacketConn, error) {
 }
 
 func (nc *NetworkConfig) buildQUICConfig(conn net.PacketConn) (*messenger.QUICConfig, error) {
+	if nc.QUIC.UsePKI {
+		return nc.buildPKIQUICConfig(conn)
+	}
 	cert, err := tls.LoadX509KeyPair(nc.QUIC.CertFile, nc.QUIC.KeyFile)
 	if err != nil {
 		return nil, err
@@ -248,6 +279,66 @@ func (nc *NetworkConfig) buildQUICConfig(conn net.PacketConn) (*messenger.QUICCo
 	}, nil
 }
 
+// buildPKIQUICConfig builds a QUIC configuration with TLS credentials derived
+// from the latest local AS certificate chain and signing key. The server
+// certificate is renewed when the chain changes.
+//
+// End hosts do not have an AS signing key. In that case, servers are still
+// authenticated, but no client certificate is presented. The QUIC server uses
+// the certificate in CertFile and KeyFile, which is not accepted by peers that
+// use the PKI.
+func (nc *NetworkConfig) buildPKIQUICConfig(conn net.PacketConn) (*messenger.QUICConfig,
+	error) {
+
+	keyFile := filepath.Join(nc.QUIC.KeyDir, keyconf.SigKeyFile)
+	if _, err := os.Stat(keyFile); os.IsNotExist(err) {
+		log.Info("No AS signing key, QUIC client certificates disabled", "file", keyFile)
+		return nc.buildClientPKIQUICConfig(conn)
+	}
+	creds := &squic.Credentials{
+		Chain: func(ctx context.Context) (*cert.Chain, error) {
+			return nc.TrustStore.GetChain(ctx, nc.IA, scrypto.LatestVer,
+				infra.ChainOpts{TrustStoreOpts: infra.TrustStoreOpts{LocalOnly: true}})
+		},
+		SignKey: func() (common.RawBytes, error) {
+			keys, err := keyconf.Load(nc.QUIC.KeyDir, false, false, false, false)
+			if err != nil {
+				return nil, common.NewBasicError("Unable to load key config", err)
+			}
+			return keys.SignKey, nil
+		},
+	}
+	tlsConfig, err := squic.NewTLSConfig(creds, nc.TrustStore, false)
+	if err != nil {
+		return nil, err
+	}
+	return &messenger.QUICConfig{
+		Conn:       conn,
+		TLSConfig:  tlsConfig,
+		VerifyPeer: squic.VerifyPeerIA,
+		Reload:     creds.ServerConfigs(tlsConfig, CertificateRenewalInterval),
+	}, nil
+}
+
+func (nc *NetworkConfig) buildClientPKIQUICConfig(conn net.PacketConn) (*messenger.QUICConfig,
+	error) {
+
+	tlsConfig, err := squic.NewTLSConfig(nil, nc.TrustStore, false)
+	if err != nil {
+		return nil, err
+	}
+	staticCert, err := tls.LoadX509KeyPair(nc.QUIC.CertFile, nc.QUIC.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.Certificates = []tls.Certificate{staticCert}
+	return &messenger.QUICConfig{
+		Conn:       conn,
+		TLSConfig:  tlsConfig,
+		VerifyPeer: squic.VerifyPeerIA,
+	}, nil
+}
+
 func buildLocalMachine(bind, public *snet.Addr) snet.LocalMachine {
 	var mi snet.LocalMachine
 	mi.PublicIP = public.Host.L3.IP()
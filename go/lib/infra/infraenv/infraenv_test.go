@@ -0,0 +1,85 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infraenv_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/infra/infraenv"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/mock_snet"
+	"github.com/scionproto/scion/go/lib/svc"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestAddressRewriterCachesResolution(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dstIA := xtest.MustParseIA("1-ff00:0:2")
+	mockPath := mock_snet.NewMockPath(ctrl)
+	mockPath.EXPECT().Path().Return(nil).AnyTimes()
+	mockPath.EXPECT().OverlayNextHop().Return(&net.UDPAddr{}).AnyTimes()
+	mockPath.EXPECT().Destination().Return(dstIA).AnyTimes()
+
+	reply := &svc.Reply{Transports: map[svc.Transport]string{svc.UDP: "192.0.2.1:30041"}}
+	buf := &bytes.Buffer{}
+	require.NoError(t, reply.SerializeTo(buf))
+
+	mockConn := mock_snet.NewMockPacketConn(ctrl)
+	mockConn.EXPECT().SetDeadline(gomock.Any()).AnyTimes()
+	mockConn.EXPECT().Close().AnyTimes()
+	mockConn.EXPECT().WriteTo(gomock.Any(), gomock.Any())
+	var read bool
+	mockConn.EXPECT().ReadFrom(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(pkt *snet.SCIONPacket, _ *net.UDPAddr) error {
+			if read {
+				return errors.New("conn closed")
+			}
+			pkt.Payload = common.RawBytes(buf.Bytes())
+			read = true
+			return nil
+		},
+	).Times(2)
+	// The dispatcher is only contacted for the first lookup, the second one
+	// is served from the cache.
+	mockDispService := mock_snet.NewMockPacketDispatcherService(ctrl)
+	mockDispService.EXPECT().RegisterTimeout(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any()).Return(mockConn, uint16(42), nil).Times(1)
+
+	nc := &infraenv.NetworkConfig{
+		IA: xtest.MustParseIA("1-ff00:0:1"),
+		Public: &snet.Addr{
+			IA:   xtest.MustParseIA("1-ff00:0:1"),
+			Host: &addr.AppAddr{L3: addr.HostFromIPStr("192.0.2.100"), L4: 30255},
+		},
+	}
+	resolver := nc.AddressRewriter(mockDispService).Resolver
+	for i := 0; i < 2; i++ {
+		r, err := resolver.LookupSVC(context.Background(), mockPath, addr.SvcCS)
+		require.NoError(t, err)
+		assert.Equal(t, reply.Transports, r.Transports)
+	}
+}
@@ -36,6 +36,19 @@ type Resolver interface {
 	LookupSVC(ctx context.Context, path snet.Path, svc addr.HostSVC) (*svc.Reply, error)
 }
 
+var _ InstanceReporter = (*svc.CachingResolver)(nil)
+
+// InstanceReporter is implemented by resolvers that keep track of the health
+// of the instances SVC addresses are resolved to, e.g., svc.CachingResolver.
+type InstanceReporter interface {
+	// Fail reports that a request to the instance of the SVC address in the
+	// given AS failed.
+	Fail(ia addr.IA, svc addr.HostSVC, instance *addr.AppAddr)
+	// ReportLatency reports the round trip time of a successful request to
+	// the instance of the SVC address in the given AS.
+	ReportLatency(ia addr.IA, svc addr.HostSVC, instance *addr.AppAddr, latency time.Duration)
+}
+
 // AddressRewriter is used to compute paths and replace SVC destinations with
 // unicast addresses.
 type AddressRewriter struct {
@@ -96,6 +109,40 @@ func (r AddressRewriter) RedirectToQUIC(ctx context.Context, a net.Addr) (net.Ad
 	return fullAddress, quicRedirect, err
 }
 
+// report reports the outcome of a request to the instance that the SVC
+// destination of a was resolved to, if the resolver keeps track of the health
+// of instances. If err is nil, the round trip time of the request is
+// reported, otherwise the failure. Nothing is reported if a is not an SVC
+// address, or if the request was canceled by the caller.
+func (r AddressRewriter) report(ctx context.Context, a, resolved net.Addr,
+	latency time.Duration, err error) {
+
+	if err != nil && ctx.Err() == context.Canceled {
+		return
+	}
+	reporter, ok := r.Resolver.(InstanceReporter)
+	if !ok {
+		return
+	}
+	svcAddr, ok := a.(*snet.Addr)
+	if !ok || svcAddr.Host == nil {
+		return
+	}
+	svc, ok := svcAddr.Host.L3.(addr.HostSVC)
+	if !ok {
+		return
+	}
+	instance, ok := resolved.(*snet.Addr)
+	if !ok || instance.Host == nil {
+		return
+	}
+	if err != nil {
+		reporter.Fail(svcAddr.IA, svc, instance.Host)
+		return
+	}
+	reporter.ReportLatency(svcAddr.IA, svc, instance.Host, latency)
+}
+
 // buildFullAddress checks that a is a well-formed address (all fields set,
 // non-nil, only supported protocols). If the path is missing, the path and
 // next-hop are added by performing a routing lookup. The returned address is
@@ -493,6 +493,10 @@ func (t *testPath) Expiry() time.Time {
 	panic("not implemented")
 }
 
+func (t *testPath) Metadata() *snet.PathMetadata {
+	panic("not implemented")
+}
+
 func (t *testPath) Copy() snet.Path {
 	panic("not implemented")
 }
@@ -508,3 +512,60 @@ func newUDPAppAddr(u *net.UDPAddr) *addr.AppAddr {
 		L4: uint16(u.Port),
 	}
 }
+
+type reportingResolver struct {
+	messenger.Resolver
+	failed    []*addr.AppAddr
+	latencies map[string]time.Duration
+}
+
+func (r *reportingResolver) Fail(_ addr.IA, _ addr.HostSVC, instance *addr.AppAddr) {
+	r.failed = append(r.failed, instance)
+}
+
+func (r *reportingResolver) ReportLatency(_ addr.IA, _ addr.HostSVC, instance *addr.AppAddr,
+	latency time.Duration) {
+
+	if r.latencies == nil {
+		r.latencies = make(map[string]time.Duration)
+	}
+	r.latencies[instance.String()] = latency
+}
+
+func TestReport(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:2")
+	instance := &addr.AppAddr{L3: addr.HostFromIPStr("192.0.2.1"), L4: 30041}
+	svcAddr := &snet.Addr{IA: ia, Host: addr.NewSVCUDPAppAddr(addr.SvcCS)}
+	resolved := &snet.Addr{IA: ia, Host: instance}
+	errTimeout := errors.New("timeout")
+
+	t.Run("failed request", func(t *testing.T) {
+		resolver := &reportingResolver{}
+		r := messenger.AddressRewriter{Resolver: resolver}
+		r.Report(context.Background(), svcAddr, resolved, time.Second, errTimeout)
+		assert.Equal(t, []*addr.AppAddr{instance}, resolver.failed)
+		assert.Empty(t, resolver.latencies)
+	})
+	t.Run("successful request", func(t *testing.T) {
+		resolver := &reportingResolver{}
+		r := messenger.AddressRewriter{Resolver: resolver}
+		r.Report(context.Background(), svcAddr, resolved, time.Millisecond, nil)
+		assert.Empty(t, resolver.failed)
+		assert.Equal(t, map[string]time.Duration{instance.String(): time.Millisecond},
+			resolver.latencies)
+	})
+	t.Run("unicast address", func(t *testing.T) {
+		resolver := &reportingResolver{}
+		r := messenger.AddressRewriter{Resolver: resolver}
+		r.Report(context.Background(), resolved, resolved, time.Second, errTimeout)
+		assert.Empty(t, resolver.failed)
+	})
+	t.Run("canceled request", func(t *testing.T) {
+		resolver := &reportingResolver{}
+		r := messenger.AddressRewriter{Resolver: resolver}
+		ctx, cancelF := context.WithCancel(context.Background())
+		cancelF()
+		r.Report(ctx, svcAddr, resolved, time.Second, errTimeout)
+		assert.Empty(t, resolver.failed)
+	})
+}
@@ -3,6 +3,7 @@ package messenger
 import (
 	"context"
 	"net"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -21,3 +22,9 @@ func (r AddressRewriter) ResolveIfSVC(ctx context.Context, p snet.Path,
 func ParseReply(reply *svc.Reply) (*addr.AppAddr, error) {
 	return parseReply(reply)
 }
+
+func (r AddressRewriter) Report(ctx context.Context, a, resolved net.Addr,
+	latency time.Duration, err error) {
+
+	r.report(ctx, a, resolved, latency, err)
+}
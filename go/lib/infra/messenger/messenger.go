@@ -139,6 +139,12 @@ type QUICConfig struct {
 	Conn       net.PacketConn
 	TLSConfig  *tls.Config
 	QUICConfig *quic.Config
+	// VerifyPeer is called for every QUIC session the Messenger establishes
+	// as a client. See rpc.Client.
+	VerifyPeer func(state quic.ConnectionState, address net.Addr) error
+	// Reload delivers new TLS configurations for the QUIC server. See
+	// rpc.Server.
+	Reload <-chan *tls.Config
 }
 
 func (c *Config) InitDefaults() {
@@ -206,6 +212,7 @@ func New(config *Config) *Messenger {
 			Conn:       config.QUIC.Conn,
 			TLSConfig:  config.QUIC.TLSConfig,
 			QUICConfig: config.QUIC.QUICConfig,
+			VerifyPeer: config.QUIC.VerifyPeer,
 		}
 		quicHandler = &QUICHandler{
 			handlers:     make(map[infra.MessageType]infra.Handler),
@@ -218,6 +225,7 @@ func New(config *Config) *Messenger {
 			TLSConfig:  config.QUIC.TLSConfig,
 			QUICConfig: config.QUIC.QUICConfig,
 			Handler:    quicHandler,
+			Reload:     config.QUIC.Reload,
 		}
 	}
 
@@ -781,7 +789,7 @@ func (m *Messenger) listenAndServeUDP() {
 			continue
 		}
 		debugId := util.GetDebugID()
-		logger := m.log.New("debug_id", debugId)
+		logger := m.log.New(log.KeyDebugID, debugId)
 
 		signedPld, ok := genericMsg.(*ctrl.SignedPld)
 		if !ok {
@@ -986,7 +994,10 @@ func (pr *pathingRequester) Request(ctx context.Context, pld *ctrl.Pld,
 	logger := log.FromCtx(ctx)
 	if redirect && pr.quicRequester != nil {
 		logger.Trace("Request upgraded to QUIC", "remote", newAddr)
-		return pr.quicRequester.Request(ctx, pld, newAddr)
+		start := time.Now()
+		reply, err := pr.quicRequester.Request(ctx, pld, newAddr)
+		pr.addressRewriter.report(ctx, a, newAddr, time.Since(start), err)
+		return reply, err
 	}
 	logger.Trace("Request could not be upgraded to QUIC, using UDP", "remote", newAddr)
 	if downgradeToNotify {
@@ -1039,7 +1050,9 @@ func (r *QUICRequester) Request(ctx context.Context, pld *ctrl.Pld,
 	}
 
 	request := &rpc.Request{Message: msg}
+	start := time.Now()
 	reply, err := r.QUICClientConfig.Request(ctx, request, newAddr)
+	r.AddressRewriter.report(ctx, a, newAddr, time.Since(start), err)
 	log.FromCtx(ctx).Trace("QUICRequester", "err", err)
 	if err != nil {
 		return nil, err
@@ -277,6 +277,7 @@ func (solution *PathSolution) GetFwdPathMetadata() *Path {
 		Weight: solution.cost,
 		Mtu:    ^uint16(0),
 	}
+	var infos staticInfos
 	for edgeIdx, solEdge := range solution.edges {
 		currentSeg := &Segment{
 			Type: solEdge.segment.Type,
@@ -301,6 +302,13 @@ func (solution *PathSolution) GetFwdPathMetadata() *Path {
 			// entry might be used instead, which would override mtu later)
 			forwardingLinkMtu := hopEntry.InMTU
 			inIFID, outIFID = newHF.ConsEgress, newHF.ConsIngress
+			if asEntry.Exts.StaticInfo != nil {
+				infos = append(infos, staticInfo{
+					ia:     asEntry.IA(),
+					egress: newHF.ConsEgress,
+					extn:   asEntry.Exts.StaticInfo,
+				})
+			}
 
 			// If we've transitioned from a previous segment, set Xover flag.
 			if edgeIdx > 0 {
@@ -361,6 +369,7 @@ func (solution *PathSolution) GetFwdPathMetadata() *Path {
 	}
 	path.reverseDownSegment()
 	path.aggregateInterfaces()
+	path.Metadata = infos.metadata(path.Interfaces)
 	return path
 }
 
@@ -0,0 +1,138 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package combinator
+
+import (
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/sciond"
+)
+
+// staticInfo is the static info extension of an AS entry that is traversed by
+// a path.
+type staticInfo struct {
+	ia addr.IA
+	// egress is the egress interface of the AS entry, the intra-AS values of
+	// the extension are relative to it.
+	egress common.IFIDType
+	extn   *seg.StaticInfoExtn
+}
+
+// staticInfos is the list of static info extensions of all AS entries
+// traversed by a path.
+type staticInfos []staticInfo
+
+// inter returns the latency, bandwidth and link type of the inter-AS link
+// attached to the interface.
+func (s staticInfos) inter(intf sciond.PathInterface) (uint32, uint64, seg.LinkType) {
+	var lat uint32
+	var bw uint64
+	var lt seg.LinkType
+	for _, info := range s {
+		if !info.ia.Equal(intf.IA()) {
+			continue
+		}
+		if l, ok := info.extn.LatencyFor(intf.IfID); ok && lat == 0 {
+			lat = l.Inter
+		}
+		if b, ok := info.extn.BandwidthFor(intf.IfID); ok && bw == 0 {
+			bw = b.Inter
+		}
+		if t, ok := info.extn.LinkTypeFor(intf.IfID); ok && lt == seg.LinkTypeUnset {
+			lt = t
+		}
+	}
+	return lat, bw, lt
+}
+
+// intra returns the latency and bandwidth between two interfaces of the same
+// AS. Only extensions of AS entries whose egress interface is one of the two
+// interfaces can provide the values.
+func (s staticInfos) intra(a, b sciond.PathInterface) (uint32, uint64) {
+	var lat uint32
+	var bw uint64
+	for _, info := range s {
+		if !info.ia.Equal(a.IA()) {
+			continue
+		}
+		var other common.IFIDType
+		switch info.egress {
+		case a.IfID:
+			other = b.IfID
+		case b.IfID:
+			other = a.IfID
+		default:
+			continue
+		}
+		if l, ok := info.extn.LatencyFor(other); ok && lat == 0 {
+			lat = l.Intra
+		}
+		if bwInfo, ok := info.extn.BandwidthFor(other); ok && bw == 0 {
+			bw = bwInfo.Intra
+		}
+	}
+	return lat, bw
+}
+
+// geo returns the location of the interface.
+func (s staticInfos) geo(intf sciond.PathInterface) seg.GeoInfo {
+	for _, info := range s {
+		if !info.ia.Equal(intf.IA()) {
+			continue
+		}
+		if g, ok := info.extn.GeoFor(intf.IfID); ok {
+			return g
+		}
+	}
+	return seg.GeoInfo{IfID: intf.IfID}
+}
+
+// metadata aggregates the static info extensions along the interfaces of the
+// path. The result is nil if no AS on the path announced static info.
+func (s staticInfos) metadata(ifaces []sciond.PathInterface) *sciond.PathMetadata {
+	if len(s) == 0 || len(ifaces) == 0 {
+		return nil
+	}
+	m := &sciond.PathMetadata{
+		Latencies:  make([]uint32, len(ifaces)-1),
+		Bandwidths: make([]uint64, len(ifaces)-1),
+		LinkTypes:  make([]seg.LinkType, len(ifaces)/2),
+		Geo:        make([]seg.GeoInfo, len(ifaces)),
+	}
+	for i := 0; i < len(ifaces)-1; i++ {
+		if i%2 == 1 {
+			m.Latencies[i], m.Bandwidths[i] = s.intra(ifaces[i], ifaces[i+1])
+			continue
+		}
+		// The link can be described by either of its ends.
+		lat, bw, lt := s.inter(ifaces[i])
+		rLat, rBw, rLt := s.inter(ifaces[i+1])
+		if lat == 0 {
+			lat = rLat
+		}
+		if bw == 0 {
+			bw = rBw
+		}
+		if lt == seg.LinkTypeUnset {
+			lt = rLt
+		}
+		m.Latencies[i], m.Bandwidths[i], m.LinkTypes[i/2] = lat, bw, lt
+	}
+	for i, intf := range ifaces {
+		m.Geo[i] = s.geo(intf)
+	}
+	return m
+}
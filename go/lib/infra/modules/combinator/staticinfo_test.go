@@ -0,0 +1,97 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package combinator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestStaticInfosMetadata(t *testing.T) {
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	ia112 := xtest.MustParseIA("1-ff00:0:112")
+	intf := func(ia addr.IA, ifid common.IFIDType) sciond.PathInterface {
+		return sciond.PathInterface{RawIsdas: ia.IAInt(), IfID: ifid}
+	}
+	ifaces := []sciond.PathInterface{
+		intf(ia110, 1), intf(ia111, 2), intf(ia111, 3), intf(ia112, 4),
+	}
+	zurich := seg.GeoInfo{IfID: 1, Latitude: 47.3769, Longitude: 8.5417, Address: "Zurich"}
+
+	t.Run("no static info", func(t *testing.T) {
+		var infos staticInfos
+		assert.Nil(t, infos.metadata(ifaces))
+	})
+	t.Run("aggregated along the path", func(t *testing.T) {
+		infos := staticInfos{
+			{
+				ia:     ia110,
+				egress: 1,
+				extn: &seg.StaticInfoExtn{
+					Latency:  []seg.LatencyInfo{{IfID: 1, Inter: 5000}},
+					LinkType: []seg.LinkTypeInfo{{IfID: 1, LinkType: seg.LinkTypeDirect}},
+					Geo:      []seg.GeoInfo{zurich},
+				},
+			},
+			{
+				ia:     ia111,
+				egress: 3,
+				extn: &seg.StaticInfoExtn{
+					Latency: []seg.LatencyInfo{
+						{IfID: 2, Intra: 1000},
+						{IfID: 3, Inter: 7000},
+					},
+					Bandwidth: []seg.BandwidthInfo{{IfID: 2, Intra: 100, Inter: 50}},
+				},
+			},
+			{
+				ia:     ia112,
+				egress: 0,
+				extn: &seg.StaticInfoExtn{
+					LinkType: []seg.LinkTypeInfo{{IfID: 4, LinkType: seg.LinkTypeOpenNet}},
+				},
+			},
+		}
+		expected := &sciond.PathMetadata{
+			Latencies:  []uint32{5000, 1000, 7000},
+			Bandwidths: []uint64{50, 100, 0},
+			LinkTypes:  []seg.LinkType{seg.LinkTypeDirect, seg.LinkTypeOpenNet},
+			Geo: []seg.GeoInfo{
+				zurich, {IfID: 2}, {IfID: 3}, {IfID: 4},
+			},
+		}
+		assert.Equal(t, expected, infos.metadata(ifaces))
+	})
+	t.Run("intra values require matching egress", func(t *testing.T) {
+		infos := staticInfos{
+			{
+				ia:     ia111,
+				egress: 5,
+				extn: &seg.StaticInfoExtn{
+					Latency: []seg.LatencyInfo{{IfID: 2, Intra: 1000}},
+				},
+			},
+		}
+		assert.Equal(t, []uint32{0, 0, 0}, infos.metadata(ifaces).Latencies)
+	})
+}
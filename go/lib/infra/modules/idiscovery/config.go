@@ -104,6 +104,9 @@ type FetchConfig struct {
 	Timeout util.DurWrap
 	// Https indicates whether https must be used to fetch the topology.
 	Https bool
+	// Signed indicates whether the topology must be signed by the local AS.
+	// Topologies without a valid signature are rejected.
+	Signed bool
 	// Connect contains the parameters for the initial connection
 	// check to the discovery service.
 	Connect ConnectParams
@@ -26,6 +26,11 @@
 // By default changes to the semi-mutable section of static topologies is
 // not allowed. It can be enabled by providing a custom topo handler.
 //
+// If Signed is set in the fetch configuration, only topologies signed by the
+// local AS are accepted. The signature is verified with the provided verifier.
+// Independent of the signature, topologies that are older than the most recent
+// accepted topology, or the topology loaded at startup, are rejected.
+//
 // The periodic.Runner for the static topology can be instructed to
 // write updated versions to the file system. To enable this, set
 // the filename in StaticConfig.
@@ -45,6 +50,7 @@ import (
 	"github.com/scionproto/scion/go/lib/discovery"
 	"github.com/scionproto/scion/go/lib/discovery/topofetcher"
 	"github.com/scionproto/scion/go/lib/fatal"
+	"github.com/scionproto/scion/go/lib/infra"
 	"github.com/scionproto/scion/go/lib/infra/modules/idiscovery/internal/metrics"
 	"github.com/scionproto/scion/go/lib/infra/modules/itopo"
 	"github.com/scionproto/scion/go/lib/log"
@@ -101,11 +107,15 @@ type Runners struct {
 }
 
 // StartRunners starts the runners for the specified configuration. In case the topo handler
-// function is not set, StartRunners defaults to setting the topology in itopo.
+// function is not set, StartRunners defaults to setting the topology in itopo. The verifier
+// is used to verify signed topologies. It must be set if signed topologies are required.
 func StartRunners(cfg Config, file discovery.File, handlers TopoHandlers,
-	client *http.Client, caller string) (Runners, error) {
+	verifier infra.Verifier, client *http.Client, caller string) (Runners, error) {
 
 	cfg.InitDefaults()
+	if (cfg.Static.Signed || cfg.Dynamic.Signed) && verifier == nil {
+		return Runners{}, serrors.New("Verifier required for signed topologies")
+	}
 	var err error
 	r := Runners{}
 	if cfg.Static.Enable {
@@ -118,6 +128,7 @@ func StartRunners(cfg Config, file discovery.File, handlers TopoHandlers,
 				File:  file,
 			},
 			cfg.Static.Filename,
+			verifierFor(cfg.Static.FetchConfig, verifier),
 			client,
 			caller,
 		)
@@ -135,6 +146,7 @@ func StartRunners(cfg Config, file discovery.File, handlers TopoHandlers,
 				File:  file,
 			},
 			"",
+			verifierFor(cfg.Dynamic, verifier),
 			client,
 			caller,
 		)
@@ -148,6 +160,15 @@ func StartRunners(cfg Config, file discovery.File, handlers TopoHandlers,
 	return r, nil
 }
 
+// verifierFor returns the verifier bound to the local AS, if the configuration
+// requires signed topologies. Otherwise, nil is returned.
+func verifierFor(cfg FetchConfig, verifier infra.Verifier) infra.Verifier {
+	if !cfg.Signed {
+		return nil
+	}
+	return verifier.WithIA(itopo.Get().IA())
+}
+
 // Kill kills all runners.
 func (r *Runners) Kill() {
 	if r.Static != nil {
@@ -193,7 +214,8 @@ func (r *Runner) Kill() {
 // If during the InitialPeriod no topology is successfully fetched, the process takes
 // the configured FailAction.
 func startPeriodicFetcher(cfg FetchConfig, handler TopoHandler, params discovery.FetchParams,
-	filename string, client *http.Client, caller string) (*Runner, error) {
+	filename string, verifier infra.Verifier, client *http.Client,
+	caller string) (*Runner, error) {
 
 	fatal.Check()
 	r := &Runner{
@@ -203,7 +225,7 @@ func startPeriodicFetcher(cfg FetchConfig, handler TopoHandler, params discovery
 			c: make(chan struct{}),
 		},
 	}
-	fetcher, err := NewFetcher(r.handler, params, filename, client, caller)
+	fetcher, err := NewFetcher(r.handler, params, filename, verifier, client, caller)
 	if err != nil {
 		return nil, err
 	}
@@ -293,9 +315,10 @@ type task struct {
 
 // NewFetcher creates a periodic.Task that fetches the topology from the discovery
 // service and calls the provided handler on the received topology. If the handler
-// indicates an update, and filename is set, the topology is written.
-func NewFetcher(handler TopoHandler, params discovery.FetchParams,
-	filename string, client *http.Client, caller string) (*task, error) {
+// indicates an update, and filename is set, the topology is written. If verifier
+// is set, only topologies with a valid signature are handled.
+func NewFetcher(handler TopoHandler, params discovery.FetchParams, filename string,
+	verifier infra.Verifier, client *http.Client, caller string) (*task, error) {
 
 	if handler == nil {
 		return nil, serrors.New("handler must not be nil")
@@ -320,6 +343,9 @@ func NewFetcher(handler TopoHandler, params discovery.FetchParams,
 	if err != nil {
 		return nil, common.NewBasicError("Unable to initialize fetcher", err)
 	}
+	t.fetcher.Verifier = verifier
+	// Prevent rolling back the topology that is loaded at startup.
+	t.fetcher.SetLastTimestamp(itopo.Get().Raw().Timestamp)
 	return t, nil
 }
 
@@ -25,8 +25,10 @@ import (
 func InitTestConfig(cfg *idiscovery.Config) {
 	cfg.Dynamic.Enable = true
 	cfg.Dynamic.Https = true
+	cfg.Dynamic.Signed = true
 	cfg.Static.Enable = true
 	cfg.Static.Https = true
+	cfg.Static.Signed = true
 	cfg.Static.Filename = "topology.json"
 }
 
@@ -44,6 +46,7 @@ func checkCommon(t *testing.T, cfg idiscovery.FetchConfig) {
 	assert.False(t, cfg.Enable)
 	assert.Equal(t, idiscovery.DefaultFetchTimeout, cfg.Timeout.Duration)
 	assert.False(t, cfg.Https)
+	assert.False(t, cfg.Signed)
 	assert.Equal(t, idiscovery.DefaultInitialConnectPeriod, cfg.Connect.InitialPeriod.Duration)
 	assert.Equal(t, idiscovery.FailActionContinue, cfg.Connect.FailAction)
 }
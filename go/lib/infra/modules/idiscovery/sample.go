@@ -27,6 +27,9 @@ Timeout = "1s"
 # Require https connection. (default false)
 Https = false
 
+# Require the topology to be signed by the local AS. (default false)
+Signed = false
+
 # Filename where the updated static topologies are written. In case of the
 # empty string, the updated topologies are not written. (default "")
 Filename = ""
@@ -44,6 +47,9 @@ Timeout = "1s"
 
 # Require https connection. (default false)
 Https = false
+
+# Require the topology to be signed by the local AS. (default false)
+Signed = false
 `
 
 const connectSample = `
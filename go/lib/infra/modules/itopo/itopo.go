@@ -81,6 +81,17 @@ func Get() Topology {
 	}
 }
 
+// Topologies returns the static and the dynamic topology. The dynamic
+// topology is nil if it is not set. Both are nil if itopo is not initialized.
+func Topologies() (static, dynamic *topology.Topo) {
+	if st == nil {
+		return nil, nil
+	}
+	st.RLock()
+	defer st.RUnlock()
+	return st.topo.static, st.topo.dynamic
+}
+
 // SetDynamic atomically sets the dynamic topology. The returned topology is a pointer
 // to the currently active topology at the end of the function call. It might differ from
 // the input topology. The second return value indicates whether the in-memory
@@ -335,6 +346,8 @@ func (s *state) updateStatic(static *topology.Topo) {
 		metrics.Current.Active().Set(0)
 	}
 	s.topo.static = static
+	// The IA is immutable, JSON log records are tagged with it.
+	log.SetIA(static.ISD_AS.String())
 	call(s.clbks.UpdateStatic)
 	cl := metrics.CurrentLabels{Type: metrics.Static}
 	metrics.Current.Timestamp(cl).Set(metrics.Timestamp(static.Timestamp))
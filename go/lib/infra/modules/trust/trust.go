@@ -60,6 +60,9 @@ var (
 	// ErrNotFound indicates that a chain or TRC was not found even after a
 	// network lookup.
 	ErrNotFound = serrors.New("chain/TRC not found")
+	// ErrNoMessenger indicates that a chain or TRC was not found locally, and
+	// no messenger is set to fetch it over the network.
+	ErrNoMessenger = serrors.New("chain/TRC not found locally and no messenger set")
 	// ErrChainVerification indicates the chain verification failed.
 	ErrChainVerification = errors.New("chain verification failed")
 	// ErrParse indicates the trust material could not be parsed.
@@ -283,6 +286,9 @@ func (store *Store) getTRCFromNetwork(ctx context.Context, req *trcRequest) (*tr
 	var span opentracing.Span
 	span, ctx = opentracing.StartSpanFromContext(ctx, "getTRCFromNet")
 	defer span.Finish()
+	if store.msger == nil {
+		return nil, serrors.WithCtx(ErrNoMessenger, "isd", req.isd, "version", req.version)
+	}
 	responseC, cancelF, span := store.trcDeduper.Request(ctx, req)
 	defer cancelF()
 	defer span.Finish()
@@ -374,10 +380,11 @@ func (store *Store) getChain(ctx context.Context, ia addr.IA, version scrypto.Ve
 		metrics.Store.Lookup(l.WithResult(metrics.OkCached)).Inc()
 		return chain, nil
 	}
+	// The topology is only consulted for the CS, other services might not
+	// have a topology provider.
 	isCS := store.config.ServiceType == proto.ServiceType_cs
-	isCore := store.config.TopoProvider.Get().Core()
 	if (isCS && store.ia.Equal(ia)) ||
-		(isCS && isCore && store.ia.I == ia.I) ||
+		(isCS && store.config.TopoProvider.Get().Core() && store.ia.I == ia.I) ||
 		(store.config.MustHaveLocalChain && store.ia.Equal(ia) && version.IsLatest()) {
 		// Either:
 		// - CS can't find a cert for its own AS
@@ -532,6 +539,9 @@ func (store *Store) getChainFromNetwork(ctx context.Context,
 	var span opentracing.Span
 	span, ctx = opentracing.StartSpanFromContext(ctx, "getChainFromNetwork")
 	defer span.Finish()
+	if store.msger == nil {
+		return nil, serrors.WithCtx(ErrNoMessenger, "ia", req.ia, "version", req.version)
+	}
 	responseC, cancelF, span := store.chainDeduper.Request(ctx, req)
 	defer cancelF()
 	defer span.Finish()
@@ -750,11 +760,10 @@ func (store *Store) isLocal(address net.Addr) error {
 //  * a local core CS if destination is isd-local or any core CS.
 //  * a remote core CS if destination is remote isd.
 func (store *Store) ChooseServer(ctx context.Context, destination addr.IA) (net.Addr, error) {
-	topo := store.config.TopoProvider.Get()
 	if store.config.ServiceType != proto.ServiceType_cs {
 		return &snet.Addr{IA: store.ia, Host: addr.NewSVCUDPAppAddr(addr.SvcCS)}, nil
 	}
-	destISD, err := store.chooseDestCSIsd(ctx, destination, topo)
+	destISD, err := store.chooseDestCSIsd(ctx, destination, store.config.TopoProvider.Get())
 	if err != nil {
 		return nil, common.NewBasicError("Unable to determine dest ISD to query", err)
 	}
@@ -802,6 +811,11 @@ func (store *Store) NewSigner(key common.RawBytes, meta infra.SignerMeta) (infra
 	return NewBasicSigner(key, meta)
 }
 
+// VerifyChain verifies the chain based on the TRCs present in the store.
+func (store *Store) VerifyChain(ctx context.Context, subject addr.IA, chain *cert.Chain) error {
+	return VerifyChain(ctx, subject, chain, store)
+}
+
 func (store *Store) NewVerifier() infra.Verifier {
 	return NewBasicVerifier(store)
 }
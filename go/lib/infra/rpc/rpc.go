@@ -52,20 +52,38 @@ type Server struct {
 	QUICConfig *quic.Config
 	// Handler is called for every RPC Request receivd by the server.
 	Handler Handler
+	// Reload, if set, delivers new TLS configurations, e.g., with a renewed
+	// certificate. The server restarts the listener with the new
+	// configuration, because the QUIC library ignores
+	// tls.Config.GetCertificate. Active QUIC connections are torn down.
+	Reload <-chan *tls.Config
 
 	mu sync.Mutex
 	// listener is the conn to accept connections on.
 	listener quic.Listener
+	// closed indicates that the server has been closed.
+	closed bool
 }
 
 func (s *Server) ListenAndServe() error {
 	if err := s.initListener(); err != nil {
 		return err
 	}
+	if s.Reload != nil {
+		go func() {
+			defer log.LogPanicAndExit()
+			s.reload()
+		}()
+	}
 	for {
-		session, err := s.listener.Accept()
+		listener := s.getListener()
+		session, err := listener.Accept()
 		if err != nil {
 			if strings.Contains(err.Error(), "server closed") {
+				if s.getListener() != listener {
+					// The listener has been restarted by reload.
+					continue
+				}
 				return err
 			}
 			log.Warn("[quic] server accept error", "err", err)
@@ -92,6 +110,45 @@ func (s *Server) initListener() error {
 	return nil
 }
 
+func (s *Server) getListener() quic.Listener {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.listener
+}
+
+// reload restarts the listener for every configuration received on Reload,
+// until the server is closed.
+func (s *Server) reload() {
+	for cfg := range s.Reload {
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			return
+		}
+		// The old listener must be closed first. Closing it tears down all
+		// server sessions on the conn, including the ones of a new listener.
+		if err := s.listener.Close(); err != nil {
+			log.Warn("[quic] Unable to close listener on reload", "err", err)
+		}
+		listener, err := quic.Listen(s.Conn, cfg, s.QUICConfig)
+		if err != nil {
+			log.Error("[quic] Unable to listen with new TLS config, keeping old config",
+				"err", err)
+			cfg = s.TLSConfig
+			listener, err = quic.Listen(s.Conn, cfg, s.QUICConfig)
+		}
+		if err != nil {
+			// ListenAndServe returns, because the listener is closed.
+			log.Error("[quic] Unable to restart listener", "err", err)
+			s.mu.Unlock()
+			return
+		}
+		s.TLSConfig, s.listener = cfg, listener
+		log.Info("[quic] Listener restarted with new TLS config")
+		s.mu.Unlock()
+	}
+}
+
 // Close closes the Server's listener. All active QUIC connections are
 // immediately torn down. It is safe to call close multiple times.
 func (s *Server) Close() error {
@@ -101,6 +158,7 @@ func (s *Server) Close() error {
 		// Close on non-listening server is a no-op
 		return nil
 	}
+	s.closed = true
 	return s.listener.Close()
 }
 
@@ -133,6 +191,10 @@ type Client struct {
 	TLSConfig *tls.Config
 	// QUICConfig is the client's QUIC configuration.
 	QUICConfig *quic.Config
+	// VerifyPeer is called after the QUIC session to the server has been
+	// established. If it returns an error, the session is closed and the
+	// request fails. If it is nil, the server is not checked.
+	VerifyPeer func(state quic.ConnectionState, address net.Addr) error
 }
 
 // Request sends the request to the host described by address, and blocks until
@@ -146,6 +208,12 @@ func (c *Client) Request(ctx context.Context, request *Request, address net.Addr
 	if err != nil {
 		return nil, err
 	}
+	if c.VerifyPeer != nil {
+		if err := c.VerifyPeer(session.ConnectionState(), address); err != nil {
+			session.Close()
+			return nil, err
+		}
+	}
 
 	stream, err := session.OpenStream()
 	if err != nil {
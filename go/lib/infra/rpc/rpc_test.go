@@ -15,18 +15,26 @@
 package rpc
 
 import (
+	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
+	"crypto/x509"
+	"math/big"
 	"net"
 	"testing"
 	"time"
 
+	"github.com/lucas-clemente/quic-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	capnp "zombiezen.com/go/capnproto2"
 	"zombiezen.com/go/capnproto2/pogs"
 
 	"github.com/scionproto/scion/go/lib/ctrl"
+	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/proto"
 )
 
@@ -90,6 +98,75 @@ func TestClientServer(t *testing.T) {
 	assert.Equal(t, mustMarshalMessage(t, getMessage(t)), mustMarshalMessage(t, reply.Message))
 }
 
+func TestClientVerifyPeer(t *testing.T) {
+	client, server, cleaner := getCliSrv(t, 60004, 60005)
+	defer cleaner()
+	go server.ListenAndServe()
+	client.VerifyPeer = func(quic.ConnectionState, net.Addr) error {
+		return serrors.New("untrusted")
+	}
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+	_, err := client.Request(
+		ctx,
+		&Request{Message: getMessage(t)},
+		&net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 60005},
+	)
+	assert.Error(t, err)
+}
+
+func TestServerReload(t *testing.T) {
+	client, server, cleaner := getCliSrv(t, 60006, 60007)
+	defer cleaner()
+	reload := make(chan *tls.Config)
+	server.Reload = reload
+	go server.ListenAndServe()
+	defer server.Close()
+
+	renewed := newSelfSignedCert(t)
+	var peerCert []byte
+	client.VerifyPeer = func(state quic.ConnectionState, _ net.Addr) error {
+		peerCert = state.PeerCertificates[0].Raw
+		return nil
+	}
+	request := func() error {
+		ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+		defer cancelF()
+		_, err := client.Request(
+			ctx,
+			&Request{Message: getMessage(t)},
+			&net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 60007},
+		)
+		return err
+	}
+	require.NoError(t, request())
+	assert.NotEqual(t, renewed.Certificate[0], peerCert)
+
+	reload <- &tls.Config{Certificates: []tls.Certificate{renewed}}
+	var err error
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); {
+		if err = request(); err == nil && bytes.Equal(renewed.Certificate[0], peerCert) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.NoError(t, err)
+	assert.Equal(t, renewed.Certificate[0], peerCert)
+}
+
+func newSelfSignedCert(t *testing.T) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
 func MustLoadCertificate(pem, key string) tls.Certificate {
 	cert, err := tls.LoadX509KeyPair(pem, key)
 	if err != nil {
@@ -14,8 +14,326 @@
 
 package l4
 
-type TCP struct{}
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+const (
+	// TCPMinLen is the length of a TCP header without options.
+	TCPMinLen = 20
+	// TCPMaxLen is the maximum length of a TCP header including options.
+	TCPMaxLen = 60
+)
+
+// TCPFlags contains the control bits of a TCP header.
+type TCPFlags uint16
+
+const (
+	TCPFlagFIN TCPFlags = 1 << iota
+	TCPFlagSYN
+	TCPFlagRST
+	TCPFlagPSH
+	TCPFlagACK
+	TCPFlagURG
+	TCPFlagECE
+	TCPFlagCWR
+	TCPFlagNS
+
+	tcpFlagsMask = 1<<9 - 1
+)
+
+var tcpFlagNames = []string{"FIN", "SYN", "RST", "PSH", "ACK", "URG", "ECE", "CWR", "NS"}
+
+func (f TCPFlags) String() string {
+	var names []string
+	for i, name := range tcpFlagNames {
+		if f&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// TCPOptionKind is the kind of a TCP option.
+type TCPOptionKind uint8
+
+const (
+	TCPOptEOL           TCPOptionKind = 0
+	TCPOptNOP           TCPOptionKind = 1
+	TCPOptMSS           TCPOptionKind = 2
+	TCPOptWindowScale   TCPOptionKind = 3
+	TCPOptSACKPermitted TCPOptionKind = 4
+	TCPOptSACK          TCPOptionKind = 5
+	TCPOptTimestamps    TCPOptionKind = 8
+)
+
+// TCPOption is a TCP option. The end of option list is not represented as an
+// option, instead the option list is padded when it is written.
+type TCPOption struct {
+	Kind TCPOptionKind
+	// Data contains the option data without kind and length. It is empty for
+	// the no-operation option.
+	Data common.RawBytes
+}
+
+func (o TCPOption) len() int {
+	if o.Kind == TCPOptNOP {
+		return 1
+	}
+	return 2 + len(o.Data)
+}
+
+var _ L4Header = (*TCP)(nil)
+
+// TCP is a TCP header. The data offset is derived from the options and the
+// padding.
+type TCP struct {
+	SrcPort  uint16
+	DstPort  uint16
+	Seq      uint32
+	Ack      uint32
+	Flags    TCPFlags
+	Window   uint16
+	Checksum common.RawBytes `struct:"[2]byte"`
+	Urgent   uint16
+	Options  []TCPOption
+	// Padding contains the bytes between the options and the data offset,
+	// starting with the end of option list, as parsed. It is written after
+	// the options, such that parsed headers are written unmodified.
+	Padding common.RawBytes
+}
+
+// TCPFromRaw parses the TCP header at the start of b. The buffer can contain
+// the payload after the header.
+func TCPFromRaw(b common.RawBytes) (*TCP, error) {
+	t := &TCP{Checksum: make(common.RawBytes, 2)}
+	if err := t.Parse(b); err != nil {
+		return nil, common.NewBasicError("Error unpacking TCP header", err)
+	}
+	return t, nil
+}
+
+// Validate checks that the options fit into the header. TCP does not carry
+// the payload length, so plen is not checked.
+func (t *TCP) Validate(plen int) error {
+	if l := t.L4Len(); l > TCPMaxLen {
+		return common.NewBasicError("TCP header too long", nil,
+			"max", TCPMaxLen, "actual", l)
+	}
+	return nil
+}
+
+func (t *TCP) Parse(b common.RawBytes) error {
+	if len(b) < TCPMinLen {
+		return common.NewBasicError("Buffer is shorter than the TCP header", nil,
+			"expected", TCPMinLen, "actual", len(b))
+	}
+	hdrLen := int(b[12]>>4) * 4
+	if hdrLen < TCPMinLen {
+		return common.NewBasicError("Invalid TCP data offset", nil, "hdrLen", hdrLen)
+	}
+	if len(b) < hdrLen {
+		return common.NewBasicError("Buffer is shorter than the TCP header", nil,
+			"expected", hdrLen, "actual", len(b))
+	}
+	offset := 0
+	t.SrcPort = common.Order.Uint16(b[offset:])
+	offset += 2
+	t.DstPort = common.Order.Uint16(b[offset:])
+	offset += 2
+	t.Seq = common.Order.Uint32(b[offset:])
+	offset += 4
+	t.Ack = common.Order.Uint32(b[offset:])
+	offset += 4
+	t.Flags = TCPFlags(common.Order.Uint16(b[offset:]) & tcpFlagsMask)
+	offset += 2
+	t.Window = common.Order.Uint16(b[offset:])
+	offset += 2
+	if len(t.Checksum) != 2 {
+		t.Checksum = make(common.RawBytes, 2)
+	}
+	copy(t.Checksum, b[offset:])
+	offset += 2
+	t.Urgent = common.Order.Uint16(b[offset:])
+	offset += 2
+	options, padding, err := parseTCPOptions(b[offset:hdrLen])
+	if err != nil {
+		return err
+	}
+	t.Options = options
+	t.Padding = nil
+	if len(padding) != 0 {
+		t.Padding = append(common.RawBytes(nil), padding...)
+	}
+	return nil
+}
+
+// parseTCPOptions parses the options in b. It returns the options and the
+// bytes starting at the end of option list.
+func parseTCPOptions(b common.RawBytes) ([]TCPOption, common.RawBytes, error) {
+	var options []TCPOption
+	for len(b) > 0 {
+		kind := TCPOptionKind(b[0])
+		switch kind {
+		case TCPOptEOL:
+			return options, b, nil
+		case TCPOptNOP:
+			options = append(options, TCPOption{Kind: kind})
+			b = b[1:]
+			continue
+		}
+		if len(b) < 2 {
+			return nil, nil, common.NewBasicError("Truncated TCP option", nil, "kind", kind)
+		}
+		optLen := int(b[1])
+		if optLen < 2 || optLen > len(b) {
+			return nil, nil, common.NewBasicError("Invalid TCP option length", nil,
+				"kind", kind, "len", optLen, "available", len(b))
+		}
+		data := append(common.RawBytes(nil), b[2:optLen]...)
+		options = append(options, TCPOption{Kind: kind, Data: data})
+		b = b[optLen:]
+	}
+	return options, nil, nil
+}
+
+func (t *TCP) Pack(csum bool) (common.RawBytes, error) {
+	b := make(common.RawBytes, t.L4Len())
+	if err := t.Write(b); err != nil {
+		return nil, common.NewBasicError("Error packing TCP header", err)
+	}
+	if csum {
+		// Zero out the checksum field if this is being used for checksum calculation.
+		b[16] = 0
+		b[17] = 0
+	}
+	return b, nil
+}
+
+func (t *TCP) Write(b common.RawBytes) error {
+	hdrLen := t.L4Len()
+	if hdrLen > TCPMaxLen {
+		return common.NewBasicError("TCP options too long", nil,
+			"max", TCPMaxLen, "actual", hdrLen)
+	}
+	if len(b) < hdrLen {
+		return common.NewBasicError("Buffer is shorter than the TCP header", nil,
+			"expected", hdrLen, "actual", len(b))
+	}
+	offset := 0
+	common.Order.PutUint16(b[offset:], t.SrcPort)
+	offset += 2
+	common.Order.PutUint16(b[offset:], t.DstPort)
+	offset += 2
+	common.Order.PutUint32(b[offset:], t.Seq)
+	offset += 4
+	common.Order.PutUint32(b[offset:], t.Ack)
+	offset += 4
+	common.Order.PutUint16(b[offset:], uint16(hdrLen/4)<<12|uint16(t.Flags&tcpFlagsMask))
+	offset += 2
+	common.Order.PutUint16(b[offset:], t.Window)
+	offset += 2
+	copy(b[offset:offset+2], t.Checksum)
+	offset += 2
+	common.Order.PutUint16(b[offset:], t.Urgent)
+	offset += 2
+	for _, o := range t.Options {
+		b[offset] = uint8(o.Kind)
+		if o.Kind == TCPOptNOP {
+			offset++
+			continue
+		}
+		b[offset+1] = uint8(o.len())
+		offset += 2 + copy(b[offset+2:], o.Data)
+	}
+	// Pad with the parsed padding and the end of option list.
+	offset += copy(b[offset:hdrLen], t.Padding)
+	zeroMemory(b[offset:hdrLen])
+	return nil
+}
+
+// MSS returns the value of the maximum segment size option, if present.
+func (t *TCP) MSS() (uint16, bool) {
+	for _, o := range t.Options {
+		if o.Kind == TCPOptMSS && len(o.Data) == 2 {
+			return common.Order.Uint16(o.Data), true
+		}
+	}
+	return 0, false
+}
+
+// SetMSS sets the value of the maximum segment size option. The option is
+// added if it is not present. The checksum is not updated.
+func (t *TCP) SetMSS(mss uint16) {
+	data := make(common.RawBytes, 2)
+	common.Order.PutUint16(data, mss)
+	for i, o := range t.Options {
+		if o.Kind == TCPOptMSS {
+			t.Options[i].Data = data
+			return
+		}
+	}
+	t.Options = append(t.Options, TCPOption{Kind: TCPOptMSS, Data: data})
+}
+
+func (t *TCP) GetCSum() common.RawBytes {
+	return t.Checksum
+}
+
+func (t *TCP) SetCSum(csum common.RawBytes) {
+	t.Checksum = csum
+}
+
+// SetPldLen is a no-op, because the TCP header does not contain the payload
+// length.
+func (t *TCP) SetPldLen(pldLen int) {}
+
+func (t *TCP) Copy() L4Header {
+	c := *t
+	c.Checksum = append(common.RawBytes(nil), t.Checksum...)
+	if t.Padding != nil {
+		c.Padding = append(common.RawBytes(nil), t.Padding...)
+	}
+	if t.Options != nil {
+		c.Options = make([]TCPOption, 0, len(t.Options))
+		for _, o := range t.Options {
+			c.Options = append(c.Options,
+				TCPOption{Kind: o.Kind, Data: append(common.RawBytes(nil), o.Data...)})
+		}
+	}
+	return &c
+}
+
+// L4Len returns the length of the header including the options and the
+// padding, rounded up to a multiple of 4 bytes. For parsed headers, it is the
+// length indicated by the data offset.
+func (t *TCP) L4Len() int {
+	l := TCPMinLen + len(t.Padding)
+	for _, o := range t.Options {
+		l += o.len()
+	}
+	return (l + 3) &^ 3
+}
+
+func (t *TCP) L4Type() common.L4ProtocolType {
+	return common.L4TCP
+}
+
+func (t *TCP) Reverse() {
+	t.SrcPort, t.DstPort = t.DstPort, t.SrcPort
+}
 
 func (t *TCP) String() string {
-	return "<TCP header isn't supported>"
+	return fmt.Sprintf("SPort=%v DPort=%v Seq=%v Ack=%v Flags=%v Window=%v Checksum=%v "+
+		"Urgent=%v Options=%v", t.SrcPort, t.DstPort, t.Seq, t.Ack, t.Flags, t.Window,
+		t.Checksum, t.Urgent, t.Options)
+}
+
+func zeroMemory(b common.RawBytes) {
+	for i := range b {
+		b[i] = 0
+	}
 }
@@ -0,0 +1,175 @@
+// Copyright 2019 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+// rawTCP is a SYN with the options MSS=1460, NOP and window scale 7.
+var rawTCP = common.RawBytes{
+	0x12, 0x34, 0x56, 0x78, // ports
+	0, 0, 0, 1, // seq
+	0, 0, 0, 0, // ack
+	0x70, 0x02, // data offset 7, SYN
+	0x04, 0x00, // window
+	0xab, 0xcd, // checksum
+	0, 0, // urgent
+	0x02, 0x04, 0x05, 0xb4, // MSS
+	0x01,             // NOP
+	0x03, 0x03, 0x07, // window scale
+}
+
+func createTCP() *TCP {
+	return &TCP{
+		SrcPort:  0x1234,
+		DstPort:  0x5678,
+		Seq:      1,
+		Flags:    TCPFlagSYN,
+		Window:   1024,
+		Checksum: common.RawBytes{0xab, 0xcd},
+		Options: []TCPOption{
+			{Kind: TCPOptMSS, Data: common.RawBytes{0x05, 0xb4}},
+			{Kind: TCPOptNOP},
+			{Kind: TCPOptWindowScale, Data: common.RawBytes{7}},
+		},
+	}
+}
+
+func TestTCPFromRaw(t *testing.T) {
+	// Trailing payload is ignored.
+	tcp, err := TCPFromRaw(append(append(common.RawBytes(nil), rawTCP...), 0xff, 0xff))
+	require.NoError(t, err)
+	assert.Equal(t, createTCP(), tcp)
+	assert.Equal(t, 28, tcp.L4Len())
+}
+
+func TestTCPFromRawPadding(t *testing.T) {
+	tests := map[string]common.RawBytes{
+		// The data offset covers a word of zeros after the end of option list.
+		"zeros": {0, 0, 0, 0},
+		// Bytes after the end of option list are not interpreted.
+		"garbage": {0, 0xaa, 0xbb, 0xcc},
+	}
+	for name, padding := range tests {
+		t.Run(name, func(t *testing.T) {
+			raw := append(common.RawBytes(nil), rawTCP...)
+			raw[12] = 0x80
+			raw = append(raw, padding...)
+			tcp, err := TCPFromRaw(append(raw, 0xff, 0xff))
+			require.NoError(t, err)
+			assert.Equal(t, createTCP().Options, tcp.Options)
+			assert.Equal(t, padding, tcp.Padding)
+			assert.Equal(t, 32, tcp.L4Len())
+			written := make(common.RawBytes, tcp.L4Len())
+			require.NoError(t, tcp.Write(written))
+			assert.Equal(t, raw, written)
+		})
+	}
+}
+
+func TestTCPFromRawErrors(t *testing.T) {
+	tests := map[string]common.RawBytes{
+		"short buffer":       rawTCP[:TCPMinLen-1],
+		"truncated options":  rawTCP[:24],
+		"small data offset":  append(common.RawBytes{}, rawTCP[:12]...),
+		"bad option length":  append(append(common.RawBytes{}, rawTCP[:20]...), 2, 9, 0, 0),
+		"option length zero": append(append(common.RawBytes{}, rawTCP[:20]...), 2, 0, 0, 0),
+	}
+	// Fix up the data offsets of the synthesized headers.
+	tests["small data offset"] = append(tests["small data offset"],
+		0x40, 0, 0, 0, 0, 0, 0, 0)
+	tests["bad option length"][12] = 0x60
+	tests["option length zero"][12] = 0x60
+	for name, raw := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := TCPFromRaw(raw)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestTCPWrite(t *testing.T) {
+	tcp := createTCP()
+	raw := make(common.RawBytes, tcp.L4Len())
+	require.NoError(t, tcp.Write(raw))
+	assert.Equal(t, rawTCP, raw)
+}
+
+func TestTCPPack(t *testing.T) {
+	raw, err := createTCP().Pack(true)
+	require.NoError(t, err)
+	expected := append(common.RawBytes(nil), rawTCP...)
+	expected[16], expected[17] = 0, 0
+	assert.Equal(t, expected, raw)
+}
+
+func TestTCPWriteTooLong(t *testing.T) {
+	tcp := createTCP()
+	tcp.Options = append(tcp.Options, TCPOption{Kind: 254, Data: make(common.RawBytes, 40)})
+	assert.Error(t, tcp.Validate(0))
+	_, err := tcp.Pack(false)
+	assert.Error(t, err)
+}
+
+func TestTCPChecksum(t *testing.T) {
+	tcp := createTCP()
+	addrHdr := common.RawBytes{1, 2, 3, 4, 5, 6, 7, 8}
+	pld := common.RawBytes("payload")
+	require.NoError(t, SetCSum(tcp, addrHdr, pld))
+	assert.NoError(t, CheckCSum(tcp, addrHdr, pld))
+	tcp.SetMSS(1200)
+	assert.Error(t, CheckCSum(tcp, addrHdr, pld))
+}
+
+func TestTCPMSS(t *testing.T) {
+	tcp := createTCP()
+	mss, ok := tcp.MSS()
+	assert.True(t, ok)
+	assert.Equal(t, uint16(1460), mss)
+	tcp.SetMSS(1200)
+	mss, _ = tcp.MSS()
+	assert.Equal(t, uint16(1200), mss)
+	assert.Equal(t, 28, tcp.L4Len())
+
+	tcp.Options = nil
+	_, ok = tcp.MSS()
+	assert.False(t, ok)
+	tcp.SetMSS(1000)
+	assert.Equal(t, 24, tcp.L4Len())
+}
+
+func TestTCPCopy(t *testing.T) {
+	tcp := createTCP()
+	c := tcp.Copy().(*TCP)
+	assert.Equal(t, tcp, c)
+	c.Options[0].Data[0] = 0
+	c.Checksum[0] = 0
+	assert.Equal(t, createTCP(), tcp)
+
+	tcp.Padding = common.RawBytes{0, 1, 2, 3}
+	c = tcp.Copy().(*TCP)
+	c.Padding[1] = 0
+	assert.Equal(t, common.RawBytes{0, 1, 2, 3}, tcp.Padding)
+}
+
+func TestTCPFlagsString(t *testing.T) {
+	assert.Equal(t, "SYN|ACK", (TCPFlagSYN | TCPFlagACK).String())
+}
@@ -32,6 +32,8 @@ var (
 		gopacket.LayerTypeMetadata{Name: "SCIONUDP", Decoder: nil})
 	LayerTypeSCMP = gopacket.RegisterLayerType(1104,
 		gopacket.LayerTypeMetadata{Name: "SCMP", Decoder: nil})
+	LayerTypeSCIONTCP = gopacket.RegisterLayerType(1105,
+		gopacket.LayerTypeMetadata{Name: "SCIONTCP", Decoder: nil})
 )
 
 var (
@@ -40,6 +42,7 @@ var (
 		LayerTypeEndToEndExtension: common.End2EndClass,
 		LayerTypeSCIONUDP:          common.L4UDP,
 		LayerTypeSCMP:              common.L4SCMP,
+		LayerTypeSCIONTCP:          common.L4TCP,
 	}
 )
 
@@ -0,0 +1,176 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/kormat/fmt15"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// Log formats.
+const (
+	// FmtHuman is the human readable format, one line per record followed by
+	// continuation lines for multi-line values.
+	FmtHuman = "human"
+	// FmtJSON writes one JSON object per record.
+	FmtJSON = "json"
+	// DefaultFormat is the default log format.
+	DefaultFormat = FmtHuman
+)
+
+// Keys of JSON log records. The context of the record follows the keys below,
+// in the order it was passed to the logger. Context keys that collide with
+// them are prefixed with "ctx_".
+const (
+	// KeyTime is the timestamp of the record, formatted with common.TimeFmt.
+	KeyTime = "ts"
+	// KeyLevel is the level of the record: debug, info, warn, error or crit.
+	KeyLevel = "level"
+	// KeyMsg is the message of the record.
+	KeyMsg = "msg"
+	// KeyService is the ID of the service, if it is set with SetService.
+	KeyService = "service"
+	// KeyIA is the IA of the service, if it is set with SetIA.
+	KeyIA = "ia"
+	// KeyDebugID is the context key of debug IDs.
+	KeyDebugID = "debug_id"
+	// KeyTraceID is the context key of trace IDs.
+	KeyTraceID = "trace_id"
+)
+
+var (
+	service atomic.Value
+	localIA atomic.Value
+)
+
+func init() {
+	service.Store("")
+	localIA.Store("")
+}
+
+// SetService sets the service ID JSON records are tagged with.
+func SetService(id string) {
+	service.Store(id)
+}
+
+// SetIA sets the IA JSON records are tagged with.
+func SetIA(ia string) {
+	localIA.Store(ia)
+}
+
+var lvlNames = map[log15.Lvl]string{
+	log15.LvlDebug: "debug",
+	log15.LvlInfo:  "info",
+	log15.LvlWarn:  "warn",
+	log15.LvlError: "error",
+	log15.LvlCrit:  "crit",
+}
+
+// formatFromString returns the log15 format for the format name. cMap is the
+// color map of the human readable format.
+func formatFromString(format string, cMap map[log15.Lvl]int) (log15.Format, error) {
+	switch format {
+	case "", FmtHuman:
+		return fmt15.Fmt15Format(cMap), nil
+	case FmtJSON:
+		return JSONFormat(), nil
+	default:
+		return nil, serrors.New("Unknown log format", "format", format)
+	}
+}
+
+// JSONFormat returns a format that writes records as JSON objects, one per
+// line.
+func JSONFormat() log15.Format {
+	return log15.FormatFunc(func(r *log15.Record) []byte {
+		b := &bytes.Buffer{}
+		b.WriteByte('{')
+		writeJSONField(b, KeyTime, r.Time.Format(common.TimeFmt), true)
+		writeJSONField(b, KeyLevel, lvlNames[r.Lvl], false)
+		writeJSONField(b, KeyMsg, r.Msg, false)
+		if s := service.Load().(string); s != "" {
+			writeJSONField(b, KeyService, s, false)
+		}
+		if ia := localIA.Load().(string); ia != "" {
+			writeJSONField(b, KeyIA, ia, false)
+		}
+		for i := 0; i+1 < len(r.Ctx); i += 2 {
+			k, ok := r.Ctx[i].(string)
+			if !ok {
+				k = fmt.Sprint(r.Ctx[i])
+			}
+			switch k {
+			case KeyTime, KeyLevel, KeyMsg, KeyService, KeyIA:
+				k = "ctx_" + k
+			}
+			// log15 normalizes the context to an even length.
+			writeJSONField(b, k, r.Ctx[i+1], false)
+		}
+		b.WriteString("}\n")
+		return b.Bytes()
+	})
+}
+
+func writeJSONField(b *bytes.Buffer, k string, v interface{}, first bool) {
+	if !first {
+		b.WriteByte(',')
+	}
+	// Marshaling strings never fails.
+	raw, _ := json.Marshal(k)
+	b.Write(raw)
+	b.WriteByte(':')
+	raw, err := json.Marshal(jsonValue(v))
+	if err != nil {
+		raw, _ = json.Marshal(fmt.Sprintf("%+v", v))
+	}
+	b.Write(raw)
+}
+
+// jsonValue converts v to a value that is encoded to JSON the way it is
+// printed in the human readable format.
+func jsonValue(v interface{}) (result interface{}) {
+	defer func() {
+		if err := recover(); err != nil {
+			if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+				result = "nil"
+				return
+			}
+			panic(err)
+		}
+	}()
+	switch v := v.(type) {
+	case nil, bool, string, int, int8, int16, int32, int64, uint, uint8, uint16, uint32,
+		uint64, float32, float64:
+		return v
+	case time.Time:
+		return v.Format(common.TimeFmt)
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprintf("%+v", v)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
+)
+
+func TestJSONFormat(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := log.New(log.KeyDebugID, "0a1b")
+	logger.SetHandler(log15.StreamHandler(buf, log.JSONFormat()))
+	log.SetService("cs1-ff00:0:110-1")
+	log.SetIA("1-ff00:0:110")
+	defer log.SetService("")
+	defer log.SetIA("")
+
+	var nilAddr *net.UDPAddr
+	logger.Info("Multi\nline", "err", errors.New("failed"), "addr", nilAddr, "count", 3,
+		"msg", "collision")
+	logger.Trace("Hidden")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], `{"ts":`), "time must be the first key")
+
+	var rec map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &rec))
+	ts, err := time.Parse(common.TimeFmt, rec[log.KeyTime].(string))
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), ts, time.Minute)
+	delete(rec, log.KeyTime)
+	assert.Equal(t, map[string]interface{}{
+		log.KeyLevel:   "info",
+		log.KeyMsg:     "Multi\nline",
+		log.KeyService: "cs1-ff00:0:110-1",
+		log.KeyIA:      "1-ff00:0:110",
+		log.KeyDebugID: "0a1b",
+		"err":          "failed",
+		"addr":         "<nil>",
+		"count":        float64(3),
+		"ctx_msg":      "collision",
+	}, rec)
+
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &rec))
+	assert.Equal(t, "debug", rec[log.KeyLevel])
+	assert.Equal(t, log.TraceMsgPrefix+"Hidden", rec[log.KeyMsg])
+}
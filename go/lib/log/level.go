@@ -0,0 +1,116 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+var (
+	levelMtx     sync.Mutex
+	fileLevel    *levelHandler
+	consoleLevel *levelHandler
+)
+
+// Levels returns the current levels of console and file logging. A level is
+// empty if the respective logging is not set up.
+func Levels() (console, file string) {
+	levelMtx.Lock()
+	defer levelMtx.Unlock()
+	if consoleLevel != nil {
+		console = consoleLevel.Level()
+	}
+	if fileLevel != nil {
+		file = fileLevel.Level()
+	}
+	return console, file
+}
+
+// SetConsoleLevel changes the level of console logging at runtime. It fails if
+// console logging is not set up.
+func SetConsoleLevel(lvl string) error {
+	levelMtx.Lock()
+	defer levelMtx.Unlock()
+	if consoleLevel == nil {
+		return serrors.New("Console logging not set up")
+	}
+	return consoleLevel.SetLevel(lvl)
+}
+
+// SetFileLevel changes the level of file logging at runtime. It fails if file
+// logging is not set up.
+func SetFileLevel(lvl string) error {
+	levelMtx.Lock()
+	defer levelMtx.Unlock()
+	if fileLevel == nil {
+		return serrors.New("File logging not set up")
+	}
+	return fileLevel.SetLevel(lvl)
+}
+
+type level struct {
+	name  string
+	lvl   log15.Lvl
+	trace bool
+}
+
+func parseLevel(name string) (level, error) {
+	lvl, err := log15.LvlFromString(changeTraceToDebug(name))
+	if err != nil {
+		return level{}, err
+	}
+	return level{name: name, lvl: lvl, trace: name == LvlTraceStr}, nil
+}
+
+// levelHandler passes records that are at least at its level to the wrapped
+// handler. Trace messages are only passed at the trace level. The level can be
+// changed at runtime.
+type levelHandler struct {
+	handler log15.Handler
+	level   atomic.Value
+}
+
+func newLevelHandler(l level, h log15.Handler) *levelHandler {
+	lh := &levelHandler{handler: h}
+	lh.level.Store(l)
+	return lh
+}
+
+func (h *levelHandler) Log(r *log15.Record) error {
+	l := h.level.Load().(level)
+	if r.Lvl > l.lvl || (!l.trace && strings.HasPrefix(r.Msg, TraceMsgPrefix)) {
+		return nil
+	}
+	return h.handler.Log(r)
+}
+
+func (h *levelHandler) Level() string {
+	return h.level.Load().(level).name
+}
+
+func (h *levelHandler) SetLevel(name string) error {
+	l, err := parseLevel(name)
+	if err != nil {
+		return err
+	}
+	h.level.Store(l)
+	return nil
+}
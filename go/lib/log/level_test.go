@@ -0,0 +1,117 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelHandler(t *testing.T) {
+	var msgs []string
+	l, err := parseLevel("info")
+	require.NoError(t, err)
+	h := newLevelHandler(l, log15.FuncHandler(func(r *log15.Record) error {
+		msgs = append(msgs, r.Msg)
+		return nil
+	}))
+	logger := log15.New()
+	logger.SetHandler(h)
+
+	logger.Debug("debug")
+	logger.Info("info")
+	require.NoError(t, h.SetLevel("trace"))
+	logger.Debug(TraceMsgPrefix + "trace")
+	logger.Debug("debug")
+	require.NoError(t, h.SetLevel("debug"))
+	logger.Debug(TraceMsgPrefix + "trace")
+	assert.Error(t, h.SetLevel("verbose"))
+
+	assert.Equal(t, "debug", h.Level())
+	assert.Equal(t, []string{"info", TraceMsgPrefix + "trace", "debug"}, msgs)
+}
+
+func TestLevelHTTPHandler(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, SetupLogConsole("crit", FmtHuman))
+	require.NoError(t, SetupLogFile("test", dir, "debug", FmtHuman, 1, 1, 1, 0))
+
+	h := NewLevelHTTPHandler()
+	do := func(method, query string) (int, levelInfo) {
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, httptest.NewRequest(method, "/loglevel?"+query, nil))
+		var info levelInfo
+		if rr.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &info))
+		}
+		return rr.Code, info
+	}
+
+	code, info := do(http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, levelInfo{Console: "crit", File: "debug"}, info)
+
+	for name, query := range map[string]string{
+		"no level":        "",
+		"invalid level":   "console=verbose",
+		"invalid revert":  "console=debug&revert=soon",
+		"negative revert": "console=debug&revert=-1s",
+	} {
+		t.Run(name, func(t *testing.T) {
+			code, _ := do(http.MethodPut, query)
+			assert.Equal(t, http.StatusBadRequest, code)
+		})
+	}
+	code, _ = do(http.MethodPost, "console=debug")
+	assert.Equal(t, http.StatusMethodNotAllowed, code)
+
+	code, info = do(http.MethodPut, "console=debug&file=info")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, levelInfo{Console: "debug", File: "info"}, info)
+
+	// A second request cancels the pending revert, and its revert restores the
+	// levels from before the first one.
+	code, info = do(http.MethodPut, "console=trace&revert=1h")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "trace", info.Console)
+	require.NotNil(t, info.RevertAt)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), *info.RevertAt, time.Minute)
+	code, info = do(http.MethodPut, "file=warn&revert=50ms")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "trace", info.Console)
+	assert.Equal(t, "warn", info.File)
+	var console, file string
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); {
+		if console, file = Levels(); console == "debug" && file == "info" {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, "debug", console)
+	assert.Equal(t, "info", file)
+	_, info = do(http.MethodGet, "")
+	assert.Nil(t, info.RevertAt)
+}
@@ -0,0 +1,152 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// NewLevelHTTPHandler returns a handler to get and set the log levels at
+// runtime. It serves JSON and supports the following methods:
+//
+//	GET:  Show the console and file levels, and the time of the pending
+//	      revert, if any.
+//	PUT:  Set the levels in the console and file query parameters. Unset
+//	      parameters keep the level. If the revert query parameter is set to a
+//	      duration, e.g. 10m, the levels are reverted after that duration.
+//
+// Setting the levels again while a revert is pending cancels the revert. If
+// the new request has a revert duration, the levels are reverted to the ones
+// before the first request.
+func NewLevelHTTPHandler() http.Handler {
+	return &levelHTTPHandler{}
+}
+
+type levelHTTPHandler struct {
+	mtx sync.Mutex
+	// revert is the timer of the pending revert, or nil.
+	revert   *time.Timer
+	revertAt time.Time
+	// prevConsole and prevFile are the levels that are restored on revert.
+	prevConsole string
+	prevFile    string
+}
+
+type levelInfo struct {
+	Console  string     `json:"console,omitempty"`
+	File     string     `json:"file,omitempty"`
+	RevertAt *time.Time `json:"revert_at,omitempty"`
+}
+
+func (h *levelHTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.writeLevels(w)
+	case http.MethodPut:
+		h.set(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *levelHTTPHandler) set(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	console, file := q.Get("console"), q.Get("file")
+	if console == "" && file == "" {
+		http.Error(w, "console or file level required", http.StatusBadRequest)
+		return
+	}
+	for _, lvl := range []string{console, file} {
+		if _, err := parseLevel(lvl); lvl != "" && err != nil {
+			http.Error(w, fmt.Sprintf("invalid level: %s", err), http.StatusBadRequest)
+			return
+		}
+	}
+	var revert time.Duration
+	if raw := q.Get("revert"); raw != "" {
+		var err error
+		if revert, err = time.ParseDuration(raw); err != nil || revert <= 0 {
+			http.Error(w, fmt.Sprintf("invalid revert duration: %s", raw),
+				http.StatusBadRequest)
+			return
+		}
+	}
+	currConsole, currFile := Levels()
+	if (console != "" && currConsole == "") || (file != "" && currFile == "") {
+		http.Error(w, "logging not set up", http.StatusBadRequest)
+		return
+	}
+
+	h.mtx.Lock()
+	prevConsole, prevFile := currConsole, currFile
+	if h.revert != nil {
+		h.revert.Stop()
+		h.revert = nil
+		prevConsole, prevFile = h.prevConsole, h.prevFile
+	}
+	setLevels(console, file)
+	Info("[loglevel] Log levels changed", "console", console, "file", file, "revert", revert)
+	if revert > 0 {
+		h.prevConsole, h.prevFile = prevConsole, prevFile
+		h.revertAt = time.Now().Add(revert)
+		var t *time.Timer
+		t = time.AfterFunc(revert, func() {
+			h.mtx.Lock()
+			defer h.mtx.Unlock()
+			if h.revert != t {
+				return
+			}
+			h.revert = nil
+			setLevels(h.prevConsole, h.prevFile)
+			Info("[loglevel] Log levels reverted", "console", h.prevConsole, "file", h.prevFile)
+		})
+		h.revert = t
+	}
+	h.mtx.Unlock()
+	h.writeLevels(w)
+}
+
+func (h *levelHTTPHandler) writeLevels(w http.ResponseWriter) {
+	var info levelInfo
+	info.Console, info.File = Levels()
+	h.mtx.Lock()
+	if h.revert != nil {
+		revertAt := h.revertAt
+		info.RevertAt = &revertAt
+	}
+	h.mtx.Unlock()
+	raw, err := json.MarshalIndent(info, "", "    ")
+	if err != nil {
+		Error("[loglevel] Unable to encode response", "err", err)
+		http.Error(w, "unable to encode response", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(raw)
+}
+
+// setLevels sets the levels that are not empty. The levels must be valid.
+func setLevels(console, file string) {
+	if console != "" {
+		SetConsoleLevel(console)
+	}
+	if file != "" {
+		SetFileLevel(file)
+	}
+}
@@ -46,20 +46,26 @@ var (
 // SetupLogFile initializes a file for logging. The path is logDir/name.log if
 // name doesn't already contain the .log extension, or logDir/name otherwise.
 // logLevel can be one of trace, debug, info, warn, error, and crit and states
-// the minimum level of logging events that get written to the file. logSize is
+// the minimum level of logging events that get written to the file. logFormat
+// is one of human and json, and states the format of the file. logSize is
 // the maximum size, in MiB, until the log rotates. logAge is the maximum
 // number of days to retain old log files. logBackups is the maximum number of
 // old log files to retain. If logFlush > 0, logging output is
 // buffered, and flushed every logFlush seconds.  If logFlush < 0: logging
 // output is buffered, but must be manually flushed by calling Flush(). If
-// logFlush = 0 logging output is unbuffered and Flush() is a no-op.
-func SetupLogFile(name string, logDir string, logLevel string, logSize int, logAge int,
-	logBackups int, logFlush int) error {
+// logFlush = 0 logging output is unbuffered and Flush() is a no-op. The level
+// can be changed at runtime with SetFileLevel.
+func SetupLogFile(name string, logDir string, logLevel string, logFormat string, logSize int,
+	logAge int, logBackups int, logFlush int) error {
 
-	logLvl, err := log15.LvlFromString(changeTraceToDebug(logLevel))
+	logLvl, err := parseLevel(logLevel)
 	if err != nil {
 		return common.NewBasicError("Unable to parse log.level flag:", err)
 	}
+	format, err := formatFromString(logFormat, nil)
+	if err != nil {
+		return err
+	}
 
 	// Strip .log extension s.t. config files can contain the exact filename
 	// while not breaking existing behavior for apps that don't contain the
@@ -78,12 +84,10 @@ func SetupLogFile(name string, logDir string, logLevel string, logSize int, logA
 		fileLogger = logBuf
 	}
 
-	logFileHandler = log15.LvlFilterHandler(logLvl,
-		log15.StreamHandler(fileLogger, fmt15.Fmt15Format(nil)))
-	if logLevel != LvlTraceStr {
-		// Discard trace messages
-		logFileHandler = FilterTraceHandler(logFileHandler)
-	}
+	levelMtx.Lock()
+	fileLevel = newLevelHandler(logLvl, log15.StreamHandler(fileLogger, format))
+	logFileHandler = fileLevel
+	levelMtx.Unlock()
 	setHandlers()
 
 	if logFlush > 0 {
@@ -99,9 +103,10 @@ func SetupLogFile(name string, logDir string, logLevel string, logSize int, logA
 
 // SetupLogConsole sets up logging on default stderr. logLevel can be one of
 // trace, debug, info, warn, error, and crit, and states the minimum level of
-// logging events that gets printed to the console.
-func SetupLogConsole(logLevel string) error {
-	lvl, err := log15.LvlFromString(changeTraceToDebug(logLevel))
+// logging events that gets printed to the console. logFormat is one of human
+// and json. The level can be changed at runtime with SetConsoleLevel.
+func SetupLogConsole(logLevel string, logFormat string) error {
+	lvl, err := parseLevel(logLevel)
 	if err != nil {
 		return common.NewBasicError("Unable to parse log.console flag:", err)
 	}
@@ -109,12 +114,14 @@ func SetupLogConsole(logLevel string) error {
 	if isatty.IsTerminal(os.Stderr.Fd()) {
 		cMap = fmt15.ColorMap
 	}
-	logConsHandler = log15.LvlFilterHandler(lvl,
-		log15.StreamHandler(os.Stderr, fmt15.Fmt15Format(cMap)))
-	if logLevel != LvlTraceStr {
-		// Discard trace messages
-		logConsHandler = FilterTraceHandler(logConsHandler)
+	format, err := formatFromString(logFormat, cMap)
+	if err != nil {
+		return err
 	}
+	levelMtx.Lock()
+	consoleLevel = newLevelHandler(lvl, log15.StreamHandler(os.Stderr, format))
+	logConsHandler = consoleLevel
+	levelMtx.Unlock()
 	setHandlers()
 	return nil
 }
@@ -16,9 +16,11 @@ package logparse
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"io"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -37,6 +39,13 @@ type LogEntry struct {
 	Element string
 	Level   log.Lvl
 	Lines   []string
+	// Msg is the message of entries in the JSON format. It is empty for
+	// entries in the human readable format, whose message is part of Lines.
+	Msg string
+	// Fields contains the context of entries in the JSON format, e.g., the
+	// service, IA and debug ID, keyed by the JSON key. It is nil for entries
+	// in the human readable format.
+	Fields map[string]string
 }
 
 func (l LogEntry) String() string {
@@ -55,6 +64,13 @@ func (l LogEntry) String() string {
 // Lines starting with "> " or a space are assumed to be continuations, i.e.
 // they belong with the line(s) above them.
 //
+// Lines starting with "{" are parsed as entries in the JSON format:
+//
+// {"ts":"2017-05-16 13:18:16.539658+0000","level":"info","msg":"Started","service":"br1"}
+//
+// The message and the remaining keys of JSON entries are put in a single line
+// of the form "<msg> key=value ...", with the keys sorted.
+//
 // The fileName is used for logging.
 // The element is put in LogEntry.Element.
 // Parsed entries are passed to the entryConsumer.
@@ -74,6 +90,10 @@ func ParseFrom(reader io.Reader, fileName, element string, entryConsumer func(Lo
 		if prevEntry != nil {
 			entryConsumer(*prevEntry)
 		}
+		if strings.HasPrefix(line, "{") {
+			prevEntry = parseJSONEntry(line, fileName, element, lineno)
+			continue
+		}
 		prevEntry = parseInitialEntry(line, fileName, element, lineno)
 	}
 	if prevEntry != nil {
@@ -113,6 +133,71 @@ func parseInitialEntry(line, fileName, element string, lineno int) *LogEntry {
 	}
 }
 
+// parseJSONEntry parses a line with an entry in the JSON format.
+func parseJSONEntry(line, fileName, element string, lineno int) *LogEntry {
+	var raw map[string]interface{}
+	dec := json.NewDecoder(strings.NewReader(line))
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil {
+		log.Error(fmt.Sprintf("%s:%d: Could not parse JSON entry: %v", fileName, lineno, err))
+		return nil
+	}
+	fields := make(map[string]string, len(raw))
+	for k, v := range raw {
+		if s, ok := v.(string); ok {
+			fields[k] = s
+			continue
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			b = []byte(fmt.Sprint(v))
+		}
+		fields[k] = string(b)
+	}
+	ts, err := time.Parse(common.TimeFmt, fields[log.KeyTime])
+	if err != nil {
+		log.Error(fmt.Sprintf("%s:%d: Could not parse timestamp %+v: %+v",
+			fileName, lineno, fields[log.KeyTime], err))
+		return nil
+	}
+	lvl, err := log.LvlFromString(fields[log.KeyLevel])
+	if err != nil {
+		log.Error(fmt.Sprintf("%s:%d: Unknown log level: %v", fileName, lineno, err))
+	}
+	msg := fields[log.KeyMsg]
+	delete(fields, log.KeyTime)
+	delete(fields, log.KeyLevel)
+	delete(fields, log.KeyMsg)
+	return &LogEntry{
+		Timestamp: ts,
+		Element:   element,
+		Level:     lvl,
+		Lines:     []string{formatFields(msg, fields)},
+		Msg:       msg,
+		Fields:    fields,
+	}
+}
+
+// formatFields formats the message and the fields of a JSON entry in a single
+// line.
+func formatFields(msg string, fields map[string]string) string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	b := &strings.Builder{}
+	b.WriteString(msg)
+	for _, k := range keys {
+		v := fields[k]
+		if v == "" || strings.ContainsAny(v, " =\"\n") {
+			v = fmt.Sprintf("%q", v)
+		}
+		fmt.Fprintf(b, " %s=%s", k, v)
+	}
+	return b.String()
+}
+
 func isContinuation(line string) bool {
 	return strings.HasPrefix(line, "> ") || strings.HasPrefix(line, " ")
 }
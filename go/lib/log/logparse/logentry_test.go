@@ -84,6 +84,48 @@ func TestParseFrom(t *testing.T) {
 				},
 			},
 		},
+		"JSON": {
+			Input: `{"ts":"2018-07-19 14:39:29.489625+0000","level":"error","msg":"Txt",` +
+				`"service":"cs1","debug_id":"0a1b","count":3,"err":"a b"}`,
+			Entries: []LogEntry{
+				{
+					Timestamp: defaultTs,
+					Level:     log.LvlError,
+					Lines:     []string{`Txt count=3 debug_id=0a1b err="a b" service=cs1`},
+					Msg:       "Txt",
+					Fields: map[string]string{
+						"service":  "cs1",
+						"debug_id": "0a1b",
+						"count":    "3",
+						"err":      "a b",
+					},
+				},
+			},
+		},
+		"JSONMixed": {
+			Input: "2018-07-19 14:39:29.489625+0000 [ERROR] Txt\n" +
+				"> cont\n" +
+				`{"ts":"2018-07-19 14:39:30.489625+0000","level":"info","msg":"Txt2"}` + "\n" +
+				`{"ts":"2018-07-19 14:39:30.489625+0000","level":"info","msg":` + "\n" +
+				"> dropped",
+			Entries: []LogEntry{
+				{
+					Timestamp: defaultTs,
+					Level:     log.LvlError,
+					Lines:     []string{"Txt", "> cont"},
+				},
+				{
+					Timestamp: mustParse("2018-07-19 14:39:30.489625+0000", t),
+					Level:     log.LvlInfo,
+					Lines:     []string{"Txt2"},
+					Msg:       "Txt2",
+					Fields:    map[string]string{},
+				},
+			},
+		},
+		"JSONBadTimestamp": {
+			Input: `{"ts":"yesterday","level":"info","msg":"Txt"}`,
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -20,6 +20,8 @@
 package pathpol
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"sort"
 
 	"github.com/scionproto/scion/go/lib/common"
@@ -60,6 +62,20 @@ func NewPolicy(name string, acl *ACL, sequence *Sequence, options []Option) *Pol
 	return policy
 }
 
+// PolicyFromFile loads a policy from a JSON file. The name of the policy is the
+// file name.
+func PolicyFromFile(file string) (*Policy, error) {
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, common.NewBasicError("Unable to read policy file", err, "file", file)
+	}
+	var p Policy
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return nil, common.NewBasicError("Unable to parse policy", err, "file", file)
+	}
+	return NewPolicy(file, p.ACL, p.Sequence, p.Options), nil
+}
+
 // Filter filters the path set according to the policy.
 func (p *Policy) Filter(paths PathSet) PathSet {
 	return p.FilterOpt(paths, FilterOptions{})
@@ -18,6 +18,9 @@ package pathpol
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -609,6 +612,33 @@ func TestPolicyJsonConversion(t *testing.T) {
 	assert.Equal(t, policy, &pol)
 }
 
+func TestPolicyFromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pathpol")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "policy.json")
+
+	t.Run("valid policy", func(t *testing.T) {
+		raw := `{"acl": ["- 1-ff00:0:133#0", "+"], "sequence": "1-ff00:0:110#0 0*"}`
+		require.NoError(t, ioutil.WriteFile(file, []byte(raw), 0644))
+		policy, err := PolicyFromFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, file, policy.Name)
+		require.NotNil(t, policy.ACL)
+		assert.Len(t, policy.ACL.Entries, 2)
+		assert.Equal(t, newSequence(t, "1-ff00:0:110#0 0*"), policy.Sequence)
+	})
+	t.Run("invalid policy", func(t *testing.T) {
+		require.NoError(t, ioutil.WriteFile(file, []byte(`{"acl": ["+ 1-"]}`), 0644))
+		_, err := PolicyFromFile(file)
+		assert.Error(t, err)
+	})
+	t.Run("missing file", func(t *testing.T) {
+		_, err := PolicyFromFile(filepath.Join(dir, "missing.json"))
+		assert.Error(t, err)
+	})
+}
+
 func newSequence(t *testing.T, str string) *Sequence {
 	seq, err := NewSequence(str)
 	xtest.FailOnErr(t, err)
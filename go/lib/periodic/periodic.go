@@ -51,7 +51,7 @@ type Runner struct {
 // time it will be immediately retriggered.
 func Start(task Task, period, timeout time.Duration) *Runner {
 	ctx, cancelF := context.WithCancel(context.Background())
-	logger := log.New("debug_id", util.GetDebugID())
+	logger := log.New(log.KeyDebugID, util.GetDebugID())
 	ctx = log.CtxWith(ctx, logger)
 	r := &Runner{
 		task:         task,
@@ -36,9 +36,14 @@ type Querier struct {
 	Connector Connector
 	IA        addr.IA
 	MaxPaths  uint16
+	// Filter optionally restricts the paths returned by SCIOND.
+	Filter *PathReqFilter
 }
 
 func (q Querier) Query(ctx context.Context, dst addr.IA) ([]snet.Path, error) {
+	if q.Filter != nil {
+		return q.Connector.PathsWithFilter(ctx, dst, q.IA, q.MaxPaths, PathReqFlags{}, q.Filter)
+	}
 	return q.Connector.Paths(ctx, dst, q.IA, q.MaxPaths, PathReqFlags{})
 }
 
@@ -93,6 +98,8 @@ type Path struct {
 	spath      *spath.Path
 	mtu        uint16
 	expiry     time.Time
+	metadata   *snet.PathMetadata
+	quality    *PathQuality
 	dst        addr.IA
 }
 
@@ -111,6 +118,11 @@ func pathReplyToPaths(pathReply *PathReply, dst addr.IA) ([]snet.Path, error) {
 	return paths, nil
 }
 
+// PathFromEntry converts a path reply entry to a path to dst.
+func PathFromEntry(pe PathReplyEntry, dst addr.IA) (Path, error) {
+	return pathReplyEntryToPath(pe, dst)
+}
+
 func pathReplyEntryToPath(pe PathReplyEntry, dst addr.IA) (Path, error) {
 	if len(pe.Path.Interfaces) == 0 {
 		return Path{
@@ -128,6 +140,8 @@ func pathReplyEntryToPath(pe PathReplyEntry, dst addr.IA) (Path, error) {
 		spath:      sp,
 		mtu:        pe.Path.Mtu,
 		expiry:     pe.Path.Expiry(),
+		metadata:   pathMetadataToSnet(pe.Path.Metadata),
+		quality:    pe.Quality.Copy(),
 	}
 	for _, intf := range pe.Path.Interfaces {
 		p.interfaces = append(p.interfaces, pathInterface{ia: intf.IA(), id: intf.ID()})
@@ -135,6 +149,32 @@ func pathReplyEntryToPath(pe PathReplyEntry, dst addr.IA) (Path, error) {
 	return p, nil
 }
 
+func pathMetadataToSnet(pm *PathMetadata) *snet.PathMetadata {
+	if pm == nil {
+		return nil
+	}
+	m := &snet.PathMetadata{
+		Latency:   make([]time.Duration, 0, len(pm.Latencies)),
+		Bandwidth: append(pm.Bandwidths[:0:0], pm.Bandwidths...),
+		LinkType:  make([]snet.LinkType, 0, len(pm.LinkTypes)),
+		Geo:       make([]snet.GeoCoordinates, 0, len(pm.Geo)),
+	}
+	for _, l := range pm.Latencies {
+		m.Latency = append(m.Latency, time.Duration(l)*time.Microsecond)
+	}
+	for _, t := range pm.LinkTypes {
+		m.LinkType = append(m.LinkType, snet.LinkType(t))
+	}
+	for _, g := range pm.Geo {
+		m.Geo = append(m.Geo, snet.GeoCoordinates{
+			Latitude:  g.Latitude,
+			Longitude: g.Longitude,
+			Address:   g.Address,
+		})
+	}
+	return m
+}
+
 func (p Path) Fingerprint() string {
 	if len(p.interfaces) == 0 {
 		return ""
@@ -191,6 +231,16 @@ func (p Path) Expiry() time.Time {
 	return p.expiry
 }
 
+func (p Path) Metadata() *snet.PathMetadata {
+	return p.metadata.Copy()
+}
+
+// Quality returns the quality SCIOND observed when probing the path. If SCIOND
+// does not probe the path, the result is nil.
+func (p Path) Quality() *PathQuality {
+	return p.quality.Copy()
+}
+
 func (p Path) Copy() snet.Path {
 	return Path{
 		interfaces: append(p.interfaces[:0:0], p.interfaces...),
@@ -198,6 +248,8 @@ func (p Path) Copy() snet.Path {
 		spath:      p.Path(),           // creates copy
 		mtu:        p.mtu,
 		expiry:     p.expiry,
+		metadata:   p.Metadata(), // creates copy
+		quality:    p.Quality(),  // creates copy
 	}
 }
 
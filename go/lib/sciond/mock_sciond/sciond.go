@@ -136,6 +136,21 @@ func (mr *MockConnectorMockRecorder) Paths(arg0, arg1, arg2, arg3, arg4 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Paths", reflect.TypeOf((*MockConnector)(nil).Paths), arg0, arg1, arg2, arg3, arg4)
 }
 
+// PathsWithFilter mocks base method
+func (m *MockConnector) PathsWithFilter(arg0 context.Context, arg1, arg2 addr.IA, arg3 uint16, arg4 sciond.PathReqFlags, arg5 *sciond.PathReqFilter) ([]snet.Path, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PathsWithFilter", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]snet.Path)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PathsWithFilter indicates an expected call of PathsWithFilter
+func (mr *MockConnectorMockRecorder) PathsWithFilter(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PathsWithFilter", reflect.TypeOf((*MockConnector)(nil).PathsWithFilter), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // RevNotification mocks base method
 func (m *MockConnector) RevNotification(arg0 context.Context, arg1 *path_mgmt.SignedRevInfo) (*sciond.RevReply, error) {
 	m.ctrl.T.Helper()
@@ -180,3 +195,18 @@ func (mr *MockConnectorMockRecorder) SVCInfo(arg0, arg1 interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SVCInfo", reflect.TypeOf((*MockConnector)(nil).SVCInfo), arg0, arg1)
 }
+
+// SubscribePaths mocks base method
+func (m *MockConnector) SubscribePaths(arg0 context.Context, arg1, arg2 addr.IA, arg3 uint16, arg4 sciond.PathReqFlags) (sciond.PathSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribePaths", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(sciond.PathSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribePaths indicates an expected call of SubscribePaths
+func (mr *MockConnectorMockRecorder) SubscribePaths(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribePaths", reflect.TypeOf((*MockConnector)(nil).SubscribePaths), arg0, arg1, arg2, arg3, arg4)
+}
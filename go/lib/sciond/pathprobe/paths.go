@@ -52,13 +52,15 @@ const (
 type Status struct {
 	Status         StatusName
 	AdditionalInfo string
+	// RTT is the round-trip time of the probe. It is only set if the path is
+	// alive.
+	RTT time.Duration
 }
 
 // Predefined path status
 var (
 	unknown = Status{Status: StatusUnknown}
 	timeout = Status{Status: StatusTimeout}
-	alive   = Status{Status: StatusAlive}
 )
 
 func (s Status) String() string {
@@ -110,7 +112,7 @@ func (p Prober) GetStatuses(ctx context.Context,
 	// is going to reply with SCMP error. Receiving the error means that
 	// the path is alive.
 	pathStatuses := make(map[string]Status, len(paths))
-	scmpH := &scmpHandler{statuses: pathStatuses}
+	scmpH := &scmpHandler{statuses: pathStatuses, sent: make(map[string]time.Time, len(paths))}
 	network := snet.NewCustomNetworkWithPR(p.Local.IA,
 		&snet.DefaultPacketDispatcherService{
 			Dispatcher:  reliable.NewDispatcherService(p.DispPath),
@@ -123,9 +125,26 @@ func (p Prober) GetStatuses(ctx context.Context,
 	}
 	defer snetConn.Close()
 	snetConn.SetDeadline(deadline)
-	var sendErrors common.MultiError
 	for _, path := range paths {
 		scmpH.setStatus(PathKey(path), timeout)
+	}
+	// Replies are received while the probes are sent, such that the RTT of a
+	// path does not include the time spent sending the probes on the other
+	// paths.
+	receiveErrs := make(chan error, 1)
+	go func(replies int) {
+		defer log.LogPanicAndExit()
+		var errs common.MultiError
+		for i := replies; i > 0; i-- {
+			if err := p.receive(snetConn); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		receiveErrs <- errs.ToError()
+	}(len(scmpH.statuses))
+	var sendErrors common.MultiError
+	for _, path := range paths {
+		scmpH.setSent(PathKey(path), time.Now())
 		if err := p.send(snetConn, path); err != nil {
 			sendErrors = append(sendErrors, err)
 		}
@@ -133,13 +152,7 @@ func (p Prober) GetStatuses(ctx context.Context,
 	if err := sendErrors.ToError(); err != nil {
 		return nil, err
 	}
-	var receiveErrors common.MultiError
-	for i := len(scmpH.statuses); i > 0; i-- {
-		if err := p.receive(snetConn); err != nil {
-			receiveErrors = append(receiveErrors, err)
-		}
-	}
-	if err := receiveErrors.ToError(); err != nil {
+	if err := <-receiveErrs; err != nil {
 		return nil, err
 	}
 	return scmpH.statuses, nil
@@ -185,6 +198,7 @@ var errSCMP = errors.New("scmp: other")
 type scmpHandler struct {
 	mtx      sync.Mutex
 	statuses map[string]Status
+	sent     map[string]time.Time
 }
 
 func (h *scmpHandler) Handle(pkt *snet.SCIONPacket) error {
@@ -195,7 +209,7 @@ func (h *scmpHandler) Handle(pkt *snet.SCIONPacket) error {
 			return err
 		}
 		if hdr.Class == scmp.C_Routing && hdr.Type == scmp.T_R_BadHost {
-			h.setStatus(path, alive)
+			h.setStatus(path, Status{Status: StatusAlive, RTT: h.rtt(path)})
 			return errBadHost
 		}
 		h.setStatus(path, Status{Status: StatusSCMP, AdditionalInfo: hdr.String()})
@@ -217,3 +231,20 @@ func (h *scmpHandler) setStatus(path string, status Status) {
 	defer h.mtx.Unlock()
 	h.statuses[path] = status
 }
+
+func (h *scmpHandler) setSent(path string, t time.Time) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.sent[path] = t
+}
+
+// rtt returns the time since the probe was sent on the path.
+func (h *scmpHandler) rtt(path string) time.Duration {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	sent, ok := h.sent[path]
+	if !ok {
+		return 0
+	}
+	return time.Since(sent)
+}
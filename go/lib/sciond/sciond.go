@@ -84,6 +84,17 @@ type Connector interface {
 	// Paths requests from SCIOND a set of end to end paths between src and
 	// dst. max specifies the maximum number of paths returned.
 	Paths(ctx context.Context, dst, src addr.IA, max uint16, f PathReqFlags) ([]snet.Path, error)
+	// PathsWithFilter requests from SCIOND a set of end to end paths between
+	// src and dst that satisfy the filter. SCIOND filters and ranks all
+	// available paths before it truncates the result to max paths.
+	PathsWithFilter(ctx context.Context, dst, src addr.IA, max uint16, f PathReqFlags,
+		filter *PathReqFilter) ([]snet.Path, error)
+	// SubscribePaths subscribes to the paths between src and dst. SCIOND
+	// pushes the paths whenever new paths appear, or paths are revoked or
+	// expire, until the subscription is closed. max specifies the maximum
+	// number of paths in each update.
+	SubscribePaths(ctx context.Context, dst, src addr.IA, max uint16,
+		f PathReqFlags) (PathSubscription, error)
 	// ASInfo requests from SCIOND information about AS ia.
 	ASInfo(ctx context.Context, ia addr.IA) (*ASInfoReply, error)
 	// SVCInfo requests from SCIOND information about addresses and ports of
@@ -167,6 +178,16 @@ func (c *conn) ctxAwareConnect(ctx context.Context) (*disp.Dispatcher, error) {
 func (c *conn) Paths(ctx context.Context, dst, src addr.IA, max uint16,
 	f PathReqFlags) ([]snet.Path, error) {
 
+	return c.PathsWithFilter(ctx, dst, src, max, f, nil)
+}
+
+func (c *conn) PathsWithFilter(ctx context.Context, dst, src addr.IA, max uint16,
+	f PathReqFlags, filter *PathReqFilter) ([]snet.Path, error) {
+
+	req, err := NewPathReq(dst, src, max, f, filter)
+	if err != nil {
+		return nil, err
+	}
 	roundTripper, err := c.ctxAwareConnect(ctx)
 	if err != nil {
 		metrics.PathRequests.Inc(errorToPrometheusLabel(err))
@@ -176,13 +197,8 @@ func (c *conn) Paths(ctx context.Context, dst, src addr.IA, max uint16,
 	reply, err := roundTripper.Request(
 		ctx,
 		&Pld{
-			Which: proto.SCIONDMsg_Which_pathReq,
-			PathReq: &PathReq{
-				Dst:      dst.IAInt(),
-				Src:      src.IAInt(),
-				MaxPaths: max,
-				Flags:    f,
-			},
+			Which:   proto.SCIONDMsg_Which_pathReq,
+			PathReq: req,
 		},
 		nil,
 	)
@@ -0,0 +1,152 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sciond
+
+import (
+	"context"
+	"sync"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/infra/disp"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/sciond/internal/metrics"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/proto"
+)
+
+// PathSubscription is a subscription to the paths to a destination. SCIOND
+// pushes the complete set of paths whenever it changes.
+type PathSubscription interface {
+	// Events returns the channel on which path events are delivered. The first
+	// event contains the paths at the time of the subscription. The channel is
+	// closed when the subscription ends.
+	Events() <-chan PathEvent
+	// Close ends the subscription.
+	Close(ctx context.Context) error
+}
+
+// PathEvent is a change of the paths to a subscribed destination.
+type PathEvent struct {
+	// Reason describes why the paths changed.
+	Reason PathUpdateReason
+	// Paths is the complete current set of paths. It is empty if no path to
+	// the destination is available.
+	Paths []snet.Path
+	// Err is set if SCIOND failed to look up the paths.
+	Err error
+}
+
+func (c *conn) SubscribePaths(ctx context.Context, dst, src addr.IA, max uint16,
+	f PathReqFlags) (PathSubscription, error) {
+
+	req, err := NewPathReq(dst, src, max, f, nil)
+	if err != nil {
+		return nil, err
+	}
+	dispatcher, err := c.ctxAwareConnect(ctx)
+	if err != nil {
+		metrics.PathRequests.Inc(errorToPrometheusLabel(err))
+		return nil, serrors.Wrap(ErrUnableToConnect, err)
+	}
+	reply, err := dispatcher.Request(
+		ctx,
+		&Pld{
+			Which:            proto.SCIONDMsg_Which_pathSubscribeReq,
+			PathSubscribeReq: req,
+		},
+		nil,
+	)
+	if err != nil {
+		metrics.PathRequests.Inc(errorToPrometheusLabel(err))
+		dispatcher.Close(ctx)
+		return nil, serrors.WrapStr("[sciond-API] Failed to subscribe to paths", err)
+	}
+	initial, ok := reply.(*Pld)
+	if !ok || initial.Which != proto.SCIONDMsg_Which_pathUpdate {
+		dispatcher.Close(ctx)
+		return nil, serrors.New("[sciond-API] Unexpected reply to path subscription",
+			"reply", reply)
+	}
+	metrics.PathRequests.Inc(metrics.OkSuccess)
+	s := &pathSubscription{
+		dispatcher: dispatcher,
+		dst:        dst,
+		events:     make(chan PathEvent),
+		closed:     make(chan struct{}),
+	}
+	go func() {
+		defer log.LogPanicAndExit()
+		s.run(initial.PathUpdate)
+	}()
+	return s, nil
+}
+
+type pathSubscription struct {
+	dispatcher *disp.Dispatcher
+	dst        addr.IA
+	events     chan PathEvent
+	closed     chan struct{}
+	closeOnce  sync.Once
+}
+
+func (s *pathSubscription) Events() <-chan PathEvent {
+	return s.events
+}
+
+func (s *pathSubscription) Close(ctx context.Context) error {
+	s.closeOnce.Do(func() { close(s.closed) })
+	return s.dispatcher.Close(ctx)
+}
+
+// run delivers the initial update and all updates pushed by SCIOND until the
+// subscription is closed.
+func (s *pathSubscription) run(initial *PathUpdate) {
+	defer close(s.events)
+	if !s.deliver(initial) {
+		return
+	}
+	for {
+		msg, _, _, err := s.dispatcher.RecvFrom(context.Background())
+		if err != nil {
+			// The dispatcher was closed, either by the client or because
+			// SCIOND went away.
+			return
+		}
+		pld, ok := msg.(*Pld)
+		if !ok || pld.Which != proto.SCIONDMsg_Which_pathUpdate {
+			log.Debug("[sciond-API] Ignoring unexpected message on subscription", "msg", msg)
+			continue
+		}
+		if !s.deliver(pld.PathUpdate) {
+			return
+		}
+	}
+}
+
+// deliver sends the update to the subscriber. It returns false if the
+// subscription was closed in the meantime.
+func (s *pathSubscription) deliver(update *PathUpdate) bool {
+	event := PathEvent{Reason: update.Reason}
+	if update.Reply != nil && update.Reply.ErrorCode != ErrorNoPaths {
+		event.Paths, event.Err = pathReplyToPaths(update.Reply, s.dst)
+	}
+	select {
+	case s.events <- event:
+		return true
+	case <-s.closed:
+		return false
+	}
+}
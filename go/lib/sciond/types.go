@@ -16,6 +16,7 @@
 package sciond
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -23,7 +24,9 @@ import (
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
 	"github.com/scionproto/scion/go/lib/hostinfo"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/proto"
 )
@@ -37,6 +40,7 @@ const (
 	ErrorInternal
 	ErrorBadSrcIA
 	ErrorBadDstIA
+	ErrorBadPolicy
 )
 
 func (c PathErrorCode) String() string {
@@ -53,6 +57,8 @@ func (c PathErrorCode) String() string {
 		return "Bad source ISD/AS"
 	case ErrorBadDstIA:
 		return "Bad destination ISD/AS"
+	case ErrorBadPolicy:
+		return "Bad path policy"
 	default:
 		return fmt.Sprintf("Unknown error (%v)", uint16(c))
 	}
@@ -73,6 +79,8 @@ type Pld struct {
 	IfInfoReply        *IFInfoReply
 	ServiceInfoRequest *ServiceInfoRequest
 	ServiceInfoReply   *ServiceInfoReply
+	PathSubscribeReq   *PathReq
+	PathUpdate         *PathUpdate
 }
 
 func NewPldFromRaw(b common.RawBytes) (*Pld, error) {
@@ -117,6 +125,10 @@ func (p *Pld) union() (interface{}, error) {
 		return p.ServiceInfoRequest, nil
 	case proto.SCIONDMsg_Which_serviceInfoReply:
 		return p.ServiceInfoReply, nil
+	case proto.SCIONDMsg_Which_pathSubscribeReq:
+		return p.PathSubscribeReq, nil
+	case proto.SCIONDMsg_Which_pathUpdate:
+		return p.PathUpdate, nil
 	}
 	return nil, common.NewBasicError("Unsupported SCIOND union type", nil, "type", p.Which)
 }
@@ -127,20 +139,107 @@ type PathReq struct {
 	MaxPaths uint16
 	HPCfgs   []*path_mgmt.HPGroupId `capnp:"hpCfgs"`
 	Flags    PathReqFlags
+	// Policy is the optional path policy in JSON format. The returned paths
+	// must satisfy the policy.
+	Policy common.RawBytes
+	// RequiredIntfs contains interfaces that every returned path must
+	// traverse. An interface ID of 0 matches any interface of the AS.
+	RequiredIntfs []PathInterface
+	// ForbiddenASes contains ASes that no returned path may traverse. Wildcard
+	// values match all ASes of the ISD.
+	ForbiddenASes []addr.IAInt `capnp:"forbiddenASes"`
+}
+
+// NewPathReq creates a path request. If filter is not nil, the request is
+// restricted to the paths that satisfy the filter.
+func NewPathReq(dst, src addr.IA, max uint16, f PathReqFlags,
+	filter *PathReqFilter) (*PathReq, error) {
+
+	req := &PathReq{
+		Dst:      dst.IAInt(),
+		Src:      src.IAInt(),
+		MaxPaths: max,
+		Flags:    f,
+	}
+	if filter == nil {
+		return req, nil
+	}
+	if filter.Policy != nil {
+		raw, err := json.Marshal(filter.Policy)
+		if err != nil {
+			return nil, common.NewBasicError("Unable to marshal path policy", err)
+		}
+		req.Policy = raw
+	}
+	req.RequiredIntfs = append(req.RequiredIntfs, filter.RequiredIntfs...)
+	for _, ia := range filter.ForbiddenASes {
+		req.ForbiddenASes = append(req.ForbiddenASes, ia.IAInt())
+	}
+	return req, nil
 }
 
 func (pathReq *PathReq) Copy() *PathReq {
 	return &PathReq{
-		Dst:      pathReq.Dst,
-		Src:      pathReq.Src,
-		MaxPaths: pathReq.MaxPaths,
-		Flags:    pathReq.Flags,
+		Dst:           pathReq.Dst,
+		Src:           pathReq.Src,
+		MaxPaths:      pathReq.MaxPaths,
+		Flags:         pathReq.Flags,
+		Policy:        common.CloneByteSlice(pathReq.Policy),
+		RequiredIntfs: append(pathReq.RequiredIntfs[:0:0], pathReq.RequiredIntfs...),
+		ForbiddenASes: append(pathReq.ForbiddenASes[:0:0], pathReq.ForbiddenASes...),
 	}
 }
 
+// Filter returns the filter contained in the request. If the request does not
+// restrict the paths, the result is nil.
+func (pathReq *PathReq) Filter() (*PathReqFilter, error) {
+	if len(pathReq.Policy) == 0 && len(pathReq.RequiredIntfs) == 0 &&
+		len(pathReq.ForbiddenASes) == 0 {
+		return nil, nil
+	}
+	filter := &PathReqFilter{
+		RequiredIntfs: append(pathReq.RequiredIntfs[:0:0], pathReq.RequiredIntfs...),
+	}
+	if len(pathReq.Policy) != 0 {
+		filter.Policy = &pathpol.Policy{}
+		if err := json.Unmarshal(pathReq.Policy, filter.Policy); err != nil {
+			return nil, common.NewBasicError("Unable to parse path policy", err)
+		}
+	}
+	for _, ia := range pathReq.ForbiddenASes {
+		filter.ForbiddenASes = append(filter.ForbiddenASes, ia.IA())
+	}
+	return filter, nil
+}
+
 func (pathReq *PathReq) String() string {
-	return fmt.Sprintf("%v -> %v, maxPaths=%d, flags=%v",
+	s := fmt.Sprintf("%v -> %v, maxPaths=%d, flags=%v",
 		pathReq.Src, pathReq.Dst, pathReq.MaxPaths, pathReq.Flags)
+	if len(pathReq.Policy) != 0 {
+		s += fmt.Sprintf(", policy=%s", pathReq.Policy)
+	}
+	if len(pathReq.RequiredIntfs) != 0 {
+		s += fmt.Sprintf(", requiredIntfs=%v", pathReq.RequiredIntfs)
+	}
+	if len(pathReq.ForbiddenASes) != 0 {
+		s += fmt.Sprintf(", forbiddenASes=%v", pathReq.ForbiddenASes)
+	}
+	return s
+}
+
+// PathReqFilter restricts the paths returned by SCIOND. SCIOND applies the
+// filter to all combined paths before truncating the result to the maximum
+// number of paths.
+type PathReqFilter struct {
+	// Policy is the path policy the paths must satisfy. If nil, all paths
+	// satisfy it.
+	Policy *pathpol.Policy
+	// RequiredIntfs contains interfaces that every path must traverse. An
+	// interface ID of 0 matches any interface of the AS.
+	RequiredIntfs []PathInterface
+	// ForbiddenASes contains ASes that no path may traverse. Wildcard values
+	// match all ASes of the ISD.
+	ForbiddenASes []addr.IA
 }
 
 type PathReqFlags struct {
@@ -161,9 +260,52 @@ func (r *PathReply) String() string {
 	return fmt.Sprintf("ErrorCode=%v\n  %v", r.ErrorCode, strings.Join(strEntries, "\n  "))
 }
 
+// PathUpdateReason describes why SCIOND pushed a path update to a subscriber.
+type PathUpdateReason uint16
+
+const (
+	// PathUpdateInitial is the reason of the first update of a subscription.
+	PathUpdateInitial PathUpdateReason = iota
+	// PathUpdateNewPaths indicates that new or refreshed paths are available.
+	PathUpdateNewPaths
+	// PathUpdateRevoked indicates that paths were removed because of a
+	// revocation.
+	PathUpdateRevoked
+	// PathUpdateExpired indicates that paths were removed because they expired.
+	PathUpdateExpired
+)
+
+func (r PathUpdateReason) String() string {
+	switch r {
+	case PathUpdateInitial:
+		return "initial"
+	case PathUpdateNewPaths:
+		return "new_paths"
+	case PathUpdateRevoked:
+		return "revoked"
+	case PathUpdateExpired:
+		return "expired"
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", uint16(r))
+}
+
+// PathUpdate is pushed by SCIOND to a client that subscribed to the paths to a
+// destination. It contains the complete current set of paths.
+type PathUpdate struct {
+	Reason PathUpdateReason
+	Reply  *PathReply
+}
+
+func (u *PathUpdate) String() string {
+	return fmt.Sprintf("Reason=%v %v", u.Reason, u.Reply)
+}
+
 type PathReplyEntry struct {
 	Path     *FwdPathMeta
 	HostInfo hostinfo.Host
+	// Quality is the quality SCIOND observed when probing the path. It is nil
+	// if SCIOND does not probe the path.
+	Quality *PathQuality
 }
 
 func (e *PathReplyEntry) Copy() *PathReplyEntry {
@@ -173,18 +315,75 @@ func (e *PathReplyEntry) Copy() *PathReplyEntry {
 	return &PathReplyEntry{
 		Path:     e.Path.Copy(),
 		HostInfo: *e.HostInfo.Copy(),
+		Quality:  e.Quality.Copy(),
 	}
 }
 
 func (e *PathReplyEntry) String() string {
+	if e.Quality != nil {
+		return fmt.Sprintf("%v NextHop=%v Quality=[%v]", e.Path, &e.HostInfo, e.Quality)
+	}
 	return fmt.Sprintf("%v NextHop=%v", e.Path, &e.HostInfo)
 }
 
+// PathQuality contains the quality of a path as observed by the probes SCIOND
+// sends over it.
+type PathQuality struct {
+	// Rtt is the smoothed round-trip time in microseconds. It is zero if no
+	// probe was answered yet.
+	Rtt uint32
+	// RttVar is the round-trip time variation in microseconds.
+	RttVar uint32
+	// Sent is the number of probes in the loss window.
+	Sent uint32
+	// Lost is the number of lost probes in the loss window.
+	Lost uint32
+	// LastProbe is the time of the last probe in seconds since epoch.
+	LastProbe uint32
+}
+
+// RTT returns the smoothed round-trip time. It is zero if unknown.
+func (q *PathQuality) RTT() time.Duration {
+	return time.Duration(q.Rtt) * time.Microsecond
+}
+
+// RTTVar returns the round-trip time variation.
+func (q *PathQuality) RTTVar() time.Duration {
+	return time.Duration(q.RttVar) * time.Microsecond
+}
+
+// LossRate returns the fraction of lost probes in the loss window.
+func (q *PathQuality) LossRate() float64 {
+	if q.Sent == 0 {
+		return 0
+	}
+	return float64(q.Lost) / float64(q.Sent)
+}
+
+// LastProbeTime returns the time of the last probe.
+func (q *PathQuality) LastProbeTime() time.Time {
+	return util.SecsToTime(q.LastProbe)
+}
+
+func (q *PathQuality) Copy() *PathQuality {
+	if q == nil {
+		return nil
+	}
+	c := *q
+	return &c
+}
+
+func (q *PathQuality) String() string {
+	return fmt.Sprintf("RTT=%v RTTVar=%v Loss=%d/%d LastProbe=%v", q.RTT(), q.RTTVar(),
+		q.Lost, q.Sent, util.TimeToCompact(q.LastProbeTime()))
+}
+
 type FwdPathMeta struct {
 	FwdPath    []byte
 	Mtu        uint16
 	Interfaces []PathInterface
 	ExpTime    uint32
+	Metadata   *PathMetadata
 }
 
 func (fpm *FwdPathMeta) SrcIA() addr.IA {
@@ -217,6 +416,7 @@ func (fpm *FwdPathMeta) Copy() *FwdPathMeta {
 		res.Interfaces = make([]PathInterface, len(fpm.Interfaces))
 		copy(res.Interfaces, fpm.Interfaces)
 	}
+	res.Metadata = fpm.Metadata.Copy()
 	return res
 }
 
@@ -242,6 +442,32 @@ func (fpm *FwdPathMeta) fmtIfaces() []string {
 	return hops
 }
 
+// PathMetadata contains the static metadata of a path aggregated from the
+// static info extensions of the AS entries. Latencies and Bandwidths are
+// indexed by the hop between interface i and i+1 of the path, LinkTypes by the
+// inter-AS link between interface 2i and 2i+1, and Geo by interface. Unknown
+// values are zero.
+type PathMetadata struct {
+	// Latencies contains the latencies in microseconds.
+	Latencies []uint32
+	// Bandwidths contains the bandwidths in Kbit/s.
+	Bandwidths []uint64
+	LinkTypes  []seg.LinkType
+	Geo        []seg.GeoInfo
+}
+
+func (pm *PathMetadata) Copy() *PathMetadata {
+	if pm == nil {
+		return nil
+	}
+	return &PathMetadata{
+		Latencies:  append(pm.Latencies[:0:0], pm.Latencies...),
+		Bandwidths: append(pm.Bandwidths[:0:0], pm.Bandwidths...),
+		LinkTypes:  append(pm.LinkTypes[:0:0], pm.LinkTypes...),
+		Geo:        append(pm.Geo[:0:0], pm.Geo...),
+	}
+}
+
 type PathInterface struct {
 	RawIsdas addr.IAInt `capnp:"isdas"`
 	IfID     common.IFIDType
@@ -19,6 +19,7 @@ import (
 	"net"
 	"time"
 
+	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/scmp"
 )
@@ -38,6 +39,8 @@ var _ Error = (*OpError)(nil)
 type OpError struct {
 	scmp    *scmp.Hdr
 	revInfo *path_mgmt.RevInfo
+	// quotedPath is the raw path header quoted in the SCMP message.
+	quotedPath common.RawBytes
 }
 
 func (e *OpError) SCMP() *scmp.Hdr {
@@ -48,6 +51,12 @@ func (e *OpError) RevInfo() *path_mgmt.RevInfo {
 	return e.revInfo
 }
 
+// QuotedPath returns the raw path header of the packet that caused the SCMP
+// message, or nil if the SCMP message does not quote it.
+func (e *OpError) QuotedPath() common.RawBytes {
+	return e.quotedPath
+}
+
 func (e *OpError) Error() string {
 	return e.scmp.String()
 }
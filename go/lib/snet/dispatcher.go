@@ -94,7 +94,8 @@ func NewSCMPHandler(rh RevocationHandler) SCMPHandler {
 
 // scmpHandler handles SCMP messages received from the network. If a revocation handler is
 // configured, it is informed of any received revocations. All revocations are passed back to the
-// caller embedded in the error, so applications can handle them manually.
+// caller embedded in the error, so applications can handle them manually. Other SCMP errors are
+// passed back to the caller the same way, general SCMP messages are ignored.
 type scmpHandler struct {
 	// revocationHandler manages revocations received via SCMP. If nil, the handler is not called.
 	revocationHandler RevocationHandler
@@ -113,14 +114,28 @@ func (h *scmpHandler) Handle(pkt *SCIONPacket) error {
 		metrics.M.SCMPErrors().Inc()
 	}
 
-	// Only handle revocations for now
 	if hdr.Class == scmp.C_Path && hdr.Type == scmp.T_P_RevokedIF {
 		return h.handleSCMPRev(hdr, pkt)
 	}
+	if hdr.Class != scmp.C_General {
+		return h.handleSCMPError(hdr, pkt)
+	}
 	log.Debug("Ignoring scmp packet", "hdr", hdr, "src", pkt.Source)
 	return nil
 }
 
+// handleSCMPError passes the SCMP error back to the caller, including the path
+// quoted in the SCMP payload, such that the caller can identify the affected path.
+func (h *scmpHandler) handleSCMPError(hdr *scmp.Hdr, pkt *SCIONPacket) error {
+	scmpPayload, ok := pkt.Payload.(*scmp.Payload)
+	if !ok {
+		return common.NewBasicError("Unable to type assert payload to SCMP payload", nil,
+			"type", common.TypeOf(pkt.Payload))
+	}
+	log.Debug("Received SCMP error", "header", hdr.String(), "src", pkt.Source)
+	return &OpError{scmp: hdr, quotedPath: scmpPayload.PathHdr}
+}
+
 func (h *scmpHandler) handleSCMPRev(hdr *scmp.Hdr, pkt *SCIONPacket) error {
 	scmpPayload, ok := pkt.Payload.(*scmp.Payload)
 	if !ok {
@@ -145,5 +160,5 @@ func (h *scmpHandler) handleSCMPRev(hdr *scmp.Hdr, pkt *SCIONPacket) error {
 	if err != nil {
 		return err
 	}
-	return &OpError{scmp: hdr, revInfo: revInfo}
+	return &OpError{scmp: hdr, revInfo: revInfo, quotedPath: scmpPayload.PathHdr}
 }
@@ -16,6 +16,9 @@ package snet
 
 import (
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/scmp"
 	"github.com/scionproto/scion/go/lib/snet/internal/ctxmonitor"
 )
 
@@ -36,3 +39,9 @@ func NewRemoteAddressResolver(localIA addr.IA, pq PathQuerier,
 		monitor:     monitor,
 	}
 }
+
+func NewOpError(hdr *scmp.Hdr, revInfo *path_mgmt.RevInfo,
+	quotedPath common.RawBytes) *OpError {
+
+	return &OpError{scmp: hdr, revInfo: revInfo, quotedPath: quotedPath}
+}
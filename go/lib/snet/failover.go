@@ -0,0 +1,469 @@
+// Copyright 2019 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet/internal/ctxmonitor"
+)
+
+const (
+	// DefaultExpiryMargin is the default time before the expiration of the
+	// active path at which a FailoverConn switches to a fresher path.
+	DefaultExpiryMargin = 10 * time.Second
+	// DefaultRefreshInterval is the default minimum time between two path set
+	// refreshes that are caused by an expiring active path.
+	DefaultRefreshInterval = time.Second
+	// revokedIntfMaxTTL bounds the time a revoked interface is excluded if
+	// the revocation does not carry a usable expiration time.
+	revokedIntfMaxTTL = 10 * time.Second
+)
+
+// SwitchReason describes why a FailoverConn switched paths.
+type SwitchReason string
+
+const (
+	// SwitchInitial is the reason for the first path selection.
+	SwitchInitial SwitchReason = "initial"
+	// SwitchRevocation is the reason if an interface on the active path was
+	// revoked.
+	SwitchRevocation SwitchReason = "revocation"
+	// SwitchSCMPError is the reason if an SCMP error was received for the
+	// active path.
+	SwitchSCMPError SwitchReason = "scmp_error"
+	// SwitchExpiry is the reason if the active path is about to expire.
+	SwitchExpiry SwitchReason = "expiry"
+)
+
+// FailoverOptions configures a FailoverConn.
+type FailoverOptions struct {
+	// ExpiryMargin is the time before the expiration of the active path at
+	// which the connection switches to a fresher path. If it is zero,
+	// DefaultExpiryMargin is used.
+	ExpiryMargin time.Duration
+	// RefreshInterval is the minimum time between two path set refreshes that
+	// are caused by an expiring active path. Until a fresher path is found,
+	// the expiring path is used. If it is zero, DefaultRefreshInterval is
+	// used.
+	RefreshInterval time.Duration
+	// OnSwitch is called whenever the active path changes. Parameter old is
+	// nil for the initial path selection, and current is nil if no path is
+	// left. The callback is invoked synchronously and must not call methods
+	// of the connection.
+	OnSwitch func(old, current Path, reason SwitchReason)
+}
+
+var _ Conn = (*FailoverConn)(nil)
+
+// FailoverConn is a connection to a fixed remote address that holds a set of
+// paths to the remote AS. It switches to another path from the set if an
+// interface on the active path is revoked, an SCMP error that quotes the active
+// path is received or the active path is about to expire. If no usable path is
+// left, the path set is refreshed from the path querier.
+//
+// Revocations and SCMP errors are only detected if they are returned by the
+// read calls of the connection, so applications must keep reading from the
+// connection. The errors are still returned to the caller.
+type FailoverConn struct {
+	conn    Conn
+	raddr   *Addr
+	localIA addr.IA
+	querier PathQuerier
+	monitor ctxmonitor.Monitor
+	opts    FailoverOptions
+
+	mtx sync.Mutex
+	// paths contains the usable paths, the active path is the first entry.
+	paths []Path
+	// revoked contains the revoked interfaces and the time until which they
+	// are excluded.
+	revoked map[revokedIntf]time.Time
+	// lastRefresh is the time of the last path set refresh.
+	lastRefresh time.Time
+}
+
+type revokedIntf struct {
+	ia   addr.IA
+	ifID common.IFIDType
+}
+
+// NewFailoverConn creates a connection to raddr that sends packets through
+// conn. Parameter conn must not have a fixed remote address, i.e., it must be
+// created with ListenSCION. Paths to the remote AS are fetched from querier.
+func NewFailoverConn(conn Conn, raddr *Addr, localIA addr.IA, querier PathQuerier,
+	opts FailoverOptions) (*FailoverConn, error) {
+
+	if raddr == nil || raddr.Host == nil {
+		return nil, common.NewBasicError(ErrNoApplicationAddress, nil)
+	}
+	if !localIA.Equal(raddr.IA) && querier == nil {
+		return nil, serrors.New("Path querier required for remote destination")
+	}
+	if opts.ExpiryMargin == 0 {
+		opts.ExpiryMargin = DefaultExpiryMargin
+	}
+	if opts.RefreshInterval == 0 {
+		opts.RefreshInterval = DefaultRefreshInterval
+	}
+	c := &FailoverConn{
+		conn:    conn,
+		raddr:   raddr.Copy(),
+		localIA: localIA,
+		querier: querier,
+		monitor: ctxmonitor.NewMonitor(),
+		opts:    opts,
+		revoked: make(map[revokedIntf]time.Time),
+	}
+	// The path is set per packet.
+	c.raddr.Path, c.raddr.NextHop = nil, nil
+	return c, nil
+}
+
+// DialSCIONFailover returns a FailoverConn to raddr that fetches paths from
+// the path querier of the network. See DialSCION for the parameters.
+func (n *SCIONNetwork) DialSCIONFailover(network string, laddr, raddr *Addr,
+	timeout time.Duration, opts FailoverOptions) (*FailoverConn, error) {
+
+	if raddr == nil {
+		return nil, serrors.New("Unable to dial to nil remote")
+	}
+	conn, err := n.ListenSCION(network, laddr, timeout)
+	if err != nil {
+		return nil, err
+	}
+	c, err := NewFailoverConn(conn, raddr, n.localIA, n.querier, opts)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// ActivePath returns the path currently used to reach the remote address. It
+// returns nil if the remote address is in the local AS, or if no path has
+// been selected yet.
+func (c *FailoverConn) ActivePath() Path {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if len(c.paths) == 0 {
+		return nil
+	}
+	return c.paths[0]
+}
+
+// Write sends b to the remote address on the active path.
+func (c *FailoverConn) Write(b []byte) (int, error) {
+	raddr, err := c.remote()
+	if err != nil {
+		return 0, err
+	}
+	return c.conn.WriteToSCION(b, raddr)
+}
+
+// WriteTo always returns an error, because the remote address is fixed.
+func (c *FailoverConn) WriteTo(b []byte, address net.Addr) (int, error) {
+	return 0, common.NewBasicError(ErrDuplicateAddr, nil)
+}
+
+// WriteToSCION always returns an error, because the remote address is fixed.
+func (c *FailoverConn) WriteToSCION(b []byte, address *Addr) (int, error) {
+	return 0, common.NewBasicError(ErrDuplicateAddr, nil)
+}
+
+func (c *FailoverConn) Read(b []byte) (int, error) {
+	n, _, err := c.ReadFromSCION(b)
+	return n, err
+}
+
+func (c *FailoverConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	return c.ReadFromSCION(b)
+}
+
+func (c *FailoverConn) ReadFromSCION(b []byte) (int, *Addr, error) {
+	n, address, err := c.conn.ReadFromSCION(b)
+	if opErr, ok := err.(*OpError); ok {
+		c.handleSCMP(opErr)
+	}
+	return n, address, err
+}
+
+// RemoteAddr returns the remote address including the active path.
+func (c *FailoverConn) RemoteAddr() net.Addr {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	raddr := c.raddr.Copy()
+	if len(c.paths) != 0 {
+		raddr.Path = c.paths[0].Path()
+		raddr.NextHop = c.paths[0].OverlayNextHop()
+	}
+	return raddr
+}
+
+func (c *FailoverConn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+func (c *FailoverConn) BindAddr() net.Addr {
+	return c.conn.BindAddr()
+}
+
+func (c *FailoverConn) SVC() addr.HostSVC {
+	return c.conn.SVC()
+}
+
+func (c *FailoverConn) SetDeadline(t time.Time) error {
+	if err := c.conn.SetDeadline(t); err != nil {
+		return err
+	}
+	c.monitor.SetDeadline(t)
+	return nil
+}
+
+func (c *FailoverConn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+func (c *FailoverConn) SetWriteDeadline(t time.Time) error {
+	if err := c.conn.SetWriteDeadline(t); err != nil {
+		return err
+	}
+	c.monitor.SetDeadline(t)
+	return nil
+}
+
+func (c *FailoverConn) Close() error {
+	return c.conn.Close()
+}
+
+// remote returns the remote address with the active path. The path set is
+// refreshed if it is empty or the active path is about to expire. Refreshes
+// because of expiry are done at most once per refresh interval.
+func (c *FailoverConn) remote() (*Addr, error) {
+	if c.localIA.Equal(c.raddr.IA) {
+		return c.raddr, nil
+	}
+	c.mtx.Lock()
+	var reason SwitchReason
+	switch {
+	case len(c.paths) == 0:
+		reason = SwitchInitial
+	case c.expiring(c.paths[0]) && time.Since(c.lastRefresh) >= c.opts.RefreshInterval:
+		reason = SwitchExpiry
+		// Concurrent writes keep using the expiring path instead of
+		// refreshing as well.
+		c.lastRefresh = time.Now()
+	}
+	c.mtx.Unlock()
+	if reason != "" {
+		if err := c.refresh(nil, reason); err != nil {
+			if reason == SwitchInitial {
+				return nil, err
+			}
+			log.Debug("Unable to refresh expiring path", "remote", c.raddr, "err", err)
+		}
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if len(c.paths) == 0 {
+		return nil, serrors.WithCtx(ErrPath, "reason", "no path found")
+	}
+	raddr := c.raddr.Copy()
+	raddr.Path = c.paths[0].Path()
+	raddr.NextHop = c.paths[0].OverlayNextHop()
+	return raddr, nil
+}
+
+// refresh fetches the path set from the querier and updates the path set. The
+// querier is contacted without holding the lock, so that concurrent reads and
+// writes are not blocked by the query.
+func (c *FailoverConn) refresh(failed Path, reason SwitchReason) error {
+	ctx, cancelF := c.monitor.WithTimeout(context.Background(), DefaultPathQueryTimeout)
+	defer cancelF()
+	paths, err := c.querier.Query(ctx, c.raddr.IA)
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.lastRefresh = time.Now()
+	if err != nil {
+		return serrors.Wrap(ErrPath, err)
+	}
+	c.update(paths, failed, reason)
+	return nil
+}
+
+// update sets the path set to the usable paths. It keeps the active path if it
+// is still part of the new set, does not expire soon and is not the failed
+// path. Otherwise, the path that expires last becomes active. It must be called
+// while holding the lock.
+func (c *FailoverConn) update(paths []Path, failed Path, reason SwitchReason) {
+	var usable []Path
+	for _, path := range paths {
+		if !c.isRevoked(path) && !c.expiring(path) {
+			usable = append(usable, path)
+		}
+	}
+	if len(usable) == 0 {
+		// Paths that are about to expire are better than no paths.
+		for _, path := range paths {
+			if !c.isRevoked(path) {
+				usable = append(usable, path)
+			}
+		}
+	}
+	var old Path
+	if len(c.paths) != 0 {
+		old = c.paths[0]
+	}
+	keep := old
+	if samePath(old, failed) {
+		keep = nil
+	}
+	best := 0
+	for i, path := range usable {
+		if keep != nil && path.Fingerprint() == keep.Fingerprint() && !c.expiring(path) {
+			best = i
+			break
+		}
+		if path.Expiry().After(usable[best].Expiry()) {
+			best = i
+		}
+	}
+	if len(usable) != 0 {
+		usable[0], usable[best] = usable[best], usable[0]
+	}
+	c.setPaths(usable, old, reason)
+}
+
+// handleSCMP removes the paths affected by the SCMP error from the path set.
+// Revocations affect all paths that contain the revoked interface, other SCMP
+// errors only affect the path quoted in the SCMP message. If no path is left,
+// the path set is refreshed from the querier.
+func (c *FailoverConn) handleSCMP(opErr *OpError) {
+	hdr := opErr.SCMP()
+	if hdr == nil {
+		return
+	}
+	c.mtx.Lock()
+	if len(c.paths) == 0 {
+		c.mtx.Unlock()
+		return
+	}
+	var reason SwitchReason
+	var remaining []Path
+	switch {
+	case opErr.RevInfo() != nil:
+		reason = SwitchRevocation
+		c.revoke(opErr.RevInfo())
+		for _, path := range c.paths {
+			if !c.isRevoked(path) {
+				remaining = append(remaining, path)
+			}
+		}
+	case hdr.Class != scmp.C_General && len(opErr.QuotedPath()) != 0:
+		reason = SwitchSCMPError
+		for _, path := range c.paths {
+			if !quotes(opErr, path) {
+				remaining = append(remaining, path)
+			}
+		}
+	default:
+		c.mtx.Unlock()
+		return
+	}
+	old := c.paths[0]
+	if len(remaining) != 0 {
+		c.setPaths(remaining, old, reason)
+		c.mtx.Unlock()
+		return
+	}
+	// The affected paths are kept until the refresh completes, concurrent
+	// writes fail the same way in the meantime.
+	c.mtx.Unlock()
+	if err := c.refresh(old, reason); err != nil {
+		log.Info("Unable to refresh paths", "remote", c.raddr, "err", err)
+		c.mtx.Lock()
+		if len(c.paths) != 0 && samePath(c.paths[0], old) {
+			c.setPaths(nil, old, reason)
+		}
+		c.mtx.Unlock()
+	}
+}
+
+func (c *FailoverConn) setPaths(paths []Path, old Path, reason SwitchReason) {
+	c.paths = paths
+	var current Path
+	if len(paths) != 0 {
+		current = paths[0]
+	}
+	if samePath(old, current) {
+		return
+	}
+	log.Debug("Switched path", "remote", c.raddr, "reason", reason,
+		"old", old, "new", current)
+	if c.opts.OnSwitch != nil {
+		c.opts.OnSwitch(old, current, reason)
+	}
+}
+
+func (c *FailoverConn) revoke(revInfo *path_mgmt.RevInfo) {
+	until := revInfo.Expiration()
+	if now := time.Now(); !until.After(now) {
+		until = now.Add(revokedIntfMaxTTL)
+	}
+	c.revoked[revokedIntf{ia: revInfo.IA(), ifID: revInfo.IfID}] = until
+}
+
+func (c *FailoverConn) isRevoked(path Path) bool {
+	now := time.Now()
+	for intf, until := range c.revoked {
+		if now.After(until) {
+			delete(c.revoked, intf)
+		}
+	}
+	for _, intf := range path.Interfaces() {
+		if _, ok := c.revoked[revokedIntf{ia: intf.IA(), ifID: intf.ID()}]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *FailoverConn) expiring(path Path) bool {
+	expiry := path.Expiry()
+	return !expiry.IsZero() && time.Until(expiry) < c.opts.ExpiryMargin
+}
+
+// quotes returns whether path is the path quoted in the SCMP error.
+func quotes(opErr *OpError, path Path) bool {
+	spath := path.Path()
+	return spath != nil && bytes.Equal(spath.Raw, opErr.QuotedPath())
+}
+
+func samePath(a, b Path) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Fingerprint() == b.Fingerprint()
+}
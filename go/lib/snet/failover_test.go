@@ -0,0 +1,325 @@
+// Copyright 2019 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/hpkt"
+	"github.com/scionproto/scion/go/lib/mocks/net/mock_net"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/mock_snet"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/spkt"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+var (
+	localIA  = xtest.MustParseIA("1-ff00:0:110")
+	remoteIA = xtest.MustParseIA("1-ff00:0:112")
+	hopIA    = xtest.MustParseIA("1-ff00:0:111")
+)
+
+type testIntf struct {
+	ia   addr.IA
+	ifID common.IFIDType
+}
+
+func (i testIntf) ID() common.IFIDType { return i.ifID }
+func (i testIntf) IA() addr.IA         { return i.ia }
+
+// testPath is a path over the interfaces of hopIA with the given IDs.
+type testPath struct {
+	name   string
+	ifIDs  []common.IFIDType
+	expiry time.Time
+}
+
+func (p *testPath) Fingerprint() string          { return p.name }
+func (p *testPath) OverlayNextHop() *net.UDPAddr { return &net.UDPAddr{Port: 30041} }
+func (p *testPath) Path() *spath.Path            { return &spath.Path{Raw: common.RawBytes(p.name)} }
+func (p *testPath) Destination() addr.IA         { return remoteIA }
+func (p *testPath) MTU() uint16                  { return 1280 }
+func (p *testPath) Expiry() time.Time            { return p.expiry }
+func (p *testPath) Metadata() *snet.PathMetadata { return nil }
+func (p *testPath) Copy() snet.Path              { return p }
+func (p *testPath) Interfaces() []snet.PathInterface {
+	intfs := make([]snet.PathInterface, 0, len(p.ifIDs))
+	for _, ifID := range p.ifIDs {
+		intfs = append(intfs, testIntf{ia: hopIA, ifID: ifID})
+	}
+	return intfs
+}
+
+type switchEvent struct {
+	old, current string
+	reason       snet.SwitchReason
+}
+
+func name(p snet.Path) string {
+	if p == nil {
+		return ""
+	}
+	return p.Fingerprint()
+}
+
+func TestFailoverConn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	raddr := MustParseAddr("1-ff00:0:112,[127.0.0.2]:80")
+	expiry := time.Now().Add(time.Hour)
+	pathA := &testPath{name: "A", ifIDs: []common.IFIDType{1, 2}, expiry: expiry}
+	pathB := &testPath{name: "B", ifIDs: []common.IFIDType{3, 4}, expiry: expiry}
+	pathC := &testPath{name: "C", ifIDs: []common.IFIDType{5, 6}, expiry: expiry}
+
+	newConnWithOpts := func(t *testing.T, opts snet.FailoverOptions) (*snet.FailoverConn,
+		*mock_snet.MockConn, *mock_snet.MockPathQuerier, *[]switchEvent) {
+
+		conn := mock_snet.NewMockConn(ctrl)
+		querier := mock_snet.NewMockPathQuerier(ctrl)
+		var events []switchEvent
+		opts.OnSwitch = func(old, current snet.Path, reason snet.SwitchReason) {
+			events = append(events, switchEvent{name(old), name(current), reason})
+		}
+		c, err := snet.NewFailoverConn(conn, raddr, localIA, querier, opts)
+		require.NoError(t, err)
+		return c, conn, querier, &events
+	}
+	newConn := func(t *testing.T) (*snet.FailoverConn, *mock_snet.MockConn,
+		*mock_snet.MockPathQuerier, *[]switchEvent) {
+
+		return newConnWithOpts(t, snet.FailoverOptions{})
+	}
+	expectWriteOn := func(conn *mock_snet.MockConn, path string) {
+		conn.EXPECT().WriteToSCION(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(b []byte, a *snet.Addr) (int, error) {
+				assert.Equal(t, path, string(a.Path.Raw))
+				assert.Equal(t, remoteIA, a.IA)
+				return len(b), nil
+			},
+		)
+	}
+
+	t.Run("initial path is selected on first write", func(t *testing.T) {
+		c, conn, querier, events := newConn(t)
+		assert.Nil(t, c.ActivePath())
+		querier.EXPECT().Query(gomock.Any(), remoteIA).Return([]snet.Path{pathA, pathB}, nil)
+		expectWriteOn(conn, "A")
+		_, err := c.Write([]byte("hello"))
+		require.NoError(t, err)
+		assert.Equal(t, "A", name(c.ActivePath()))
+		assert.Equal(t, []switchEvent{{"", "A", snet.SwitchInitial}}, *events)
+	})
+	t.Run("revocation switches to unaffected path", func(t *testing.T) {
+		c, conn, querier, events := newConn(t)
+		querier.EXPECT().Query(gomock.Any(), remoteIA).Return(
+			[]snet.Path{pathA, pathB, pathC}, nil)
+		expectWriteOn(conn, "A")
+		_, err := c.Write([]byte("hello"))
+		require.NoError(t, err)
+
+		revInfo := &path_mgmt.RevInfo{
+			IfID:         2,
+			RawIsdas:     hopIA.IAInt(),
+			RawTimestamp: util.TimeToSecs(time.Now()),
+			RawTTL:       10,
+		}
+		opErr := snet.NewOpError(
+			&scmp.Hdr{Class: scmp.C_Path, Type: scmp.T_P_RevokedIF}, revInfo, nil)
+		conn.EXPECT().ReadFromSCION(gomock.Any()).Return(0, nil, opErr)
+		_, err = c.Read(make([]byte, 10))
+		assert.Equal(t, opErr, err)
+		assert.Equal(t, "B", name(c.ActivePath()))
+
+		expectWriteOn(conn, "B")
+		_, err = c.Write([]byte("hello"))
+		require.NoError(t, err)
+		assert.Equal(t, snet.SwitchRevocation, (*events)[1].reason)
+	})
+	t.Run("SCMP error switches to next path", func(t *testing.T) {
+		c, conn, querier, events := newConn(t)
+		querier.EXPECT().Query(gomock.Any(), remoteIA).Return([]snet.Path{pathA, pathB}, nil)
+		expectWriteOn(conn, "A")
+		_, err := c.Write([]byte("hello"))
+		require.NoError(t, err)
+
+		opErr := snet.NewOpError(
+			&scmp.Hdr{Class: scmp.C_Path, Type: scmp.T_P_BadMac}, nil, pathA.Path().Raw)
+		conn.EXPECT().ReadFromSCION(gomock.Any()).Return(0, nil, opErr)
+		_, err = c.Read(make([]byte, 10))
+		assert.Error(t, err)
+		assert.Equal(t, "B", name(c.ActivePath()))
+		assert.Equal(t, switchEvent{"A", "B", snet.SwitchSCMPError}, (*events)[1])
+	})
+	t.Run("SCMP error for other path keeps active path", func(t *testing.T) {
+		c, conn, querier, events := newConn(t)
+		querier.EXPECT().Query(gomock.Any(), remoteIA).Return(
+			[]snet.Path{pathA, pathB, pathC}, nil)
+		expectWriteOn(conn, "A")
+		_, err := c.Write([]byte("hello"))
+		require.NoError(t, err)
+
+		for _, quoted := range []common.RawBytes{nil, pathB.Path().Raw} {
+			conn.EXPECT().ReadFromSCION(gomock.Any()).Return(0, nil, snet.NewOpError(
+				&scmp.Hdr{Class: scmp.C_Path, Type: scmp.T_P_BadMac}, nil, quoted))
+			c.Read(make([]byte, 10))
+			assert.Equal(t, "A", name(c.ActivePath()))
+		}
+		assert.Len(t, *events, 1)
+
+		// Path B was removed from the set, so C is next.
+		conn.EXPECT().ReadFromSCION(gomock.Any()).Return(0, nil, snet.NewOpError(
+			&scmp.Hdr{Class: scmp.C_Path, Type: scmp.T_P_BadMac}, nil, pathA.Path().Raw))
+		c.Read(make([]byte, 10))
+		assert.Equal(t, "C", name(c.ActivePath()))
+	})
+	t.Run("paths are refreshed if none is left", func(t *testing.T) {
+		c, conn, querier, _ := newConn(t)
+		querier.EXPECT().Query(gomock.Any(), remoteIA).Return([]snet.Path{pathA}, nil)
+		expectWriteOn(conn, "A")
+		_, err := c.Write([]byte("hello"))
+		require.NoError(t, err)
+
+		// The querier is contacted without holding the lock of the connection.
+		querier.EXPECT().Query(gomock.Any(), remoteIA).DoAndReturn(
+			func(_ context.Context, _ addr.IA) ([]snet.Path, error) {
+				assert.Equal(t, "A", name(c.ActivePath()))
+				return []snet.Path{pathA, pathC}, nil
+			},
+		)
+		revInfo := &path_mgmt.RevInfo{
+			IfID:         1,
+			RawIsdas:     hopIA.IAInt(),
+			RawTimestamp: util.TimeToSecs(time.Now()),
+			RawTTL:       10,
+		}
+		conn.EXPECT().ReadFromSCION(gomock.Any()).Return(0, nil, snet.NewOpError(
+			&scmp.Hdr{Class: scmp.C_Path, Type: scmp.T_P_RevokedIF}, revInfo, nil))
+		c.Read(make([]byte, 10))
+		// Path A is still revoked, even though it was returned again.
+		assert.Equal(t, "C", name(c.ActivePath()))
+	})
+	t.Run("expiring path is replaced", func(t *testing.T) {
+		c, conn, querier, events := newConnWithOpts(t,
+			snet.FailoverOptions{RefreshInterval: 50 * time.Millisecond})
+		expiring := &testPath{name: "E", ifIDs: []common.IFIDType{7},
+			expiry: time.Now().Add(time.Second)}
+		querier.EXPECT().Query(gomock.Any(), remoteIA).Return([]snet.Path{expiring}, nil)
+		expectWriteOn(conn, "E")
+		_, err := c.Write([]byte("hello"))
+		require.NoError(t, err)
+
+		// The paths are not refreshed again within the refresh interval.
+		expectWriteOn(conn, "E")
+		_, err = c.Write([]byte("hello"))
+		require.NoError(t, err)
+
+		time.Sleep(50 * time.Millisecond)
+		querier.EXPECT().Query(gomock.Any(), remoteIA).Return(
+			[]snet.Path{expiring, pathB}, nil)
+		expectWriteOn(conn, "B")
+		_, err = c.Write([]byte("hello"))
+		require.NoError(t, err)
+		assert.Equal(t, switchEvent{"E", "B", snet.SwitchExpiry}, (*events)[1])
+	})
+	t.Run("local destination does not need paths", func(t *testing.T) {
+		conn := mock_snet.NewMockConn(ctrl)
+		local := MustParseAddr("1-ff00:0:110,[127.0.0.2]:80")
+		c, err := snet.NewFailoverConn(conn, local, localIA, nil, snet.FailoverOptions{})
+		require.NoError(t, err)
+		conn.EXPECT().WriteToSCION(gomock.Any(), local).Return(5, nil)
+		_, err = c.Write([]byte("hello"))
+		require.NoError(t, err)
+		assert.Nil(t, c.ActivePath())
+	})
+}
+
+func TestFailoverConnSCMPError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	laddr := MustParseAddr("1-ff00:0:110,[127.0.0.1]:40000")
+	raddr := MustParseAddr("1-ff00:0:112,[127.0.0.2]:80")
+	expiry := time.Now().Add(time.Hour)
+	// The raw paths are quoted in the SCMP payload, so their length must be a
+	// multiple of the line length.
+	pathA := &testPath{name: "path-one", ifIDs: []common.IFIDType{1, 2}, expiry: expiry}
+	pathB := &testPath{name: "path-two", ifIDs: []common.IFIDType{3, 4}, expiry: expiry}
+
+	pconn := mock_net.NewMockPacketConn(ctrl)
+	dispatcher := mock_snet.NewMockPacketDispatcherService(ctrl)
+	dispatcher.EXPECT().RegisterTimeout(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any()).Return(
+		snet.NewSCIONPacketConn(pconn, snet.NewSCMPHandler(nil)), uint16(40000), nil)
+	conn, err := snet.NewCustomNetworkWithPR(localIA, dispatcher).ListenSCION("udp4", laddr, 0)
+	require.NoError(t, err)
+	querier := mock_snet.NewMockPathQuerier(ctrl)
+	c, err := snet.NewFailoverConn(conn, raddr, localIA, querier, snet.FailoverOptions{})
+	require.NoError(t, err)
+
+	querier.EXPECT().Query(gomock.Any(), remoteIA).Return([]snet.Path{pathA, pathB}, nil)
+	pconn.EXPECT().WriteTo(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(b []byte, _ net.Addr) (int, error) { return len(b), nil },
+	).Times(2)
+	_, err = c.Write([]byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, "path-one", name(c.ActivePath()))
+
+	ct := scmp.ClassType{Class: scmp.C_Path, Type: scmp.T_P_BadMac}
+	scmpPkt := &spkt.ScnPkt{
+		DstIA:   localIA,
+		SrcIA:   hopIA,
+		DstHost: laddr.Host.L3,
+		SrcHost: addr.HostFromIPStr("127.0.0.3"),
+		L4:      scmp.NewHdr(ct, 0),
+		Pld: scmp.PldFromQuotes(ct, &scmp.InfoPathOffsets{IfID: 2}, common.L4UDP,
+			func(blk scmp.RawBlock) common.RawBytes {
+				if blk == scmp.RawPathHdr {
+					return pathA.Path().Raw
+				}
+				return nil
+			},
+		),
+	}
+	raw := make(common.RawBytes, common.MaxMTU)
+	n, err := hpkt.WriteScnPkt(scmpPkt, raw)
+	require.NoError(t, err)
+	pconn.EXPECT().ReadFrom(gomock.Any()).DoAndReturn(
+		func(b []byte) (int, net.Addr, error) {
+			return copy(b, raw[:n]), &net.UDPAddr{IP: net.IPv4(127, 0, 0, 3), Port: 30041}, nil
+		},
+	)
+	_, err = c.Read(make([]byte, common.MaxMTU))
+	require.IsType(t, &snet.OpError{}, err)
+	hdr := err.(*snet.OpError).SCMP()
+	assert.Equal(t, ct, scmp.ClassType{Class: hdr.Class, Type: hdr.Type})
+	assert.Equal(t, "path-two", name(c.ActivePath()))
+
+	_, err = c.Write([]byte("hello"))
+	require.NoError(t, err)
+}
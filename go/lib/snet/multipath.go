@@ -0,0 +1,564 @@
+// Copyright 2019 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet/internal/ctxmonitor"
+)
+
+// rttSmoothing is the weight of a new sample in the smoothed RTT of a path.
+const rttSmoothing = 0.125
+
+// SchedulingMode determines how a MultipathConn distributes packets over its
+// paths.
+type SchedulingMode int
+
+const (
+	// SchedulingRoundRobin sends each packet on the next path.
+	SchedulingRoundRobin SchedulingMode = iota
+	// SchedulingRedundant sends each packet on every path.
+	SchedulingRedundant
+	// SchedulingWeightedRTT sends packets on the paths with a share that is
+	// inversely proportional to the RTT of the path. The RTTs are not
+	// measured by the connection, they must be reported by the application
+	// with ObserveRTT. Paths without measurement are weighted with the mean
+	// RTT of the measured paths. If no RTT is reported, the packets are
+	// distributed evenly.
+	SchedulingWeightedRTT
+)
+
+func (m SchedulingMode) String() string {
+	switch m {
+	case SchedulingRoundRobin:
+		return "round-robin"
+	case SchedulingRedundant:
+		return "redundant"
+	case SchedulingWeightedRTT:
+		return "weighted-rtt"
+	default:
+		return fmt.Sprintf("UNKNOWN (%d)", int(m))
+	}
+}
+
+// MultipathOptions configures a MultipathConn.
+type MultipathOptions struct {
+	// Policy filters the paths to the remote AS. If it is nil, all paths are
+	// used.
+	Policy *pathpol.Policy
+	// Mode is the scheduling mode.
+	Mode SchedulingMode
+	// MaxPaths limits the number of paths that are used. If it is zero, all
+	// paths are used. The paths that expire last are preferred.
+	MaxPaths int
+	// ExpiryMargin is the time before the expiration of a path at which it is
+	// no longer used. If it is zero, DefaultExpiryMargin is used.
+	ExpiryMargin time.Duration
+	// RefreshInterval is the minimum time between two path set refreshes that
+	// are caused by expiring paths. Until fresher paths are found, the
+	// expiring paths are used. If it is zero, DefaultRefreshInterval is used.
+	RefreshInterval time.Duration
+}
+
+// PathStats contains the counters of a path used by a MultipathConn.
+type PathStats struct {
+	// Path is the path the counters belong to.
+	Path Path
+	// Packets is the number of packets successfully sent on the path.
+	Packets uint64
+	// Bytes is the number of bytes successfully sent on the path.
+	Bytes uint64
+	// Errors is the number of failed writes on the path.
+	Errors uint64
+	// RTT is the smoothed RTT reported with ObserveRTT. It is zero if no
+	// RTT was reported.
+	RTT time.Duration
+}
+
+var _ Conn = (*MultipathConn)(nil)
+
+// MultipathConn is a connection to a fixed remote address that sends packets
+// on multiple paths to the remote AS. The paths are filtered with a path
+// policy, and the packets are distributed according to the scheduling mode.
+// The path set is refreshed from the path querier if no usable path is left,
+// or at most once per refresh interval if all paths are about to expire.
+// Paths that contain an interface for which a revocation is read from the
+// connection are removed from the set.
+//
+// The connection does not measure RTTs itself. Applications that use
+// SchedulingWeightedRTT must report their measurements with ObserveRTT.
+type MultipathConn struct {
+	conn    Conn
+	raddr   *Addr
+	localIA addr.IA
+	querier PathQuerier
+	monitor ctxmonitor.Monitor
+	opts    MultipathOptions
+
+	mtx   sync.Mutex
+	paths []*pathState
+	// next is the index of the next path in round-robin mode.
+	next int
+	// stats contains the counters of the paths in the set, keyed by
+	// fingerprint. The counters of paths that are part of the new set after a
+	// refresh are kept.
+	stats map[string]*PathStats
+	// lastRefresh is the time of the last path set refresh.
+	lastRefresh time.Time
+}
+
+type pathState struct {
+	path Path
+	// credit is the current credit of the path in weighted mode.
+	credit float64
+}
+
+// NewMultipathConn creates a multipath connection to raddr that sends packets
+// through conn. Parameter conn must not have a fixed remote address, i.e., it
+// must be created with ListenSCION. Paths to the remote AS are fetched from
+// querier.
+func NewMultipathConn(conn Conn, raddr *Addr, localIA addr.IA, querier PathQuerier,
+	opts MultipathOptions) (*MultipathConn, error) {
+
+	if raddr == nil || raddr.Host == nil {
+		return nil, common.NewBasicError(ErrNoApplicationAddress, nil)
+	}
+	if !localIA.Equal(raddr.IA) && querier == nil {
+		return nil, serrors.New("Path querier required for remote destination")
+	}
+	switch opts.Mode {
+	case SchedulingRoundRobin, SchedulingRedundant, SchedulingWeightedRTT:
+	default:
+		return nil, serrors.New("Unknown scheduling mode", "mode", opts.Mode)
+	}
+	if opts.MaxPaths < 0 {
+		return nil, serrors.New("Negative maximum number of paths", "max", opts.MaxPaths)
+	}
+	if opts.ExpiryMargin == 0 {
+		opts.ExpiryMargin = DefaultExpiryMargin
+	}
+	if opts.RefreshInterval == 0 {
+		opts.RefreshInterval = DefaultRefreshInterval
+	}
+	c := &MultipathConn{
+		conn:    conn,
+		raddr:   raddr.Copy(),
+		localIA: localIA,
+		querier: querier,
+		monitor: ctxmonitor.NewMonitor(),
+		opts:    opts,
+		stats:   make(map[string]*PathStats),
+	}
+	c.raddr.Path, c.raddr.NextHop = nil, nil
+	return c, nil
+}
+
+// DialSCIONMultipath returns a MultipathConn to raddr that fetches paths from
+// the path querier of the network. See DialSCION for the parameters.
+func (n *SCIONNetwork) DialSCIONMultipath(network string, laddr, raddr *Addr,
+	timeout time.Duration, opts MultipathOptions) (*MultipathConn, error) {
+
+	if raddr == nil {
+		return nil, serrors.New("Unable to dial to nil remote")
+	}
+	conn, err := n.ListenSCION(network, laddr, timeout)
+	if err != nil {
+		return nil, err
+	}
+	c, err := NewMultipathConn(conn, raddr, n.localIA, n.querier, opts)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Paths returns the paths that are currently used.
+func (c *MultipathConn) Paths() []Path {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	paths := make([]Path, 0, len(c.paths))
+	for _, s := range c.paths {
+		paths = append(paths, s.path)
+	}
+	return paths
+}
+
+// Stats returns the counters of the paths that are currently used, sorted by
+// fingerprint.
+func (c *MultipathConn) Stats() []PathStats {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	stats := make([]PathStats, 0, len(c.stats))
+	for _, s := range c.stats {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Path.Fingerprint() < stats[j].Path.Fingerprint()
+	})
+	return stats
+}
+
+// ObserveRTT records an RTT measurement for the path with the given
+// fingerprint. The connection does not measure RTTs, so applications that use
+// SchedulingWeightedRTT must call it, e.g., with the RTT of request-reply
+// exchanges or probes sent on the path. Measurements for paths that are not in
+// the current set are ignored.
+func (c *MultipathConn) ObserveRTT(fingerprint string, rtt time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	s, ok := c.stats[fingerprint]
+	if !ok || rtt <= 0 {
+		return
+	}
+	if s.RTT == 0 {
+		s.RTT = rtt
+		return
+	}
+	s.RTT += time.Duration(rttSmoothing * float64(rtt-s.RTT))
+}
+
+// Refresh replaces the path set with the paths returned by the path querier.
+// The counters of paths that are part of the new set are kept.
+func (c *MultipathConn) Refresh() error {
+	if c.localIA.Equal(c.raddr.IA) {
+		return nil
+	}
+	return c.refresh()
+}
+
+// Write sends b to the remote address on the paths selected by the
+// scheduling mode. In redundant mode, the write succeeds if b was sent on at
+// least one path.
+func (c *MultipathConn) Write(b []byte) (int, error) {
+	if c.localIA.Equal(c.raddr.IA) {
+		return c.conn.WriteToSCION(b, c.raddr)
+	}
+	paths, err := c.schedule()
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	var errs []error
+	for _, path := range paths {
+		raddr := c.raddr.Copy()
+		raddr.Path = path.Path()
+		raddr.NextHop = path.OverlayNextHop()
+		written, err := c.conn.WriteToSCION(b, raddr)
+		c.count(path, written, err)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		n = written
+	}
+	if len(errs) == len(paths) {
+		return 0, errs[0]
+	}
+	return n, nil
+}
+
+// WriteTo always returns an error, because the remote address is fixed.
+func (c *MultipathConn) WriteTo(b []byte, address net.Addr) (int, error) {
+	return 0, common.NewBasicError(ErrDuplicateAddr, nil)
+}
+
+// WriteToSCION always returns an error, because the remote address is fixed.
+func (c *MultipathConn) WriteToSCION(b []byte, address *Addr) (int, error) {
+	return 0, common.NewBasicError(ErrDuplicateAddr, nil)
+}
+
+func (c *MultipathConn) Read(b []byte) (int, error) {
+	n, _, err := c.ReadFromSCION(b)
+	return n, err
+}
+
+func (c *MultipathConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	return c.ReadFromSCION(b)
+}
+
+func (c *MultipathConn) ReadFromSCION(b []byte) (int, *Addr, error) {
+	n, address, err := c.conn.ReadFromSCION(b)
+	if opErr, ok := err.(*OpError); ok && opErr.RevInfo() != nil {
+		c.removeRevoked(revokedIntf{ia: opErr.RevInfo().IA(), ifID: opErr.RevInfo().IfID})
+	}
+	return n, address, err
+}
+
+// RemoteAddr returns the remote address without path.
+func (c *MultipathConn) RemoteAddr() net.Addr {
+	return c.raddr.Copy()
+}
+
+func (c *MultipathConn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+func (c *MultipathConn) BindAddr() net.Addr {
+	return c.conn.BindAddr()
+}
+
+func (c *MultipathConn) SVC() addr.HostSVC {
+	return c.conn.SVC()
+}
+
+func (c *MultipathConn) SetDeadline(t time.Time) error {
+	if err := c.conn.SetDeadline(t); err != nil {
+		return err
+	}
+	c.monitor.SetDeadline(t)
+	return nil
+}
+
+func (c *MultipathConn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+func (c *MultipathConn) SetWriteDeadline(t time.Time) error {
+	if err := c.conn.SetWriteDeadline(t); err != nil {
+		return err
+	}
+	c.monitor.SetDeadline(t)
+	return nil
+}
+
+func (c *MultipathConn) Close() error {
+	return c.conn.Close()
+}
+
+// schedule returns the paths on which the next packet is sent. The path set is
+// refreshed if it is empty, or if all paths are about to expire and the last
+// refresh is at least the refresh interval ago.
+func (c *MultipathConn) schedule() ([]Path, error) {
+	c.mtx.Lock()
+	allExpiring := c.removeExpiring()
+	refresh := len(c.paths) == 0 ||
+		(allExpiring && time.Since(c.lastRefresh) >= c.opts.RefreshInterval)
+	if refresh {
+		// Concurrent writes keep using the expiring paths instead of
+		// refreshing as well.
+		c.lastRefresh = time.Now()
+	}
+	c.mtx.Unlock()
+	if refresh {
+		if err := c.refresh(); err != nil {
+			if !allExpiring {
+				return nil, err
+			}
+			log.Debug("Unable to refresh expiring paths", "remote", c.raddr, "err", err)
+		}
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if len(c.paths) == 0 {
+		return nil, serrors.WithCtx(ErrPath, "reason", "no path found")
+	}
+	switch c.opts.Mode {
+	case SchedulingRedundant:
+		paths := make([]Path, 0, len(c.paths))
+		for _, s := range c.paths {
+			paths = append(paths, s.path)
+		}
+		return paths, nil
+	case SchedulingWeightedRTT:
+		return []Path{c.weighted()}, nil
+	default:
+		c.next %= len(c.paths)
+		path := c.paths[c.next].path
+		c.next++
+		return []Path{path}, nil
+	}
+}
+
+// weighted selects a path with smooth weighted round-robin. The weight of a
+// path is the inverse of its RTT.
+func (c *MultipathConn) weighted() Path {
+	var sum time.Duration
+	var measured int
+	for _, s := range c.paths {
+		if rtt := c.stats[s.path.Fingerprint()].RTT; rtt > 0 {
+			sum += rtt
+			measured++
+		}
+	}
+	var total float64
+	var best *pathState
+	for _, s := range c.paths {
+		weight := 1.0
+		if measured > 0 {
+			rtt := c.stats[s.path.Fingerprint()].RTT
+			if rtt == 0 {
+				rtt = sum / time.Duration(measured)
+			}
+			weight = float64(time.Second) / float64(rtt)
+		}
+		s.credit += weight
+		total += weight
+		if best == nil || s.credit > best.credit {
+			best = s
+		}
+	}
+	best.credit -= total
+	return best.path
+}
+
+func (c *MultipathConn) count(path Path, n int, err error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	s, ok := c.stats[path.Fingerprint()]
+	if !ok {
+		// The path was removed from the set concurrently.
+		return
+	}
+	if err != nil {
+		s.Errors++
+		return
+	}
+	s.Packets++
+	s.Bytes += uint64(n)
+}
+
+// refresh fetches the path set from the querier and updates the path set. The
+// querier is contacted without holding the lock, so that concurrent reads and
+// writes are not blocked by the query.
+func (c *MultipathConn) refresh() error {
+	ctx, cancelF := c.monitor.WithTimeout(context.Background(), DefaultPathQueryTimeout)
+	defer cancelF()
+	paths, err := c.querier.Query(ctx, c.raddr.IA)
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.lastRefresh = time.Now()
+	if err != nil {
+		return serrors.Wrap(ErrPath, err)
+	}
+	c.update(paths)
+	return nil
+}
+
+// update applies the policy to paths and sets the path set to the usable
+// paths. The state and the counters of paths that are part of the new set are
+// kept. It must be called while holding the lock.
+func (c *MultipathConn) update(paths []Path) {
+	paths = FilterPaths(paths, c.opts.Policy)
+	var usable []Path
+	for _, path := range paths {
+		if !c.expiring(path) {
+			usable = append(usable, path)
+		}
+	}
+	if len(usable) == 0 {
+		// Paths that are about to expire are better than no paths.
+		usable = paths
+	}
+	sort.SliceStable(usable, func(i, j int) bool {
+		return usable[i].Expiry().After(usable[j].Expiry())
+	})
+	if c.opts.MaxPaths != 0 && len(usable) > c.opts.MaxPaths {
+		usable = usable[:c.opts.MaxPaths]
+	}
+	old := make(map[string]*pathState, len(c.paths))
+	for _, s := range c.paths {
+		old[s.path.Fingerprint()] = s
+	}
+	states := make([]*pathState, 0, len(usable))
+	for _, path := range usable {
+		state, ok := old[path.Fingerprint()]
+		if !ok {
+			state = &pathState{}
+		}
+		state.path = path
+		states = append(states, state)
+		if s, ok := c.stats[path.Fingerprint()]; ok {
+			s.Path = path
+			continue
+		}
+		c.stats[path.Fingerprint()] = &PathStats{Path: path}
+	}
+	c.paths = states
+	c.pruneStats()
+	c.next = 0
+}
+
+// removeExpiring removes the paths that are about to expire from the set. If
+// all paths are about to expire, they are kept, because they are better than
+// no paths, and true is returned.
+func (c *MultipathConn) removeExpiring() bool {
+	var remaining []*pathState
+	for _, s := range c.paths {
+		if !c.expiring(s.path) {
+			remaining = append(remaining, s)
+		}
+	}
+	if len(remaining) == 0 {
+		return len(c.paths) != 0
+	}
+	c.paths = remaining
+	c.pruneStats()
+	return false
+}
+
+func (c *MultipathConn) removeRevoked(intf revokedIntf) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	var remaining []*pathState
+	for _, s := range c.paths {
+		if !containsIntf(s.path, intf) {
+			remaining = append(remaining, s)
+		}
+	}
+	c.paths = remaining
+	c.pruneStats()
+}
+
+// pruneStats removes the counters of the paths that are no longer in the set.
+func (c *MultipathConn) pruneStats() {
+	if len(c.stats) == len(c.paths) {
+		return
+	}
+	current := make(map[string]struct{}, len(c.paths))
+	for _, s := range c.paths {
+		current[s.path.Fingerprint()] = struct{}{}
+	}
+	for fingerprint := range c.stats {
+		if _, ok := current[fingerprint]; !ok {
+			delete(c.stats, fingerprint)
+		}
+	}
+}
+
+func (c *MultipathConn) expiring(path Path) bool {
+	expiry := path.Expiry()
+	return !expiry.IsZero() && time.Until(expiry) < c.opts.ExpiryMargin
+}
+
+func containsIntf(path Path, intf revokedIntf) bool {
+	for _, i := range path.Interfaces() {
+		if i.IA().Equal(intf.ia) && i.ID() == intf.ifID {
+			return true
+		}
+	}
+	return false
+}
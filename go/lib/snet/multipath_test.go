@@ -0,0 +1,233 @@
+// Copyright 2019 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/mock_snet"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+func TestMultipathConn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	raddr := MustParseAddr("1-ff00:0:112,[127.0.0.2]:80")
+	expiry := time.Now().Add(time.Hour)
+	pathA := &testPath{name: "A", ifIDs: []common.IFIDType{1, 2}, expiry: expiry}
+	pathB := &testPath{name: "B", ifIDs: []common.IFIDType{3, 4}, expiry: expiry}
+	pathC := &testPath{name: "C", ifIDs: []common.IFIDType{5, 6}, expiry: expiry.Add(time.Hour)}
+
+	newConn := func(t *testing.T, opts snet.MultipathOptions) (*snet.MultipathConn,
+		*mock_snet.MockConn) {
+
+		conn := mock_snet.NewMockConn(ctrl)
+		querier := mock_snet.NewMockPathQuerier(ctrl)
+		querier.EXPECT().Query(gomock.Any(), remoteIA).Return(
+			[]snet.Path{pathA, pathB, pathC}, nil).AnyTimes()
+		c, err := snet.NewMultipathConn(conn, raddr, localIA, querier, opts)
+		require.NoError(t, err)
+		return c, conn
+	}
+	// recordWrites records the paths of the written packets.
+	recordWrites := func(conn *mock_snet.MockConn, sent *[]string, times int) {
+		conn.EXPECT().WriteToSCION(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(b []byte, a *snet.Addr) (int, error) {
+				*sent = append(*sent, string(a.Path.Raw))
+				return len(b), nil
+			},
+		).Times(times)
+	}
+	write := func(t *testing.T, c *snet.MultipathConn, times int) {
+		for i := 0; i < times; i++ {
+			n, err := c.Write([]byte("hello"))
+			require.NoError(t, err)
+			assert.Equal(t, 5, n)
+		}
+	}
+
+	t.Run("round-robin", func(t *testing.T) {
+		c, conn := newConn(t, snet.MultipathOptions{})
+		var sent []string
+		recordWrites(conn, &sent, 6)
+		write(t, c, 6)
+		// Paths that expire last are preferred.
+		assert.Equal(t, []string{"C", "A", "B", "C", "A", "B"}, sent)
+		stats := c.Stats()
+		require.Len(t, stats, 3)
+		for _, s := range stats {
+			assert.Equal(t, uint64(2), s.Packets)
+			assert.Equal(t, uint64(10), s.Bytes)
+		}
+	})
+	t.Run("redundant", func(t *testing.T) {
+		c, conn := newConn(t, snet.MultipathOptions{Mode: snet.SchedulingRedundant, MaxPaths: 2})
+		var sent []string
+		recordWrites(conn, &sent, 4)
+		write(t, c, 2)
+		assert.Equal(t, []string{"C", "A", "C", "A"}, sent)
+	})
+	t.Run("redundant with partial failure", func(t *testing.T) {
+		c, conn := newConn(t, snet.MultipathOptions{Mode: snet.SchedulingRedundant})
+		gomock.InOrder(
+			conn.EXPECT().WriteToSCION(gomock.Any(), gomock.Any()).Return(5, nil),
+			conn.EXPECT().WriteToSCION(gomock.Any(), gomock.Any()).Return(
+				0, errors.New("test")),
+			conn.EXPECT().WriteToSCION(gomock.Any(), gomock.Any()).Return(5, nil),
+		)
+		write(t, c, 1)
+		stats := c.Stats()
+		require.Len(t, stats, 3)
+		assert.Equal(t, "A", stats[0].Path.Fingerprint())
+		assert.Equal(t, uint64(1), stats[0].Errors)
+		assert.Equal(t, uint64(0), stats[0].Packets)
+	})
+	t.Run("all writes fail", func(t *testing.T) {
+		c, conn := newConn(t, snet.MultipathOptions{Mode: snet.SchedulingRedundant})
+		conn.EXPECT().WriteToSCION(gomock.Any(), gomock.Any()).Return(
+			0, errors.New("test")).Times(3)
+		_, err := c.Write([]byte("hello"))
+		assert.Error(t, err)
+	})
+	t.Run("weighted by RTT", func(t *testing.T) {
+		c, conn := newConn(t, snet.MultipathOptions{Mode: snet.SchedulingWeightedRTT})
+		var sent []string
+		recordWrites(conn, &sent, 3)
+		write(t, c, 3)
+		c.ObserveRTT("A", 10*time.Millisecond)
+		c.ObserveRTT("B", 40*time.Millisecond)
+		c.ObserveRTT("C", 40*time.Millisecond)
+
+		sent = nil
+		recordWrites(conn, &sent, 60)
+		write(t, c, 60)
+		counts := make(map[string]int)
+		for _, p := range sent {
+			counts[p]++
+		}
+		assert.Equal(t, map[string]int{"A": 40, "B": 10, "C": 10}, counts)
+	})
+	t.Run("policy filters paths", func(t *testing.T) {
+		deny := &pathpol.ACLEntry{}
+		require.NoError(t, deny.LoadFromString("- 1-ff00:0:111#3"))
+		allow := &pathpol.ACLEntry{}
+		require.NoError(t, allow.LoadFromString("+"))
+		acl, err := pathpol.NewACL(deny, allow)
+		require.NoError(t, err)
+		policy := pathpol.NewPolicy("test", acl, nil, nil)
+
+		c, conn := newConn(t, snet.MultipathOptions{Policy: policy,
+			Mode: snet.SchedulingRedundant})
+		var sent []string
+		recordWrites(conn, &sent, 2)
+		write(t, c, 1)
+		assert.Equal(t, []string{"C", "A"}, sent)
+	})
+	t.Run("revoked paths are removed", func(t *testing.T) {
+		c, conn := newConn(t, snet.MultipathOptions{Mode: snet.SchedulingRedundant})
+		var sent []string
+		recordWrites(conn, &sent, 3)
+		write(t, c, 1)
+
+		revInfo := &path_mgmt.RevInfo{
+			IfID:         5,
+			RawIsdas:     hopIA.IAInt(),
+			RawTimestamp: util.TimeToSecs(time.Now()),
+			RawTTL:       10,
+		}
+		conn.EXPECT().ReadFromSCION(gomock.Any()).Return(0, nil, snet.NewOpError(
+			&scmp.Hdr{Class: scmp.C_Path, Type: scmp.T_P_RevokedIF}, revInfo, nil))
+		c.Read(make([]byte, 10))
+		paths := c.Paths()
+		require.Len(t, paths, 2)
+		assert.Equal(t, "A", paths[0].Fingerprint())
+		assert.Equal(t, "B", paths[1].Fingerprint())
+		// The counters of removed paths are pruned.
+		stats := c.Stats()
+		require.Len(t, stats, 2)
+		assert.Equal(t, "A", stats[0].Path.Fingerprint())
+		assert.Equal(t, "B", stats[1].Path.Fingerprint())
+	})
+	t.Run("counters are kept on refresh", func(t *testing.T) {
+		c, conn := newConn(t, snet.MultipathOptions{})
+		var sent []string
+		recordWrites(conn, &sent, 3)
+		write(t, c, 3)
+		require.NoError(t, c.Refresh())
+		stats := c.Stats()
+		require.Len(t, stats, 3)
+		for _, s := range stats {
+			assert.Equal(t, uint64(1), s.Packets)
+		}
+	})
+	t.Run("querier is contacted without holding the lock", func(t *testing.T) {
+		conn := mock_snet.NewMockConn(ctrl)
+		querier := mock_snet.NewMockPathQuerier(ctrl)
+		c, err := snet.NewMultipathConn(conn, raddr, localIA, querier, snet.MultipathOptions{})
+		require.NoError(t, err)
+		querier.EXPECT().Query(gomock.Any(), remoteIA).DoAndReturn(
+			func(_ context.Context, _ addr.IA) ([]snet.Path, error) {
+				assert.Empty(t, c.Paths())
+				return []snet.Path{pathA}, nil
+			},
+		)
+		require.NoError(t, c.Refresh())
+		assert.Len(t, c.Paths(), 1)
+	})
+	t.Run("expiring paths are refreshed once per interval", func(t *testing.T) {
+		conn := mock_snet.NewMockConn(ctrl)
+		querier := mock_snet.NewMockPathQuerier(ctrl)
+		c, err := snet.NewMultipathConn(conn, raddr, localIA, querier,
+			snet.MultipathOptions{RefreshInterval: 50 * time.Millisecond})
+		require.NoError(t, err)
+		expiring := &testPath{name: "E", ifIDs: []common.IFIDType{7},
+			expiry: time.Now().Add(time.Second)}
+		querier.EXPECT().Query(gomock.Any(), remoteIA).Return([]snet.Path{expiring}, nil)
+		var sent []string
+		recordWrites(conn, &sent, 3)
+		write(t, c, 3)
+		assert.Equal(t, []string{"E", "E", "E"}, sent)
+		stats := c.Stats()
+		require.Len(t, stats, 1)
+		assert.Equal(t, uint64(3), stats[0].Packets)
+
+		time.Sleep(50 * time.Millisecond)
+		querier.EXPECT().Query(gomock.Any(), remoteIA).Return(
+			[]snet.Path{expiring, pathA}, nil)
+		sent = nil
+		recordWrites(conn, &sent, 2)
+		write(t, c, 2)
+		assert.Equal(t, []string{"A", "A"}, sent)
+	})
+	t.Run("unknown mode", func(t *testing.T) {
+		_, err := snet.NewMultipathConn(mock_snet.NewMockConn(ctrl), raddr, localIA,
+			mock_snet.NewMockPathQuerier(ctrl), snet.MultipathOptions{Mode: 42})
+		assert.Error(t, err)
+	})
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/spath"
 )
 
@@ -54,6 +55,9 @@ type Path interface {
 	// Expiry returns the expiration time of the path. If the result is a zero
 	// value expiration time is unknown.
 	Expiry() time.Time
+	// Metadata returns the static metadata of the path. If the metadata is not
+	// available the result is nil.
+	Metadata() *PathMetadata
 	// Copy create a copy of the path.
 	Copy() Path
 }
@@ -106,6 +110,10 @@ func (p *partialPath) Expiry() time.Time {
 	return time.Time{}
 }
 
+func (p *partialPath) Metadata() *PathMetadata {
+	return nil
+}
+
 func (p *partialPath) Copy() Path {
 	return &partialPath{
 		spath:       p.spath.Copy(),
@@ -123,3 +131,41 @@ func copyUDP(udp *net.UDPAddr) *net.UDPAddr {
 		Port: udp.Port,
 	}
 }
+
+// FilterPaths returns the paths that are accepted by policy, in the original
+// order. If policy is nil, all paths are returned.
+func FilterPaths(paths []Path, policy *pathpol.Policy) []Path {
+	if policy == nil {
+		return paths
+	}
+	ps := make(pathpol.PathSet, len(paths))
+	for _, path := range paths {
+		ps[path.Fingerprint()] = policyPath{Path: path}
+	}
+	ps = policy.Filter(ps)
+	var filtered []Path
+	for _, path := range paths {
+		if _, ok := ps[path.Fingerprint()]; ok {
+			filtered = append(filtered, path)
+		}
+	}
+	return filtered
+}
+
+// policyPath adapts a Path to the path interface of the policy package.
+type policyPath struct {
+	Path
+}
+
+func (p policyPath) Interfaces() []pathpol.PathInterface {
+	intfs := p.Path.Interfaces()
+	res := make([]pathpol.PathInterface, 0, len(intfs))
+	for _, intf := range intfs {
+		res = append(res, intf)
+	}
+	return res
+}
+
+func (p policyPath) Key() string {
+	return p.Path.Fingerprint()
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"fmt"
+	"time"
+)
+
+// LinkType is the type of an inter-AS link.
+type LinkType uint8
+
+const (
+	LinkTypeUnset LinkType = iota
+	// LinkTypeDirect is a direct physical connection.
+	LinkTypeDirect
+	// LinkTypeMultiHop is a connection with local routing or switching.
+	LinkTypeMultiHop
+	// LinkTypeOpenNet is a connection overlaid over the public internet.
+	LinkTypeOpenNet
+)
+
+func (t LinkType) String() string {
+	switch t {
+	case LinkTypeUnset:
+		return "unset"
+	case LinkTypeDirect:
+		return "direct"
+	case LinkTypeMultiHop:
+		return "multihop"
+	case LinkTypeOpenNet:
+		return "opennet"
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", t)
+}
+
+// GeoCoordinates is the geographic location of an interface.
+type GeoCoordinates struct {
+	Latitude  float32
+	Longitude float32
+	Address   string
+}
+
+// PathMetadata contains static metadata of a path, as announced by the ASes on
+// the path. Unknown values are zero.
+type PathMetadata struct {
+	// Latency contains the latency between interface i and i+1 of the path.
+	// Even entries are the latencies of inter-AS links, odd entries the
+	// latencies within an AS.
+	Latency []time.Duration
+	// Bandwidth contains the bandwidth in Kbit/s between interface i and i+1
+	// of the path, indexed like Latency.
+	Bandwidth []uint64
+	// LinkType contains the type of the inter-AS link between interface 2i
+	// and 2i+1 of the path.
+	LinkType []LinkType
+	// Geo contains the location of interface i of the path.
+	Geo []GeoCoordinates
+}
+
+// TotalLatency returns the sum of the latencies on the path. The second return
+// value is false if the latency of at least one hop is unknown, in which case
+// the sum is a lower bound.
+func (m *PathMetadata) TotalLatency() (time.Duration, bool) {
+	if m == nil || len(m.Latency) == 0 {
+		return 0, false
+	}
+	var total time.Duration
+	complete := true
+	for _, l := range m.Latency {
+		if l == 0 {
+			complete = false
+		}
+		total += l
+	}
+	return total, complete
+}
+
+// MinBandwidth returns the smallest known bandwidth on the path in Kbit/s. The
+// result is zero if the bandwidth of no hop is known.
+func (m *PathMetadata) MinBandwidth() uint64 {
+	if m == nil {
+		return 0
+	}
+	var min uint64
+	for _, bw := range m.Bandwidth {
+		if bw != 0 && (min == 0 || bw < min) {
+			min = bw
+		}
+	}
+	return min
+}
+
+// Copy creates a deep copy of the metadata.
+func (m *PathMetadata) Copy() *PathMetadata {
+	if m == nil {
+		return nil
+	}
+	return &PathMetadata{
+		Latency:   append(m.Latency[:0:0], m.Latency...),
+		Bandwidth: append(m.Bandwidth[:0:0], m.Bandwidth...),
+		LinkType:  append(m.LinkType[:0:0], m.LinkType...),
+		Geo:       append(m.Geo[:0:0], m.Geo...),
+	}
+}
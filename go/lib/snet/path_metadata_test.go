@@ -0,0 +1,75 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+func TestPathMetadataTotalLatency(t *testing.T) {
+	tests := map[string]struct {
+		Metadata *snet.PathMetadata
+		Latency  time.Duration
+		Complete bool
+	}{
+		"nil": {},
+		"complete": {
+			Metadata: &snet.PathMetadata{
+				Latency: []time.Duration{5 * time.Millisecond, time.Millisecond},
+			},
+			Latency:  6 * time.Millisecond,
+			Complete: true,
+		},
+		"partial": {
+			Metadata: &snet.PathMetadata{
+				Latency: []time.Duration{5 * time.Millisecond, 0, 2 * time.Millisecond},
+			},
+			Latency:  7 * time.Millisecond,
+			Complete: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			latency, complete := test.Metadata.TotalLatency()
+			assert.Equal(t, test.Latency, latency)
+			assert.Equal(t, test.Complete, complete)
+		})
+	}
+}
+
+func TestPathMetadataMinBandwidth(t *testing.T) {
+	var m *snet.PathMetadata
+	assert.Zero(t, m.MinBandwidth())
+	m = &snet.PathMetadata{Bandwidth: []uint64{0, 400, 100, 0, 1000}}
+	assert.Equal(t, uint64(100), m.MinBandwidth())
+}
+
+func TestPathMetadataCopy(t *testing.T) {
+	m := &snet.PathMetadata{
+		Latency:   []time.Duration{time.Millisecond},
+		Bandwidth: []uint64{100},
+		LinkType:  []snet.LinkType{snet.LinkTypeDirect},
+		Geo:       []snet.GeoCoordinates{{Latitude: 1, Longitude: 2, Address: "a"}},
+	}
+	c := m.Copy()
+	assert.Equal(t, m, c)
+	c.Latency[0] = time.Second
+	assert.Equal(t, time.Millisecond, m.Latency[0])
+}
@@ -111,10 +111,12 @@ func (c *scionConnReader) read(b []byte) (int, *Addr, error) {
 		switch hdr := pkt.L4Header.(type) {
 		case *l4.UDP:
 			l4i = hdr.SrcPort
+		case *l4.TCP:
+			l4i = hdr.SrcPort
 		case *scmp.Hdr:
 		default:
 			err = common.NewBasicError("Unexpected SCION L4 protocol", nil,
-				"expected", "UDP or SCMP", "actual", pkt.L4Header.L4Type())
+				"expected", "UDP, TCP or SCMP", "actual", pkt.L4Header.L4Type())
 		}
 		// Copy the address to prevent races. See
 		// https://github.com/scionproto/scion/issues/1659.
@@ -0,0 +1,400 @@
+// Copyright 2019 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package squic
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/lucas-clemente/quic-go"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// The TLS credentials derived from the SCION control-plane PKI consist of an
+// ephemeral ECDSA key and a self-signed X.509 certificate. The certificate
+// carries an extension that contains the AS certificate chain and a signature
+// of the certificate's public key created with the AS signing key. This binds
+// the TLS key to the AS identity. Peers verify the signature with the public
+// key in the leaf certificate and the chain against the TRCs in the trust
+// store.
+//
+// AS certificates are short-lived and reissued regularly. The TLS certificate
+// is therefore derived from the latest chain on demand, see Credentials.
+
+const (
+	// VerificationTimeout is the maximum time spent verifying the
+	// certificate chain of a peer during the handshake.
+	VerificationTimeout = 5 * time.Second
+	// CredentialsTimeout is the maximum time spent fetching the latest
+	// certificate chain during the handshake.
+	CredentialsTimeout = time.Second
+)
+
+var (
+	// ExtensionOID identifies the X.509 extension that carries the AS
+	// certificate chain and the signature of the TLS key.
+	ExtensionOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 55324, 1, 1}
+	// keyBindingPrefix is prepended to the DER encoded SubjectPublicKeyInfo of
+	// the TLS key before it is signed with the AS signing key. It separates
+	// these signatures from the ones the AS key creates for other purposes.
+	keyBindingPrefix = []byte("SCION squic TLS key binding v1\x00")
+)
+
+const (
+	ErrNoPeerCert       common.ErrMsg = "No peer certificate"
+	ErrNoExtension      common.ErrMsg = "Certificate does not contain SCION extension"
+	ErrInvalidTLSKeySig common.ErrMsg = "Invalid signature of TLS key"
+	ErrUnexpectedPeerIA common.ErrMsg = "Unexpected peer IA"
+)
+
+// ChainVerifier verifies the AS certificate chain of a peer. The trust store
+// implements this interface.
+type ChainVerifier interface {
+	// VerifyChain verifies that the chain is valid for the subject.
+	VerifyChain(ctx context.Context, subject addr.IA, chain *cert.Chain) error
+}
+
+type extension struct {
+	// Chain is the compressed AS certificate chain.
+	Chain []byte
+	// Signature is the signature of the DER encoded SubjectPublicKeyInfo of
+	// the TLS certificate prefixed with keyBindingPrefix, created with the AS
+	// signing key.
+	Signature []byte
+}
+
+// Credentials derives TLS certificates from the latest certificate chain and
+// signing key of the local AS. A new certificate is only created if the chain
+// changed, e.g., because the AS certificate was reissued.
+type Credentials struct {
+	// Chain returns the latest certificate chain of the local AS.
+	Chain func(ctx context.Context) (*cert.Chain, error)
+	// SignKey returns the signing key that matches the latest chain. It is
+	// only called if the chain changed.
+	SignKey func() (common.RawBytes, error)
+
+	mtx   sync.Mutex
+	chain *cert.Chain
+	cert  *tls.Certificate
+}
+
+// Certificate returns the TLS certificate that is bound to the latest chain.
+func (c *Credentials) Certificate(ctx context.Context) (*tls.Certificate, error) {
+	chain, err := c.Chain(ctx)
+	if err != nil {
+		return nil, common.NewBasicError("Unable to get certificate chain", err)
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.cert != nil && c.chain.Equal(chain) {
+		return c.cert, nil
+	}
+	signKey, err := c.SignKey()
+	if err != nil {
+		return nil, common.NewBasicError("Unable to get signing key", err)
+	}
+	tlsCert, err := NewCertificate(chain, signKey)
+	if err != nil {
+		return nil, err
+	}
+	c.chain, c.cert = chain, &tlsCert
+	return c.cert, nil
+}
+
+// ServerConfigs checks the credentials every interval and sends a copy of cfg
+// on the returned channel whenever the certificate changed. The copy contains
+// the new certificate in Certificates. The QUIC library ignores
+// GetCertificate, thus servers must be restarted with these configurations,
+// see rpc.Server.Reload. The check runs for the lifetime of the process.
+func (c *Credentials) ServerConfigs(cfg *tls.Config,
+	interval time.Duration) <-chan *tls.Config {
+
+	configs := make(chan *tls.Config, 1)
+	go func() {
+		defer log.LogPanicAndExit()
+		var last *tls.Certificate
+		if len(cfg.Certificates) > 0 {
+			last = &cfg.Certificates[0]
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			ctx, cancelF := context.WithTimeout(context.Background(), CredentialsTimeout)
+			tlsCert, err := c.Certificate(ctx)
+			cancelF()
+			if err != nil {
+				log.Error("[squic] Unable to renew TLS certificate", "err", err)
+				continue
+			}
+			if last != nil && bytes.Equal(last.Certificate[0], tlsCert.Certificate[0]) {
+				continue
+			}
+			last = tlsCert
+			newCfg := cfg.Clone()
+			newCfg.Certificates = []tls.Certificate{*tlsCert}
+			// Drop a pending configuration that has not been picked up.
+			select {
+			case <-configs:
+			default:
+			}
+			configs <- newCfg
+		}
+	}()
+	return configs
+}
+
+// InitPKI configures the package level TLS configuration used by the dial and
+// listen functions to derive the credentials from the latest AS certificate
+// chain and signing key. Peers are authenticated with verifier, and dialed
+// peers must be in the IA of the remote address. Clients are not required to
+// present a certificate. InitPKI replaces the configuration set by Init.
+func InitPKI(creds *Credentials, verifier ChainVerifier) error {
+	cfg, err := NewTLSConfig(creds, verifier, false)
+	if err != nil {
+		return err
+	}
+	cliTlsCfg, srvTlsCfg, verifyDialed = cfg, cfg, true
+	return nil
+}
+
+// NewTLSConfig creates a TLS configuration with credentials derived from the
+// AS certificate chain and signing key. The configuration can be used both by
+// clients and servers. The certificate of the peer is only accepted if it is
+// bound to an AS certificate chain that is valid according to verifier.
+// Clients should additionally check the IA of the server with VerifyPeerIA.
+//
+// If creds is nil, the configuration can only be used by clients, which do
+// not present a certificate. This allows end hosts without an AS signing key
+// to authenticate servers. Otherwise, Certificates is set to the current
+// certificate, because the QUIC library requires it for servers. Servers
+// only require clients to present a certificate if requireClientCert is set.
+// Handlers can check the IA of the client with PeerIA.
+func NewTLSConfig(creds *Credentials, verifier ChainVerifier,
+	requireClientCert bool) (*tls.Config, error) {
+
+	if verifier == nil {
+		return nil, serrors.New("squic: Chain verifier must not be nil")
+	}
+	cfg := &tls.Config{
+		// The certificates are not issued by a TLS PKI. They are verified in
+		// VerifyPeerCertificate instead. For the same reason, client
+		// certificates are requested rather than verified by the TLS library.
+		InsecureSkipVerify: true,
+		ClientAuth:         tls.RequestClientCert,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				// Servers always present a certificate. Thus, this is a
+				// client that is not required to present one.
+				return nil
+			}
+			c, err := x509.ParseCertificate(rawCerts[0])
+			if err != nil {
+				return common.NewBasicError("Unable to parse peer certificate", err)
+			}
+			ctx, cancelF := context.WithTimeout(context.Background(), VerificationTimeout)
+			defer cancelF()
+			_, err = VerifyCertificate(ctx, c, verifier)
+			return err
+		},
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			if creds == nil {
+				return &tls.Certificate{}, nil
+			}
+			ctx, cancelF := context.WithTimeout(context.Background(), CredentialsTimeout)
+			defer cancelF()
+			return creds.Certificate(ctx)
+		},
+	}
+	if requireClientCert {
+		cfg.ClientAuth = tls.RequireAnyClientCert
+	}
+	if creds == nil {
+		return cfg, nil
+	}
+	cfg.GetCertificate = func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+		ctx, cancelF := context.WithTimeout(context.Background(), CredentialsTimeout)
+		defer cancelF()
+		return creds.Certificate(ctx)
+	}
+	ctx, cancelF := context.WithTimeout(context.Background(), CredentialsTimeout)
+	defer cancelF()
+	tlsCert, err := creds.Certificate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Certificates = []tls.Certificate{*tlsCert}
+	return cfg, nil
+}
+
+// NewCertificate creates a TLS certificate with a fresh key that is bound to
+// the AS certificate chain. The validity period of the certificate matches
+// the validity period of the leaf certificate.
+func NewCertificate(chain *cert.Chain, signKey common.RawBytes) (tls.Certificate, error) {
+	if chain == nil || chain.Leaf == nil {
+		return tls.Certificate{}, serrors.New("squic: Certificate chain must not be nil")
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, common.NewBasicError("Unable to generate TLS key", err)
+	}
+	spki, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return tls.Certificate{}, common.NewBasicError("Unable to encode TLS key", err)
+	}
+	sig, err := scrypto.Sign(keyBindingInput(spki), signKey, chain.Leaf.SignAlgorithm)
+	if err != nil {
+		return tls.Certificate{}, common.NewBasicError("Unable to sign TLS key", err)
+	}
+	rawChain, err := chain.Compress()
+	if err != nil {
+		return tls.Certificate{}, common.NewBasicError("Unable to compress chain", err)
+	}
+	ext, err := asn1.Marshal(extension{Chain: rawChain, Signature: sig})
+	if err != nil {
+		return tls.Certificate{}, common.NewBasicError("Unable to encode extension", err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, common.NewBasicError("Unable to generate serial", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: chain.Leaf.Subject.String()},
+		NotBefore:    util.SecsToTime(chain.Leaf.IssuingTime),
+		NotAfter:     util.SecsToTime(chain.Leaf.ExpirationTime),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{
+			x509.ExtKeyUsageServerAuth,
+			x509.ExtKeyUsageClientAuth,
+		},
+		ExtraExtensions: []pkix.Extension{{Id: ExtensionOID, Value: ext}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, common.NewBasicError("Unable to create certificate", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
+
+// VerifyCertificate verifies that the TLS certificate is currently valid and
+// bound to an AS certificate chain that is valid according to verifier. The
+// chain is returned on success.
+func VerifyCertificate(ctx context.Context, c *x509.Certificate,
+	verifier ChainVerifier) (*cert.Chain, error) {
+
+	now := time.Now()
+	if now.Before(c.NotBefore) || now.After(c.NotAfter) {
+		return nil, common.NewBasicError("Certificate not valid", nil,
+			"notBefore", c.NotBefore, "notAfter", c.NotAfter)
+	}
+	chain, ext, err := parseExtension(c)
+	if err != nil {
+		return nil, err
+	}
+	err = scrypto.Verify(keyBindingInput(c.RawSubjectPublicKeyInfo), ext.Signature,
+		chain.Leaf.SubjectSignKey, chain.Leaf.SignAlgorithm)
+	if err != nil {
+		return nil, common.NewBasicError(ErrInvalidTLSKeySig, err)
+	}
+	if err := verifier.VerifyChain(ctx, chain.Leaf.Subject, chain); err != nil {
+		return nil, common.NewBasicError("Unable to verify chain", err,
+			"subject", chain.Leaf.Subject)
+	}
+	return chain, nil
+}
+
+// PeerIA returns the IA of the peer of the session. The session must have
+// been established with a configuration created by NewTLSConfig, such that
+// the certificate of the peer has been verified during the handshake.
+func PeerIA(state quic.ConnectionState) (addr.IA, error) {
+	if len(state.PeerCertificates) == 0 {
+		return addr.IA{}, common.NewBasicError(ErrNoPeerCert, nil)
+	}
+	chain, _, err := parseExtension(state.PeerCertificates[0])
+	if err != nil {
+		return addr.IA{}, err
+	}
+	return chain.Leaf.Subject, nil
+}
+
+// VerifyPeerIA checks that the peer of the session is in the IA of address.
+// Addresses that are not SCION addresses are rejected.
+func VerifyPeerIA(state quic.ConnectionState, address net.Addr) error {
+	snetAddr, ok := address.(*snet.Addr)
+	if !ok {
+		return common.NewBasicError("Unsupported address type", nil,
+			"type", common.TypeOf(address))
+	}
+	ia, err := PeerIA(state)
+	if err != nil {
+		return err
+	}
+	if !ia.Equal(snetAddr.IA) {
+		return common.NewBasicError(ErrUnexpectedPeerIA, nil,
+			"expected", snetAddr.IA, "actual", ia)
+	}
+	return nil
+}
+
+// keyBindingInput returns the input of the signature that binds the TLS key
+// to the AS identity.
+func keyBindingInput(spki []byte) []byte {
+	return append(append([]byte(nil), keyBindingPrefix...), spki...)
+}
+
+func parseExtension(c *x509.Certificate) (*cert.Chain, *extension, error) {
+	for _, e := range c.Extensions {
+		if !e.Id.Equal(ExtensionOID) {
+			continue
+		}
+		var ext extension
+		rest, err := asn1.Unmarshal(e.Value, &ext)
+		if err != nil {
+			return nil, nil, common.NewBasicError("Unable to parse extension", err)
+		}
+		if len(rest) != 0 {
+			return nil, nil, common.NewBasicError("Trailing data in extension", nil,
+				"len", len(rest))
+		}
+		chain, err := cert.ChainFromRaw(ext.Chain, true)
+		if err != nil {
+			return nil, nil, common.NewBasicError("Unable to parse chain", err)
+		}
+		if chain.Leaf == nil {
+			return nil, nil, serrors.New("Chain without leaf certificate")
+		}
+		return chain, &ext, nil
+	}
+	return nil, nil, common.NewBasicError(ErrNoExtension, nil)
+}
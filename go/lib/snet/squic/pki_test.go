@@ -0,0 +1,263 @@
+// Copyright 2019 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package squic
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lucas-clemente/quic-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+var (
+	ia110 = xtest.MustParseIA("1-ff00:0:110")
+	ia111 = xtest.MustParseIA("1-ff00:0:111")
+)
+
+// chainVerifier accepts all chains of the trusted IAs.
+type chainVerifier map[addr.IA]bool
+
+func (v chainVerifier) VerifyChain(_ context.Context, subject addr.IA, _ *cert.Chain) error {
+	if !v[subject] {
+		return serrors.New("untrusted")
+	}
+	return nil
+}
+
+func newChain(t *testing.T, ia addr.IA) (*cert.Chain, common.RawBytes) {
+	pub, priv, err := scrypto.GenKeyPair(scrypto.Ed25519)
+	require.NoError(t, err)
+	now := time.Now()
+	leaf := &cert.Certificate{
+		Subject:        ia,
+		Issuer:         ia,
+		SubjectSignKey: pub,
+		SignAlgorithm:  scrypto.Ed25519,
+		SubjectEncKey:  make(common.RawBytes, 32),
+		EncAlgorithm:   scrypto.Curve25519xSalsa20Poly1305,
+		Comment:        "test",
+		Signature:      make(common.RawBytes, 64),
+		IssuingTime:    uint32(now.Add(-time.Hour).Unix()),
+		ExpirationTime: uint32(now.Add(time.Hour).Unix()),
+		TRCVersion:     1,
+		Version:        1,
+	}
+	issuer := *leaf
+	issuer.CanIssue = true
+	return &cert.Chain{Leaf: leaf, Issuer: &issuer}, priv
+}
+
+func newCredentials(chain *cert.Chain, key common.RawBytes) *Credentials {
+	return &Credentials{
+		Chain: func(context.Context) (*cert.Chain, error) {
+			return chain, nil
+		},
+		SignKey: func() (common.RawBytes, error) {
+			return key, nil
+		},
+	}
+}
+
+func TestCredentials(t *testing.T) {
+	var mtx sync.Mutex
+	chain, key := newChain(t, ia110)
+	var keyLoads int
+	creds := &Credentials{
+		Chain: func(context.Context) (*cert.Chain, error) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			return chain, nil
+		},
+		SignKey: func() (common.RawBytes, error) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			keyLoads++
+			return key, nil
+		},
+	}
+	first, err := creds.Certificate(context.Background())
+	require.NoError(t, err)
+	second, err := creds.Certificate(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, keyLoads)
+
+	// Reissuing the AS certificate results in a new TLS certificate.
+	configs := creds.ServerConfigs(&tls.Config{Certificates: []tls.Certificate{*first}},
+		10*time.Millisecond)
+	renewed, renewedKey := newChain(t, ia110)
+	renewed.Leaf.Version = 2
+	mtx.Lock()
+	chain, key = renewed, renewedKey
+	mtx.Unlock()
+	select {
+	case cfg := <-configs:
+		require.Len(t, cfg.Certificates, 1)
+		c, err := x509.ParseCertificate(cfg.Certificates[0].Certificate[0])
+		require.NoError(t, err)
+		verified, err := VerifyCertificate(context.Background(), c, chainVerifier{ia110: true})
+		require.NoError(t, err)
+		assert.Equal(t, scrypto.Version(2), verified.Leaf.Version)
+	case <-time.After(time.Second):
+		t.Fatal("No config with renewed certificate")
+	}
+	mtx.Lock()
+	defer mtx.Unlock()
+	assert.Equal(t, 2, keyLoads)
+}
+
+func TestVerifyCertificate(t *testing.T) {
+	chain, key := newChain(t, ia110)
+	tlsCert, err := NewCertificate(chain, key)
+	require.NoError(t, err)
+	c, err := x509.ParseCertificate(tlsCert.Certificate[0])
+	require.NoError(t, err)
+
+	t.Run("valid", func(t *testing.T) {
+		verified, err := VerifyCertificate(context.Background(), c, chainVerifier{ia110: true})
+		require.NoError(t, err)
+		assert.Equal(t, ia110, verified.Leaf.Subject)
+	})
+	t.Run("untrusted chain", func(t *testing.T) {
+		_, err := VerifyCertificate(context.Background(), c, chainVerifier{})
+		assert.Error(t, err)
+	})
+	t.Run("key not signed by chain", func(t *testing.T) {
+		_, otherKey := newChain(t, ia110)
+		tlsCert, err := NewCertificate(chain, otherKey)
+		require.NoError(t, err)
+		c, err := x509.ParseCertificate(tlsCert.Certificate[0])
+		require.NoError(t, err)
+		_, err = VerifyCertificate(context.Background(), c, chainVerifier{ia110: true})
+		assert.True(t, common.GetErrorMsg(err) == string(ErrInvalidTLSKeySig), "err: %v", err)
+	})
+	t.Run("expired", func(t *testing.T) {
+		expired := chain.Copy()
+		expired.Leaf.ExpirationTime = uint32(time.Now().Add(-time.Minute).Unix())
+		tlsCert, err := NewCertificate(expired, key)
+		require.NoError(t, err)
+		c, err := x509.ParseCertificate(tlsCert.Certificate[0])
+		require.NoError(t, err)
+		_, err = VerifyCertificate(context.Background(), c, chainVerifier{ia110: true})
+		assert.Error(t, err)
+	})
+}
+
+func TestHandshake(t *testing.T) {
+	srvChain, srvKey := newChain(t, ia110)
+	cliChain, cliKey := newChain(t, ia111)
+	verifier := chainVerifier{ia110: true, ia111: true}
+	listen := func(t *testing.T, requireClientCert bool) (net.Addr, <-chan addr.IA, func()) {
+		srvCfg, err := NewTLSConfig(newCredentials(srvChain, srvKey), verifier,
+			requireClientCert)
+		require.NoError(t, err)
+		srvConn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IP{127, 0, 0, 1}})
+		require.NoError(t, err)
+		listener, err := quic.Listen(srvConn, srvCfg, nil)
+		require.NoError(t, err)
+		// The IA of clients without certificate is the zero value.
+		srvIAs := make(chan addr.IA, 1)
+		go func() {
+			for {
+				sess, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				ia, _ := PeerIA(sess.ConnectionState())
+				srvIAs <- ia
+			}
+		}()
+		return srvConn.LocalAddr(), srvIAs, func() {
+			listener.Close()
+			srvConn.Close()
+		}
+	}
+	srvAddr, srvIAs, cleanup := listen(t, false)
+	defer cleanup()
+
+	dial := func(t *testing.T, srvAddr net.Addr, creds *Credentials,
+		verifier ChainVerifier) (quic.Session, error) {
+
+		cfg, err := NewTLSConfig(creds, verifier, false)
+		require.NoError(t, err)
+		cliConn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IP{127, 0, 0, 1}})
+		require.NoError(t, err)
+		return quic.Dial(cliConn, srvAddr, "host:0", cfg,
+			&quic.Config{HandshakeTimeout: time.Second})
+	}
+
+	t.Run("mutual authentication", func(t *testing.T) {
+		sess, err := dial(t, srvAddr, newCredentials(cliChain, cliKey), verifier)
+		require.NoError(t, err)
+		defer sess.Close()
+		ia, err := PeerIA(sess.ConnectionState())
+		require.NoError(t, err)
+		assert.Equal(t, ia110, ia)
+		assert.Equal(t, ia111, <-srvIAs)
+		assert.NoError(t, VerifyPeerIA(sess.ConnectionState(), &snet.Addr{IA: ia110}))
+		assert.True(t, common.GetErrorMsg(VerifyPeerIA(sess.ConnectionState(),
+			&snet.Addr{IA: ia111})) == string(ErrUnexpectedPeerIA))
+	})
+	t.Run("client without certificate", func(t *testing.T) {
+		sess, err := dial(t, srvAddr, nil, verifier)
+		require.NoError(t, err)
+		defer sess.Close()
+		ia, err := PeerIA(sess.ConnectionState())
+		require.NoError(t, err)
+		assert.Equal(t, ia110, ia)
+		assert.Equal(t, addr.IA{}, <-srvIAs)
+	})
+	t.Run("client without certificate, certificate required", func(t *testing.T) {
+		srvAddr, _, cleanup := listen(t, true)
+		defer cleanup()
+		sess, err := dial(t, srvAddr, nil, verifier)
+		if err == nil {
+			_, err = sess.AcceptStream()
+			sess.Close()
+		}
+		assert.Error(t, err)
+	})
+	t.Run("untrusted server", func(t *testing.T) {
+		_, err := dial(t, srvAddr, newCredentials(cliChain, cliKey), chainVerifier{})
+		assert.Error(t, err)
+	})
+	t.Run("untrusted client", func(t *testing.T) {
+		otherChain, otherKey := newChain(t, xtest.MustParseIA("1-ff00:0:112"))
+		sess, err := dial(t, srvAddr, newCredentials(otherChain, otherKey), verifier)
+		if err == nil {
+			// With TLS 1.3, the client can finish the handshake before the
+			// server verified the client certificate. The server closes
+			// the session in that case.
+			_, err = sess.AcceptStream()
+			sess.Close()
+		}
+		assert.Error(t, err)
+	})
+}
@@ -35,8 +35,14 @@ var (
 	// Don't verify the server's cert, as we are not using the TLS PKI.
 	cliTlsCfg = &tls.Config{InsecureSkipVerify: true}
 	srvTlsCfg = &tls.Config{}
+	// verifyDialed indicates whether the IA of dialed servers is checked. It
+	// is set by InitPKI.
+	verifyDialed bool
 )
 
+// Init loads the static TLS certificate and key. Dialed servers are not
+// authenticated. Use InitPKI to authenticate peers with the SCION
+// control-plane PKI instead.
 func Init(keyPath, pemPath string) error {
 	if keyPath == "" {
 		keyPath = defKeyPath
@@ -48,7 +54,9 @@ func Init(keyPath, pemPath string) error {
 	if err != nil {
 		return common.NewBasicError("squic: Unable to load TLS cert/key", err)
 	}
-	srvTlsCfg.Certificates = []tls.Certificate{cert}
+	cliTlsCfg = &tls.Config{InsecureSkipVerify: true}
+	srvTlsCfg = &tls.Config{Certificates: []tls.Certificate{cert}}
+	verifyDialed = false
 	return nil
 }
 
@@ -66,7 +74,17 @@ func DialSCIONWithBindSVC(network *snet.SCIONNetwork, laddr, raddr, baddr *snet.
 		return nil, err
 	}
 	// Use dummy hostname, as it's used for SNI, and we're not doing cert verification.
-	return quic.Dial(sconn, raddr, "host:0", cliTlsCfg, quicConfig)
+	sess, err := quic.Dial(sconn, raddr, "host:0", cliTlsCfg, quicConfig)
+	if err != nil {
+		return nil, err
+	}
+	if verifyDialed {
+		if err := VerifyPeerIA(sess.ConnectionState(), raddr); err != nil {
+			sess.Close()
+			return nil, err
+		}
+	}
+	return sess, nil
 }
 
 func ListenSCION(network *snet.SCIONNetwork, laddr *snet.Addr,
@@ -21,6 +21,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/lib/sock/reliable/reconnect/internal/metrics"
 )
 
 var _ net.PacketConn = (*PacketConn)(nil)
@@ -55,6 +56,8 @@ type PacketConn struct {
 	closeCh chan struct{}
 	// closeMtx is used to guarantee that a single goroutine enters Close
 	closeMtx sync.Mutex
+	// events receives connection state changes, see Events.
+	events chan Event
 }
 
 func NewPacketConn(dispConn net.PacketConn, reconnecter Reconnecter) *PacketConn {
@@ -65,9 +68,17 @@ func NewPacketConn(dispConn net.PacketConn, reconnecter Reconnecter) *PacketConn
 		deadlineChangedEvent: make(chan struct{}, 1),
 		fatalError:           make(chan error, 1),
 		closeCh:              make(chan struct{}),
+		events:               make(chan Event, eventQueueSize),
 	}
 }
 
+// Events returns a channel on which the changes of the dispatcher connection
+// state are announced. Events are dropped if the channel is full, the
+// channel is never closed.
+func (conn *PacketConn) Events() <-chan Event {
+	return conn.events
+}
+
 func (conn *PacketConn) ReadFrom(b []byte) (int, net.Addr, error) {
 	op := &ReadFromOperation{}
 	op.buffer = b
@@ -135,6 +146,9 @@ func (conn *PacketConn) spawnAsyncReconnecterOnce() {
 	select {
 	case <-conn.dispatcherState.Up():
 		conn.dispatcherState.SetDown()
+		metrics.M.Disconnects().Inc()
+		log.Info("Lost connection to dispatcher, reconnecting")
+		conn.notify(Event{Type: EventDisconnected})
 		go func() {
 			defer log.LogPanicAndExit()
 			conn.asyncReconnectWrapper()
@@ -147,14 +161,32 @@ func (conn *PacketConn) spawnAsyncReconnecterOnce() {
 func (conn *PacketConn) asyncReconnectWrapper() {
 	newConn, err := conn.Reconnect()
 	if err != nil {
+		if !conn.isClosing() {
+			metrics.M.Failures().Inc()
+			log.Error("Unable to restore dispatcher registration, connection unusable",
+				"err", err)
+			conn.notify(Event{Type: EventFailed, Err: err})
+		}
 		conn.fatalError <- err
 		close(conn.fatalError)
 		return
 	}
-	newConn.SetReadDeadline(conn.readDeadline)
-	newConn.SetWriteDeadline(conn.writeDeadline)
+	newConn.SetReadDeadline(conn.getReadDeadline())
+	newConn.SetWriteDeadline(conn.getWriteDeadline())
 	conn.setConn(newConn)
 	conn.dispatcherState.SetUp()
+	metrics.M.Reconnects().Inc()
+	log.Info("Restored dispatcher registration")
+	conn.notify(Event{Type: EventReconnected})
+}
+
+// notify announces the event without blocking.
+func (conn *PacketConn) notify(e Event) {
+	e.Time = time.Now()
+	select {
+	case conn.events <- e:
+	default:
+	}
 }
 
 // Reconnect is only used internally and should never be called from outside
@@ -30,6 +30,48 @@ import (
 	"github.com/scionproto/scion/go/lib/xtest"
 )
 
+func TestPacketConnEvents(t *testing.T) {
+	Convey("Given a connection that loses the dispatcher", t, func() {
+		ctrl := gomock.NewController(&xtest.PanickingReporter{T: t})
+		defer ctrl.Finish()
+		mockConn := mock_net.NewMockPacketConn(ctrl)
+		mockReconnecter := mock_reconnect.NewMockReconnecter(ctrl)
+		packetConn := reconnect.NewPacketConn(mockConn, mockReconnecter)
+		mockIO := mock_reconnect.NewMockIOOperation(ctrl)
+		mockIO.EXPECT().IsWrite().Return(true).AnyTimes()
+		Convey("A restored registration announces disconnect and reconnect", func() {
+			connFromReconnect := mock_net.NewMockPacketConn(ctrl)
+			connFromReconnect.EXPECT().SetWriteDeadline(Any()).Return(nil).AnyTimes()
+			connFromReconnect.EXPECT().SetReadDeadline(Any()).Return(nil).AnyTimes()
+			gomock.InOrder(
+				mockIO.EXPECT().Do(mockConn).Return(dispatcherError),
+				mockReconnecter.EXPECT().Reconnect(Any()).Return(connFromReconnect, uint16(0), nil),
+				mockIO.EXPECT().Do(connFromReconnect).Return(nil),
+			)
+			err := packetConn.DoIO(mockIO)
+			SoMsg("err", err, ShouldBeNil)
+			SoMsg("first", (<-packetConn.Events()).Type, ShouldEqual,
+				reconnect.EventDisconnected)
+			SoMsg("second", (<-packetConn.Events()).Type, ShouldEqual,
+				reconnect.EventReconnected)
+		})
+		Convey("A lost registration announces the failure", func() {
+			gomock.InOrder(
+				mockIO.EXPECT().Do(mockConn).Return(writeDispatcherError),
+				mockReconnecter.EXPECT().Reconnect(Any()).
+					Return(nil, uint16(0), reconnect.ErrRegistrationLost),
+			)
+			err := packetConn.DoIO(mockIO)
+			SoMsg("err", err, ShouldEqual, reconnect.ErrRegistrationLost)
+			SoMsg("first", (<-packetConn.Events()).Type, ShouldEqual,
+				reconnect.EventDisconnected)
+			e := <-packetConn.Events()
+			SoMsg("second", e.Type, ShouldEqual, reconnect.EventFailed)
+			SoMsg("second err", e.Err, ShouldEqual, reconnect.ErrRegistrationLost)
+		})
+	})
+}
+
 func TestPacketConnIO(t *testing.T) {
 	Convey("Given an underlying connection, a reconnecter and an IO operation", t, func() {
 		ctrl := gomock.NewController(&xtest.PanickingReporter{T: t})
@@ -14,4 +14,7 @@
 
 // Package reconnect implements transparent logic for reconnecting to the
 // dispatcher.
+//
+// Applications that need to know when the dispatcher connection is lost and
+// restored can consume the events of a PacketConn, see PacketConn.Events.
 package reconnect
@@ -22,4 +22,7 @@ var (
 	ErrReconnecterTimeoutExpired = serrors.New("timeout expired")
 	ErrReconnecterStopped        = serrors.New("stop method was called")
 	ErrClosed                    = serrors.New("closed")
+	// ErrRegistrationLost indicates that the registration could not be
+	// restored after reconnecting to the dispatcher.
+	ErrRegistrationLost = serrors.New("dispatcher registration lost")
 )
@@ -0,0 +1,61 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconnect
+
+import (
+	"fmt"
+	"time"
+)
+
+// eventQueueSize is the number of events that are buffered for a connection.
+// If the application does not consume the events, newer events are dropped.
+const eventQueueSize = 16
+
+// EventType is the type of a connection event.
+type EventType int
+
+const (
+	// EventDisconnected indicates that the connection to the dispatcher was
+	// lost. I/O blocks until the registration is restored.
+	EventDisconnected EventType = iota
+	// EventReconnected indicates that the registration was restored.
+	EventReconnected
+	// EventFailed indicates that the registration could not be restored. The
+	// connection is unusable afterwards.
+	EventFailed
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventDisconnected:
+		return "disconnected"
+	case EventReconnected:
+		return "reconnected"
+	case EventFailed:
+		return "failed"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(t))
+	}
+}
+
+// Event describes a change of the dispatcher connection state.
+type Event struct {
+	Type EventType
+	// Time is the time the event occurred.
+	Time time.Time
+	// Err is the reason the registration could not be restored. It is only
+	// set for EventFailed.
+	Err error
+}
@@ -31,8 +31,11 @@ var (
 )
 
 type metrics struct {
-	timeouts prometheus.Counter
-	retries  prometheus.Counter
+	timeouts    prometheus.Counter
+	retries     prometheus.Counter
+	disconnects prometheus.Counter
+	reconnects  prometheus.Counter
+	failures    prometheus.Counter
 }
 
 func newMetrics() metrics {
@@ -41,6 +44,12 @@ func newMetrics() metrics {
 			"Total number of reconnection attempt timeouts"),
 		retries: prom.NewCounter(Namespace, sub, "retries_total",
 			"Total number of reconnection attempt retries"),
+		disconnects: prom.NewCounter(Namespace, sub, "disconnects_total",
+			"Total number of lost dispatcher connections"),
+		reconnects: prom.NewCounter(Namespace, sub, "reconnects_total",
+			"Total number of restored dispatcher registrations"),
+		failures: prom.NewCounter(Namespace, sub, "failures_total",
+			"Total number of dispatcher registrations that could not be restored"),
 	}
 }
 
@@ -53,3 +62,19 @@ func (m metrics) Timeouts() prometheus.Counter {
 func (m metrics) Retries() prometheus.Counter {
 	return m.retries
 }
+
+// Disconnects returns a counter for lost dispatcher connections.
+func (m metrics) Disconnects() prometheus.Counter {
+	return m.disconnects
+}
+
+// Reconnects returns a counter for restored dispatcher registrations.
+func (m metrics) Reconnects() prometheus.Counter {
+	return m.reconnects
+}
+
+// Failures returns a counter for dispatcher registrations that could not be
+// restored.
+func (m metrics) Failures() prometheus.Counter {
+	return m.failures
+}
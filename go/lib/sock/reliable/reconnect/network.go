@@ -21,14 +21,17 @@ import (
 	"golang.org/x/xerrors"
 
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
 	"github.com/scionproto/scion/go/lib/sock/reliable/reconnect/internal/metrics"
 )
 
 // DispatcherService is a dispatcher wrapper that creates conns
 // with transparent reconnection capabilities. Connections created by
-// DispatcherService also validate that dispatcher registrations do
-// not change addresses.
+// DispatcherService replay the full registration (IA, public and bind
+// address, SVC address) on reconnect, and fail with ErrRegistrationLost if
+// the registration can not be restored identically, e.g., because the port
+// was taken in the meantime.
 //
 // Callers interested in providing their own reconnection callbacks and
 // validating the new connection themselves should use the connection
@@ -53,35 +56,83 @@ func (pn *DispatcherService) RegisterTimeout(ia addr.IA, public *addr.AppAddr,
 	bind *net.UDPAddr, svc addr.HostSVC,
 	timeout time.Duration) (net.PacketConn, uint16, error) {
 
+	reg := newRegistration(ia, public, bind, svc)
 	// Perform initial connection to allocate port. We use a reconnecter here
 	// to set up the initial connection using the same retry logic we use when
 	// losing the connection to the dispatcher.
-	reconnecter := pn.newReconnecterFromListenArgs(ia, public, bind, svc, timeout)
+	reconnecter := pn.newReconnecter(reg, false)
 	conn, port, err := reconnecter.Reconnect(timeout)
 	if err != nil {
 		return nil, 0, err
 	}
-	var newPublic *addr.AppAddr
-	if public != nil {
-		newPublic = public.Copy()
-		newPublic.L4 = port
+	// Pin the allocated port, such that reconnecting restores the exact same
+	// registration.
+	if reg.PublicAddress != nil {
+		reg.PublicAddress.Port = int(port)
 	}
-	reconnecter = pn.newReconnecterFromListenArgs(ia, newPublic, bind, svc, timeout)
-	return NewPacketConn(conn, reconnecter), port, nil
+	return NewPacketConn(conn, pn.newReconnecter(reg, true)), port, nil
 }
 
-func (pn *DispatcherService) newReconnecterFromListenArgs(ia addr.IA,
-	public *addr.AppAddr, bind *net.UDPAddr,
-	svc addr.HostSVC, timeout time.Duration) *TickingReconnecter {
+// newReconnecter returns a reconnecter that registers reg with the
+// dispatcher. If replay is set, the registration was already established
+// before, and every error that is not caused by the dispatcher being
+// unreachable (e.g., the port was taken in the meantime) is wrapped in
+// ErrRegistrationLost.
+func (pn *DispatcherService) newReconnecter(reg *reliable.Registration,
+	replay bool) *TickingReconnecter {
 
 	// f represents individual connection attempts
 	f := func(timeout time.Duration) (net.PacketConn, uint16, error) {
 		metrics.M.Retries().Inc()
-		conn, port, err := pn.dispatcher.RegisterTimeout(ia, public, bind, svc, timeout)
+		public := addr.AppAddrFromUDP(reg.PublicAddress)
+		conn, port, err := pn.dispatcher.RegisterTimeout(reg.IA, public,
+			copyUDPAddr(reg.BindAddress), reg.SVCAddress, timeout)
 		if xerrors.Is(err, ErrReconnecterTimeoutExpired) {
 			metrics.M.Timeouts().Inc()
 		}
-		return conn, port, err
+		if !replay {
+			return conn, port, err
+		}
+		if err != nil {
+			if reliable.IsSysError(err) {
+				return nil, 0, err
+			}
+			return nil, 0, serrors.Wrap(ErrRegistrationLost, err, "ia", reg.IA,
+				"public", reg.PublicAddress, "svc", reg.SVCAddress)
+		}
+		if public != nil && port != public.L4 {
+			conn.Close()
+			return nil, 0, serrors.WithCtx(ErrRegistrationLost, "ia", reg.IA,
+				"public", reg.PublicAddress, "svc", reg.SVCAddress, "port", port)
+		}
+		return conn, port, nil
 	}
 	return NewTickingReconnecter(f)
 }
+
+// newRegistration creates a registration with copies of the addresses, such
+// that later modifications by the caller do not change the registration.
+func newRegistration(ia addr.IA, public *addr.AppAddr, bind *net.UDPAddr,
+	svc addr.HostSVC) *reliable.Registration {
+
+	reg := &reliable.Registration{
+		IA:          ia,
+		BindAddress: copyUDPAddr(bind),
+		SVCAddress:  svc,
+	}
+	if public != nil && public.L3 != nil {
+		reg.PublicAddress = &net.UDPAddr{IP: public.L3.IP(), Port: int(public.L4)}
+	}
+	return reg
+}
+
+func copyUDPAddr(a *net.UDPAddr) *net.UDPAddr {
+	if a == nil {
+		return nil
+	}
+	return &net.UDPAddr{
+		IP:   append(a.IP[:0:0], a.IP...),
+		Port: a.Port,
+		Zone: a.Zone,
+	}
+}
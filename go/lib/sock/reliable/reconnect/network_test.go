@@ -22,6 +22,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/xerrors"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/mocks/net/mock_net"
@@ -56,6 +57,39 @@ func TestReconnect(t *testing.T) {
 	})
 }
 
+func TestReconnectRegistrationLost(t *testing.T) {
+	svcAddr := addr.SvcCS
+	Convey("Reconnections must restore the identical registration", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockNetwork := mock_reliable.NewMockDispatcherService(ctrl)
+		mockConn := mock_net.NewMockPacketConn(ctrl)
+		mockNetwork.EXPECT().
+			RegisterTimeout(localAddr.IA, localNoPortAddr.Host, bindAddr, svcAddr, timeout).
+			Return(mockConn, uint16(80), nil)
+		network := reconnect.NewDispatcherService(mockNetwork)
+		packetConn, _, err := network.RegisterTimeout(localAddr.IA,
+			localNoPortAddr.Host, bindAddr, svcAddr, timeout)
+		SoMsg("err", err, ShouldBeNil)
+		Convey("A different port fails the reconnect", func() {
+			otherConn := mock_net.NewMockPacketConn(ctrl)
+			otherConn.EXPECT().Close()
+			mockNetwork.EXPECT().
+				RegisterTimeout(localAddr.IA, localAddr.Host, bindAddr, svcAddr, timeout).
+				Return(otherConn, uint16(81), nil)
+			_, err := packetConn.(*reconnect.PacketConn).Reconnect()
+			SoMsg("err", xerrors.Is(err, reconnect.ErrRegistrationLost), ShouldBeTrue)
+		})
+		Convey("A rejected registration fails the reconnect", func() {
+			mockNetwork.EXPECT().
+				RegisterTimeout(localAddr.IA, localAddr.Host, bindAddr, svcAddr, timeout).
+				Return(nil, uint16(0), connectErrorFromDispatcher)
+			_, err := packetConn.(*reconnect.PacketConn).Reconnect()
+			SoMsg("err", xerrors.Is(err, reconnect.ErrRegistrationLost), ShouldBeTrue)
+		})
+	})
+}
+
 func TestNetworkFatalError(t *testing.T) {
 	Convey("Given a network running over an underlying mocked network", t, func() {
 		ctrl := gomock.NewController(t)
@@ -0,0 +1,340 @@
+// Copyright 2019 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package svc
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/healthpool"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// DefaultCacheTTL is the default amount of time replies are cached by the
+// CachingResolver.
+const DefaultCacheTTL = time.Minute
+
+// DefaultAlgorithm is the default algorithm the CachingResolver uses to choose
+// among the instances. It spreads the requests across all healthy instances.
+const DefaultAlgorithm = healthpool.RoundRobin
+
+// MultiResolver resolves an SVC address to all instances of the service.
+type MultiResolver interface {
+	// LookupSVCAll resolves the SVC address for all instances of the service
+	// in the AS terminating the path.
+	LookupSVCAll(ctx context.Context, p snet.Path, svc addr.HostSVC) ([]*Reply, error)
+}
+
+var _ MultiResolver = (*Resolver)(nil)
+var _ MultiResolver = (*CachingResolver)(nil)
+
+// CachingResolver caches the replies of all instances of a service per
+// destination AS. LookupSVC cycles through the cached replies of the
+// instances with the fewest failures, such that callers spread their requests
+// across the instances and fail over to the next instance without a fresh
+// lookup. Callers report failed requests to an instance with Fail, and the
+// round trip time of successful requests with ReportLatency.
+//
+// The zero value is not valid, Resolver must be set.
+type CachingResolver struct {
+	// Resolver is used to fetch the replies on a cache miss.
+	Resolver MultiResolver
+	// TTL is the amount of time replies are cached. If 0, DefaultCacheTTL is
+	// used.
+	TTL time.Duration
+	// Algorithm is the algorithm used to choose among the instances with the
+	// fewest failures. If empty, DefaultAlgorithm is used. With
+	// healthpool.LatencyEWMA, the instance with the lowest reported latency
+	// is chosen.
+	Algorithm healthpool.Algorithm
+
+	mtx     sync.Mutex
+	entries map[cacheKey]*cacheEntry
+}
+
+type cacheKey struct {
+	ia  addr.IA
+	svc addr.HostSVC
+}
+
+type cacheEntry struct {
+	replies   []*Reply
+	instances []*instance
+	// pool chooses among the instances. It must be closed when the entry is
+	// removed.
+	pool *healthpool.Pool
+	// expiry is the time after which the replies are fetched again.
+	expiry time.Time
+}
+
+// instance keeps track of the failures and the latency of the instance that
+// sent reply.
+type instance struct {
+	healthpool.Info
+	reply *Reply
+}
+
+// LookupSVC returns the next cached reply for the AS terminating the path. On
+// a cache miss, the replies of all instances are fetched first.
+func (c *CachingResolver) LookupSVC(ctx context.Context, p snet.Path,
+	svc addr.HostSVC) (*Reply, error) {
+
+	key := cacheKey{ia: p.Destination(), svc: svc.Base()}
+	c.mtx.Lock()
+	entry := c.validEntry(key, time.Now())
+	var reply *Reply
+	if entry != nil {
+		reply = entry.nextReply()
+	}
+	c.mtx.Unlock()
+	if reply != nil {
+		return reply, nil
+	}
+	// The reply is taken from the fetched entry, not from the cache, such
+	// that it is returned even if the entry expired in the meantime.
+	entry, err := c.fetch(ctx, p, key)
+	if err != nil {
+		return nil, err
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if reply = entry.nextReply(); reply == nil {
+		return nil, common.NewBasicError(errNoReplies, nil, "ia", key.ia, "svc", key.svc)
+	}
+	return reply, nil
+}
+
+// LookupSVCAll returns all cached replies for the AS terminating the path. On
+// a cache miss, the replies are fetched first.
+func (c *CachingResolver) LookupSVCAll(ctx context.Context, p snet.Path,
+	svc addr.HostSVC) ([]*Reply, error) {
+
+	key := cacheKey{ia: p.Destination(), svc: svc.Base()}
+	c.mtx.Lock()
+	entry := c.validEntry(key, time.Now())
+	var replies []*Reply
+	if entry != nil {
+		replies = copyReplies(entry.replies)
+	}
+	c.mtx.Unlock()
+	if replies != nil {
+		return replies, nil
+	}
+	entry, err := c.fetch(ctx, p, key)
+	if err != nil {
+		return nil, err
+	}
+	return copyReplies(entry.replies), nil
+}
+
+// Fail reports that a request to the instance of the SVC address in the given
+// AS failed, e.g., because it timed out. Subsequent lookups skip the instance
+// as long as other instances have fewer failures. Failures expire over time,
+// and are kept if the instance is still present after the replies are
+// fetched again.
+func (c *CachingResolver) Fail(ia addr.IA, svc addr.HostSVC, a *addr.AppAddr) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	entry, ok := c.entries[cacheKey{ia: ia, svc: svc.Base()}]
+	if !ok {
+		return
+	}
+	for _, inst := range entry.instances {
+		if hasAddr(inst.reply, a) {
+			inst.Fail()
+		}
+	}
+}
+
+// ReportLatency reports the round trip time of a successful request to the
+// instance of the SVC address in the given AS. The latency is kept if the
+// instance is still present after the replies are fetched again.
+func (c *CachingResolver) ReportLatency(ia addr.IA, svc addr.HostSVC, a *addr.AppAddr,
+	latency time.Duration) {
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	entry, ok := c.entries[cacheKey{ia: ia, svc: svc.Base()}]
+	if !ok {
+		return
+	}
+	for _, inst := range entry.instances {
+		if hasAddr(inst.reply, a) {
+			inst.ReportLatency(latency)
+		}
+	}
+}
+
+// Invalidate removes the cached replies for the SVC address in the given AS.
+// Callers should invalidate the cache if none of the instances is reachable
+// anymore.
+func (c *CachingResolver) Invalidate(ia addr.IA, svc addr.HostSVC) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.remove(cacheKey{ia: ia, svc: svc.Base()})
+}
+
+// fetch resolves all instances and stores the resulting entry in the cache.
+// The replies of the returned entry must not be modified.
+func (c *CachingResolver) fetch(ctx context.Context, p snet.Path,
+	key cacheKey) (*cacheEntry, error) {
+
+	replies, err := c.Resolver.LookupSVCAll(ctx, p, key.svc)
+	if err != nil {
+		return nil, err
+	}
+	if len(replies) == 0 {
+		return nil, common.NewBasicError(errNoReplies, nil, "ia", key.ia, "svc", key.svc)
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	now := time.Now()
+	c.removeStale(now)
+	if c.entries == nil {
+		c.entries = make(map[cacheKey]*cacheEntry)
+	}
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &cacheEntry{}
+	}
+	if err := entry.update(replies, c.algorithm()); err != nil {
+		return nil, err
+	}
+	entry.expiry = now.Add(c.ttl())
+	c.entries[key] = entry
+	return entry, nil
+}
+
+// validEntry returns the entry for key if it exists and is not expired. The
+// caller must hold the lock.
+func (c *CachingResolver) validEntry(key cacheKey, now time.Time) *cacheEntry {
+	entry, ok := c.entries[key]
+	if !ok || now.After(entry.expiry) {
+		return nil
+	}
+	return entry
+}
+
+// removeStale removes the entries that have not been fetched again for a TTL
+// after they expired. Expired entries are kept until then, such that the
+// health of the instances survives fetching the replies again. The caller
+// must hold the lock.
+func (c *CachingResolver) removeStale(now time.Time) {
+	for key, entry := range c.entries {
+		if now.After(entry.expiry.Add(c.ttl())) {
+			c.remove(key)
+		}
+	}
+}
+
+// remove removes the entry for key and closes its pool. The caller must hold
+// the lock.
+func (c *CachingResolver) remove(key cacheKey) {
+	if entry, ok := c.entries[key]; ok {
+		entry.pool.Close()
+		delete(c.entries, key)
+	}
+}
+
+func (c *CachingResolver) ttl() time.Duration {
+	if c.TTL == 0 {
+		return DefaultCacheTTL
+	}
+	return c.TTL
+}
+
+func (c *CachingResolver) algorithm() healthpool.Algorithm {
+	if c.Algorithm == "" {
+		return DefaultAlgorithm
+	}
+	return c.Algorithm
+}
+
+// update replaces the replies of the entry. Instances that are still present
+// keep their health info. The caller must hold the lock of the resolver.
+func (e *cacheEntry) update(replies []*Reply, algo healthpool.Algorithm) error {
+	instances := make([]*instance, 0, len(replies))
+	infos := make(healthpool.InfoSet, len(replies))
+	for _, reply := range replies {
+		inst := e.instance(reply)
+		if inst == nil {
+			inst = &instance{Info: healthpool.NewInfo(), reply: reply}
+		}
+		inst.reply = reply
+		instances = append(instances, inst)
+		infos[inst] = struct{}{}
+	}
+	if e.pool == nil {
+		pool, err := healthpool.NewPool(infos, healthpool.PoolOptions{Algorithm: algo})
+		if err != nil {
+			return err
+		}
+		e.pool = pool
+	} else if err := e.pool.Update(infos); err != nil {
+		return err
+	}
+	e.replies = replies
+	e.instances = instances
+	return nil
+}
+
+// instance returns the instance with the same transports as reply, or nil.
+func (e *cacheEntry) instance(reply *Reply) *instance {
+	for _, inst := range e.instances {
+		if inst.reply.equalTransports(reply) {
+			return inst
+		}
+	}
+	return nil
+}
+
+// nextReply returns a copy of the reply of the next instance, or nil if
+// there is none. The caller must hold the lock of the resolver.
+func (e *cacheEntry) nextReply() *Reply {
+	info, err := e.pool.Choose()
+	if err != nil {
+		return nil
+	}
+	return info.(*instance).reply.Copy()
+}
+
+// hasAddr returns whether one of the transports of reply has address a.
+func hasAddr(reply *Reply, a *addr.AppAddr) bool {
+	if a == nil || a.L3 == nil {
+		return false
+	}
+	for _, raw := range reply.Transports {
+		udpAddr, err := net.ResolveUDPAddr("udp", raw)
+		if err != nil {
+			continue
+		}
+		if udpAddr.IP.Equal(a.L3.IP()) && udpAddr.Port == int(a.L4) {
+			return true
+		}
+	}
+	return false
+}
+
+func copyReplies(replies []*Reply) []*Reply {
+	c := make([]*Reply, 0, len(replies))
+	for _, r := range replies {
+		c = append(c, r.Copy())
+	}
+	return c
+}
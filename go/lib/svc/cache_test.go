@@ -0,0 +1,166 @@
+// Copyright 2019 ETH Zurich, Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package svc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/healthpool"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/mock_snet"
+	"github.com/scionproto/scion/go/lib/svc"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+type countingResolver struct {
+	calls   int
+	replies []*svc.Reply
+	err     error
+}
+
+func (r *countingResolver) LookupSVCAll(_ context.Context, _ snet.Path,
+	_ addr.HostSVC) ([]*svc.Reply, error) {
+
+	r.calls++
+	return r.replies, r.err
+}
+
+func TestCachingResolver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dstIA := xtest.MustParseIA("1-ff00:0:2")
+	mockPath := mock_snet.NewMockPath(ctrl)
+	mockPath.EXPECT().Destination().Return(dstIA).AnyTimes()
+
+	replyOne := &svc.Reply{Transports: map[svc.Transport]string{svc.UDP: "192.0.2.1:30041"}}
+	replyTwo := &svc.Reply{Transports: map[svc.Transport]string{svc.UDP: "192.0.2.2:30041"}}
+
+	t.Run("LookupSVC cycles through cached replies", func(t *testing.T) {
+		backend := &countingResolver{replies: []*svc.Reply{replyOne, replyTwo}}
+		c := &svc.CachingResolver{Resolver: backend}
+		var chosen []map[svc.Transport]string
+		for i := 0; i < 4; i++ {
+			reply, err := c.LookupSVC(context.Background(), mockPath, addr.SvcCS)
+			require.NoError(t, err)
+			chosen = append(chosen, reply.Transports)
+		}
+		assert.ElementsMatch(t, []map[svc.Transport]string{replyOne.Transports,
+			replyTwo.Transports}, chosen[:2])
+		assert.Equal(t, chosen[:2], chosen[2:])
+		assert.Equal(t, 1, backend.calls)
+
+		replies, err := c.LookupSVCAll(context.Background(), mockPath, addr.SvcCS.Multicast())
+		require.NoError(t, err)
+		assert.Len(t, replies, 2)
+		assert.Equal(t, 1, backend.calls)
+	})
+	t.Run("expired entries are fetched again", func(t *testing.T) {
+		backend := &countingResolver{replies: []*svc.Reply{replyOne}}
+		c := &svc.CachingResolver{Resolver: backend, TTL: time.Nanosecond}
+		reply, err := c.LookupSVC(context.Background(), mockPath, addr.SvcCS)
+		require.NoError(t, err)
+		require.NotNil(t, reply, "reply of expired entry")
+		assert.Equal(t, replyOne.Transports, reply.Transports)
+		time.Sleep(time.Millisecond)
+		reply, err = c.LookupSVC(context.Background(), mockPath, addr.SvcCS)
+		require.NoError(t, err)
+		require.NotNil(t, reply, "reply of expired entry")
+		replies, err := c.LookupSVCAll(context.Background(), mockPath, addr.SvcCS)
+		require.NoError(t, err)
+		assert.Len(t, replies, 1)
+		assert.Equal(t, 3, backend.calls)
+	})
+	t.Run("invalidated entries are fetched again", func(t *testing.T) {
+		backend := &countingResolver{replies: []*svc.Reply{replyOne}}
+		c := &svc.CachingResolver{Resolver: backend}
+		_, err := c.LookupSVC(context.Background(), mockPath, addr.SvcCS)
+		require.NoError(t, err)
+		c.Invalidate(dstIA, addr.SvcCS)
+		_, err = c.LookupSVC(context.Background(), mockPath, addr.SvcCS)
+		require.NoError(t, err)
+		assert.Equal(t, 2, backend.calls)
+	})
+	t.Run("failed instances are skipped", func(t *testing.T) {
+		backend := &countingResolver{replies: []*svc.Reply{replyOne, replyTwo}}
+		c := &svc.CachingResolver{Resolver: backend}
+		_, err := c.LookupSVC(context.Background(), mockPath, addr.SvcCS)
+		require.NoError(t, err)
+		c.Fail(dstIA, addr.SvcCS, &addr.AppAddr{L3: addr.HostFromIPStr("192.0.2.1"), L4: 30041})
+		for i := 0; i < 3; i++ {
+			reply, err := c.LookupSVC(context.Background(), mockPath, addr.SvcCS)
+			require.NoError(t, err)
+			assert.Equal(t, replyTwo.Transports, reply.Transports)
+		}
+		assert.Equal(t, 1, backend.calls)
+	})
+	t.Run("lower latency instance is chosen", func(t *testing.T) {
+		backend := &countingResolver{replies: []*svc.Reply{replyOne, replyTwo}}
+		c := &svc.CachingResolver{Resolver: backend, Algorithm: healthpool.LatencyEWMA}
+		_, err := c.LookupSVC(context.Background(), mockPath, addr.SvcCS)
+		require.NoError(t, err)
+		one := &addr.AppAddr{L3: addr.HostFromIPStr("192.0.2.1"), L4: 30041}
+		two := &addr.AppAddr{L3: addr.HostFromIPStr("192.0.2.2"), L4: 30041}
+		c.ReportLatency(dstIA, addr.SvcCS, one, 50*time.Millisecond)
+		c.ReportLatency(dstIA, addr.SvcCS, two, 10*time.Millisecond)
+		for i := 0; i < 3; i++ {
+			reply, err := c.LookupSVC(context.Background(), mockPath, addr.SvcCS)
+			require.NoError(t, err)
+			assert.Equal(t, replyTwo.Transports, reply.Transports)
+		}
+		// The moving average catches up with the new latencies.
+		for i := 0; i < 10; i++ {
+			c.ReportLatency(dstIA, addr.SvcCS, two, 100*time.Millisecond)
+		}
+		reply, err := c.LookupSVC(context.Background(), mockPath, addr.SvcCS)
+		require.NoError(t, err)
+		assert.Equal(t, replyOne.Transports, reply.Transports)
+		assert.Equal(t, 1, backend.calls)
+	})
+	t.Run("latencies are kept when fetching again", func(t *testing.T) {
+		backend := &countingResolver{replies: []*svc.Reply{replyOne, replyTwo}}
+		c := &svc.CachingResolver{Resolver: backend, TTL: 50 * time.Millisecond,
+			Algorithm: healthpool.LatencyEWMA}
+		_, err := c.LookupSVC(context.Background(), mockPath, addr.SvcCS)
+		require.NoError(t, err)
+		c.ReportLatency(dstIA, addr.SvcCS,
+			&addr.AppAddr{L3: addr.HostFromIPStr("192.0.2.1"), L4: 30041}, 10*time.Millisecond)
+		c.ReportLatency(dstIA, addr.SvcCS,
+			&addr.AppAddr{L3: addr.HostFromIPStr("192.0.2.2"), L4: 30041}, 50*time.Millisecond)
+		// Expired, but not stale yet.
+		time.Sleep(60 * time.Millisecond)
+		reply, err := c.LookupSVC(context.Background(), mockPath, addr.SvcCS)
+		require.NoError(t, err)
+		assert.Equal(t, 2, backend.calls)
+		assert.Equal(t, replyOne.Transports, reply.Transports)
+	})
+	t.Run("errors are not cached", func(t *testing.T) {
+		backend := &countingResolver{err: errors.New("no replies")}
+		c := &svc.CachingResolver{Resolver: backend}
+		_, err := c.LookupSVC(context.Background(), mockPath, addr.SvcCS)
+		assert.Error(t, err)
+		_, err = c.LookupSVC(context.Background(), mockPath, addr.SvcCS)
+		assert.Error(t, err)
+		assert.Equal(t, 2, backend.calls)
+	})
+}
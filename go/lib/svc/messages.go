@@ -54,6 +54,34 @@ func (r *Reply) SerializeTo(wr io.Writer) error {
 	return r.toProtoFormat().SerializeTo(wr)
 }
 
+// Copy returns a deep copy of the reply.
+func (r *Reply) Copy() *Reply {
+	if r == nil {
+		return nil
+	}
+	c := &Reply{Transports: make(map[Transport]string, len(r.Transports))}
+	for k, v := range r.Transports {
+		c.Transports[k] = v
+	}
+	if r.ReturnPath != nil {
+		c.ReturnPath = r.ReturnPath.Copy()
+	}
+	return c
+}
+
+// equalTransports returns whether both replies contain the same transports.
+func (r *Reply) equalTransports(o *Reply) bool {
+	if len(r.Transports) != len(o.Transports) {
+		return false
+	}
+	for k, v := range r.Transports {
+		if ov, ok := o.Transports[k]; !ok || ov != v {
+			return false
+		}
+	}
+	return true
+}
+
 // toProtoFormat converts a reply message to a low-level format suitable for
 // network exchanges. The serializer uses this under the hood to convert the
 // reply message to a byte stream.
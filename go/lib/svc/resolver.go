@@ -41,8 +41,13 @@ const (
 	errRead           common.ErrMsg = "unable to read"
 	errDecode         common.ErrMsg = "decode failed"
 	errBadPath        common.ErrMsg = "unable to parse return path"
+	errNoReplies      common.ErrMsg = "no replies received"
 )
 
+// DefaultCollectWindow is the default amount of time LookupSVCAll waits for
+// replies from SVC instances.
+const DefaultCollectWindow = 200 * time.Millisecond
+
 // Resolver performs SVC address resolution.
 type Resolver struct {
 	// LocalIA is the local AS.
@@ -56,6 +61,9 @@ type Resolver struct {
 	RoundTripper RoundTripper
 	// Payload is used for the data part of SVC requests.
 	Payload []byte
+	// CollectWindow is the amount of time LookupSVCAll waits for replies. If
+	// 0, DefaultCollectWindow is used.
+	CollectWindow time.Duration
 }
 
 // LookupSVC resolves the SVC address for the AS terminating the path.
@@ -64,12 +72,45 @@ func (r *Resolver) LookupSVC(ctx context.Context, p snet.Path, svc addr.HostSVC)
 	span, ctx = opentracing.StartSpanFromContext(ctx, "svc.resolution")
 	defer span.Finish()
 
+	conn, requestPacket, err := r.buildRequest(p, svc)
+	if err != nil {
+		return nil, err
+	}
+	return r.getRoundTripper().RoundTrip(ctx, conn, requestPacket, p.OverlayNextHop())
+}
+
+// LookupSVCAll resolves the SVC address for all instances of the service in
+// the AS terminating the path. The request is sent to the multicast variant
+// of svc, and replies are collected until the collect window elapses or ctx
+// is done, whichever comes first. Duplicate replies are only returned once.
+// If no valid reply is received, an error is returned.
+func (r *Resolver) LookupSVCAll(ctx context.Context, p snet.Path,
+	svc addr.HostSVC) ([]*Reply, error) {
+
+	var span opentracing.Span
+	span, ctx = opentracing.StartSpanFromContext(ctx, "svc.resolution.all")
+	defer span.Finish()
+
+	conn, requestPacket, err := r.buildRequest(p, svc.Multicast())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelF := context.WithTimeout(ctx, r.getCollectWindow())
+	defer cancelF()
+	return collectReplies(ctx, conn, requestPacket, p.OverlayNextHop())
+}
+
+// buildRequest opens a conn for the resolution and builds the request packet
+// destined to svc in the AS terminating the path.
+func (r *Resolver) buildRequest(p snet.Path,
+	svc addr.HostSVC) (snet.PacketConn, *snet.SCIONPacket, error) {
+
 	// FIXME(scrye): Assume registration is always instant for now. This,
 	// however, should respect ctx.
 	conn, port, err := r.ConnFactory.RegisterTimeout(r.LocalIA, r.Machine.AppAddress(),
 		nil, addr.SvcNone, 0)
 	if err != nil {
-		return nil, common.NewBasicError(errRegistration, err)
+		return nil, nil, common.NewBasicError(errRegistration, err)
 	}
 
 	requestPacket := &snet.SCIONPacket{
@@ -89,7 +130,14 @@ func (r *Resolver) LookupSVC(ctx context.Context, p snet.Path, svc addr.HostSVC)
 			Payload: common.RawBytes(r.Payload),
 		},
 	}
-	return r.getRoundTripper().RoundTrip(ctx, conn, requestPacket, p.OverlayNextHop())
+	return conn, requestPacket, nil
+}
+
+func (r *Resolver) getCollectWindow() time.Duration {
+	if r.CollectWindow == 0 {
+		return DefaultCollectWindow
+	}
+	return r.CollectWindow
 }
 
 func (r *Resolver) getRoundTripper() RoundTripper {
@@ -139,6 +187,54 @@ func (roundTripper) RoundTrip(ctx context.Context, c snet.PacketConn, pkt *snet.
 	if err := c.ReadFrom(&replyPacket, &replyOv); err != nil {
 		return nil, common.NewBasicError(errRead, err)
 	}
+	return parseReply(&replyPacket, &replyOv)
+}
+
+// collectReplies sends the request packet and reads replies until reading
+// fails, e.g., because ctx is done and the conn is closed. Replies that cannot
+// be decoded and duplicate replies are skipped.
+func collectReplies(ctx context.Context, c snet.PacketConn, pkt *snet.SCIONPacket,
+	ov *net.UDPAddr) ([]*Reply, error) {
+
+	cancelF := ctxconn.CloseConnOnDone(ctx, c)
+	defer cancelF()
+
+	if pkt == nil {
+		return nil, common.NewBasicError(errNilPacket, nil)
+	}
+	if ov == nil {
+		return nil, common.NewBasicError(errNilOverlay, nil)
+	}
+
+	if err := c.WriteTo(pkt, ov); err != nil {
+		return nil, common.NewBasicError(errWrite, err)
+	}
+
+	var replies []*Reply
+	var lastErr error
+	for {
+		var replyPacket snet.SCIONPacket
+		var replyOv net.UDPAddr
+		if err := c.ReadFrom(&replyPacket, &replyOv); err != nil {
+			if len(replies) == 0 {
+				return nil, common.NewBasicError(errNoReplies, err, "lastErr", lastErr)
+			}
+			return replies, nil
+		}
+		reply, err := parseReply(&replyPacket, &replyOv)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if !containsReply(replies, reply) {
+			replies = append(replies, reply)
+		}
+	}
+}
+
+// parseReply decodes the reply contained in the packet, and sets the return
+// path.
+func parseReply(replyPacket *snet.SCIONPacket, replyOv *net.UDPAddr) (*Reply, error) {
 	b, ok := replyPacket.Payload.(common.RawBytes)
 	if !ok {
 		return nil, common.NewBasicError(errUnsupportedPld, nil, "payload", replyPacket.Payload)
@@ -158,12 +254,21 @@ func (roundTripper) RoundTrip(ctx context.Context, c snet.PacketConn, pkt *snet.
 	}
 	reply.ReturnPath = &path{
 		spath:       replyPacket.Path,
-		overlay:     &replyOv,
+		overlay:     replyOv,
 		destination: replyPacket.Source.IA,
 	}
 	return &reply, nil
 }
 
+func containsReply(replies []*Reply, reply *Reply) bool {
+	for _, r := range replies {
+		if r.equalTransports(reply) {
+			return true
+		}
+	}
+	return false
+}
+
 type path struct {
 	spath       *spath.Path
 	overlay     *net.UDPAddr
@@ -198,10 +303,18 @@ func (p *path) Expiry() time.Time {
 	return time.Time{}
 }
 
+func (p *path) Metadata() *snet.PathMetadata {
+	return nil
+}
+
 func (p *path) Copy() snet.Path {
-	return &path{
-		spath:       p.spath.Copy(),
+	c := &path{
 		overlay:     snet.CopyUDPAddr(p.overlay),
 		destination: p.destination,
 	}
+	// Replies from the local AS have no path.
+	if p.spath != nil {
+		c.spath = p.spath.Copy()
+	}
+	return c
 }
@@ -24,6 +24,8 @@ import (
 
 	"github.com/golang/mock/gomock"
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
@@ -198,3 +200,64 @@ func TestRoundTripper(t *testing.T) {
 		}
 	})
 }
+
+func TestResolverLookupSVCAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	srcIA := xtest.MustParseIA("1-ff00:0:1")
+	dstIA := xtest.MustParseIA("1-ff00:0:2")
+	mockPath := mock_snet.NewMockPath(ctrl)
+	mockPath.EXPECT().Path().Return(nil).AnyTimes()
+	mockPath.EXPECT().OverlayNextHop().Return(&net.UDPAddr{}).AnyTimes()
+	mockPath.EXPECT().Destination().Return(dstIA).AnyTimes()
+
+	replyOne := &svc.Reply{Transports: map[svc.Transport]string{svc.UDP: "192.0.2.1:30041"}}
+	replyTwo := &svc.Reply{Transports: map[svc.Transport]string{svc.UDP: "192.0.2.2:30041"}}
+	payloads := []common.RawBytes{
+		mustSerialize(t, replyOne),
+		{42},
+		mustSerialize(t, replyOne),
+		mustSerialize(t, replyTwo),
+	}
+
+	mockConn := mock_snet.NewMockPacketConn(ctrl)
+	mockConn.EXPECT().SetDeadline(gomock.Any()).AnyTimes()
+	mockConn.EXPECT().Close().AnyTimes()
+	mockConn.EXPECT().WriteTo(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(pkt *snet.SCIONPacket, _ *net.UDPAddr) error {
+			assert.Equal(t, addr.SvcCS.Multicast(), pkt.Destination.Host)
+			return nil
+		},
+	)
+	var reads int
+	mockConn.EXPECT().ReadFrom(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(pkt *snet.SCIONPacket, _ *net.UDPAddr) error {
+			if reads == len(payloads) {
+				return errors.New("conn closed")
+			}
+			pkt.Payload = payloads[reads]
+			reads++
+			return nil
+		},
+	).Times(len(payloads) + 1)
+	mockDispService := mock_snet.NewMockPacketDispatcherService(ctrl)
+	mockDispService.EXPECT().RegisterTimeout(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any()).Return(mockConn, uint16(42), nil)
+
+	resolver := &svc.Resolver{
+		LocalIA:     srcIA,
+		ConnFactory: mockDispService,
+	}
+	replies, err := resolver.LookupSVCAll(context.Background(), mockPath, addr.SvcCS)
+	require.NoError(t, err)
+	require.Len(t, replies, 2)
+	assert.Equal(t, replyOne.Transports, replies[0].Transports)
+	assert.Equal(t, replyTwo.Transports, replies[1].Transports)
+}
+
+func mustSerialize(t *testing.T, reply *svc.Reply) common.RawBytes {
+	buf := &bytes.Buffer{}
+	require.NoError(t, reply.SerializeTo(buf))
+	return common.RawBytes(buf.Bytes())
+}
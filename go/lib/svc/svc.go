@@ -56,6 +56,18 @@ func NewResolverPacketDispatcher(d snet.PacketDispatcherService,
 	return &ResolverPacketDispatcher{dispService: d, handler: h}
 }
 
+// NewMulticastResolverPacketDispatcher creates a dispatcher service that
+// returns sockets with built-in SVC address resolution capabilities. Unlike
+// NewResolverPacketDispatcher, packets with multicast SVC destinations are
+// also passed to the handler, such that the server takes part in
+// multi-instance resolutions (see Resolver.LookupSVCAll). Handlers must
+// forward multicast packets that are not resolution requests.
+func NewMulticastResolverPacketDispatcher(d snet.PacketDispatcherService,
+	h RequestHandler) *ResolverPacketDispatcher {
+
+	return &ResolverPacketDispatcher{dispService: d, handler: h, multicast: true}
+}
+
 var _ snet.PacketDispatcherService = (*ResolverPacketDispatcher)(nil)
 
 // ResolverPacketDispatcher is a dispatcher service that returns sockets with
@@ -70,6 +82,7 @@ var _ snet.PacketDispatcherService = (*ResolverPacketDispatcher)(nil)
 type ResolverPacketDispatcher struct {
 	dispService snet.PacketDispatcherService
 	handler     RequestHandler
+	multicast   bool
 }
 
 func (d *ResolverPacketDispatcher) RegisterTimeout(ia addr.IA, public *addr.AppAddr,
@@ -86,7 +99,8 @@ func (d *ResolverPacketDispatcher) RegisterTimeout(ia addr.IA, public *addr.AppA
 			IA:   ia,
 			Host: public.L3,
 		},
-		handler: d.handler,
+		handler:   d.handler,
+		multicast: d.multicast,
 	}
 	return packetConn, port, err
 }
@@ -100,6 +114,9 @@ type resolverPacketConn struct {
 	source snet.SCIONAddress
 	// handler handles packets for SVC destinations.
 	handler RequestHandler
+	// multicast indicates whether packets with multicast SVC destinations are
+	// passed to the handler.
+	multicast bool
 }
 
 func (c *resolverPacketConn) ReadFrom(pkt *snet.SCIONPacket, ov *net.UDPAddr) error {
@@ -115,8 +132,8 @@ func (c *resolverPacketConn) ReadFrom(pkt *snet.SCIONPacket, ov *net.UDPAddr) er
 			return nil
 		}
 
-		// Multicasts do not trigger SVC resolution logic
-		if svc.IsMulticast() {
+		// Multicasts only trigger SVC resolution logic if enabled
+		if svc.IsMulticast() && !c.multicast {
 			return nil
 		}
 
@@ -145,6 +145,32 @@ func TestSVCResolutionServer(t *testing.T) {
 				SoMsg("err", err, ShouldBeNil)
 			})
 		})
+		Convey("Given an established multicast resolver conn", func() {
+			mockPacketDispatcherService.EXPECT().RegisterTimeout(gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any()).Return(mockPacketConn, uint16(1337), nil)
+
+			dispatcherService := svc.NewMulticastResolverPacketDispatcher(
+				mockPacketDispatcherService, mockReqHandler)
+			conn, _, err := dispatcherService.RegisterTimeout(addr.IA{}, &addr.AppAddr{},
+				&net.UDPAddr{}, addr.SvcPS, 0)
+			SoMsg("err", err, ShouldBeNil)
+
+			var pkt snet.SCIONPacket
+			var ov net.UDPAddr
+			Convey("Multicast SVC packets are passed to the handler", func() {
+				mockPacketConn.EXPECT().ReadFrom(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(pkt *snet.SCIONPacket, ov *net.UDPAddr) error {
+						pkt.Destination = snet.SCIONAddress{
+							Host: addr.SvcPS.Multicast(),
+						}
+						return nil
+					},
+				)
+				mockReqHandler.EXPECT().Handle(gomock.Any()).Return(svc.Forward, nil)
+				err := conn.ReadFrom(&pkt, &ov)
+				SoMsg("err", err, ShouldBeNil)
+			})
+		})
 	})
 }
 
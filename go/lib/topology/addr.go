@@ -265,6 +265,12 @@ func (a *pubBindAddr) String() string {
 	return fmt.Sprintf("public: %v bind: %v overlay: %v", a.pub, a.bind, a.overlay)
 }
 
+// MarshalText marshals the addresses in the format of String, such that
+// topologies can be inspected as JSON.
+func (a *pubBindAddr) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
 func overlayCheck(ot overlay.Type) error {
 	switch ot {
 	case overlay.IPv4, overlay.IPv6, overlay.IPv46, overlay.UDPIPv4,
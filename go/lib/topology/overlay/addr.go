@@ -82,6 +82,12 @@ func (a *OverlayAddr) String() string {
 	return fmt.Sprintf("[%s]:%d", a.l3, a.l4)
 }
 
+// MarshalText marshals the address in the format of String, such that
+// topologies can be inspected as JSON.
+func (a *OverlayAddr) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
 func (a *OverlayAddr) ToUDPAddr() *net.UDPAddr {
 	return &net.UDPAddr{IP: copyIP(a.l3), Port: int(a.l4)}
 }
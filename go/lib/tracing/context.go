@@ -32,10 +32,11 @@ func CtxWith(parentCtx context.Context, parentLogger log.Logger, operationName s
 	debugId := util.GetDebugID()
 	span, ctx := opentracing.StartSpanFromContext(parentCtx, operationName, opts...)
 	if spanCtx, ok := span.Context().(jaeger.SpanContext); ok {
-		ctx = log.CtxWith(ctx, parentLogger.New("debug_id", debugId, "trace_id", spanCtx.TraceID()))
+		ctx = log.CtxWith(ctx, parentLogger.New(log.KeyDebugID, debugId,
+			log.KeyTraceID, spanCtx.TraceID()))
 		span.SetTag("LogDebugId", debugId)
 	} else {
-		ctx = log.CtxWith(ctx, parentLogger.New("debug_id", util.GetDebugID()))
+		ctx = log.CtxWith(ctx, parentLogger.New(log.KeyDebugID, util.GetDebugID()))
 	}
 	return ctx, span
 }
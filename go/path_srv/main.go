@@ -132,6 +132,8 @@ func realMain() int {
 			Address:  cfg.QUIC.Address,
 			CertFile: cfg.QUIC.CertFile,
 			KeyFile:  cfg.QUIC.KeyFile,
+			UsePKI:   cfg.QUIC.UsePKI,
+			KeyDir:   filepath.Join(cfg.General.ConfigDir, "keys"),
 		},
 		SVCResolutionFraction: cfg.QUIC.ResolutionFraction,
 		TrustStore:            trustStore,
@@ -164,6 +166,12 @@ func realMain() int {
 		msger.AddHandler(infra.SegSync, handlers.NewSyncHandler(args))
 	}
 	msger.AddHandler(infra.SignedRev, handlers.NewRevocHandler(args))
+	env.StatusPages{
+		Config:   &cfg,
+		ID:       cfg.General.ID,
+		Features: cfg.Features,
+		Health:   env.HealthChecks(env.TopologyHealth, env.DispatcherHealth("")),
+	}.Register()
 	cfg.Metrics.StartPrometheus()
 	// Start handling requests/messages
 	go func() {
@@ -171,7 +179,7 @@ func realMain() int {
 		msger.ListenAndServe()
 	}()
 	discoRunners, err := idiscovery.StartRunners(cfg.Discovery, discovery.Full,
-		idiscovery.TopoHandlers{}, nil, "ps")
+		idiscovery.TopoHandlers{}, trustStore.NewVerifier(), nil, "ps")
 	if err != nil {
 		log.Crit("Unable to start topology fetcher", "err", err)
 		return 1
@@ -3,6 +3,7 @@
 package proto
 
 import (
+	math "math"
 	capnp "zombiezen.com/go/capnproto2"
 	text "zombiezen.com/go/capnproto2/encoding/text"
 	schemas "zombiezen.com/go/capnproto2/schemas"
@@ -236,32 +237,623 @@ func (p HiddenPathSegExtn_Promise) Struct() (HiddenPathSegExtn, error) {
 	return HiddenPathSegExtn{s}, err
 }
 
-const schema_e6c88f91b6a1209e = "x\xda\x8c\xce?\x8b\x13A\x1c\xc6\xf1\xe7\x99\xd9d\x8d" +
-	"h\x925\xfb\x02\xc4RD\x08\"\x88\x8d\x7fH\xc0t" +
-	";\xc6F\x10t\xc9\x8e\xc9Bvva'\xb0[\x05" +
-	"A\xad\x04I\xa1b#\xe45\x08bk\xa1\xf8\x1a\xae" +
-	";\xb8?/\xe0\xaa\xab\xf6\xd8\x14w\x1c\x97\xe2\xda\x1f" +
-	"\x9fy\xe6\xdb\xfd\xf6X\xf4\x1b\x1d\x02\xeaZ\xa3Y\x1d" +
-	"\xef\xdd\x7f\xf0{\xe7\xcfW\xa8\x0eE\xf5\xe3\xe6\xfa\xd7" +
-	"\xea\xf3\xff\x034\xe8\x02\xfdD\xd0+]\xc0[\x1c\x82" +
-	"\xd5\xfa\xdf\x9dwm\xfd\xf1oMyF\x1d\x17\xb8\xf7" +
-	"\x927\xd8\x8b\xebG=\xcdG`u\xf4i\x7f\xf7\xfb" +
-	"\xcf\xb2\xda\x86?\xf0*{_6x\xb5\xc1a\x9e\xbc" +
-	"\xd6\x85\xcd\xe5\xddI\x98\x99\xec\xe1\xf3tac3\x0d" +
-	"\xd2y<)\x87\x85E@\xaa\xaet\x00\x87\x80\x17\xde" +
-	"\x02\xd4+I5\x13\xf4H\x9f\xf5Q?\x05\xd4\x1bI" +
-	"5\x17\xf4\x04}\x0a\xc0\x8bo\x03*\x92T\x99 \xa5" +
-	"O\x09xI\x0dg\x92\xea\xbd\xa0\x9bkKB\x90\xe0" +
-	"2K\xe7/\xcaL\xb3\x09\xc1&\xd8\x89\xdf\x8e\x06l" +
-	"A\xb0\x05.\xe3<\x0as\x9d\xb3\x0d\x06\x92\x9bs{" +
-	"K\xf9h<xbL\xba0\x13\x9dhc\x87\x05m" +
-	"\xdd\xee\x9c\xb6_\xaf\xdb\xafH*\xff\xfc\xef\x17\x96\x9e" +
-	"\xc5Q\xa4M\x10\xda\xd9XO\x87\x855\xc0\xe5\x96N" +
-	"\x02\x00\x00\xff\xff#\x1dpa"
+type StaticInfoExtn struct{ capnp.Struct }
+
+// StaticInfoExtn_TypeID is the unique identifier for the type StaticInfoExtn.
+const StaticInfoExtn_TypeID = 0xdb505e3694652d57
+
+func NewStaticInfoExtn(s *capnp.Segment) (StaticInfoExtn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return StaticInfoExtn{st}, err
+}
+
+func NewRootStaticInfoExtn(s *capnp.Segment) (StaticInfoExtn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return StaticInfoExtn{st}, err
+}
+
+func ReadRootStaticInfoExtn(msg *capnp.Message) (StaticInfoExtn, error) {
+	root, err := msg.RootPtr()
+	return StaticInfoExtn{root.Struct()}, err
+}
+
+func (s StaticInfoExtn) String() string {
+	str, _ := text.Marshal(0xdb505e3694652d57, s.Struct)
+	return str
+}
+
+func (s StaticInfoExtn) Latency() (StaticInfoExtn_LatencyInfo_List, error) {
+	p, err := s.Struct.Ptr(0)
+	return StaticInfoExtn_LatencyInfo_List{List: p.List()}, err
+}
+
+func (s StaticInfoExtn) HasLatency() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s StaticInfoExtn) SetLatency(v StaticInfoExtn_LatencyInfo_List) error {
+	return s.Struct.SetPtr(0, v.List.ToPtr())
+}
+
+// NewLatency sets the latency field to a newly
+// allocated StaticInfoExtn_LatencyInfo_List, preferring placement in s's segment.
+func (s StaticInfoExtn) NewLatency(n int32) (StaticInfoExtn_LatencyInfo_List, error) {
+	l, err := NewStaticInfoExtn_LatencyInfo_List(s.Struct.Segment(), n)
+	if err != nil {
+		return StaticInfoExtn_LatencyInfo_List{}, err
+	}
+	err = s.Struct.SetPtr(0, l.List.ToPtr())
+	return l, err
+}
+
+func (s StaticInfoExtn) Bandwidth() (StaticInfoExtn_BandwidthInfo_List, error) {
+	p, err := s.Struct.Ptr(1)
+	return StaticInfoExtn_BandwidthInfo_List{List: p.List()}, err
+}
+
+func (s StaticInfoExtn) HasBandwidth() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s StaticInfoExtn) SetBandwidth(v StaticInfoExtn_BandwidthInfo_List) error {
+	return s.Struct.SetPtr(1, v.List.ToPtr())
+}
+
+// NewBandwidth sets the bandwidth field to a newly
+// allocated StaticInfoExtn_BandwidthInfo_List, preferring placement in s's segment.
+func (s StaticInfoExtn) NewBandwidth(n int32) (StaticInfoExtn_BandwidthInfo_List, error) {
+	l, err := NewStaticInfoExtn_BandwidthInfo_List(s.Struct.Segment(), n)
+	if err != nil {
+		return StaticInfoExtn_BandwidthInfo_List{}, err
+	}
+	err = s.Struct.SetPtr(1, l.List.ToPtr())
+	return l, err
+}
+
+func (s StaticInfoExtn) LinkType() (StaticInfoExtn_LinkTypeInfo_List, error) {
+	p, err := s.Struct.Ptr(2)
+	return StaticInfoExtn_LinkTypeInfo_List{List: p.List()}, err
+}
+
+func (s StaticInfoExtn) HasLinkType() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s StaticInfoExtn) SetLinkType(v StaticInfoExtn_LinkTypeInfo_List) error {
+	return s.Struct.SetPtr(2, v.List.ToPtr())
+}
+
+// NewLinkType sets the linkType field to a newly
+// allocated StaticInfoExtn_LinkTypeInfo_List, preferring placement in s's segment.
+func (s StaticInfoExtn) NewLinkType(n int32) (StaticInfoExtn_LinkTypeInfo_List, error) {
+	l, err := NewStaticInfoExtn_LinkTypeInfo_List(s.Struct.Segment(), n)
+	if err != nil {
+		return StaticInfoExtn_LinkTypeInfo_List{}, err
+	}
+	err = s.Struct.SetPtr(2, l.List.ToPtr())
+	return l, err
+}
+
+func (s StaticInfoExtn) Geo() (StaticInfoExtn_GeoInfo_List, error) {
+	p, err := s.Struct.Ptr(3)
+	return StaticInfoExtn_GeoInfo_List{List: p.List()}, err
+}
+
+func (s StaticInfoExtn) HasGeo() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s StaticInfoExtn) SetGeo(v StaticInfoExtn_GeoInfo_List) error {
+	return s.Struct.SetPtr(3, v.List.ToPtr())
+}
+
+// NewGeo sets the geo field to a newly
+// allocated StaticInfoExtn_GeoInfo_List, preferring placement in s's segment.
+func (s StaticInfoExtn) NewGeo(n int32) (StaticInfoExtn_GeoInfo_List, error) {
+	l, err := NewStaticInfoExtn_GeoInfo_List(s.Struct.Segment(), n)
+	if err != nil {
+		return StaticInfoExtn_GeoInfo_List{}, err
+	}
+	err = s.Struct.SetPtr(3, l.List.ToPtr())
+	return l, err
+}
+
+// StaticInfoExtn_List is a list of StaticInfoExtn.
+type StaticInfoExtn_List struct{ capnp.List }
+
+// NewStaticInfoExtn creates a new list of StaticInfoExtn.
+func NewStaticInfoExtn_List(s *capnp.Segment, sz int32) (StaticInfoExtn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4}, sz)
+	return StaticInfoExtn_List{l}, err
+}
+
+func (s StaticInfoExtn_List) At(i int) StaticInfoExtn { return StaticInfoExtn{s.List.Struct(i)} }
+
+func (s StaticInfoExtn_List) Set(i int, v StaticInfoExtn) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s StaticInfoExtn_List) String() string {
+	str, _ := text.MarshalList(0xdb505e3694652d57, s.List)
+	return str
+}
+
+// StaticInfoExtn_Promise is a wrapper for a StaticInfoExtn promised by a client call.
+type StaticInfoExtn_Promise struct{ *capnp.Pipeline }
+
+func (p StaticInfoExtn_Promise) Struct() (StaticInfoExtn, error) {
+	s, err := p.Pipeline.Struct()
+	return StaticInfoExtn{s}, err
+}
+
+type StaticInfoExtn_LatencyInfo struct{ capnp.Struct }
+
+// StaticInfoExtn_LatencyInfo_TypeID is the unique identifier for the type StaticInfoExtn_LatencyInfo.
+const StaticInfoExtn_LatencyInfo_TypeID = 0x92ae8103dd768751
+
+func NewStaticInfoExtn_LatencyInfo(s *capnp.Segment) (StaticInfoExtn_LatencyInfo, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return StaticInfoExtn_LatencyInfo{st}, err
+}
+
+func NewRootStaticInfoExtn_LatencyInfo(s *capnp.Segment) (StaticInfoExtn_LatencyInfo, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return StaticInfoExtn_LatencyInfo{st}, err
+}
+
+func ReadRootStaticInfoExtn_LatencyInfo(msg *capnp.Message) (StaticInfoExtn_LatencyInfo, error) {
+	root, err := msg.RootPtr()
+	return StaticInfoExtn_LatencyInfo{root.Struct()}, err
+}
+
+func (s StaticInfoExtn_LatencyInfo) String() string {
+	str, _ := text.Marshal(0x92ae8103dd768751, s.Struct)
+	return str
+}
+
+func (s StaticInfoExtn_LatencyInfo) IfID() uint64 {
+	return s.Struct.Uint64(0)
+}
+
+func (s StaticInfoExtn_LatencyInfo) SetIfID(v uint64) {
+	s.Struct.SetUint64(0, v)
+}
+
+func (s StaticInfoExtn_LatencyInfo) Intra() uint32 {
+	return s.Struct.Uint32(8)
+}
+
+func (s StaticInfoExtn_LatencyInfo) SetIntra(v uint32) {
+	s.Struct.SetUint32(8, v)
+}
+
+func (s StaticInfoExtn_LatencyInfo) Inter() uint32 {
+	return s.Struct.Uint32(12)
+}
+
+func (s StaticInfoExtn_LatencyInfo) SetInter(v uint32) {
+	s.Struct.SetUint32(12, v)
+}
+
+// StaticInfoExtn_LatencyInfo_List is a list of StaticInfoExtn_LatencyInfo.
+type StaticInfoExtn_LatencyInfo_List struct{ capnp.List }
+
+// NewStaticInfoExtn_LatencyInfo creates a new list of StaticInfoExtn_LatencyInfo.
+func NewStaticInfoExtn_LatencyInfo_List(s *capnp.Segment, sz int32) (StaticInfoExtn_LatencyInfo_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0}, sz)
+	return StaticInfoExtn_LatencyInfo_List{l}, err
+}
+
+func (s StaticInfoExtn_LatencyInfo_List) At(i int) StaticInfoExtn_LatencyInfo {
+	return StaticInfoExtn_LatencyInfo{s.List.Struct(i)}
+}
+
+func (s StaticInfoExtn_LatencyInfo_List) Set(i int, v StaticInfoExtn_LatencyInfo) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s StaticInfoExtn_LatencyInfo_List) String() string {
+	str, _ := text.MarshalList(0x92ae8103dd768751, s.List)
+	return str
+}
+
+// StaticInfoExtn_LatencyInfo_Promise is a wrapper for a StaticInfoExtn_LatencyInfo promised by a client call.
+type StaticInfoExtn_LatencyInfo_Promise struct{ *capnp.Pipeline }
+
+func (p StaticInfoExtn_LatencyInfo_Promise) Struct() (StaticInfoExtn_LatencyInfo, error) {
+	s, err := p.Pipeline.Struct()
+	return StaticInfoExtn_LatencyInfo{s}, err
+}
+
+type StaticInfoExtn_BandwidthInfo struct{ capnp.Struct }
+
+// StaticInfoExtn_BandwidthInfo_TypeID is the unique identifier for the type StaticInfoExtn_BandwidthInfo.
+const StaticInfoExtn_BandwidthInfo_TypeID = 0xc6ff25e3b262348a
+
+func NewStaticInfoExtn_BandwidthInfo(s *capnp.Segment) (StaticInfoExtn_BandwidthInfo, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 0})
+	return StaticInfoExtn_BandwidthInfo{st}, err
+}
+
+func NewRootStaticInfoExtn_BandwidthInfo(s *capnp.Segment) (StaticInfoExtn_BandwidthInfo, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 0})
+	return StaticInfoExtn_BandwidthInfo{st}, err
+}
+
+func ReadRootStaticInfoExtn_BandwidthInfo(msg *capnp.Message) (StaticInfoExtn_BandwidthInfo, error) {
+	root, err := msg.RootPtr()
+	return StaticInfoExtn_BandwidthInfo{root.Struct()}, err
+}
+
+func (s StaticInfoExtn_BandwidthInfo) String() string {
+	str, _ := text.Marshal(0xc6ff25e3b262348a, s.Struct)
+	return str
+}
+
+func (s StaticInfoExtn_BandwidthInfo) IfID() uint64 {
+	return s.Struct.Uint64(0)
+}
+
+func (s StaticInfoExtn_BandwidthInfo) SetIfID(v uint64) {
+	s.Struct.SetUint64(0, v)
+}
+
+func (s StaticInfoExtn_BandwidthInfo) Intra() uint64 {
+	return s.Struct.Uint64(8)
+}
+
+func (s StaticInfoExtn_BandwidthInfo) SetIntra(v uint64) {
+	s.Struct.SetUint64(8, v)
+}
+
+func (s StaticInfoExtn_BandwidthInfo) Inter() uint64 {
+	return s.Struct.Uint64(16)
+}
+
+func (s StaticInfoExtn_BandwidthInfo) SetInter(v uint64) {
+	s.Struct.SetUint64(16, v)
+}
+
+// StaticInfoExtn_BandwidthInfo_List is a list of StaticInfoExtn_BandwidthInfo.
+type StaticInfoExtn_BandwidthInfo_List struct{ capnp.List }
+
+// NewStaticInfoExtn_BandwidthInfo creates a new list of StaticInfoExtn_BandwidthInfo.
+func NewStaticInfoExtn_BandwidthInfo_List(s *capnp.Segment, sz int32) (StaticInfoExtn_BandwidthInfo_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 0}, sz)
+	return StaticInfoExtn_BandwidthInfo_List{l}, err
+}
+
+func (s StaticInfoExtn_BandwidthInfo_List) At(i int) StaticInfoExtn_BandwidthInfo {
+	return StaticInfoExtn_BandwidthInfo{s.List.Struct(i)}
+}
+
+func (s StaticInfoExtn_BandwidthInfo_List) Set(i int, v StaticInfoExtn_BandwidthInfo) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s StaticInfoExtn_BandwidthInfo_List) String() string {
+	str, _ := text.MarshalList(0xc6ff25e3b262348a, s.List)
+	return str
+}
+
+// StaticInfoExtn_BandwidthInfo_Promise is a wrapper for a StaticInfoExtn_BandwidthInfo promised by a client call.
+type StaticInfoExtn_BandwidthInfo_Promise struct{ *capnp.Pipeline }
+
+func (p StaticInfoExtn_BandwidthInfo_Promise) Struct() (StaticInfoExtn_BandwidthInfo, error) {
+	s, err := p.Pipeline.Struct()
+	return StaticInfoExtn_BandwidthInfo{s}, err
+}
+
+type StaticInfoExtn_LinkTypeInfo struct{ capnp.Struct }
+
+// StaticInfoExtn_LinkTypeInfo_TypeID is the unique identifier for the type StaticInfoExtn_LinkTypeInfo.
+const StaticInfoExtn_LinkTypeInfo_TypeID = 0xb4b9b8f861554bad
+
+func NewStaticInfoExtn_LinkTypeInfo(s *capnp.Segment) (StaticInfoExtn_LinkTypeInfo, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return StaticInfoExtn_LinkTypeInfo{st}, err
+}
+
+func NewRootStaticInfoExtn_LinkTypeInfo(s *capnp.Segment) (StaticInfoExtn_LinkTypeInfo, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return StaticInfoExtn_LinkTypeInfo{st}, err
+}
+
+func ReadRootStaticInfoExtn_LinkTypeInfo(msg *capnp.Message) (StaticInfoExtn_LinkTypeInfo, error) {
+	root, err := msg.RootPtr()
+	return StaticInfoExtn_LinkTypeInfo{root.Struct()}, err
+}
+
+func (s StaticInfoExtn_LinkTypeInfo) String() string {
+	str, _ := text.Marshal(0xb4b9b8f861554bad, s.Struct)
+	return str
+}
+
+func (s StaticInfoExtn_LinkTypeInfo) IfID() uint64 {
+	return s.Struct.Uint64(0)
+}
+
+func (s StaticInfoExtn_LinkTypeInfo) SetIfID(v uint64) {
+	s.Struct.SetUint64(0, v)
+}
+
+func (s StaticInfoExtn_LinkTypeInfo) LinkType() StaticInfoExtn_LinkType {
+	return StaticInfoExtn_LinkType(s.Struct.Uint16(8))
+}
+
+func (s StaticInfoExtn_LinkTypeInfo) SetLinkType(v StaticInfoExtn_LinkType) {
+	s.Struct.SetUint16(8, uint16(v))
+}
+
+// StaticInfoExtn_LinkTypeInfo_List is a list of StaticInfoExtn_LinkTypeInfo.
+type StaticInfoExtn_LinkTypeInfo_List struct{ capnp.List }
+
+// NewStaticInfoExtn_LinkTypeInfo creates a new list of StaticInfoExtn_LinkTypeInfo.
+func NewStaticInfoExtn_LinkTypeInfo_List(s *capnp.Segment, sz int32) (StaticInfoExtn_LinkTypeInfo_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0}, sz)
+	return StaticInfoExtn_LinkTypeInfo_List{l}, err
+}
+
+func (s StaticInfoExtn_LinkTypeInfo_List) At(i int) StaticInfoExtn_LinkTypeInfo {
+	return StaticInfoExtn_LinkTypeInfo{s.List.Struct(i)}
+}
+
+func (s StaticInfoExtn_LinkTypeInfo_List) Set(i int, v StaticInfoExtn_LinkTypeInfo) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s StaticInfoExtn_LinkTypeInfo_List) String() string {
+	str, _ := text.MarshalList(0xb4b9b8f861554bad, s.List)
+	return str
+}
+
+// StaticInfoExtn_LinkTypeInfo_Promise is a wrapper for a StaticInfoExtn_LinkTypeInfo promised by a client call.
+type StaticInfoExtn_LinkTypeInfo_Promise struct{ *capnp.Pipeline }
+
+func (p StaticInfoExtn_LinkTypeInfo_Promise) Struct() (StaticInfoExtn_LinkTypeInfo, error) {
+	s, err := p.Pipeline.Struct()
+	return StaticInfoExtn_LinkTypeInfo{s}, err
+}
+
+type StaticInfoExtn_GeoInfo struct{ capnp.Struct }
+
+// StaticInfoExtn_GeoInfo_TypeID is the unique identifier for the type StaticInfoExtn_GeoInfo.
+const StaticInfoExtn_GeoInfo_TypeID = 0xd8c2ba2779275a74
+
+func NewStaticInfoExtn_GeoInfo(s *capnp.Segment) (StaticInfoExtn_GeoInfo, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return StaticInfoExtn_GeoInfo{st}, err
+}
+
+func NewRootStaticInfoExtn_GeoInfo(s *capnp.Segment) (StaticInfoExtn_GeoInfo, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return StaticInfoExtn_GeoInfo{st}, err
+}
+
+func ReadRootStaticInfoExtn_GeoInfo(msg *capnp.Message) (StaticInfoExtn_GeoInfo, error) {
+	root, err := msg.RootPtr()
+	return StaticInfoExtn_GeoInfo{root.Struct()}, err
+}
+
+func (s StaticInfoExtn_GeoInfo) String() string {
+	str, _ := text.Marshal(0xd8c2ba2779275a74, s.Struct)
+	return str
+}
+
+func (s StaticInfoExtn_GeoInfo) IfID() uint64 {
+	return s.Struct.Uint64(0)
+}
+
+func (s StaticInfoExtn_GeoInfo) SetIfID(v uint64) {
+	s.Struct.SetUint64(0, v)
+}
+
+func (s StaticInfoExtn_GeoInfo) Latitude() float32 {
+	return math.Float32frombits(s.Struct.Uint32(8))
+}
+
+func (s StaticInfoExtn_GeoInfo) SetLatitude(v float32) {
+	s.Struct.SetUint32(8, math.Float32bits(v))
+}
+
+func (s StaticInfoExtn_GeoInfo) Longitude() float32 {
+	return math.Float32frombits(s.Struct.Uint32(12))
+}
+
+func (s StaticInfoExtn_GeoInfo) SetLongitude(v float32) {
+	s.Struct.SetUint32(12, math.Float32bits(v))
+}
+
+func (s StaticInfoExtn_GeoInfo) Address() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s StaticInfoExtn_GeoInfo) HasAddress() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s StaticInfoExtn_GeoInfo) AddressBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s StaticInfoExtn_GeoInfo) SetAddress(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+// StaticInfoExtn_GeoInfo_List is a list of StaticInfoExtn_GeoInfo.
+type StaticInfoExtn_GeoInfo_List struct{ capnp.List }
+
+// NewStaticInfoExtn_GeoInfo creates a new list of StaticInfoExtn_GeoInfo.
+func NewStaticInfoExtn_GeoInfo_List(s *capnp.Segment, sz int32) (StaticInfoExtn_GeoInfo_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1}, sz)
+	return StaticInfoExtn_GeoInfo_List{l}, err
+}
+
+func (s StaticInfoExtn_GeoInfo_List) At(i int) StaticInfoExtn_GeoInfo {
+	return StaticInfoExtn_GeoInfo{s.List.Struct(i)}
+}
+
+func (s StaticInfoExtn_GeoInfo_List) Set(i int, v StaticInfoExtn_GeoInfo) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s StaticInfoExtn_GeoInfo_List) String() string {
+	str, _ := text.MarshalList(0xd8c2ba2779275a74, s.List)
+	return str
+}
+
+// StaticInfoExtn_GeoInfo_Promise is a wrapper for a StaticInfoExtn_GeoInfo promised by a client call.
+type StaticInfoExtn_GeoInfo_Promise struct{ *capnp.Pipeline }
+
+func (p StaticInfoExtn_GeoInfo_Promise) Struct() (StaticInfoExtn_GeoInfo, error) {
+	s, err := p.Pipeline.Struct()
+	return StaticInfoExtn_GeoInfo{s}, err
+}
+
+type StaticInfoExtn_LinkType uint16
+
+// StaticInfoExtn_LinkType_TypeID is the unique identifier for the type StaticInfoExtn_LinkType.
+const StaticInfoExtn_LinkType_TypeID = 0x8e5ebeac625172fd
+
+// Values of StaticInfoExtn_LinkType.
+const (
+	StaticInfoExtn_LinkType_unset    StaticInfoExtn_LinkType = 0
+	StaticInfoExtn_LinkType_direct   StaticInfoExtn_LinkType = 1
+	StaticInfoExtn_LinkType_multiHop StaticInfoExtn_LinkType = 2
+	StaticInfoExtn_LinkType_openNet  StaticInfoExtn_LinkType = 3
+)
+
+// String returns the enum's constant name.
+func (c StaticInfoExtn_LinkType) String() string {
+	switch c {
+	case StaticInfoExtn_LinkType_unset:
+		return "unset"
+	case StaticInfoExtn_LinkType_direct:
+		return "direct"
+	case StaticInfoExtn_LinkType_multiHop:
+		return "multiHop"
+	case StaticInfoExtn_LinkType_openNet:
+		return "openNet"
+
+	default:
+		return ""
+	}
+}
+
+// StaticInfoExtn_LinkTypeFromString returns the enum value with a name,
+// or the zero value if there's no such value.
+func StaticInfoExtn_LinkTypeFromString(c string) StaticInfoExtn_LinkType {
+	switch c {
+	case "unset":
+		return StaticInfoExtn_LinkType_unset
+	case "direct":
+		return StaticInfoExtn_LinkType_direct
+	case "multiHop":
+		return StaticInfoExtn_LinkType_multiHop
+	case "openNet":
+		return StaticInfoExtn_LinkType_openNet
+
+	default:
+		return 0
+	}
+}
+
+type StaticInfoExtn_LinkType_List struct{ capnp.List }
+
+func NewStaticInfoExtn_LinkType_List(s *capnp.Segment, sz int32) (StaticInfoExtn_LinkType_List, error) {
+	l, err := capnp.NewUInt16List(s, sz)
+	return StaticInfoExtn_LinkType_List{l.List}, err
+}
+
+func (l StaticInfoExtn_LinkType_List) At(i int) StaticInfoExtn_LinkType {
+	ul := capnp.UInt16List{List: l.List}
+	return StaticInfoExtn_LinkType(ul.At(i))
+}
+
+func (l StaticInfoExtn_LinkType_List) Set(i int, v StaticInfoExtn_LinkType) {
+	ul := capnp.UInt16List{List: l.List}
+	ul.Set(i, uint16(v))
+}
+
+const schema_e6c88f91b6a1209e = "x\xda\x94U]h\x1cU\x14>\xdf\xbd\xb3\xb9\xb5\xd4" +
+	"n\xae\x93\xd7v\xddb\xa8\x09ZL\x9a\x8a\xe4%q" +
+	"Ih6\xb627ih)R;\xd9\x9ddG7" +
+	"3\xcb\xee\xac\xdd}(\xebB\xa2(U\x89\xa8\xe0\x8b" +
+	"\xd0\x07\xa1\x82?\xa0\"\xd5\x07\xa1\x82\xa5\x05_\x05\xab" +
+	"\xf8 Z\x8b\xa0\x0f\xea\x8b>\x94\x91\xbb\xbf\xb3\xdb\x95" +
+	"\xe8\xc3!\x93\xc393\xdf\xf7\x9d\xef\x9c}\xe8\x14\x9b" +
+	"e\x13\xb1\x04'R\xf7\xc5\x86\xc2\xdbE\xb5\xfa\xee\xe7" +
+	"g^&\xb9\x9f\x85'\x1ft^{\xf8\x8c\xf5\x1d\x11" +
+	"\x0e\x971\x0ds\x0b\xa2\x15\xebD\xe6\xd7\x10\xa1z\xfe" +
+	"\x99\xefy\xfd\xfdWI\xedG\xa4\xc1\x10D\x87\xaf`" +
+	"\x09\xba\xa8\x15\x1f\x10\x99\xdbL\x84\x7f\xfft\xe4\x91\xcb" +
+	"\xdf^y\x83T\x1c,|\xeb\xde\x8b\x9fl\xbfr\xed" +
+	"g\x8aA\xf7\x9cg\x0c\xe6\x0bL\x10\x99[\xec\x16!" +
+	"|\xef\xb1\x15\xfb\xaf\xcb\x9f~<\xe8\x03.?\x0d\xf3" +
+	"<\x17\xad8Gd\xc6\x0c\x11^\xbc\xfa@}\xaf\xf3" +
+	"\xdc\x97\xfa\x03\xe8~\xa0\xd1\xf2;\xbf\x07&\xf4\xa3y" +
+	"\x9b\xcf\x10\xc2\x17\xa7V?\xfcq4\xbc\xaa\xdf\xcf\xfb" +
+	"\xde\xbf\xcfX\x859a\x88Vh\x02\xbf\x19\"\x0cN" +
+	"\x1f\xac\x1e\xfc\xec\x8bo\xfa 5\x09\xdc0&a\xfe" +
+	"b\x88V\xdc\xd2=1\xd1\xad\x92\xf1\x08\xa4X\x03\xc8" +
+	"\x8d\xd8\x1f\xe6\xcd\xd8IsbH\x98\x13C\xba\xe3u" +
+	"!\xc2?/\xdc\xfc\xe1\xcd\x8f\xaa\xe1 \x16u\xb1\x1b" +
+	"\xe6\xb6\xd0\xcd/\x09\xcd\xc2.m<\xe9T\x82\x12?" +
+	"\x94\xb1\x0b^az9\xb0\x037\x93\xf6\xd6\xfc\xf9J" +
+	"\xe0\x1d:\xe6\xcexO\x9f\xa8\x16\x1c\x0b\xb0\xc0\xd40" +
+	"\x18\x91\x1c\x9b\x94c\x02\x90\xa3\xd3rT\x80\xc9\xe4\xa2" +
+	"\xfe\xcbe2%\x93\"Q\xf6JN`\x81\xcdd\xdd" +
+	"\xa2\x93\xd1O\xe1F9\x1f\xb8\x0b~\x81\x88,\xb0\x9a" +
+	"_p\xbc\xc7\x1b5\xb3]\x04\xc6\xbf \xb0\x03\xc7\xcb" +
+	"T\xd3\xde\x1a\xfc\x16\x88=\xdc 2@$\xe7\xc7\xe5" +
+	"\xbcPs\x1c\xcab\x90`#\xd0\xd9\xe3\x93\xf2\xb8P" +
+	"\xc78\xd4)\x06\xc9\xf8H\x03\xf4\xca\xa4\\\x11\xea\x04" +
+	"\x87:\xcb\x10w\xd7\xd2s\x16\x18\xee\"\x1dH\xb8^" +
+	"P\xb4ub\x17\xe9h$\x9cb$1{\xa7VK" +
+	"~9p\xbdu\xcb\xcf\xbb\x99\xea|% \x0bP\xc3" +
+	"\x1dp\xf6\x01\"\xf5\x04\x87\xcailhbsRD" +
+	"\xea,\x87\xcakhhBs\xc7\x89T\x96C\x15\x18" +
+	"\xc0G\xc0\x89\xe4\x86.\xccq\xa8M\x06Qr\x02\x80" +
+	"\x18@\xa8\x15\xfc\xbc\x1e\x08\x86\x88a\x88\x9aL\xda4" +
+	"jn)k\x97\x9c\x12\xf6\x12,\x8eFz/\xed\xac" +
+	"\xb1\xdb\x1cr\xda\xe3km\x91wux\x8c\x8d\xcb1" +
+	"\xa1\xee\xe7PS\x9a\x88\xd1$2\xb1(\x8f\x085\xc5" +
+	"\xa1\x16\xee\x943\xcc\xb7\xde\xd8\x1c8\xe2\xdd\xf3@4" +
+	"\x0b\x09a1 >P\xd5\xf4\xf2\xdc\xa3\x9e\xe7\x97\xbd" +
+	"\x8c\xb3\xe1x\xc1|\x05\x81\xd6\xd5\xe8\xe0\xb9[\xeb\xba" +
+	"\x8bC\x8d\xf4*\xb3\x13\xcb\x94\xede\xcf\xb9\xd9 \x97" +
+	"\x16\xde\xda\x8e^\xc2@/\xb1\xff\xe5\xa5H\xc2)F" +
+	"\x12\xb3;\xee\xddQ'\xe1\xa7\xbb(#\xa6\x1a\x97\xb6" +
+	"\xe8\x18\xa8\xedxwQn\x08\x95\xe7P\x95\x88\xe3\xcb" +
+	"K\xb2*T\xa5\xe9\xa1\xb6\xaf\xea)Y\x17\xeaY\x0e" +
+	"ua\xd0\xdc\xec\xc0\x0d\xca\xd9\xf6\xdcv\x93\x0e\x84y" +
+	"\xdf[\xd7y\x82\x13I\xd7\xecl\xb6\xe8\x94J:\xb5" +
+	"\x87tD\x99\xb1~f\x89\x0655\x02t\x8f\xbfL" +
+	"\xaev\x0f\xa9L\x16\xbbW[&\x9f\xea\xdeK\x99L" +
+	"E~`\xf6-\x86\xed\xab@z\x94a{\xb2\x94\xc8" +
+	"i\xd1\xc2\xb6\x9f)\xae\xff\xad\x1du\xfc\xde4Q\x9f" +
+	"\xa8\xa9\xb6\xa8\x9b\x91\xd1\xd7\x97\xe4\x96P\x9b\x1c\xeaR" +
+	"d\xf4o/\xcaw\x84\xba\xc4\xa1\xbeb\x90\xbc\xa5\xea" +
+	"\xf5\x03\xf2\xbaP\xd78\xd4\xaf\x0c\xb5|\x13\x9d\xd6\xa5" +
+	"\xb5\x88\xc3]\xca\x91\x05\xd0\xab\xb9\xda\xc6\x8e\\O}" +
+	"G\x95\xbe\xfa\xbe\xcd\xea\xd4w\x84\xeb\xad\x17\xeb\x8e\xdf" +
+	"S\xd7\xd1\xb4\xb7n\x80%\x17\xdcl\xd6\xf1,;\xc8" +
+	"-;\xebztD\xffm\x11\xff\x19\x00\xc7\xdf\xf9B"
 
 func init() {
 	schemas.Register(schema_e6c88f91b6a1209e,
+		0x8e5ebeac625172fd,
+		0x92ae8103dd768751,
 		0x96c1dab83835e4f9,
+		0xb4b9b8f861554bad,
 		0xc586650e812cc6a1,
+		0xc6ff25e3b262348a,
+		0xd8c2ba2779275a74,
+		0xdb505e3694652d57,
 		0xff79b399e1e58cf3)
 }
@@ -174,12 +174,12 @@ type ASEntry_exts ASEntry
 const ASEntry_TypeID = 0xd4a209e8e78874ff
 
 func NewASEntry(s *capnp.Segment) (ASEntry, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 4})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 5})
 	return ASEntry{st}, err
 }
 
 func NewRootASEntry(s *capnp.Segment) (ASEntry, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 4})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 5})
 	return ASEntry{st}, err
 }
 
@@ -335,12 +335,37 @@ func (s ASEntry_exts) NewHiddenPathSeg() (HiddenPathSegExtn, error) {
 	return ss, err
 }
 
+func (s ASEntry_exts) StaticInfo() (StaticInfoExtn, error) {
+	p, err := s.Struct.Ptr(4)
+	return StaticInfoExtn{Struct: p.Struct()}, err
+}
+
+func (s ASEntry_exts) HasStaticInfo() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s ASEntry_exts) SetStaticInfo(v StaticInfoExtn) error {
+	return s.Struct.SetPtr(4, v.Struct.ToPtr())
+}
+
+// NewStaticInfo sets the staticInfo field to a newly
+// allocated StaticInfoExtn struct, preferring placement in s's segment.
+func (s ASEntry_exts) NewStaticInfo() (StaticInfoExtn, error) {
+	ss, err := NewStaticInfoExtn(s.Struct.Segment())
+	if err != nil {
+		return StaticInfoExtn{}, err
+	}
+	err = s.Struct.SetPtr(4, ss.Struct.ToPtr())
+	return ss, err
+}
+
 // ASEntry_List is a list of ASEntry.
 type ASEntry_List struct{ capnp.List }
 
 // NewASEntry creates a new list of ASEntry.
 func NewASEntry_List(s *capnp.Segment, sz int32) (ASEntry_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 4}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 5}, sz)
 	return ASEntry_List{l}, err
 }
 
@@ -383,6 +408,10 @@ func (p ASEntry_exts_Promise) HiddenPathSeg() HiddenPathSegExtn_Promise {
 	return HiddenPathSegExtn_Promise{Pipeline: p.Pipeline.GetPipeline(3)}
 }
 
+func (p ASEntry_exts_Promise) StaticInfo() StaticInfoExtn_Promise {
+	return StaticInfoExtn_Promise{Pipeline: p.Pipeline.GetPipeline(4)}
+}
+
 type HopEntry struct{ capnp.Struct }
 
 // HopEntry_TypeID is the unique identifier for the type HopEntry.
@@ -729,66 +758,68 @@ func (l PathSegType_List) Set(i int, v PathSegType) {
 	ul.Set(i, uint16(v))
 }
 
-const schema_fb8053d9fb34b837 = "x\xda\xacT_h\x1c\xd5\x17>\xdf=\xb3\xbbI~" +
-	"\xbf8{\xd9\x95\xa6\"\xac\x15\x05]\xb5\xd4\xb4\x92R" +
-	"\x84\xd8\x9a\xb6\x89\x10\xdc\xdb\xc4JE\xd1\xe9\xee4\x19" +
-	"0\xb3\xc3\xce]\xda\x8d\x94\xd6\x87\xf8\x07\x15\x1f\x14\xb1" +
-	"\xa5%mQ0\xd0\xd0\x8a-\xa4A!-\x85\"\x08" +
-	"\xbe\x88h\xa0`\xb5E\x0b\xbe\xf9 m\xa9#w7" +
-	"\xb3\xbb$\xed\x9bow\x0e\xe7~\xe7|\xdf\xfd\xbeY" +
-	"w\x03\xcf\x88'\x13\x97\x04\x91z \x91\x8c\xfe\xf7\xd8" +
-	"\xdbgN]\xfe\xf2=R6\x12Q\xdf\xdc\x86[?" +
-	"\x8f\x1c\xbcE\x09\xa4\x882C\xb8\x90Q\xe6\xb4~\x18" +
-	"/\x82\x10\xdd\x0c\xe6\xdf:\xbcp\xe4#\x926\xda\x9a" +
-	"\x85i>.\x163\xb3\xf5\xd3\x8c\xd8K\x88\xec\xf9\xae" +
-	"\xa7\xdf\xd8\xf9\xe9\xb4A\xc6r\xe4\xd5\xbc\x98y\x98\xcd" +
-	"i\x0d\x9b\xe6\x83\x1bOt\xb93\x7f\x1f'i\x8bV" +
-	"/!\xb3\x9f\x173\xef\xd6\x1b\xa7x\x8c\x10E\xd3S" +
-	"G\xff\xe9\xd2\x17H\xad\x82\x15E\xfa\x9d\xdf\xff\xe8<" +
-	"\xf1\x03\xddk\xa5`&\xf3\xaf\x84\xcc,\x9f\"D\xf9" +
-	"?'W\xf5L~\x7fi\xd9\xae\x0d:V\x1e\x99W" +
-	",\x03\xbb\xcb\xea7\xb01\x90\xb2a\xb5u\xd7[j" +
-	"\xd6|\xe6MsZ\xbf\xdf\xfa\xd0\xc8pM\xd5\xfa\x8a" +
-	"\xdb\x17~\xbc#\xb5\xdb\x89\xc3\x99D\xd2\x9c\x904\xd4" +
-	"\x02G\x8f\xbf\x1a\xbacbm\xd1\x09\xfc`\xd3`9" +
-	"\xd8\xea\xebJ\x8d\x0a\x80\xeaa\x8b\xc8\x02\x91<\x94'" +
-	"R\x1f3\xd41\x01\x09da\x8aG_\"RG\x18" +
-	"\xea\x0b\x01):\xb2\x10D\xf2\xf3^\"u\x8c\xa1N" +
-	"\x0aH\xe6,\x98H\xce\x98\xe2g\x0cuZ@ZV" +
-	"\x16\x16\x91\x9c\xddM\xa4N2\xd4\x9c\x00\x12Y$\x88" +
-	"\xe4Y3\xe74C}-`{\xfe\xd0ft\x92@" +
-	"'!\xaa\xb8\x13e\xed\x0e\xf9\xc4C\xdb\xe2b\xce\xf3" +
-	"\x87G_@\x8a\x04R\x84\\\xb9\xaaW\\x\xbeJ" +
-	")\xdd\xbaa\x8f\x97\x83m\xe8&\x81\xee;\x90/8" +
-	"z|\xc4\x1d\x9bp\xd9\xd7\x86\x7fG\x93\xff\xa3\x86\xc0" +
-	"C\x0c\xb5\xae\x8d\xff\x13;\x88\xd4\xe3\x0c5(\x90\x0b" +
-	"K\x8ev\x9a\xc8NhT\xf4\\B\x88{\x08\x05\x06" +
-	"\xd2\xd1\x8e\xdfn\xf6Mm\xef\x9d&\x82)\xdem\xfc" +
-	"\xb0\xcb\xdaY6~Kk|s\xba\x91\xea\x11\x86\xda" +
-	" p h\\E\xba\x15\x02\x02\xd2\x04[\xd7\x02\x17" +
-	"v\xcb\xc2\x04\xd8w\x9f=Z\xe3\xc05\xb3\xd3\xf5\xc7" +
-	"4\xb4\x01\xb9\xe6>\"\x08\xb9:O\x04\x962O\x94" +
-	"\xab\xfa\xa1\xab\xb9\x1a\xd8\xa5\xf2^\xdf.\x96+\xee\x0a" +
-	"\xc8\xcd#u'\xaduS\xfbt\xa8\xfe\xcfV\x1aY" +
-	"$\x89\xe4\xd6\x0a\x91\x1a`\xa8\x82\x11Sd\x8d1\xe5" +
-	"\xb0Qx\x90\xa1F\x8d\x998\x8b\x0e\"\xa9Lg\x81" +
-	"\xa1^\x16\x88*\xe5\xaa\xf6\xfc\xb1\x02\xe5\xca\xaf{\xc5" +
-	"\x1a\xd2\xd1\x8d\xabOm\x9c[<\xff\xc9\x12\xd7\\\xe8" +
-	"\xed\xae8HG\x1f\xbcv}\xd7\xb9\xeb\xb7\xe7\x96\xea" +
-	"\xd1\xb8W*\xb9~\xc1\xa1\\\xac\xd2_\xef_\xbbr" +
-	"\xe8\xabZ\x14w\xc4\xbb\xf3r'\xf8z\xc4\x1b\xf3\xdd" +
-	"\x92=\xe04\x1e\xc5j>J\xb7\xd9\xb8\x83\xa1\xb2\xc2" +
-	"8qOy\xa5\xb1\x10K\xd1\xdf\xd0\xc2\x00\xdc\xdf\x04" +
-	"8\xdb\xdb2{\xd3T\xe76\x11\xa93\x0c\xb5`t" +
-	"\x10\x8dP}c\xde\x7f\x8e\xa1.\x9aP\xf54Bu" +
-	"\xfe9\"\xb5\xc0P\xdf\x09`)S\xdf\x1aS\\d" +
-	"\xa8_\x04d\xa2\xbb\x11\xaa\xcb\x0f\x12\xa9\x9f\x18\xea\xaa" +
-	"\x00\x92h\xfbM\xc9+y\x129/,9a\x9c\x90" +
-	"~])\xeet+\xf1\xe7\x81\xa2[\xd1m\xdf\x91\xb7" +
-	"gh`\xc4\x9bt\x89\x08I\x12H6B\xd5f\xf3" +
-	"\xe6O\xbba\xf3\xd4\x84\xae\xc6\x11\xb5\xdd}:\\\xa1" +
-	"N\x81\x9f\xdd\xf2\x9f\xf9\xdd\xec\x17/\xfbo\x00\x00\x00" +
-	"\xff\xff*<\x8e\xd4"
+const schema_fb8053d9fb34b837 = "x\xda\xacTm\x88T\xd5\x1f~\x9es\xee\xcc\xec\xfa" +
+	"\xff\xafw\x0e3\xe1\x1a\xc1d\x14\xd4\x94b\xab\xb5\"" +
+	"\xc1\xaa\x99:\xc1\xd2\x1c\xd7\x94\xfcP^g\xee\xee^" +
+	"\xd8\xbd3\xcc\xdc\xc1\x9d\x8d\xd0/\xdb\x0b\x19}H\xa2" +
+	"\x15eU\x12Zp\xc9Ha]\x0aV1D(\xfa" +
+	"\x12Q\x1bB\x96bB\xdf\xfa\x10)u\xe3\xec\xec\xbc" +
+	"\xb0\xab\xdf\xfav\xee\xe19\xbf\xe7<\xcf}\x9e\xb3\xf6" +
+	"\xffb\x93x:rE\x00\xfa\xe1H4\xfc\xdf\x93o" +
+	"\x9d\xfb\xf4\xdag\xefB\xdb\x8c\x84\xdd\xd3\xeb\xef\xfe\xd8" +
+	"w\xe8.\"\x8c\x01\x89\x0c/%\xb4Y\xad\xeb\xe5\x1e" +
+	"\x82\xe1\x9d\xe2\xcc\x9bGg\x8f}\x00e\xb3\x05,\x0c" +
+	"\xf8\xa4\x98KL\xcd\xaf&\xc5\x010\xb4g\x96=\xf7" +
+	"\xfa\xee\x8f&\xccd.\x9e\xbcR\xce%\x1e\x93f\xb5" +
+	"J\x1a\xf0\xa1\x0d\xa7\x96\xb9\x93\x7f\x9e\x84\xb2E\x13\x0b" +
+	"&\xde\x90s\x89w\xe6\x81cr\x00\x0c\xc3\x89\xb1\xe3" +
+	"\xff,\x0b.A\xaf\xa0\x15\x86\xc1\xdb\xb7~k?\xf5" +
+	"\x1d\x1e\x88\xc4h\x98\xe5/`bJ\xde\x02\xc3\xf4\xef" +
+	"\xa3+:G\xbf\xbd\xb2\xe8\xae\xf3r\xc6\xad4\x13\x93" +
+	"\x96\x19{\xda\xea\x01\x9b\x83\xb4M\xab\x05\x1d1\x90\xaf" +
+	"\xac\x99\xc47\x06\xbc\xee\xaa\xf5>\xc1\xf0\xa6\xaev\xe7" +
+	"\xb6\xcf~\x7fOi\x99\xe8\xd1\x84\x8e\x9aUo\xd4H" +
+	"+:\xc1\xe0kew@\xac\xc99E\xbf\xb8qG" +
+	"\xa1\xf8\x82\x1f\x94\xaa\xc8\x92\xbaSZ\x80E@\x8d\xa7" +
+	"\x01}DR\x9f\x10Td\x92f\xf3\xf8^@\x1f\x93" +
+	"\xd4\x9f\x08*\xd1\x96\xa4\x00\xd4\xe9.@\x9f\x90\xd4g" +
+	"\x04\x95\x94IJ@M\x9a\xcd\x8f%\xf5YAeY" +
+	"IZ\x80\x9a\xda\x0f\xe83\x92zZ\x90\x91$#\x80" +
+	":ox\xceJ\xea/\x04m\xcf\xcflf;\x04\xdb" +
+	"\xc1\xb0\xe4\x0e\x17\x027\xe3Cf\xb6\xd57S\x9e\xdf" +
+	"\xbb\xebe\xc6 \x18\x03S\x85J\xb0\xe4\xc0K\x15\xc4" +
+	"\x82\xe6\x09{\xb0P\xdc\xc6\x0e\x08v\xdcC|\xd6\x09" +
+	"\x06\xfb\xdc\x81aW\xfa\x81\xd1\xdf\xd6\xd0\xff\x84\x11\xf0" +
+	"\xa8\xa4^\xdb\xa2\x7f\xf5N@?%\xa9w\x08\xa6\xca" +
+	"y'p\x1a\x93\x9d\xb2q\xd1s\xc12\x97\x83YI" +
+	"\xc6\xc3\x9d\xbf\xde\xe9\x1e\xdb\xde5\x01\x90\xcb\xefO\xdf" +
+	"\xeb\xca\xc0YD\xbf\xa5I\xdf`7V=.\xa9\xd7" +
+	"\x0b\x1e,\xd6\x8e2\xde,\x01\xc88h\x07\xd5\xa2K" +
+	"\xbb\x19a\x90\xf6\xfd\xb9wUe\xd15\xdcq\x8a\x05" +
+	"\xd9\xa4Z\xf5 @\xa1V\xa6\x01J\xa5\xd2@\xaa\xe2" +
+	"\x97\xdd@V\x8av\xbep\xc0\xb7s\x85\x92\xbbd\xe4" +
+	"\xe6\xbe\xf9$\xadqc#AY\xc7\xa5\x15g\x92Q" +
+	"@9%@\xef\x93\xd4C\xc6L\x91d\x0cP\x9eq" +
+	"8/\xa9\x8b&L2\xc96@\x0d\x1b\xe4\x90\xa4\x1e" +
+	"1a\xb2\x92l\x07Te\xaf\xaa\xc6\xf4\x88\xa4>," +
+	"\x18\x96\x0a\x95\xc0\xf3\x07\xb2H\x15\x86\xbc\\\x95\xf1\xf0" +
+	"\xaf\x1b\xcfl\x98\x9e\xbb\xf8\xe1\x82\x03\xa9\xb2\xb7\xbf\xe4" +
+	"0\x1e\xbe\xb7\xef\xf6+\x17n\xff=\xbd\xb0\x1f\x0ez" +
+	"\xf9\xbc\xebg\x1d\xa4\xea\xde\xfdq\xf8\xe6\xf5\xf1\xcf\xab" +
+	"a\x1dQ\x0e\x9c\xc0\xcb\x99\xcc\xf5\x17\xb2\x14\x8c\x87{" +
+	"V\xbbG\x9e}5\xfb\x13\x80MT\x8ceE\x0dY" +
+	"\xd7.\x17'\xc9\x0f\xfa\xbc\x01\xdf\xcd\xdb[\x9d\xdaO" +
+	"\xb5\x1a?\xb5\xc3(n\x93\xd4Ia\x92\xdc_X\x1a" +
+	"L\xd6\xad\xec\xa9yi\x06<\xd4\x18p\xbe\xabY\x96" +
+	"F(/l\x04\xf49I=k|\x14\xb5R~i" +
+	"\xf23-\xa9/\x1b\x1f;k\xa5\xbc\xf8\"\xa0g%" +
+	"\xf5\xd7\x82\\\xe8\xe4U\x13\xaa\xcb\x92\xfagA\x15\xe9" +
+	"\xa8\x95\xf2\xda#\x80\xfeAR\xdf\x10d\x94-\xcf\x9c" +
+	"\xba\x9e\x86Hy\xe5\xbcS\xae7\xac'(\xe5v\xbb" +
+	"\xa5\xfa\xe7\xc1\x9c[\x0aZ\xbeC\xaf?\xb3\xb5\xcf\x1b" +
+	"u\x010\x0a\xc1h\xad\x94-5i<\xfa\xb5\x9a\xc4" +
+	"\x86\x83J\xbd\xe2\xb6;\x12\x94\x97\xb8\x93\x95\xcfo\xf9" +
+	"\xcf\xfab\xeeW\xbf\xec\xbf\x03\x00i3\x9e\x97"
 
 func init() {
 	schemas.Register(schema_fb8053d9fb34b837,
@@ -26,10 +26,12 @@ const (
 	SCIONDMsg_Which_revReply           SCIONDMsg_Which = 10
 	SCIONDMsg_Which_segTypeHopReq      SCIONDMsg_Which = 11
 	SCIONDMsg_Which_segTypeHopReply    SCIONDMsg_Which = 12
+	SCIONDMsg_Which_pathSubscribeReq   SCIONDMsg_Which = 13
+	SCIONDMsg_Which_pathUpdate         SCIONDMsg_Which = 14
 )
 
 func (w SCIONDMsg_Which) String() string {
-	const s = "unsetpathReqpathReplyasInfoReqasInfoReplyrevNotificationifInfoRequestifInfoReplyserviceInfoRequestserviceInfoReplyrevReplysegTypeHopReqsegTypeHopReply"
+	const s = "unsetpathReqpathReplyasInfoReqasInfoReplyrevNotificationifInfoRequestifInfoReplyserviceInfoRequestserviceInfoReplyrevReplysegTypeHopReqsegTypeHopReplypathSubscribeReqpathUpdate"
 	switch w {
 	case SCIONDMsg_Which_unset:
 		return s[0:5]
@@ -57,6 +59,10 @@ func (w SCIONDMsg_Which) String() string {
 		return s[122:135]
 	case SCIONDMsg_Which_segTypeHopReply:
 		return s[135:150]
+	case SCIONDMsg_Which_pathSubscribeReq:
+		return s[150:166]
+	case SCIONDMsg_Which_pathUpdate:
+		return s[166:176]
 
 	}
 	return "SCIONDMsg_Which(" + strconv.FormatUint(uint64(w), 10) + ")"
@@ -497,6 +503,72 @@ func (s SCIONDMsg) NewSegTypeHopReply() (SegTypeHopReply, error) {
 	return ss, err
 }
 
+func (s SCIONDMsg) PathSubscribeReq() (PathReq, error) {
+	if s.Struct.Uint16(8) != 13 {
+		panic("Which() != pathSubscribeReq")
+	}
+	p, err := s.Struct.Ptr(0)
+	return PathReq{Struct: p.Struct()}, err
+}
+
+func (s SCIONDMsg) HasPathSubscribeReq() bool {
+	if s.Struct.Uint16(8) != 13 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SCIONDMsg) SetPathSubscribeReq(v PathReq) error {
+	s.Struct.SetUint16(8, 13)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewPathSubscribeReq sets the pathSubscribeReq field to a newly
+// allocated PathReq struct, preferring placement in s's segment.
+func (s SCIONDMsg) NewPathSubscribeReq() (PathReq, error) {
+	s.Struct.SetUint16(8, 13)
+	ss, err := NewPathReq(s.Struct.Segment())
+	if err != nil {
+		return PathReq{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SCIONDMsg) PathUpdate() (PathUpdate, error) {
+	if s.Struct.Uint16(8) != 14 {
+		panic("Which() != pathUpdate")
+	}
+	p, err := s.Struct.Ptr(0)
+	return PathUpdate{Struct: p.Struct()}, err
+}
+
+func (s SCIONDMsg) HasPathUpdate() bool {
+	if s.Struct.Uint16(8) != 14 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SCIONDMsg) SetPathUpdate(v PathUpdate) error {
+	s.Struct.SetUint16(8, 14)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewPathUpdate sets the pathUpdate field to a newly
+// allocated PathUpdate struct, preferring placement in s's segment.
+func (s SCIONDMsg) NewPathUpdate() (PathUpdate, error) {
+	s.Struct.SetUint16(8, 14)
+	ss, err := NewPathUpdate(s.Struct.Segment())
+	if err != nil {
+		return PathUpdate{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
 // SCIONDMsg_List is a list of SCIONDMsg.
 type SCIONDMsg_List struct{ capnp.List }
 
@@ -571,6 +643,14 @@ func (p SCIONDMsg_Promise) SegTypeHopReply() SegTypeHopReply_Promise {
 	return SegTypeHopReply_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
 }
 
+func (p SCIONDMsg_Promise) PathSubscribeReq() PathReq_Promise {
+	return PathReq_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p SCIONDMsg_Promise) PathUpdate() PathUpdate_Promise {
+	return PathUpdate_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
 type PathReq struct{ capnp.Struct }
 type PathReq_flags PathReq
 
@@ -578,12 +658,12 @@ type PathReq_flags PathReq
 const PathReq_TypeID = 0xc4c61531dcc4a3eb
 
 func NewPathReq(s *capnp.Segment) (PathReq, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 1})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 4})
 	return PathReq{st}, err
 }
 
 func NewRootPathReq(s *capnp.Segment) (PathReq, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 1})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 4})
 	return PathReq{st}, err
 }
 
@@ -664,12 +744,76 @@ func (s PathReq) NewHpCfgs(n int32) (HPGroupId_List, error) {
 	return l, err
 }
 
+func (s PathReq) Policy() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return []byte(p.Data()), err
+}
+
+func (s PathReq) HasPolicy() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s PathReq) SetPolicy(v []byte) error {
+	return s.Struct.SetData(1, v)
+}
+
+func (s PathReq) RequiredIntfs() (PathInterface_List, error) {
+	p, err := s.Struct.Ptr(2)
+	return PathInterface_List{List: p.List()}, err
+}
+
+func (s PathReq) HasRequiredIntfs() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s PathReq) SetRequiredIntfs(v PathInterface_List) error {
+	return s.Struct.SetPtr(2, v.List.ToPtr())
+}
+
+// NewRequiredIntfs sets the requiredIntfs field to a newly
+// allocated PathInterface_List, preferring placement in s's segment.
+func (s PathReq) NewRequiredIntfs(n int32) (PathInterface_List, error) {
+	l, err := NewPathInterface_List(s.Struct.Segment(), n)
+	if err != nil {
+		return PathInterface_List{}, err
+	}
+	err = s.Struct.SetPtr(2, l.List.ToPtr())
+	return l, err
+}
+
+func (s PathReq) ForbiddenASes() (capnp.UInt64List, error) {
+	p, err := s.Struct.Ptr(3)
+	return capnp.UInt64List{List: p.List()}, err
+}
+
+func (s PathReq) HasForbiddenASes() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s PathReq) SetForbiddenASes(v capnp.UInt64List) error {
+	return s.Struct.SetPtr(3, v.List.ToPtr())
+}
+
+// NewForbiddenASes sets the forbiddenASes field to a newly
+// allocated capnp.UInt64List, preferring placement in s's segment.
+func (s PathReq) NewForbiddenASes(n int32) (capnp.UInt64List, error) {
+	l, err := capnp.NewUInt64List(s.Struct.Segment(), n)
+	if err != nil {
+		return capnp.UInt64List{}, err
+	}
+	err = s.Struct.SetPtr(3, l.List.ToPtr())
+	return l, err
+}
+
 // PathReq_List is a list of PathReq.
 type PathReq_List struct{ capnp.List }
 
 // NewPathReq creates a new list of PathReq.
 func NewPathReq_List(s *capnp.Segment, sz int32) (PathReq_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 1}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 4}, sz)
 	return PathReq_List{l}, err
 }
 
@@ -784,18 +928,171 @@ func (p PathReply_Promise) Struct() (PathReply, error) {
 	return PathReply{s}, err
 }
 
+type PathUpdate struct{ capnp.Struct }
+
+// PathUpdate_TypeID is the unique identifier for the type PathUpdate.
+const PathUpdate_TypeID = 0xca62c33457da1785
+
+func NewPathUpdate(s *capnp.Segment) (PathUpdate, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return PathUpdate{st}, err
+}
+
+func NewRootPathUpdate(s *capnp.Segment) (PathUpdate, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return PathUpdate{st}, err
+}
+
+func ReadRootPathUpdate(msg *capnp.Message) (PathUpdate, error) {
+	root, err := msg.RootPtr()
+	return PathUpdate{root.Struct()}, err
+}
+
+func (s PathUpdate) String() string {
+	str, _ := text.Marshal(0xca62c33457da1785, s.Struct)
+	return str
+}
+
+func (s PathUpdate) Reason() PathUpdate_Reason {
+	return PathUpdate_Reason(s.Struct.Uint16(0))
+}
+
+func (s PathUpdate) SetReason(v PathUpdate_Reason) {
+	s.Struct.SetUint16(0, uint16(v))
+}
+
+func (s PathUpdate) Reply() (PathReply, error) {
+	p, err := s.Struct.Ptr(0)
+	return PathReply{Struct: p.Struct()}, err
+}
+
+func (s PathUpdate) HasReply() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s PathUpdate) SetReply(v PathReply) error {
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewReply sets the reply field to a newly
+// allocated PathReply struct, preferring placement in s's segment.
+func (s PathUpdate) NewReply() (PathReply, error) {
+	ss, err := NewPathReply(s.Struct.Segment())
+	if err != nil {
+		return PathReply{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+// PathUpdate_List is a list of PathUpdate.
+type PathUpdate_List struct{ capnp.List }
+
+// NewPathUpdate creates a new list of PathUpdate.
+func NewPathUpdate_List(s *capnp.Segment, sz int32) (PathUpdate_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return PathUpdate_List{l}, err
+}
+
+func (s PathUpdate_List) At(i int) PathUpdate { return PathUpdate{s.List.Struct(i)} }
+
+func (s PathUpdate_List) Set(i int, v PathUpdate) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s PathUpdate_List) String() string {
+	str, _ := text.MarshalList(0xca62c33457da1785, s.List)
+	return str
+}
+
+// PathUpdate_Promise is a wrapper for a PathUpdate promised by a client call.
+type PathUpdate_Promise struct{ *capnp.Pipeline }
+
+func (p PathUpdate_Promise) Struct() (PathUpdate, error) {
+	s, err := p.Pipeline.Struct()
+	return PathUpdate{s}, err
+}
+
+func (p PathUpdate_Promise) Reply() PathReply_Promise {
+	return PathReply_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+type PathUpdate_Reason uint16
+
+// PathUpdate_Reason_TypeID is the unique identifier for the type PathUpdate_Reason.
+const PathUpdate_Reason_TypeID = 0xe2d3f1cd65170662
+
+// Values of PathUpdate_Reason.
+const (
+	PathUpdate_Reason_initial  PathUpdate_Reason = 0
+	PathUpdate_Reason_newPaths PathUpdate_Reason = 1
+	PathUpdate_Reason_revoked  PathUpdate_Reason = 2
+	PathUpdate_Reason_expired  PathUpdate_Reason = 3
+)
+
+// String returns the enum's constant name.
+func (c PathUpdate_Reason) String() string {
+	switch c {
+	case PathUpdate_Reason_initial:
+		return "initial"
+	case PathUpdate_Reason_newPaths:
+		return "newPaths"
+	case PathUpdate_Reason_revoked:
+		return "revoked"
+	case PathUpdate_Reason_expired:
+		return "expired"
+
+	default:
+		return ""
+	}
+}
+
+// PathUpdate_ReasonFromString returns the enum value with a name,
+// or the zero value if there's no such value.
+func PathUpdate_ReasonFromString(c string) PathUpdate_Reason {
+	switch c {
+	case "initial":
+		return PathUpdate_Reason_initial
+	case "newPaths":
+		return PathUpdate_Reason_newPaths
+	case "revoked":
+		return PathUpdate_Reason_revoked
+	case "expired":
+		return PathUpdate_Reason_expired
+
+	default:
+		return 0
+	}
+}
+
+type PathUpdate_Reason_List struct{ capnp.List }
+
+func NewPathUpdate_Reason_List(s *capnp.Segment, sz int32) (PathUpdate_Reason_List, error) {
+	l, err := capnp.NewUInt16List(s, sz)
+	return PathUpdate_Reason_List{l.List}, err
+}
+
+func (l PathUpdate_Reason_List) At(i int) PathUpdate_Reason {
+	ul := capnp.UInt16List{List: l.List}
+	return PathUpdate_Reason(ul.At(i))
+}
+
+func (l PathUpdate_Reason_List) Set(i int, v PathUpdate_Reason) {
+	ul := capnp.UInt16List{List: l.List}
+	ul.Set(i, uint16(v))
+}
+
 type PathReplyEntry struct{ capnp.Struct }
 
 // PathReplyEntry_TypeID is the unique identifier for the type PathReplyEntry.
 const PathReplyEntry_TypeID = 0xc5ff2e54709776ec
 
 func NewPathReplyEntry(s *capnp.Segment) (PathReplyEntry, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
 	return PathReplyEntry{st}, err
 }
 
 func NewRootPathReplyEntry(s *capnp.Segment) (PathReplyEntry, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
 	return PathReplyEntry{st}, err
 }
 
@@ -859,12 +1156,37 @@ func (s PathReplyEntry) NewHostInfo() (HostInfo, error) {
 	return ss, err
 }
 
+func (s PathReplyEntry) Quality() (PathQuality, error) {
+	p, err := s.Struct.Ptr(2)
+	return PathQuality{Struct: p.Struct()}, err
+}
+
+func (s PathReplyEntry) HasQuality() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s PathReplyEntry) SetQuality(v PathQuality) error {
+	return s.Struct.SetPtr(2, v.Struct.ToPtr())
+}
+
+// NewQuality sets the quality field to a newly
+// allocated PathQuality struct, preferring placement in s's segment.
+func (s PathReplyEntry) NewQuality() (PathQuality, error) {
+	ss, err := NewPathQuality(s.Struct.Segment())
+	if err != nil {
+		return PathQuality{}, err
+	}
+	err = s.Struct.SetPtr(2, ss.Struct.ToPtr())
+	return ss, err
+}
+
 // PathReplyEntry_List is a list of PathReplyEntry.
 type PathReplyEntry_List struct{ capnp.List }
 
 // NewPathReplyEntry creates a new list of PathReplyEntry.
 func NewPathReplyEntry_List(s *capnp.Segment, sz int32) (PathReplyEntry_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3}, sz)
 	return PathReplyEntry_List{l}, err
 }
 
@@ -893,6 +1215,101 @@ func (p PathReplyEntry_Promise) HostInfo() HostInfo_Promise {
 	return HostInfo_Promise{Pipeline: p.Pipeline.GetPipeline(1)}
 }
 
+func (p PathReplyEntry_Promise) Quality() PathQuality_Promise {
+	return PathQuality_Promise{Pipeline: p.Pipeline.GetPipeline(2)}
+}
+
+type PathQuality struct{ capnp.Struct }
+
+// PathQuality_TypeID is the unique identifier for the type PathQuality.
+const PathQuality_TypeID = 0xd1ad24b613ac14cf
+
+func NewPathQuality(s *capnp.Segment) (PathQuality, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 0})
+	return PathQuality{st}, err
+}
+
+func NewRootPathQuality(s *capnp.Segment) (PathQuality, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 0})
+	return PathQuality{st}, err
+}
+
+func ReadRootPathQuality(msg *capnp.Message) (PathQuality, error) {
+	root, err := msg.RootPtr()
+	return PathQuality{root.Struct()}, err
+}
+
+func (s PathQuality) String() string {
+	str, _ := text.Marshal(0xd1ad24b613ac14cf, s.Struct)
+	return str
+}
+
+func (s PathQuality) Rtt() uint32 {
+	return s.Struct.Uint32(0)
+}
+
+func (s PathQuality) SetRtt(v uint32) {
+	s.Struct.SetUint32(0, v)
+}
+
+func (s PathQuality) RttVar() uint32 {
+	return s.Struct.Uint32(4)
+}
+
+func (s PathQuality) SetRttVar(v uint32) {
+	s.Struct.SetUint32(4, v)
+}
+
+func (s PathQuality) Sent() uint32 {
+	return s.Struct.Uint32(8)
+}
+
+func (s PathQuality) SetSent(v uint32) {
+	s.Struct.SetUint32(8, v)
+}
+
+func (s PathQuality) Lost() uint32 {
+	return s.Struct.Uint32(12)
+}
+
+func (s PathQuality) SetLost(v uint32) {
+	s.Struct.SetUint32(12, v)
+}
+
+func (s PathQuality) LastProbe() uint32 {
+	return s.Struct.Uint32(16)
+}
+
+func (s PathQuality) SetLastProbe(v uint32) {
+	s.Struct.SetUint32(16, v)
+}
+
+// PathQuality_List is a list of PathQuality.
+type PathQuality_List struct{ capnp.List }
+
+// NewPathQuality creates a new list of PathQuality.
+func NewPathQuality_List(s *capnp.Segment, sz int32) (PathQuality_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 0}, sz)
+	return PathQuality_List{l}, err
+}
+
+func (s PathQuality_List) At(i int) PathQuality { return PathQuality{s.List.Struct(i)} }
+
+func (s PathQuality_List) Set(i int, v PathQuality) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s PathQuality_List) String() string {
+	str, _ := text.MarshalList(0xd1ad24b613ac14cf, s.List)
+	return str
+}
+
+// PathQuality_Promise is a wrapper for a PathQuality promised by a client call.
+type PathQuality_Promise struct{ *capnp.Pipeline }
+
+func (p PathQuality_Promise) Struct() (PathQuality, error) {
+	s, err := p.Pipeline.Struct()
+	return PathQuality{s}, err
+}
+
 type HostInfo struct{ capnp.Struct }
 type HostInfo_addrs HostInfo
 
@@ -999,12 +1416,12 @@ type FwdPathMeta struct{ capnp.Struct }
 const FwdPathMeta_TypeID = 0x8adfcabe5ff9daf4
 
 func NewFwdPathMeta(s *capnp.Segment) (FwdPathMeta, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
 	return FwdPathMeta{st}, err
 }
 
 func NewRootFwdPathMeta(s *capnp.Segment) (FwdPathMeta, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
 	return FwdPathMeta{st}, err
 }
 
@@ -1073,12 +1490,37 @@ func (s FwdPathMeta) SetExpTime(v uint32) {
 	s.Struct.SetUint32(4, v)
 }
 
+func (s FwdPathMeta) Metadata() (PathMetadata, error) {
+	p, err := s.Struct.Ptr(2)
+	return PathMetadata{Struct: p.Struct()}, err
+}
+
+func (s FwdPathMeta) HasMetadata() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s FwdPathMeta) SetMetadata(v PathMetadata) error {
+	return s.Struct.SetPtr(2, v.Struct.ToPtr())
+}
+
+// NewMetadata sets the metadata field to a newly
+// allocated PathMetadata struct, preferring placement in s's segment.
+func (s FwdPathMeta) NewMetadata() (PathMetadata, error) {
+	ss, err := NewPathMetadata(s.Struct.Segment())
+	if err != nil {
+		return PathMetadata{}, err
+	}
+	err = s.Struct.SetPtr(2, ss.Struct.ToPtr())
+	return ss, err
+}
+
 // FwdPathMeta_List is a list of FwdPathMeta.
 type FwdPathMeta_List struct{ capnp.List }
 
 // NewFwdPathMeta creates a new list of FwdPathMeta.
 func NewFwdPathMeta_List(s *capnp.Segment, sz int32) (FwdPathMeta_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3}, sz)
 	return FwdPathMeta_List{l}, err
 }
 
@@ -1099,6 +1541,161 @@ func (p FwdPathMeta_Promise) Struct() (FwdPathMeta, error) {
 	return FwdPathMeta{s}, err
 }
 
+func (p FwdPathMeta_Promise) Metadata() PathMetadata_Promise {
+	return PathMetadata_Promise{Pipeline: p.Pipeline.GetPipeline(2)}
+}
+
+type PathMetadata struct{ capnp.Struct }
+
+// PathMetadata_TypeID is the unique identifier for the type PathMetadata.
+const PathMetadata_TypeID = 0xa5cff7314a4335e5
+
+func NewPathMetadata(s *capnp.Segment) (PathMetadata, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return PathMetadata{st}, err
+}
+
+func NewRootPathMetadata(s *capnp.Segment) (PathMetadata, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return PathMetadata{st}, err
+}
+
+func ReadRootPathMetadata(msg *capnp.Message) (PathMetadata, error) {
+	root, err := msg.RootPtr()
+	return PathMetadata{root.Struct()}, err
+}
+
+func (s PathMetadata) String() string {
+	str, _ := text.Marshal(0xa5cff7314a4335e5, s.Struct)
+	return str
+}
+
+func (s PathMetadata) Latencies() (capnp.UInt32List, error) {
+	p, err := s.Struct.Ptr(0)
+	return capnp.UInt32List{List: p.List()}, err
+}
+
+func (s PathMetadata) HasLatencies() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s PathMetadata) SetLatencies(v capnp.UInt32List) error {
+	return s.Struct.SetPtr(0, v.List.ToPtr())
+}
+
+// NewLatencies sets the latencies field to a newly
+// allocated capnp.UInt32List, preferring placement in s's segment.
+func (s PathMetadata) NewLatencies(n int32) (capnp.UInt32List, error) {
+	l, err := capnp.NewUInt32List(s.Struct.Segment(), n)
+	if err != nil {
+		return capnp.UInt32List{}, err
+	}
+	err = s.Struct.SetPtr(0, l.List.ToPtr())
+	return l, err
+}
+
+func (s PathMetadata) Bandwidths() (capnp.UInt64List, error) {
+	p, err := s.Struct.Ptr(1)
+	return capnp.UInt64List{List: p.List()}, err
+}
+
+func (s PathMetadata) HasBandwidths() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s PathMetadata) SetBandwidths(v capnp.UInt64List) error {
+	return s.Struct.SetPtr(1, v.List.ToPtr())
+}
+
+// NewBandwidths sets the bandwidths field to a newly
+// allocated capnp.UInt64List, preferring placement in s's segment.
+func (s PathMetadata) NewBandwidths(n int32) (capnp.UInt64List, error) {
+	l, err := capnp.NewUInt64List(s.Struct.Segment(), n)
+	if err != nil {
+		return capnp.UInt64List{}, err
+	}
+	err = s.Struct.SetPtr(1, l.List.ToPtr())
+	return l, err
+}
+
+func (s PathMetadata) LinkTypes() (StaticInfoExtn_LinkType_List, error) {
+	p, err := s.Struct.Ptr(2)
+	return StaticInfoExtn_LinkType_List{List: p.List()}, err
+}
+
+func (s PathMetadata) HasLinkTypes() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s PathMetadata) SetLinkTypes(v StaticInfoExtn_LinkType_List) error {
+	return s.Struct.SetPtr(2, v.List.ToPtr())
+}
+
+// NewLinkTypes sets the linkTypes field to a newly
+// allocated StaticInfoExtn_LinkType_List, preferring placement in s's segment.
+func (s PathMetadata) NewLinkTypes(n int32) (StaticInfoExtn_LinkType_List, error) {
+	l, err := NewStaticInfoExtn_LinkType_List(s.Struct.Segment(), n)
+	if err != nil {
+		return StaticInfoExtn_LinkType_List{}, err
+	}
+	err = s.Struct.SetPtr(2, l.List.ToPtr())
+	return l, err
+}
+
+func (s PathMetadata) Geo() (StaticInfoExtn_GeoInfo_List, error) {
+	p, err := s.Struct.Ptr(3)
+	return StaticInfoExtn_GeoInfo_List{List: p.List()}, err
+}
+
+func (s PathMetadata) HasGeo() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s PathMetadata) SetGeo(v StaticInfoExtn_GeoInfo_List) error {
+	return s.Struct.SetPtr(3, v.List.ToPtr())
+}
+
+// NewGeo sets the geo field to a newly
+// allocated StaticInfoExtn_GeoInfo_List, preferring placement in s's segment.
+func (s PathMetadata) NewGeo(n int32) (StaticInfoExtn_GeoInfo_List, error) {
+	l, err := NewStaticInfoExtn_GeoInfo_List(s.Struct.Segment(), n)
+	if err != nil {
+		return StaticInfoExtn_GeoInfo_List{}, err
+	}
+	err = s.Struct.SetPtr(3, l.List.ToPtr())
+	return l, err
+}
+
+// PathMetadata_List is a list of PathMetadata.
+type PathMetadata_List struct{ capnp.List }
+
+// NewPathMetadata creates a new list of PathMetadata.
+func NewPathMetadata_List(s *capnp.Segment, sz int32) (PathMetadata_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4}, sz)
+	return PathMetadata_List{l}, err
+}
+
+func (s PathMetadata_List) At(i int) PathMetadata { return PathMetadata{s.List.Struct(i)} }
+
+func (s PathMetadata_List) Set(i int, v PathMetadata) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s PathMetadata_List) String() string {
+	str, _ := text.MarshalList(0xa5cff7314a4335e5, s.List)
+	return str
+}
+
+// PathMetadata_Promise is a wrapper for a PathMetadata promised by a client call.
+type PathMetadata_Promise struct{ *capnp.Pipeline }
+
+func (p PathMetadata_Promise) Struct() (PathMetadata, error) {
+	s, err := p.Pipeline.Struct()
+	return PathMetadata{s}, err
+}
+
 type PathInterface struct{ capnp.Struct }
 
 // PathInterface_TypeID is the unique identifier for the type PathInterface.
@@ -2248,129 +2845,177 @@ func (p SegTypeHopReplyEntry_Promise) Struct() (SegTypeHopReplyEntry, error) {
 	return SegTypeHopReplyEntry{s}, err
 }
 
-const schema_8f4bd412642c9517 = "x\xda\x94W}l[\xd5\x15?\xe7^\xbb\xb6\x13;" +
-	"\xb6\xfb\x1c\xa82m\x19UQ\x9b\x8aT$\xd0\xad\xaa" +
-	"4\xd2\xa6M\x88\xd9\x02yv6\x09\x04\x1a&~N" +
-	"<9\xb6\xfb\xdeK\x1a#\xba\xd0)\xd9X\x07\xa2\x08" +
-	"\xaa}\xb4h|\xac]\xb3\x81F\xbb\x16A\xa7!!" +
-	"\x9aM\x8b`\x03\x89\x89-*\x03:J\x9b\xb2iK" +
-	"iW\xd2\xad{\xd3y\xdf}}),\x7f]\xe7w" +
-	"|\xee\xf9\xfa\xfd\xce\xf5\xf5<\xb8\x81\xb5\x05\xff\x11\x02" +
-	"\x10\xef\x0c.\xd1>z\xee\xd9}\x1f\x9e\xbd\xf7;\x90" +
-	"\x8c\xa1v\xf5\xae\xeb\xf2K\xdf\xfc\xf2\xc3\x10\xc4\x10\x80" +
-	"\xf06\x9f\x15\xe68\x9dN\xf0\x0e@\xed\xec\xec\xc2\xd7" +
-	"_\x9ayg\x07\x881t\x1b32i\x0c\xcc\x08\xd7" +
-	"\x04\xe8\xf4\xd9\xc0I@\xad)\xb9\xa7\xfb}y\xfb\xc3" +
-	"\x1ec\xdd\"\x18<(\xc4\x82t\x8a\x04\xc9q\xf7+" +
-	"\xdd\xe3\x87v\x9f~\x84l\x99c\xdb\x85\xa1\x18\x06\x84" +
-	"\x96\xe0\x11\xa1\x8d\xacoh\x0d^\xcd\x01\xb5\xc7O\xa5" +
-	"\x8e\xafZ\xf6\xcd\xc7\xfcb~(<#\xfc0L\xa7" +
-	"]ar\xfd\xe4\xb6\xfa\xfdk7\xd4vy\\\xeba" +
-	"L\x87g\x857t\xdb\xd7\xc2[\x01\xb5\xb9\xcew&" +
-	"\x7f:\xb9d\xb7\x9f\xdf\x96\xc8iam\x84Nm\x11" +
-	"\xf2;\xfb\xe7\x1d\xa7\xde\x0b\xfe~7\x88\x8d\xc8\xb5\x0f" +
-	"\x9f>z\xac\xad\xf17G\xa1\x11C\x08 |52" +
-	"\x0b(\xdc\x1e!\xafK\xdb\x9eh\xbb3|\xdb\x94\x8f" +
-	"\xd7\x1b\x0eG\x18\x0a/\xebn\x7f\xad\xbb=4?%" +
-	"\xde\xb1\xec\xe3g\xbc%\xd6\xad\xe7#KQ\xc0:\xb2" +
-	"\xbe\x18\xf9\x05\xa0\xf6\xf9k\x1f\xdd\x1a\\\xd9t\xd0\xb7" +
-	"!\xcf\xd6\x1d\x14\x0e\xeb\xc6\x07\xea(\x8eSg\xae\x1a" +
-	"=\xf1\xf7\x0d\xaf\xf8e7_wZ\xb8\xa8\xdb.\xd4" +
-	"Q\x18v>b\x0c\xf9e\xa5\xa8\xff\x99\xd0V\xaf\xf7" +
-	"\xa3\xbe\x19\x01\xb5\xbf\x8d~\xbf\xda\xbfF\x9b\xf6x\xd6" +
-	"\xa3X\x1b=.l\x8c\xd2\xe9KQ\x8a\".\xfda" +
-	"c\xe7\xc4\xe7f\xfc\xc6\xe2\xf1\xe8\xac0\xa5\xdb\xee\x8d" +
-	"R\x14{?X\xb1g\xffS\xd2\xab~\xb6\xd3\xd1#" +
-	"\xc2k\xba\xed\xeft\xdbc\xef\xfdj\xdfw\x1f]y" +
-	"\xd2\xb7ps\xd1&\x14\x16t\xebsQ*\\\xe9\xdd" +
-	"\xcc\xd7\x9a\xde8\x7f\xd2\xaf\x16{c3\xc2\x81\x98^" +
-	"\xc1\x18y^\xb7\xf2\xado\x0f6N\xff\xd3\xcf\xb3\xf0" +
-	"v\xec\x8c0\xa7\x1b\x9f\x88Qz\x1d\x1f\xdc\xd4\xf2\xfc" +
-	"\\|\xde\xd7\xb8\xab\xe1\x88\xd0\xdb@\xa7t\x03\x19\xbf" +
-	"\xf8\xd2\xd8\xd4\xf7\xde\xdaw\xde/\x8a\x17\x1b\xce\x08\xd3" +
-	"\xba\xed\xcb\x0d\x14E\xb4\xe9/?\x1f\xbc\xf6\xc4\x02\x88" +
-	"W\xa1\xab\xf1\x8dL\x9f\xb7\xf9\x86\xe3\x80\xc29\xdd\xeb" +
-	"/\x9f\xbf\xf7\xe6CO\x1f\xb8\xe07\xf1b\xfc\x8cp" +
-	"W\x9cN\xb7\xc7\xa9\x0e\xca@\xb1R\xce\xaf\x19`\xb9" +
-	"j\xb9\xba>\xdd\x9d.\x17*\x19i\xcb\x88\xc4\x15\xb5" +
-	"\x0fQ\x0c\xf0\x00@\x00\x01\x92\xb1v\x001\xccQ\\" +
-	"\xc1\xb0\xb9XHoV\xb0\x01\xb0\x8f#F\x80\xd1\xd1" +
-	"\xe3\xab{k\xbe/\xa7\x0e\xf5Jj\x0e\x80\\%l" +
-	"W\xb9N\xd2\x1e\x8e\xe2\x10C\xc4\x14\xd2\xff\xa4\xe5\x00" +
-	"\xe2\xdd\x1c\xc5\x12\xc3$\xc3\x142\x80d\xf1\x0e\x00q" +
-	"\x88\xa38\xc10\xc91\x85\x1c \xb9\x9d\xbe}\x1fG" +
-	"\xf1\x01\x86\xe3\x05\xe3\x16\x8c\x01\xc3\x18`hX\x1d\xc1" +
-	"\x100\x0c\x01j\xc5\xb2*\xc9\x85\xdc\x00p\xc9\x8e5" +
-	"\xe1\xe8\x01 \xfds\\\x1a\xab\xf6\x17\x87%\x0c\x03\xc3" +
-	"\xb0+\x0b\xd4\xb3\xc8H\xa3\xcd\x19\xa9Z\xaay\x8a\xb1" +
-	"\xde,F\x8aa\x87,)#%\xd5\xbe\xf6R\x07\xd9" +
-	"M\xe9\x8e\xdbn\xdd\xdc\xab\x0c\x92\x87u\x96\x07\xa1\x86" +
-	"M\x00Y\x159f\xefG\x861\xd44\xbd\x10\xc26" +
-	"l\x07\xc8\x8e\x110A\x00\xfb\xaf\xa6\x17C\xd8\x8e\x9d" +
-	"\x00\xd9\xfb\x08x\x80\x00~Q\xd3\x0b\"Lb\x06 " +
-	";A\xc0N\x02\x02\xff\xd1R\x18 )\xd4\x81\x07\x09" +
-	"\xf8\x01\x01\xc1\x7fk)\x0c\x922\xe2=\x00\xd9\xc7\x08" +
-	"x\x82\x80%\x17\xb4\x14.!\x02\xe2\xb7\x00\xb2{\x08" +
-	"\xd8O@hAK\x19\x9c@\x19 \xfb\x13\x02\x9e#" +
-	" \xfc\xb1\x96\xc20QDw\xf5\x0c\x01/\x10\x109" +
-	"\xaf\xa50\x02 \x1c\xc6\x1f\x01d_ \xe0(\x01u" +
-	"\xff\xd2RXG\xe3\x8c;\x00\xb2G\x09x\x9d\x80\xfa" +
-	"sZ\x0a\xebI\x83\xf1\x16\x80\xec\xab\x04\xfc\x89\x80\xe8" +
-	"Y-\x85Q\x00\xe1\x8f\xfa\xe5o\x12\xf0.\x01\xb1\x8f" +
-	"\xb4\x14\xc6\x88|z\xb8\xc7\x088\x85\x0cy1\xaf\x0f" +
-	"c\x04\xb0y\xa4\xacH*,\x19\xaf\xe6\xd4\xa1\x8c\xb4" +
-	"\x05\x13\x8e\xa4\x01b\x02P3\x90j\x09\xb0\x86\x09\x87" +
-	"\xb7&\x9aS\x0c*\x00\xd2wm!\xf2\xa2\xa1j\x89" +
-	"\xbem/$\x13\x97\xa5\xd1[+j\xb1\x80\xc5\x81\x9c" +
-	"Z\xac\x94\x01\x13\xcer1m\x8a\x05\xd3G\xf3\x96\x11" +
-	"IQ1\xe1\xacb\xaf\x85y\x8b-Z&\xaeH\xf2" +
-	"hq@J\xa3\x8b\xb4\x98p\xf6\x8d\xafY\xb5T\x03" +
-	"\x0a\xc7\xd6\x1e'd\x13$\xd4^\xde\xb6\x8f\xc1\xfeZ" +
-	"U\xea\x81\xe6J\xd5(\xa7\xad\xe3\x1e\x0b$\x03\xf2\x83" +
-	"\x09g\xe3X6\x97(\xc4\xc6l\xda\x89\xc8\xc3\xafN" +
-	"Gl\xc6\xa5\xb2*\x17\xdd\x14\xb6\x05\xce\xa0\xb0\xc7-" +
-	"\xe9A\xda\xa0>\x1f\x90\xc8o\xd8\xf6\xdbB\"\xb6\x82" +
-	"\xa3x=\xc3\xa4%=\xad\xab\x01\xc4U\x1c\xc5\x1bI" +
-	"\xd9\x94|N\xb1\xa6(N:g}\xf0\\\x931[" +
-	"\\\x1c\xc8\xc5\xa9\xc5\x9e\x04n\x01\x10\xa3\x1c\xc5e\x0c" +
-	"5%#\x8dR\xaaFi3\x7f\xbd\xf0\xc5\xc9\x9b\xdb" +
-	"\x7f\xec_\x94>c^\xd7\x14J9>\xa8P\xe8\x89" +
-	"\x9d\x86\xee\xb5t\xbac\x7fD\xa7x\xb2u\xbd\x13\xfb" +
-	"\xb8,\x15dI\x19B\x04\x86\x08\xd81T\xcc\xe7\xa5" +
-	"\xb2\xf5\xd1\xbe\x88\x1b\xc2dN\x855;\x8a\xea\xed\xc1" +
-	"7\xcc\x14V1{\x86\xfa!^\xab:\xad\x88k\xea" +
-	"\xe0\xeb\x9fii\xcd\x1c\xf7\xb6\xc2\xba\xc3\x98\x09s$" +
-	"\xba\xca\xaa\x8c\xba\x92F\xed[\xbaH\xe27s\x14\xef" +
-	"vv\xc1]\x19g?\xd8\xbb@\xeat\x16\xc4\xa7\x93" +
-	"vM-\x0eK\x8a\x9a\x1b\x06\xacZ\xf2\xfe\x09r\xdf" +
-	"SQ\x9aU*\x89glV;\xa5\xa7?g\x19'" +
-	"[\xdb\x81\xc5\xab\x15\xd9\xd6\xff\xe6\\>/+\x9e\xae" +
-	"\xba\x0a\x11\xf7\xd9&W\x9cv\xfbE\xe8)1Z\xf3" +
-	"\x12\xa7\x81!\x8f)\xdb\xe36\xda\xa6c\xe6\xe2\xb4\xca" +
-	"\xba}\xb9\xb38\x93,l\x94u\x92\x06u\x82\xa3\xb8" +
-	"\x93!rt\xbdk\x93\x0f\xb5\x03\xc3\x80\xbe1\x92#" +
-	"4gU\x8e\xe2\x83\x0cCyE\xb5H\x11R\xe4\x01" +
-	"\x9b \xc3\xb91\x1a_\x85\xe6\xdc\xaaF\xa1\x94\x1bT" +
-	":\x86\xaa\x9b\x0a\x83\xae\x9c\x96u\xbd\x7f\x93\xf0\xdbk" +
-	"\x8e,\xce`s`B\xaa\\[\xbc\x17\x0e\x85)\x8b" +
-	"\xeb8\x8a\xeb\x18\xc6I\xd81\xe1\xfcX1y6T" +
-	"QT\x87\x85\xf6\x03\xca\x97\x85\xae~q\xa3\xb6\xaen" +
-	"\xadvv\x7f\\\xadU%\x8ck\xf7\xaf{\xaaN\x9a" +
-	":\xff$y\x8b_\xd6\xa3\x8d\xd9t\x87A\xb3E\x9e" +
-	"T)\xaf\xf0\\\x89\xab\x06\x91\xb8\xec%\xd2=&\x91" +
-	"\xfa\\u\xe9\xa5\x96\xf7p\x14\xfb\x19\"3:.\x12" +
-	"\xbb\xfaL\"\xd9\xbc\x0e\x19\x89\xb8\xf9\x1c\x07\x0c\xa9j" +
-	"\xc9\xa6\x8a]@tu\xd2]\xc7\x86E\x1f\x94\xff\xb7" +
-	"\xc4\xdbo\xeeOr\xdbL\xa2R\xbb\x12]}&\xe4" +
-	"\x12Y\xfftsa\xb3\xadc\xc8~\x0f\xban\xcc8" +
-	"2l\xdd\xd8\xd6i\xde\xd8\xc3P\x93d\xb9\"o\xaa" +
-	"\xe4\x01%\x8b\x1a\x97'm\xff\x8c\xf2M\xda5\x04\xbe" +
-	"O\xd2+\xd6\xd3\xfeu\xe4\xeb\xba\xc7,\xc1\x9a\\>" +
-	"\x94\x97\x15#1#\x8b\xcb\xe8\xc6<\x1b3^\xac\x8e" +
-	"\xdeh\xbd\xbf\xe9\xc3\x17\xac\x0f\x8b\xaf{\xa7i\xae\xf9" +
-	"%2l\xe0(~\x85.\x0a\x18\xb7\xa7\x97\xbb\x86\x9a" +
-	"\xf5\x19\xb7\xf7\xaew\x86\xfaR\xda\xb8\x9f\xff\x1dEe" +
-	"SE\x96\xac\xed\xf7\xbf\x00\x00\x00\xff\xffvu8\x1b"
+const schema_8f4bd412642c9517 = "x\xda\x94X\x0fp\x14\xd5\x19\xff\xbe\xb7\x97l\x12\xee" +
+	"r\xb7\xbc\x8d\xfc\xe9\xd8\x08C\x06\xc2\x08#\xa0-f" +
+	":&\x04ABE\xef\xddQ;e\xb4\xf5\x92\xdb$" +
+	"[/w\x97\xddM Ni\xa4#\xb5\xa5\xb5\xea(" +
+	"S\xadd**VZ\x9c\xaa\xa5\x8eRu\xfc\x03\xb5" +
+	"f\xa4J\xab#d\xb0\x0a%\x0aZg\x04\xb5\x08\xd5" +
+	"n\xe7\xdb\xbd\xdb\xdd,\x07m\x99\xc9\xf0n\x7f\xdf~" +
+	"\xef{\xdf\xfb\xbe\xdf\xef\xbd\xbd\xe8\xc9\xea6\xb6\xa0\xea" +
+	"\xeaZ\x00\x91\xab\xaa\xb6?~\xf4\x91\x87>\xf8\xe4\xc6" +
+	"[@\x89\xa1=e\xf3\x85\xd9\xc9\xaf\x7f\xfd6\xa8B" +
+	"\x19\x80\xdf\x1a\x19\xe3\xf7Dh\xb49\xd2\x0ah\x7f2" +
+	"v\xea;\xcf\x8e\xbe\xbd\x09D\x0c\x83\xc6\x12\x99\xec\x89" +
+	"\x8c\xf2}d\xbcho\xa4\x11\x01\xed\xe9\xca\x96\xe5G" +
+	"\x8c\x0d\xb7\x85\xac\x1d\x7fX\xfd8\xaf\xad\xa6QU5" +
+	"y^\xfe\xe2\xf2\xe1\x9d\xf7\xbe\x7f\x07\xd92\xdfv\x19" +
+	"\xcaq\x8c\xf0\xa6\xea]|\x1eY/j\xae\xbeE\x02" +
+	"\xb4G\x8e\xaa\x87\xe7L\xfd\xfe]\x95\x82n\xaa\x1b\xe5" +
+	"\x0b\xeah4\xaf\x8e\\o]?\xe9\xe1K\xda\x866" +
+	"\x87\\;a\\W7\xc6u\xc7V\xab[\x0bh\x1f" +
+	"k\x7f{\xe3\xaf6V\xdf[\xc9\xef\xf3u\xef\xf3\xbd" +
+	"\x8e\xed\xcb\x8e\xdf\xb1\x03\x9b\x8e\x1e\xaa\xfa\xf3\xbd \x1a" +
+	"P\xb2?x`\xf7\xc1\x05\x0d\x7f\xdc\x0d\x0d\x11\x19\x01" +
+	"\xf8\x87uc\x80\xfc\xb8\xe3u\xfc\x92\xa5+\x17\x9c|" +
+	"u[\xc8\xab\x13\xc1\xaaI\x7f\xe1\xdf\x9a$\x97\xfe\xde" +
+	"\x03\xe0\x8fEe{\xf2\x82\xfb\x16\\[s\xf5\xf6\x0a" +
+	"q,\x1a\x892\xe4\xdb\xa3\xf4\xf2\xb6(\x05\xb2\xf3\xf8" +
+	"v\xb1f\xeag;\xc2\xbb\xe2X\x1f\x88NF~\xcc" +
+	"\xb1\x1e\x8f\xfe\x16\xd0\xbe\xa0\xe9\xce\xb5U\xb3\xa7?\x1e" +
+	"\xb6f\xce6\xc7\x1e\xe7#1\x1a\xdd\x13\xa3\xc8\x8f\x9e" +
+	"8op\xfc\xc3\xb6\x17+\xe5\xe3@\xec}>\xee\xd8" +
+	"\x1e\x8aQ\x18^\x06D\x0c\xa5\xf02\x95\xfa_\xf3i" +
+	"\xf5\x14PC\xfdK\x08h\xffc\xf0\xe7\xc5\xd5\xf3\xed" +
+	"=!\xcfN%\xc5\x12\x87\xf9\xb4\x04\x8d\x1a\x12\x14r" +
+	"\\{uI\xfb\xcd_\x1e\xadTHO%\xc6\xf8\x1e" +
+	"\xc7\xf6\xf9\x04E\xb1q\xca\xd87/~\xb1s\xb4R" +
+	"2\xf8\xa1\xc4\x0b\xfcXb\x0a\xff4!\xf3O\x13k" +
+	"\x01\xf8\x06E\xb6\xb7\xbd;k\xcb\xc3\xf7k\xafTr" +
+	"\xdf\xa7\xec\xe2\x03\x0a\x8d\xfa\x15r\xff\xaa\xba\x83?1" +
+	"\xeb\x91}\xa1E:\xb6w(\xa3|D\x91\xe9o\xd1" +
+	"\x88\xe2\xb4@g\xf5\x14m\xef\xf1\xbf\x1e\x06e*\xf3" +
+	"C\x03\xe4{&\x9f\xe6\xfb&\xcb\xa5\xbf\x1e\x00>\x83" +
+	"\xcb\xf6\xc1C\x7fx\xe8\xc7w\xce~\xaf\xe2N\xc6\xf8" +
+	"t\xe4\xe7s\x9ai\x1a\xa7\xb4\xe4\xdeI]3}\xdf" +
+	"\xc9\xf7*m\xce\xcb|\x94\xbf\xe1\xd8\xee\xe3\x14\xf7\xe2" +
+	"\xd9o\xfe\xb0\xa7a\xcfG\x15\xd3\x82\xea\x09\x1eSi" +
+	"T\xab\xd2\xae\xb7\xbe{Y\xf3\x13\xc7\xe2\xc7+\x1a\xeb" +
+	"\xea.\xde\xef\x18\xf79\xc6O=\xbbn\xfbO\xde|" +
+	"\xe8d\xa5(\xdeRO\xf0c\x8e\xed\xb8JQD\xa7" +
+	"\xff\xed7=M\xe3\xa7@\x9c\x87\x81Jl`N\xcb" +
+	"44\x1c\x06\xe4\xd3\x1a\xc8\xeb\xef\x9e\xb8\xf1\x8a\x9d\x0f" +
+	"<v\xbaR\xd3\x0e4\x9c\xe0\x1b\x1ah\xb4\xbe\x81\xf2" +
+	"`v\xe9\x85|v~\x17\xcb\x14\xf3\xc5\x96\x8e\xe5\x1d" +
+	"\xf9\xeeBJ\xeb\x1f\xd0$\xd3J\"\x8a\x88\x14\x01\x88" +
+	" \x80\x12[\x08 j$\x14\xb3\x186\xea\xdd\x1d\x97" +
+	"\x9bX\x0f\x98\x94\x10k\x81a\xfd\x19\xbe\x96\xaf\xcd&" +
+	"3V\xef*\xcd\xca\x00\x90+\xd5s\xb5\xbe\x1d@\xac" +
+	"\x93P\xdc\xcc\x10QEz\xb6a&\x80\xf8\x9e\x84\xe2" +
+	"G\x0c\x15\x86*2\x00e\xe3\x1a\x00q\xb3\x84\xe2>" +
+	"\x86\x8a\x84*J\x00\xca\x08\xbd}\xb7\x84\xe2A\x86J" +
+	"\x84\xa9\x18\x01P\xb6\xaeT\xb6\xc9\xe2A\x09\xc5\x93\x0c" +
+	"\x87\xbb\xdd\xb91\x06\x0cc\x80r\x9f5\x8020\x94" +
+	"\x01m=oiFw\xa6\x0b$\xcd[A\xc2':" +
+	"@z8\xac\xad+\xae\xd6\xfb4\xac\x01\x865\x80v" +
+	"\x9ffe\xb2\x19Z\x0a$\x91a\xc2\xe7%\x806T" +
+	"PN2\xc4D \x09\xe8$!\xa5\x0d6\xa6\xb4b" +
+	"n(\x94\xcb\x96R.U\x86\xad\x86f\x0e\xe4,/" +
+	"\xbe\x89\x0e\xd2K;Z\xaf\xbe\xea\xf2Uf\x0fyh" +
+	"+{\xe0\x8f\xe1t\x80\xf4\x0e\x940\xfd$2\x8c\xa1" +
+	"m;y\xe4\xbf\xc7\x85\x00\xe9G\x09x\x9a\x00\xf6o" +
+	"\xdb\xc9%\x7f\x0a\xdb\x01\xd2;\x09x\x8e\x00\xe9\x0b\xdb" +
+	"\xc9'\x7f\x06S\x00\xe9\xa7\x09\xf8\x13\x01\x91\xcfm'" +
+	"\xa7|\x8f\x03\xec&\xe05\x02\xaa\xfee\xabX\x05\xc0" +
+	"\xf7b'@\xfa\x15\x02\xf6\x13P}\xdaV\xb1\x1a\x80" +
+	"\xbf\x81?\x00H\xbfN\xc0;\x04\xc8\xa7l\xd5-f" +
+	"4\x00\xd2\x07\x098J@\xcdg\xb6\x8a5T\xdb\x8e" +
+	"\xab#\x04|D@\xedI[\xc5Z\xd2\x00\xfc\x05@" +
+	"\xfa#\x02>'\xa0\xee\x9f\xb6\x8au\x00\xfc\x14n\x02" +
+	"H\x7fN@\x0dc\x18\x9b\xf4\xa9\xad\xe2$\x12C\xb6" +
+	"\x12 \x1da\x12\xa6\x13\x04D?\xb1U\x8c\x12%2" +
+	"\x9a<J\xc0T\x02b\x1f\xdb*\xc6\xa8i\x18\x85\xab" +
+	"\x12p\x01\x01\xf5'l\x15\xeb\x01\xf8\xf9l\x13ob" +
+	"rz\x16A\x8b\x09\x8a\x1f\xb7U\x8c\x03\xf0K\xd8\x1a" +
+	"~)\x93\xd3\x8b\x09\xba\x921\x94\xf4\xac\xd3\x01\xb5\x80" +
+	"\x8d\x03yS\xb3\xa0z\xb8\x98\xb1zSZ?&|" +
+	"b\x07\xb7<\\\xa4\x98\x03\x1c\xc2\x84O\x16%4c" +
+	"\xba\xfd\x07H\xefz\xdc\x1aF\xe5b\x8e\xde\xf6\x84\xbc" +
+	"\x84\x1b\xda\xe0U\x05K\xefF\xbd+c\xe9\x85<`" +
+	"\xc2\x17\xe5\x92\x8d\xde]\xf2\xd1\xd8?\xa0\x99\x16&\xfc" +
+	"3L\xd8\xa24\x8b\xc7\x94%\xdc\xd4\x8cA\xbdK\xeb" +
+	"\xc0\x00S`\xc2W\xdd\x8af\xc5\xdc\x10P8\x1e\xe1" +
+	"\xf9!\x97@B\xbdC\x8f\xe7\xa3g\xf5PQ[\x01" +
+	"\x8d\x85\xa2\x9bNO\xcdB\x16X(\xba~0\xe1\xeb" +
+	"n \xe5\xe9\x81N\x13\xbb\x0c\xbdSKi\xfd\xe5\x16" +
+	"\xf6\xf7fb\x0b\xd3\x0b\xdf(f3 Y\x9ak\xe9" +
+	"\xabO\xe5fw\x19oI\xba\xc3_l\xa8\xe1\xdb}" +
+	"\xf2\x1c\xd6\xf2\x96\xa1\x07\xc9\xc7#l\x97|Bn\x89" +
+	"\xc9:\\\xd2\x92\xba4\xf2[\xe3\xf9m&R\x9e%" +
+	"\xa1\xb8\x88\xa1R\xa6\xd2ys\x01\xc4\x1c\x09\xc5\xc5\xc4" +
+	"\xd4f6c\x96\x0b4N\xbc]\xfe\x11\x9a&U\xaa" +
+	"\x1e\xbd+\x13\xa7\xea\x09-`%\x80\x88J(\xa62" +
+	"\xb4\xcd\x946HKuw-\xf5\xf7\xd3_\xddx\xc5" +
+	"\xc2_z{rF\xf4)\xad\x7f~w.#\xf5\x98" +
+	"\x14z\xe2v\x97\xc7\x9b\xdb\x83\xb1\xdf\xe1\xf2\xf8\xbc\x16" +
+	"?\xf6aC\xeb64\xb3\x17\x11\x18\"`k\xaf\x9e" +
+	"\xcdj\xf9\xf2\xcf\x0a\x13\xadrY\x1a3I\xc4$2" +
+	"\x91\xf0\xe2\xcf\xa4\x14M\x16Y\x09\xc5M\x81L\xad_" +
+	"\xa3l\x90\xc5M\x12\x8a-\xa4:\xccU\x9d{R\xca" +
+	"\x88,\xb6H(\x9e#\xd9\x91\xdcp\x9f\x99\xa9<#" +
+	"\x8b\xa7%\x14\xef0\xb4s\x19K\xcbw\xe9\x1a\xa0I" +
+	"%R\xda\xca\x9a\x92\x12vf\xf2\xd9\xb5z\xd6\x02\xa9" +
+	"7\x08\x97\x852\xa7\xe7o\xa0\xb2\x9d\xf8r\xdc\xfe\xc2" +
+	"\x10\x9d;\x9e\xfd\xf6\xcf\x82eV\x0f(\xf7h\x85\x80" +
+	"]\xc2\xb6\xd6\xcc\x1e\x9a\xbd\xeb\x85\xfd!\xbb6?%" +
+	"\x92+\x1e\xa5\x1e,w\xaai\x85\xcb\xf2\xbb\xa5]\x9d" +
+	"\xc3\xbc\x8e]\x0d\xf1\xa1\xa2_\x9dq\xdb\xeay\xedK" +
+	"\xcd\xf3R\x87\xc3\xd5Y\x9e\xc3\xed\xc0R\x03.\xcb[" +
+	"\x06:j\x17\xf5fYF*~\xb9\x84\xe2z_\xee" +
+	"\xafK\x01\x88k%\x14\xbd\x01\xb9\xd7\xa8\"\xae\x97P" +
+	"\xe4\xd8\xff\xa8\xd3\xb6\xa5\xf7i\xa6\x95\xe9\x03,\x96\xb5" +
+	"\xfa\x0c\xed\x9e\xa8\xa8+\x0af\xa3E)\x09u\xd2\\" +
+	"\xbf\x1a\xe9\x9f\x7f\xdeR\xe6-\x04\x16/\x16\x0cO\xa3" +
+	"\x1b3\xd9\xaca\x86\xea/\x90\x88x\x05\xc5?'\x01" +
+	"x\xb7\x90P\x8a\xb1\\\xd9q\xea!\xf2x\x81\xe7q" +
+	"\x1f\x1d\x98^\x91P\xec\x0f\x14\xf4\x1b\xf4\xf05\x09\xc5" +
+	"AJk\x8d\x9b\xd6\x03\xd4\xbb\xfb%\x14G\x18\xa2\x84" +
+	"\x81\xdb\x97rh!0\x8c8\xaa\xae\xbcL\xad\xb7\xdb" +
+	"\xado\xa5\x0a\x1dEW\xdejQ\xde\x92\xc5A\x09\xc5" +
+	"Q\x86J5s\xe4\\\x197\x94c\xb28*a:" +
+	"\x8a\x0c\x15Yr\xc5\xbc\x16\x0d\x1eC9\x1d%q\x9e" +
+	"\x83\x0c\xe5\xaci\x95\xe9F6\x8d\xae\xf2\xd8\xee\xcb\xac" +
+	"\xa3~5\x01\xc0Kjw.\xd3c\xb6\xf6\x16\x97v" +
+	"\xf7\x04R3u\xd9\x91\xcb\xf8K3v\x95R\xd3Z" +
+	",\xe4\xf4\xae!j\x87\xd2\xe1\xce6\xb4\xfe\x01\xdd\xd0" +
+	"\xb2\xd0\xd8\x91\xb7\xba\xcd\x09\x9d\xe2\x97\xcb\x84N\xb1\xbb" +
+	"\x0bF'\x91\x094\xe6\x97\xa4\xb5J=Z\x89\xc5\x9c" +
+	"\xfa\x96-#\\\xdfT:m\x12\x8a+\x03;\xd1A" +
+	"I_!\xa1X\x1d`\x16\xd1\xae\x08Y$\xddV\x88" +
+	"\x93\xce`\xc2\xff0P\xe2\xcf\xde\x82i\xf9\xec\xea\x1d" +
+	"\xf4]t\xb8\x7f \x93\xd3\xad!W\x97\xbc\x1b\x15-" +
+	"\x8f\xcc\x01\xcf^\x95\x92\xd6\x1f\xaa\xc9\xb9\xfe)4n" +
+	"\x0d\x155\x8c\xdb7-\xbe\xbfN\xdb~r+\x00b" +
+	"\xbcb\x1aH\x19-\x0dD\x04\x83W4liMi" +
+	"\x19\x93d\x83\x05\xbb\xaaEi\x96\x1d>\xff\x9a\xdf\xfb" +
+	"\x97.T.\x95\xc5b7a\xadF\xf95\x8c\xfb\xfe" +
+	"\x02\xdb\x15\x07l4\xdc\x86b\x13\x8eL\x13\xa4\xb8-" +
+	"\xdc4K\xd2\x1d\xad.\xef\x9d\xe5\x1a\xa3\x86\xc5\xb1\xc2" +
+	"Z\x85\x9blG\xcdia\x81+\xccLe\xbd\xec\xdd" +
+	"W\xca+\xdb\xd8\xa2l\x94\x9d\x0b\xcb\xed\x81]\xbfu" +
+	"\xaer\xab,~*\xa1\xb8;\xa0'\x9b\xe7*\x9be" +
+	"qW\xe9r\x13\x89\xb8\xfa7\x92R\xb6\xca\xe2>\x09" +
+	"\xc5\x0e\x86\xb2aY\xb4\xea\x12\x91\xb5\x1a\x96uM\xc6" +
+	"\x08<\x89\x9bZ>h\x11\xcf\x15\xcc\xe0o;\x971" +
+	"\xad\xa4Q\xe8\x04\xd4\x82\x8f\xcf\xb6\xab\xf3\xbdMt\xe5" +
+	"\xd3\x09\xbf\xb9]i\x96\x11\x95\xa6\x95\xf4?S\x9a\xda" +
+	"\x95&\x19%eF\xbb2C\x1e\xd6\xf3\xba\xa5gr" +
+	"Idv^[[n\xea$\xb2aC\x1b,\xdc\xa0" +
+	"ei\xa8\xad+R\x87&\x91\x9d[\xa3\\\x01\x91\xce" +
+	"h\xb0\xce\x92\x80$\x03\xb9^5\xd3o0,\xf7\x17" +
+	"\xa9J\xb2$ \x9e\x9e\xc9ni\x07u,\x0e([" +
+	"V\xceK\x88\xd7s\x18\xa0\x9e`\xeb\xd5\x9f\xf5\xae\xfc" +
+	"\x7f\x9f\xf6\xbc\xcf\x09\xff\xcdm#\x89\xe9\xd0\xb9d\xaa" +
+	"t\xde#\xaa\xb9PB\xb1\x98\x85Nx\xe7\xa4\x923" +
+	"U\xa6\xb5\xd7\xbb\xab\x06fL\xf9'\xb2\xf2\x8c\x0b\xda" +
+	"K3\xae`hk\x86Q0\x96\x16\xb2\x80Z\x99\xcb" +
+	"\xcf\\\xb4\xf7\xc9\xaa\xe2\xa2\x03EP\xf1\xba|\xce|" +
+	"z\x1f~*\xba^QJ\xc1\xfcLV\xce\x1a\xa6\xbb" +
+	"0\x15\xc3\xb9t\xca\x8a\x85\x0e\xcfq\xbd8xqY" +
+	"g\xe8\xc7W\xca?\xce~\xf2\xf77-P\xbf\x0b\x83" +
+	"\x02\x11)\x09\xc4\xcc@Q\xb3\xa4;\xfb\xaa\x16\xbf\xa8" +
+	"'\xb2S\xf0\x1bF\xabn.-\x18Z\xf9 \xfc\x9f" +
+	"\x01\x009\xb8\x81\xa4"
 
 func init() {
 	schemas.Register(schema_8f4bd412642c9517,
@@ -2382,6 +3027,7 @@ func init() {
 		0x95794035a80b7da1,
 		0x9b0685a785df42e9,
 		0x9bce05e1e88ad9da,
+		0xa5cff7314a4335e5,
 		0xa94f085c31a03112,
 		0xacf8185a51a9f1b4,
 		0xb21a270577932520,
@@ -2389,7 +3035,10 @@ func init() {
 		0xc4c61531dcc4a3eb,
 		0xc5ff2e54709776ec,
 		0xca1e844241cf650f,
+		0xca62c33457da1785,
 		0xcc65a2a89c24e6a5,
+		0xd1ad24b613ac14cf,
+		0xe2d3f1cd65170662,
 		0xe7279389a6bbe1dc,
 		0xe7f7d11a5652e06c,
 		0xf0c5156786d72738,
@@ -13,13 +13,16 @@ type SIGCtrl struct{ capnp.Struct }
 type SIGCtrl_Which uint16
 
 const (
-	SIGCtrl_Which_unset   SIGCtrl_Which = 0
-	SIGCtrl_Which_pollReq SIGCtrl_Which = 1
-	SIGCtrl_Which_pollRep SIGCtrl_Which = 2
+	SIGCtrl_Which_unset          SIGCtrl_Which = 0
+	SIGCtrl_Which_pollReq        SIGCtrl_Which = 1
+	SIGCtrl_Which_pollRep        SIGCtrl_Which = 2
+	SIGCtrl_Which_prefixAnnounce SIGCtrl_Which = 3
+	SIGCtrl_Which_keyExchangeReq SIGCtrl_Which = 4
+	SIGCtrl_Which_keyExchangeRep SIGCtrl_Which = 5
 )
 
 func (w SIGCtrl_Which) String() string {
-	const s = "unsetpollReqpollRep"
+	const s = "unsetpollReqpollRepprefixAnnouncekeyExchangeReqkeyExchangeRep"
 	switch w {
 	case SIGCtrl_Which_unset:
 		return s[0:5]
@@ -27,6 +30,12 @@ func (w SIGCtrl_Which) String() string {
 		return s[5:12]
 	case SIGCtrl_Which_pollRep:
 		return s[12:19]
+	case SIGCtrl_Which_prefixAnnounce:
+		return s[19:33]
+	case SIGCtrl_Which_keyExchangeReq:
+		return s[33:47]
+	case SIGCtrl_Which_keyExchangeRep:
+		return s[47:61]
 
 	}
 	return "SIGCtrl_Which(" + strconv.FormatUint(uint64(w), 10) + ")"
@@ -137,6 +146,105 @@ func (s SIGCtrl) NewPollRep() (SIGPoll, error) {
 	return ss, err
 }
 
+func (s SIGCtrl) PrefixAnnounce() (SIGPrefixAnnounce, error) {
+	if s.Struct.Uint16(8) != 3 {
+		panic("Which() != prefixAnnounce")
+	}
+	p, err := s.Struct.Ptr(0)
+	return SIGPrefixAnnounce{Struct: p.Struct()}, err
+}
+
+func (s SIGCtrl) HasPrefixAnnounce() bool {
+	if s.Struct.Uint16(8) != 3 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGCtrl) SetPrefixAnnounce(v SIGPrefixAnnounce) error {
+	s.Struct.SetUint16(8, 3)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewPrefixAnnounce sets the prefixAnnounce field to a newly
+// allocated SIGPrefixAnnounce struct, preferring placement in s's segment.
+func (s SIGCtrl) NewPrefixAnnounce() (SIGPrefixAnnounce, error) {
+	s.Struct.SetUint16(8, 3)
+	ss, err := NewSIGPrefixAnnounce(s.Struct.Segment())
+	if err != nil {
+		return SIGPrefixAnnounce{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SIGCtrl) KeyExchangeReq() (SIGKeyExchange, error) {
+	if s.Struct.Uint16(8) != 4 {
+		panic("Which() != keyExchangeReq")
+	}
+	p, err := s.Struct.Ptr(0)
+	return SIGKeyExchange{Struct: p.Struct()}, err
+}
+
+func (s SIGCtrl) HasKeyExchangeReq() bool {
+	if s.Struct.Uint16(8) != 4 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGCtrl) SetKeyExchangeReq(v SIGKeyExchange) error {
+	s.Struct.SetUint16(8, 4)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewKeyExchangeReq sets the keyExchangeReq field to a newly
+// allocated SIGKeyExchange struct, preferring placement in s's segment.
+func (s SIGCtrl) NewKeyExchangeReq() (SIGKeyExchange, error) {
+	s.Struct.SetUint16(8, 4)
+	ss, err := NewSIGKeyExchange(s.Struct.Segment())
+	if err != nil {
+		return SIGKeyExchange{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SIGCtrl) KeyExchangeRep() (SIGKeyExchange, error) {
+	if s.Struct.Uint16(8) != 5 {
+		panic("Which() != keyExchangeRep")
+	}
+	p, err := s.Struct.Ptr(0)
+	return SIGKeyExchange{Struct: p.Struct()}, err
+}
+
+func (s SIGCtrl) HasKeyExchangeRep() bool {
+	if s.Struct.Uint16(8) != 5 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGCtrl) SetKeyExchangeRep(v SIGKeyExchange) error {
+	s.Struct.SetUint16(8, 5)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewKeyExchangeRep sets the keyExchangeRep field to a newly
+// allocated SIGKeyExchange struct, preferring placement in s's segment.
+func (s SIGCtrl) NewKeyExchangeRep() (SIGKeyExchange, error) {
+	s.Struct.SetUint16(8, 5)
+	ss, err := NewSIGKeyExchange(s.Struct.Segment())
+	if err != nil {
+		return SIGKeyExchange{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
 // SIGCtrl_List is a list of SIGCtrl.
 type SIGCtrl_List struct{ capnp.List }
 
@@ -171,6 +279,18 @@ func (p SIGCtrl_Promise) PollRep() SIGPoll_Promise {
 	return SIGPoll_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
 }
 
+func (p SIGCtrl_Promise) PrefixAnnounce() SIGPrefixAnnounce_Promise {
+	return SIGPrefixAnnounce_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p SIGCtrl_Promise) KeyExchangeReq() SIGKeyExchange_Promise {
+	return SIGKeyExchange_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p SIGCtrl_Promise) KeyExchangeRep() SIGKeyExchange_Promise {
+	return SIGKeyExchange_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
 type SIGPoll struct{ capnp.Struct }
 
 // SIGPoll_TypeID is the unique identifier for the type SIGPoll.
@@ -347,33 +467,563 @@ func (p SIGAddr_Promise) Ctrl() HostInfo_Promise {
 	return HostInfo_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
 }
 
-const schema_8273379c3e06a721 = "x\xda\x8c\x91\xb1\xeb\xd3@\x1c\xc5\xdf\xbbk\x92\xdfO" +
-	",Mh\xa7\x82\xa8P\xb1\x15\x15[,jA\xb1\x8a" +
-	"\x88[\xaf\xae\"\x86&\xd8@L\xd2$\xa5c\xc1?" +
-	"\xa1n\xea(8\xb9\xf8\x7f88\x88\x93C'gw" +
-	"\xeb\xc9\x11\xb5C\x1d~\xd3\x17\xbe\xef\xdd}\xee\xdds" +
-	"?\xdc\x15}\xabA@\x9d\xb6l=\xf6\xdf\x0d\xc5\xe8" +
-	"\xebk\xa8S\xa4>\xff\xde\xbe\xf3\xf6F\xf1\x12\x16\x1d" +
-	"\xc0{\xb1\xf1\x96f.V\xe0\xae\xfb\xe6\xcc\xf6\xe7\x8f" +
-	"o\xff\xf3}\xdax_\xcc\xfc\xbc\x02\xf5\xd1\xedA\xd1" +
-	"\xeb<\xdd\x9a\x1b\xc5\xde\xf9\x80\x8ed\xad\xd9\xe7\xa6y" +
-	"\xcb\x1cj\x0e\xf9\x1d\xd4E\xf4\xfc\xea\xcc\xcf\x12f\xa3" +
-	"\xc7\x8f\x1eN\xd2\x98\xf1\x84TG\xb2\x06\xd4\x08x\xbd" +
-	"K\x80\xeaH\xaak\x82d\x8bfw\xe5\x1e\xa0\xba\x92" +
-	"\xea\xba`\xc3\x0f\x82\x9c\xee\xdf\xe7\x81t\xc1u\x11\x16" +
-	"E\x94&\xb4!h\x1f`\xc6A\xc0\xfc\x04\x98)\xa0" +
-	".K\xaa\x9b\x82\x8dY\x99\xc7t\xf5\xb9\x0b\xafV\xd6" +
-	"\xc5\xf6GT \x1d&3?\x9b\xa49X\xd2\x81\xa0" +
-	"s\x00\xbb_\xe6U&\xf7\x1f\xcco\x03\xea\x89\xa4\x9a" +
-	"\x0b\xd6\xa9u\x85\x0b\x07\x80z&\xa9b\xc1\xba\xf8\xa5" +
-	"[\x14\x80\x17\x99\xac\x81\xa4\xca\x04\xebr\xa7[\x94\xa6" +
-	"\x19\xb3\x9dK\xaaRPF\x01\x8f!x\x0c\x9e]&" +
-	"EX\xc2^gi\x1cO\xc3\x05\xdd}\xc1\x7f~\xa6" +
-	"R\xb2C\xe5w\x00\x00\x00\xff\xfflyx\xa7"
+type SIGPrefixAnnounce struct{ capnp.Struct }
+
+// SIGPrefixAnnounce_TypeID is the unique identifier for the type SIGPrefixAnnounce.
+const SIGPrefixAnnounce_TypeID = 0xba1ec5d95807aedd
+
+func NewSIGPrefixAnnounce(s *capnp.Segment) (SIGPrefixAnnounce, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return SIGPrefixAnnounce{st}, err
+}
+
+func NewRootSIGPrefixAnnounce(s *capnp.Segment) (SIGPrefixAnnounce, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return SIGPrefixAnnounce{st}, err
+}
+
+func ReadRootSIGPrefixAnnounce(msg *capnp.Message) (SIGPrefixAnnounce, error) {
+	root, err := msg.RootPtr()
+	return SIGPrefixAnnounce{root.Struct()}, err
+}
+
+func (s SIGPrefixAnnounce) String() string {
+	str, _ := text.Marshal(0xba1ec5d95807aedd, s.Struct)
+	return str
+}
+
+func (s SIGPrefixAnnounce) Blob() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return []byte(p.Data()), err
+}
+
+func (s SIGPrefixAnnounce) HasBlob() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGPrefixAnnounce) SetBlob(v []byte) error {
+	return s.Struct.SetData(0, v)
+}
+
+func (s SIGPrefixAnnounce) Sign() (Sign, error) {
+	p, err := s.Struct.Ptr(1)
+	return Sign{Struct: p.Struct()}, err
+}
+
+func (s SIGPrefixAnnounce) HasSign() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGPrefixAnnounce) SetSign(v Sign) error {
+	return s.Struct.SetPtr(1, v.Struct.ToPtr())
+}
+
+// NewSign sets the sign field to a newly
+// allocated Sign struct, preferring placement in s's segment.
+func (s SIGPrefixAnnounce) NewSign() (Sign, error) {
+	ss, err := NewSign(s.Struct.Segment())
+	if err != nil {
+		return Sign{}, err
+	}
+	err = s.Struct.SetPtr(1, ss.Struct.ToPtr())
+	return ss, err
+}
+
+// SIGPrefixAnnounce_List is a list of SIGPrefixAnnounce.
+type SIGPrefixAnnounce_List struct{ capnp.List }
+
+// NewSIGPrefixAnnounce creates a new list of SIGPrefixAnnounce.
+func NewSIGPrefixAnnounce_List(s *capnp.Segment, sz int32) (SIGPrefixAnnounce_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
+	return SIGPrefixAnnounce_List{l}, err
+}
+
+func (s SIGPrefixAnnounce_List) At(i int) SIGPrefixAnnounce {
+	return SIGPrefixAnnounce{s.List.Struct(i)}
+}
+
+func (s SIGPrefixAnnounce_List) Set(i int, v SIGPrefixAnnounce) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s SIGPrefixAnnounce_List) String() string {
+	str, _ := text.MarshalList(0xba1ec5d95807aedd, s.List)
+	return str
+}
+
+// SIGPrefixAnnounce_Promise is a wrapper for a SIGPrefixAnnounce promised by a client call.
+type SIGPrefixAnnounce_Promise struct{ *capnp.Pipeline }
+
+func (p SIGPrefixAnnounce_Promise) Struct() (SIGPrefixAnnounce, error) {
+	s, err := p.Pipeline.Struct()
+	return SIGPrefixAnnounce{s}, err
+}
+
+func (p SIGPrefixAnnounce_Promise) Sign() Sign_Promise {
+	return Sign_Promise{Pipeline: p.Pipeline.GetPipeline(1)}
+}
+
+type SIGPrefixes struct{ capnp.Struct }
+
+// SIGPrefixes_TypeID is the unique identifier for the type SIGPrefixes.
+const SIGPrefixes_TypeID = 0xf1bfb8bd1b98911c
+
+func NewSIGPrefixes(s *capnp.Segment) (SIGPrefixes, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return SIGPrefixes{st}, err
+}
+
+func NewRootSIGPrefixes(s *capnp.Segment) (SIGPrefixes, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return SIGPrefixes{st}, err
+}
+
+func ReadRootSIGPrefixes(msg *capnp.Message) (SIGPrefixes, error) {
+	root, err := msg.RootPtr()
+	return SIGPrefixes{root.Struct()}, err
+}
+
+func (s SIGPrefixes) String() string {
+	str, _ := text.Marshal(0xf1bfb8bd1b98911c, s.Struct)
+	return str
+}
+
+func (s SIGPrefixes) Ia() uint64 {
+	return s.Struct.Uint64(0)
+}
+
+func (s SIGPrefixes) SetIa(v uint64) {
+	s.Struct.SetUint64(0, v)
+}
+
+func (s SIGPrefixes) Version() uint64 {
+	return s.Struct.Uint64(8)
+}
+
+func (s SIGPrefixes) SetVersion(v uint64) {
+	s.Struct.SetUint64(8, v)
+}
+
+func (s SIGPrefixes) Prefixes() (SIGPrefix_List, error) {
+	p, err := s.Struct.Ptr(0)
+	return SIGPrefix_List{List: p.List()}, err
+}
+
+func (s SIGPrefixes) HasPrefixes() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGPrefixes) SetPrefixes(v SIGPrefix_List) error {
+	return s.Struct.SetPtr(0, v.List.ToPtr())
+}
+
+// NewPrefixes sets the prefixes field to a newly
+// allocated SIGPrefix_List, preferring placement in s's segment.
+func (s SIGPrefixes) NewPrefixes(n int32) (SIGPrefix_List, error) {
+	l, err := NewSIGPrefix_List(s.Struct.Segment(), n)
+	if err != nil {
+		return SIGPrefix_List{}, err
+	}
+	err = s.Struct.SetPtr(0, l.List.ToPtr())
+	return l, err
+}
+
+// SIGPrefixes_List is a list of SIGPrefixes.
+type SIGPrefixes_List struct{ capnp.List }
+
+// NewSIGPrefixes creates a new list of SIGPrefixes.
+func NewSIGPrefixes_List(s *capnp.Segment, sz int32) (SIGPrefixes_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1}, sz)
+	return SIGPrefixes_List{l}, err
+}
+
+func (s SIGPrefixes_List) At(i int) SIGPrefixes { return SIGPrefixes{s.List.Struct(i)} }
+
+func (s SIGPrefixes_List) Set(i int, v SIGPrefixes) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s SIGPrefixes_List) String() string {
+	str, _ := text.MarshalList(0xf1bfb8bd1b98911c, s.List)
+	return str
+}
+
+// SIGPrefixes_Promise is a wrapper for a SIGPrefixes promised by a client call.
+type SIGPrefixes_Promise struct{ *capnp.Pipeline }
+
+func (p SIGPrefixes_Promise) Struct() (SIGPrefixes, error) {
+	s, err := p.Pipeline.Struct()
+	return SIGPrefixes{s}, err
+}
+
+type SIGPrefix struct{ capnp.Struct }
+
+// SIGPrefix_TypeID is the unique identifier for the type SIGPrefix.
+const SIGPrefix_TypeID = 0xf7b4413c3b5cec08
+
+func NewSIGPrefix(s *capnp.Segment) (SIGPrefix, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return SIGPrefix{st}, err
+}
+
+func NewRootSIGPrefix(s *capnp.Segment) (SIGPrefix, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return SIGPrefix{st}, err
+}
+
+func ReadRootSIGPrefix(msg *capnp.Message) (SIGPrefix, error) {
+	root, err := msg.RootPtr()
+	return SIGPrefix{root.Struct()}, err
+}
+
+func (s SIGPrefix) String() string {
+	str, _ := text.Marshal(0xf7b4413c3b5cec08, s.Struct)
+	return str
+}
+
+func (s SIGPrefix) Ip() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return []byte(p.Data()), err
+}
+
+func (s SIGPrefix) HasIp() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGPrefix) SetIp(v []byte) error {
+	return s.Struct.SetData(0, v)
+}
+
+func (s SIGPrefix) Len() uint8 {
+	return s.Struct.Uint8(0)
+}
+
+func (s SIGPrefix) SetLen(v uint8) {
+	s.Struct.SetUint8(0, v)
+}
+
+// SIGPrefix_List is a list of SIGPrefix.
+type SIGPrefix_List struct{ capnp.List }
+
+// NewSIGPrefix creates a new list of SIGPrefix.
+func NewSIGPrefix_List(s *capnp.Segment, sz int32) (SIGPrefix_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return SIGPrefix_List{l}, err
+}
+
+func (s SIGPrefix_List) At(i int) SIGPrefix { return SIGPrefix{s.List.Struct(i)} }
+
+func (s SIGPrefix_List) Set(i int, v SIGPrefix) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s SIGPrefix_List) String() string {
+	str, _ := text.MarshalList(0xf7b4413c3b5cec08, s.List)
+	return str
+}
+
+// SIGPrefix_Promise is a wrapper for a SIGPrefix promised by a client call.
+type SIGPrefix_Promise struct{ *capnp.Pipeline }
+
+func (p SIGPrefix_Promise) Struct() (SIGPrefix, error) {
+	s, err := p.Pipeline.Struct()
+	return SIGPrefix{s}, err
+}
+
+type SIGKeyExchange struct{ capnp.Struct }
+
+// SIGKeyExchange_TypeID is the unique identifier for the type SIGKeyExchange.
+const SIGKeyExchange_TypeID = 0xe5f18aa38782d632
+
+func NewSIGKeyExchange(s *capnp.Segment) (SIGKeyExchange, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return SIGKeyExchange{st}, err
+}
+
+func NewRootSIGKeyExchange(s *capnp.Segment) (SIGKeyExchange, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return SIGKeyExchange{st}, err
+}
+
+func ReadRootSIGKeyExchange(msg *capnp.Message) (SIGKeyExchange, error) {
+	root, err := msg.RootPtr()
+	return SIGKeyExchange{root.Struct()}, err
+}
+
+func (s SIGKeyExchange) String() string {
+	str, _ := text.Marshal(0xe5f18aa38782d632, s.Struct)
+	return str
+}
+
+func (s SIGKeyExchange) Blob() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return []byte(p.Data()), err
+}
+
+func (s SIGKeyExchange) HasBlob() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGKeyExchange) SetBlob(v []byte) error {
+	return s.Struct.SetData(0, v)
+}
+
+func (s SIGKeyExchange) Sign() (Sign, error) {
+	p, err := s.Struct.Ptr(1)
+	return Sign{Struct: p.Struct()}, err
+}
+
+func (s SIGKeyExchange) HasSign() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGKeyExchange) SetSign(v Sign) error {
+	return s.Struct.SetPtr(1, v.Struct.ToPtr())
+}
+
+// NewSign sets the sign field to a newly
+// allocated Sign struct, preferring placement in s's segment.
+func (s SIGKeyExchange) NewSign() (Sign, error) {
+	ss, err := NewSign(s.Struct.Segment())
+	if err != nil {
+		return Sign{}, err
+	}
+	err = s.Struct.SetPtr(1, ss.Struct.ToPtr())
+	return ss, err
+}
+
+// SIGKeyExchange_List is a list of SIGKeyExchange.
+type SIGKeyExchange_List struct{ capnp.List }
+
+// NewSIGKeyExchange creates a new list of SIGKeyExchange.
+func NewSIGKeyExchange_List(s *capnp.Segment, sz int32) (SIGKeyExchange_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
+	return SIGKeyExchange_List{l}, err
+}
+
+func (s SIGKeyExchange_List) At(i int) SIGKeyExchange { return SIGKeyExchange{s.List.Struct(i)} }
+
+func (s SIGKeyExchange_List) Set(i int, v SIGKeyExchange) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s SIGKeyExchange_List) String() string {
+	str, _ := text.MarshalList(0xe5f18aa38782d632, s.List)
+	return str
+}
+
+// SIGKeyExchange_Promise is a wrapper for a SIGKeyExchange promised by a client call.
+type SIGKeyExchange_Promise struct{ *capnp.Pipeline }
+
+func (p SIGKeyExchange_Promise) Struct() (SIGKeyExchange, error) {
+	s, err := p.Pipeline.Struct()
+	return SIGKeyExchange{s}, err
+}
+
+func (p SIGKeyExchange_Promise) Sign() Sign_Promise {
+	return Sign_Promise{Pipeline: p.Pipeline.GetPipeline(1)}
+}
+
+type SIGKeyShare struct{ capnp.Struct }
+
+// SIGKeyShare_TypeID is the unique identifier for the type SIGKeyShare.
+const SIGKeyShare_TypeID = 0xd833189e6d181523
+
+func NewSIGKeyShare(s *capnp.Segment) (SIGKeyShare, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 3})
+	return SIGKeyShare{st}, err
+}
+
+func NewRootSIGKeyShare(s *capnp.Segment) (SIGKeyShare, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 3})
+	return SIGKeyShare{st}, err
+}
+
+func ReadRootSIGKeyShare(msg *capnp.Message) (SIGKeyShare, error) {
+	root, err := msg.RootPtr()
+	return SIGKeyShare{root.Struct()}, err
+}
+
+func (s SIGKeyShare) String() string {
+	str, _ := text.Marshal(0xd833189e6d181523, s.Struct)
+	return str
+}
+
+func (s SIGKeyShare) Ia() uint64 {
+	return s.Struct.Uint64(0)
+}
+
+func (s SIGKeyShare) SetIa(v uint64) {
+	s.Struct.SetUint64(0, v)
+}
+
+func (s SIGKeyShare) Session() uint8 {
+	return s.Struct.Uint8(8)
+}
+
+func (s SIGKeyShare) SetSession(v uint8) {
+	s.Struct.SetUint8(8, v)
+}
+
+func (s SIGKeyShare) KeyId() uint8 {
+	return s.Struct.Uint8(9)
+}
+
+func (s SIGKeyShare) SetKeyId(v uint8) {
+	s.Struct.SetUint8(9, v)
+}
+
+func (s SIGKeyShare) PubKey() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return []byte(p.Data()), err
+}
+
+func (s SIGKeyShare) HasPubKey() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGKeyShare) SetPubKey(v []byte) error {
+	return s.Struct.SetData(0, v)
+}
+
+func (s SIGKeyShare) PeerPubKey() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return []byte(p.Data()), err
+}
+
+func (s SIGKeyShare) HasPeerPubKey() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGKeyShare) SetPeerPubKey(v []byte) error {
+	return s.Struct.SetData(1, v)
+}
+
+func (s SIGKeyShare) Addr() (SIGAddr, error) {
+	p, err := s.Struct.Ptr(2)
+	return SIGAddr{Struct: p.Struct()}, err
+}
+
+func (s SIGKeyShare) HasAddr() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGKeyShare) SetAddr(v SIGAddr) error {
+	return s.Struct.SetPtr(2, v.Struct.ToPtr())
+}
+
+// NewAddr sets the addr field to a newly
+// allocated SIGAddr struct, preferring placement in s's segment.
+func (s SIGKeyShare) NewAddr() (SIGAddr, error) {
+	ss, err := NewSIGAddr(s.Struct.Segment())
+	if err != nil {
+		return SIGAddr{}, err
+	}
+	err = s.Struct.SetPtr(2, ss.Struct.ToPtr())
+	return ss, err
+}
+
+// SIGKeyShare_List is a list of SIGKeyShare.
+type SIGKeyShare_List struct{ capnp.List }
+
+// NewSIGKeyShare creates a new list of SIGKeyShare.
+func NewSIGKeyShare_List(s *capnp.Segment, sz int32) (SIGKeyShare_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 3}, sz)
+	return SIGKeyShare_List{l}, err
+}
+
+func (s SIGKeyShare_List) At(i int) SIGKeyShare { return SIGKeyShare{s.List.Struct(i)} }
+
+func (s SIGKeyShare_List) Set(i int, v SIGKeyShare) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s SIGKeyShare_List) String() string {
+	str, _ := text.MarshalList(0xd833189e6d181523, s.List)
+	return str
+}
+
+// SIGKeyShare_Promise is a wrapper for a SIGKeyShare promised by a client call.
+type SIGKeyShare_Promise struct{ *capnp.Pipeline }
+
+func (p SIGKeyShare_Promise) Struct() (SIGKeyShare, error) {
+	s, err := p.Pipeline.Struct()
+	return SIGKeyShare{s}, err
+}
+
+func (p SIGKeyShare_Promise) Addr() SIGAddr_Promise {
+	return SIGAddr_Promise{Pipeline: p.Pipeline.GetPipeline(2)}
+}
+
+const schema_8273379c3e06a721 = "x\xda\xbcT]h\x1cU\x18\xfd\xce\xbd\xbbs\xd3\xe0" +
+	"6{\xd9\x15blM\xab\x0d6A%?\x15%V" +
+	"\xf3\xa3Ab\x8c\xecM\x11\xa3\x88\xb0\xc9\xde\xa4K\xd7" +
+	"\xd9\xe9Lb\x93\xbc\x14\x8b \xf8\"\x8d\x0a\x8d\x8aX" +
+	"\xadh@\xd4\x84\x14ZiP\xa1\xc1\xea\xb3?\xe9C" +
+	"\x1e*\x04*\xf8\x12P\xfck\x1c\xb9;\xb3\xdbI\x93" +
+	"\x80O>|\xb0\xfb\xcd\x99{\xce\x9c\xf3}\xb7\xf9\x00" +
+	"\xebd-\xf1\xaf\x19\x91\xda\x13\xb7\xfc\xae\xec\x99{Y" +
+	"\xfb\x8fo\x92\xaa\x06\xfc\xbd\x1fZ\x0f\xbd}\x9fw\x82" +
+	"\xe2\x10D\xa9^L\xa7T\xe9W?\x8e\x11\xfc\x95O" +
+	"\xc4\xe0\xe5\xa5\xdb>'Y\x1d\xc52\x83\x98\xc5O\xa9" +
+	"\xb3\x10a\x19\xf4\x1d7\xd7>\xffNm\xdb\xb29\x9a" +
+	"E\xe0\xdc\xc0w\xb3\xf9T\x03\x13\xa6\xda\x1a\xd8S " +
+	"\xac\xef\x7fk\xf7\x95kk+[\x099\xc9\xa7S3" +
+	"\xa5\xf7\xde\xe0\xe6\xe8\xaa\x07[\xbd\xc6}\xcf]\xb9\xe1" +
+	"\xe8\x1e\x08\x0b\xb1\xd4o|:\xb5n\xd0m\x7f\xf2W" +
+	"A\xf0[\x7f8\xf1\xf2\xfb\xaf\xac\xadn\xa5\xfb\x83\xf8" +
+	"\xb7\xa9\xb9\xb8\x08\xcb\x1c\xbe\xeb\xe4\xa9[\x17\xcf}\xb1" +
+	"v\xa3\xee\x92\x92[\xac\xf9\xd4^K\x84\xf5\xa9\xd1\xf2" +
+	"\xcb\xb3\x0f\x1c\xecZ\xf8}K\x07W\xac\xf7R\xab\x96" +
+	"\x08\xcb\x9c\xee\xe5G\xef\x19\xce:6\x9c\xf6C\xbd\x8f" +
+	"f\x8a\x05\x142\x80\xaa\xe21\xa2\x18\x88dc\x13\x91" +
+	"\xda\xc7\xa1\x9a\x19\x804L\xef\xeen\"\xb5\x9fC\x1d" +
+	"`\xa8\xc9\xe6r.\x92e\xbb\x08H\x12\x8e{\xda\xf3" +
+	"\xf2E\x1b\x161X\x11\x1a\x16\xd0\xb8z$?\xd1e" +
+	"\xdb\xc5qa\x0f\xeb\x0c\x90\x01\xdb\xc0)\x1bE\x99@" +
+	"\x96Y[\x9ad\x8bP\xcd\x1c\xaa\x93\xa1f\xa8P\x1c" +
+	"\xca\x80!A\xa6P\xe3\xe5Gm\xf3?\xe9W\xbf\xf8" +
+	"\xc4\xaf\xb9\xd7\xfb_\"\xa2N\x10!\x19\xe1\x0f>\xb3" +
+	"OO\xd6\x1f:\x9cu\xcb\xcc\xb5\x15\xe6\x99:9#" +
+	"\xd4)\x0eu\xc60W\x05\xcc\xa7\xbb\xe5i\xa1\xde\xe5" +
+	"P\x1f3H\xb6#\x0dF$g[\xe5\xacP\x1fq" +
+	"\xa8\x05\x06\xf048\x91\x9ck\x97sB}\xc6\xa1." +
+	"0\xc8\x18\xd2\x88\x11\xc9\xf3\xcf\xc8E\xa1.p\xa8K" +
+	"\x0c2\xce\xd2\x88\x13\xc9\xa5&\xb9$\xd4E\x0e\xf5\x1d" +
+	"\x03\xcfg\x8d\xfc\x1dd\xaab\xa0i\x85\x1e\xd6\x1f\xd1" +
+	"\x93\xbd\xb9H\xa3\xc3\x19\x1f\xea\xd3\x93\x11\x13|Gk" +
+	"73>\xd4G|C\xbf\x94Q\xc9\x9cJL\xdbY" +
+	"\xd3\x95\xcb\xc1\xfd\x0f\x130@\xa4\xee\xe2P\xf73\xd4" +
+	"\x0c\x8f\xb9\x05$\xfd=\x0d\xaf\x1d\x8b\xdfY7O\xc1" +
+	"\x0c\xf8\xda\x1e\xce:\x99\xa2K\x18\x83 \x06\xb1\x89\xec" +
+	"\xe117\x18\xb7]\x15\xb2\xb3uDe\xfb\x12\xf0\xfd" +
+	"\x80\xee|+\x91Z\xe0P_2$\xd8?~\x10\xc0" +
+	"\xa2\x19\xc3s\x1c\xea\"C\x82\xaf\xfbA\x02_\x99n" +
+	"hu\"v\xcd\x0f\x12X\x9a\x92\xdf\x08u\x89C-" +
+	"3$\xe2\x7f\xfbA\x04\xdfO\xc9\xcbB-s\xa8\xab" +
+	"\x0c\x09\xeb/?\x0d\x8bH\xaeN\xc9\x9f\x85\xba\xca\xa1" +
+	"\xfe0\xc9\xe4\xca\xb1\xd4\x8f\xdb\x9e\x1e#\xeb\xb8S," +
+	"\x14\x06\xf4Q$\xaf\xdfX\xe1\xe4\x07O\x9c\xcdO|" +
+	"'\x9cz\xea\xb0\x8b\xe3\xa5\xb17\xd3Z\xb9\xc4\"\x91" +
+	"\x1c\xd1\x93=\x13\xc3\x87\xb3\xd4a\x8f\xea\x01}4@" +
+	"V\xae\x8d\xed\x91\xce\xf6\xc8\x8d\x1b\xd8\x17\xbeg\x8fj" +
+	"\xa2\xff}\xff2\xae\xae\x1f\xc9Oh/d\xbe\xa9\xc2" +
+	"\xdcS'{\x84z\x84Ce\"\xcc\xfd\xdd\xb2_\xa8" +
+	"\xc79\xd4 \x03X\x90\xfe\x93\x8f\xc9\xa7\x85\x1a\xe4P" +
+	"\x13\x9b\xb6\xe7\x05\xed\x96\xb7'l\x85\xeek\x8f\xcc\xf7" +
+	"2\xec$d8\x90\xbc~_\x86jwn\xa5V\x8c" +
+	"\xe4'6\xbbT\x17q\xa9b\xd2\xede\x93\x0e\x1aU" +
+	"N\xc4\"Q\xd0\xd1}\xfew\x00C\xa6\x968"
 
 func init() {
 	schemas.Register(schema_8273379c3e06a721,
 		0x9ad73a0235a46141,
+		0xba1ec5d95807aedd,
+		0xd833189e6d181523,
 		0xddf1fce11d9b0028,
-		0xe15e242973323d08)
+		0xe15e242973323d08,
+		0xe5f18aa38782d632,
+		0xf1bfb8bd1b98911c,
+		0xf7b4413c3b5cec08)
 }
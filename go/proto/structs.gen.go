@@ -50,6 +50,18 @@ func NewRootStruct(id ProtoIdType, seg *capnp.Segment) (capnp.Struct, error) {
 			return blank, common.NewBasicError("Error creating new SCIONDMsg capnp struct", err)
 		}
 		return v.Struct, nil
+	case SIGKeyShare_TypeID:
+		v, err := NewRootSIGKeyShare(seg)
+		if err != nil {
+			return blank, common.NewBasicError("Error creating new SIGKeyShare capnp struct", err)
+		}
+		return v.Struct, nil
+	case SIGPrefixes_TypeID:
+		v, err := NewRootSIGPrefixes(seg)
+		if err != nil {
+			return blank, common.NewBasicError("Error creating new SIGPrefixes capnp struct", err)
+		}
+		return v.Struct, nil
 	case SignedBlob_TypeID:
 		v, err := NewRootSignedBlob(seg)
 		if err != nil {
@@ -94,6 +106,12 @@ func (s RevInfo) GetStruct() capnp.Struct {
 func (s SCIONDMsg) GetStruct() capnp.Struct {
 	return s.Struct
 }
+func (s SIGKeyShare) GetStruct() capnp.Struct {
+	return s.Struct
+}
+func (s SIGPrefixes) GetStruct() capnp.Struct {
+	return s.Struct
+}
 func (s SignedBlob) GetStruct() capnp.Struct {
 	return s.Struct
 }
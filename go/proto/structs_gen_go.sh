@@ -1,6 +1,6 @@
 #!/bin/bash
 
-ROOTTYPES="ASEntry CtrlPld PathSegment PathSegmentSignedData RevInfo SCIONDMsg SignedBlob SignedCtrlPld SVCResolutionReply"
+ROOTTYPES="ASEntry CtrlPld PathSegment PathSegmentSignedData RevInfo SCIONDMsg SIGKeyShare SIGPrefixes SignedBlob SignedCtrlPld SVCResolutionReply"
 
 cat <<EOF
 // Code generated by go/proto/structs_gen_go.sh; DO NOT EDIT.
@@ -33,6 +33,21 @@ import (
 
 var (
 	DefaultQueryInterval = 5 * time.Minute
+	// DefaultProbeInterval is the default interval in which paths are probed.
+	DefaultProbeInterval = 10 * time.Second
+	// DefaultProbeTimeout is the default time to wait for probe replies.
+	DefaultProbeTimeout = 2 * time.Second
+	// DefaultProbeIdleTimeout is the default time after which a path that was
+	// not handed out again is no longer probed.
+	DefaultProbeIdleTimeout = 5 * time.Minute
+)
+
+const (
+	// DefaultProbeMaxPaths is the default maximum number of probed paths.
+	DefaultProbeMaxPaths = 200
+	// DefaultProbeMaxProbes is the default maximum number of probes per
+	// interval.
+	DefaultProbeMaxProbes = 50
 )
 
 var _ config.Config = (*Config)(nil)
@@ -118,6 +133,9 @@ type SDConfig struct {
 	// QueryInterval specifies after how much time segments
 	// for a destination should be refetched.
 	QueryInterval util.DurWrap
+	// Probing contains the configuration for probing the paths that SCIOND
+	// hands out.
+	Probing ProbingConfig
 }
 
 func (cfg *SDConfig) InitDefaults() {
@@ -133,7 +151,7 @@ func (cfg *SDConfig) InitDefaults() {
 	if cfg.QueryInterval.Duration == 0 {
 		cfg.QueryInterval.Duration = DefaultQueryInterval
 	}
-	config.InitAll(&cfg.PathDB, &cfg.RevCache)
+	config.InitAll(&cfg.PathDB, &cfg.RevCache, &cfg.Probing)
 }
 
 func (cfg *SDConfig) Validate() error {
@@ -149,12 +167,12 @@ func (cfg *SDConfig) Validate() error {
 	if cfg.QueryInterval.Duration == 0 {
 		return serrors.New("QueryInterval must not be zero")
 	}
-	return config.ValidateAll(&cfg.PathDB, &cfg.RevCache)
+	return config.ValidateAll(&cfg.PathDB, &cfg.RevCache, &cfg.Probing)
 }
 
 func (cfg *SDConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
 	config.WriteString(dst, sdSample)
-	config.WriteSample(dst, path, ctx, &cfg.PathDB, &cfg.RevCache)
+	config.WriteSample(dst, path, ctx, &cfg.PathDB, &cfg.RevCache, &cfg.Probing)
 }
 
 func (cfg *SDConfig) ConfigName() string {
@@ -170,3 +188,72 @@ func (cfg *SDConfig) CreateSocketDirs() error {
 	}
 	return nil
 }
+
+var _ config.Config = (*ProbingConfig)(nil)
+
+// ProbingConfig is the configuration for probing the paths that SCIOND hands
+// out. The observed round-trip time and loss of a path are returned in the
+// path replies.
+type ProbingConfig struct {
+	// Enabled enables path probing.
+	Enabled bool
+	// Interval is the interval in which the paths are probed.
+	Interval util.DurWrap
+	// Timeout is the time to wait for the probe replies. It must be smaller
+	// than Interval.
+	Timeout util.DurWrap
+	// IdleTimeout is the time after which a path that was not handed out again
+	// is no longer probed.
+	IdleTimeout util.DurWrap
+	// MaxPaths is the maximum number of paths that are probed. Paths handed
+	// out when the limit is reached are not probed.
+	MaxPaths int
+	// MaxProbes is the maximum number of probes sent per interval.
+	MaxProbes int
+}
+
+func (cfg *ProbingConfig) InitDefaults() {
+	if cfg.Interval.Duration == 0 {
+		cfg.Interval.Duration = DefaultProbeInterval
+	}
+	if cfg.Timeout.Duration == 0 {
+		cfg.Timeout.Duration = DefaultProbeTimeout
+	}
+	if cfg.IdleTimeout.Duration == 0 {
+		cfg.IdleTimeout.Duration = DefaultProbeIdleTimeout
+	}
+	if cfg.MaxPaths == 0 {
+		cfg.MaxPaths = DefaultProbeMaxPaths
+	}
+	if cfg.MaxProbes == 0 {
+		cfg.MaxProbes = DefaultProbeMaxProbes
+	}
+}
+
+func (cfg *ProbingConfig) Validate() error {
+	if cfg.Interval.Duration <= 0 {
+		return serrors.New("Interval must be positive", "interval", cfg.Interval)
+	}
+	if cfg.Timeout.Duration <= 0 || cfg.Timeout.Duration >= cfg.Interval.Duration {
+		return serrors.New("Timeout must be positive and smaller than Interval",
+			"timeout", cfg.Timeout, "interval", cfg.Interval)
+	}
+	if cfg.IdleTimeout.Duration <= 0 {
+		return serrors.New("IdleTimeout must be positive", "idle_timeout", cfg.IdleTimeout)
+	}
+	if cfg.MaxPaths < 0 {
+		return serrors.New("MaxPaths must not be negative", "max_paths", cfg.MaxPaths)
+	}
+	if cfg.MaxProbes < 0 {
+		return serrors.New("MaxProbes must not be negative", "max_probes", cfg.MaxProbes)
+	}
+	return nil
+}
+
+func (cfg *ProbingConfig) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, probingSample)
+}
+
+func (cfg *ProbingConfig) ConfigName() string {
+	return "probing"
+}
@@ -69,4 +69,10 @@ func CheckTestSDConfig(t *testing.T, cfg *SDConfig, id string) {
 	assert.Equal(t, "1-ff00:0:110,[127.0.0.1]:0", cfg.Public.String())
 	assert.Equal(t, DefaultQueryInterval, cfg.QueryInterval.Duration)
 	assert.False(t, cfg.DeleteSocket)
+	assert.False(t, cfg.Probing.Enabled)
+	assert.Equal(t, DefaultProbeInterval, cfg.Probing.Interval.Duration)
+	assert.Equal(t, DefaultProbeTimeout, cfg.Probing.Timeout.Duration)
+	assert.Equal(t, DefaultProbeIdleTimeout, cfg.Probing.IdleTimeout.Duration)
+	assert.Equal(t, DefaultProbeMaxPaths, cfg.Probing.MaxPaths)
+	assert.Equal(t, DefaultProbeMaxProbes, cfg.Probing.MaxProbes)
 }
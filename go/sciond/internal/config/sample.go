@@ -42,3 +42,26 @@ Public = "1-ff00:0:110,[127.0.0.1]:0"
 # The time after which segments for a destination are refetched. (default 5m)
 QueryInterval = "5m"
 `
+
+const probingSample = `
+# Enable probing of the paths handed out by SCIOND. The observed round-trip
+# time and loss of the paths are returned in the path replies. (default false)
+Enabled = false
+
+# The interval in which the paths are probed. (default 10s)
+Interval = "10s"
+
+# The time to wait for the probe replies. Must be smaller than Interval.
+# (default 2s)
+Timeout = "2s"
+
+# The time after which a path that was not handed out again is no longer
+# probed. (default 5m)
+IdleTimeout = "5m"
+
+# The maximum number of paths that are probed. (default 200)
+MaxPaths = 200
+
+# The maximum number of probes sent per interval. (default 50)
+MaxProbes = 50
+`
@@ -135,6 +135,10 @@ func (f *fetcherHandler) GetPaths(ctx context.Context, req *sciond.PathReq,
 	if req.Dst.IA().Equal(f.topology.IA()) {
 		return f.buildSCIONDReply(nil, 0, sciond.ErrorOk), nil
 	}
+	filter, err := req.Filter()
+	if err != nil {
+		return f.buildSCIONDReply(nil, 0, sciond.ErrorBadPolicy), err
+	}
 	if req.Flags.Refresh {
 		// This is a workaround for https://github.com/scionproto/scion/issues/1876
 		err := f.flushSegmentsWithFirstHopInterfaces(ctx)
@@ -161,6 +165,9 @@ func (f *fetcherHandler) GetPaths(ctx context.Context, req *sciond.PathReq,
 	if err != nil {
 		return f.buildSCIONDReply(nil, 0, sciond.ErrorInternal), err
 	}
+	if filter != nil {
+		paths = FilterAndRank(paths, filter)
+	}
 	if len(paths) == 0 {
 		return f.buildSCIONDReply(nil, req.MaxPaths, sciond.ErrorNoPaths), nil
 	}
@@ -237,6 +244,7 @@ func (f *fetcherHandler) buildSCIONDReplyEntries(paths []*combinator.Path,
 				Mtu:        path.Mtu,
 				Interfaces: path.Interfaces,
 				ExpTime:    uint32(path.ComputeExpTime().Unix()),
+				Metadata:   path.Metadata,
 			},
 			HostInfo: hostinfo.FromUDPAddr(*nextHop),
 		})
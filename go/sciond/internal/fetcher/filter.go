@@ -16,10 +16,14 @@ package fetcher
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/infra/modules/combinator"
 	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/sciond"
 )
 
 // Policy is a filter on path sets.
@@ -33,6 +37,99 @@ func Filter(paths []*combinator.Path, policy Policy) []*combinator.Path {
 	return psToPaths(policy.Filter(pathsToPs(paths)))
 }
 
+// FilterAndRank filters the given paths with the filter of a path request and
+// ranks the remaining paths. Paths are ranked by the number of AS hops, then by
+// the total static latency, if it is known for all hops, and then by their
+// interfaces.
+func FilterAndRank(paths []*combinator.Path, filter *sciond.PathReqFilter) []*combinator.Path {
+	ps := requestPolicy{filter: filter}.Filter(pathsToPs(paths))
+	wraps := make([]pathWrap, 0, len(ps))
+	for _, wp := range ps {
+		wraps = append(wraps, wp.(pathWrap))
+	}
+	sort.Slice(wraps, func(i, j int) bool {
+		a, b := wraps[i], wraps[j]
+		if a.origPath.Weight != b.origPath.Weight {
+			return a.origPath.Weight < b.origPath.Weight
+		}
+		if la, lb := totalLatency(a.origPath), totalLatency(b.origPath); la != lb {
+			return la < lb
+		}
+		return a.key < b.key
+	})
+	result := make([]*combinator.Path, 0, len(wraps))
+	for _, wp := range wraps {
+		result = append(result, wp.origPath)
+	}
+	return result
+}
+
+// requestPolicy is the policy described by the filter of a path request.
+type requestPolicy struct {
+	filter *sciond.PathReqFilter
+}
+
+func (p requestPolicy) Filter(ps pathpol.PathSet) pathpol.PathSet {
+	if p.filter == nil {
+		return ps
+	}
+	ps = p.filter.Policy.Filter(ps)
+	for key, path := range ps {
+		if !p.allowed(path.Interfaces()) {
+			delete(ps, key)
+		}
+	}
+	return ps
+}
+
+func (p requestPolicy) allowed(intfs []pathpol.PathInterface) bool {
+	for _, intf := range intfs {
+		for _, forbidden := range p.filter.ForbiddenASes {
+			if matchIA(forbidden, intf.IA()) {
+				return false
+			}
+		}
+	}
+	for _, required := range p.filter.RequiredIntfs {
+		if !containsIntf(intfs, required) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchIA returns whether ia matches the pattern. Zero values in the pattern
+// are wildcards.
+func matchIA(pattern, ia addr.IA) bool {
+	return (pattern.I == 0 || pattern.I == ia.I) && (pattern.A == 0 || pattern.A == ia.A)
+}
+
+func containsIntf(intfs []pathpol.PathInterface, required sciond.PathInterface) bool {
+	for _, intf := range intfs {
+		if intf.IA().Equal(required.IA()) && (required.IfID == 0 || intf.ID() == required.IfID) {
+			return true
+		}
+	}
+	return false
+}
+
+// totalLatency returns the total static latency of the path in microseconds.
+// If the latency of at least one hop is unknown, the maximum value is
+// returned.
+func totalLatency(p *combinator.Path) uint64 {
+	if p.Metadata == nil || len(p.Metadata.Latencies) == 0 {
+		return math.MaxUint64
+	}
+	var total uint64
+	for _, l := range p.Metadata.Latencies {
+		if l == 0 {
+			return math.MaxUint64
+		}
+		total += uint64(l)
+	}
+	return total
+}
+
 func pathsToPs(paths []*combinator.Path) pathpol.PathSet {
 	ps := make(pathpol.PathSet, len(paths))
 	for _, path := range paths {
@@ -21,10 +21,12 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/seg"
 	"github.com/scionproto/scion/go/lib/infra/modules/combinator"
 	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/sciond"
 	"github.com/scionproto/scion/go/lib/xtest"
 	"github.com/scionproto/scion/go/lib/xtest/graph"
 	"github.com/scionproto/scion/go/sciond/internal/fetcher"
@@ -82,3 +84,79 @@ func TestFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterAndRank(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	g := graph.NewDefaultGraph(ctrl)
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	ia120 := xtest.MustParseIA("1-ff00:0:120")
+	seg110To120 := g.Beacon([]common.IFIDType{graph.If_110_X_120_A})
+	seg110To130 := g.Beacon([]common.IFIDType{graph.If_110_X_130_A})
+	seg120To111 := g.Beacon([]common.IFIDType{graph.If_120_X_111_B})
+	seg130To111 := g.Beacon([]common.IFIDType{graph.If_130_B_111_A})
+
+	paths111To110 := combinator.Combine(ia111, ia110,
+		[]*seg.PathSegment{seg120To111, seg130To111},
+		[]*seg.PathSegment{seg110To120, seg110To130},
+		nil)
+	via120 := combinator.Combine(ia111, ia110,
+		[]*seg.PathSegment{seg120To111},
+		[]*seg.PathSegment{seg110To120},
+		nil)
+	via130 := combinator.Combine(ia111, ia110,
+		[]*seg.PathSegment{seg130To111},
+		[]*seg.PathSegment{seg110To130},
+		nil)
+
+	tests := map[string]struct {
+		Filter        *sciond.PathReqFilter
+		ExpectedPaths []*combinator.Path
+	}{
+		"forbidden AS": {
+			Filter:        &sciond.PathReqFilter{ForbiddenASes: []addr.IA{ia120}},
+			ExpectedPaths: via130,
+		},
+		"forbidden ISD wildcard": {
+			Filter:        &sciond.PathReqFilter{ForbiddenASes: []addr.IA{{I: 1}}},
+			ExpectedPaths: []*combinator.Path{},
+		},
+		"required AS": {
+			Filter: &sciond.PathReqFilter{
+				RequiredIntfs: []sciond.PathInterface{{RawIsdas: ia120.IAInt()}},
+			},
+			ExpectedPaths: via120,
+		},
+		"required interface": {
+			Filter: &sciond.PathReqFilter{
+				RequiredIntfs: []sciond.PathInterface{
+					{RawIsdas: ia110.IAInt(), IfID: graph.If_110_X_130_A},
+				},
+			},
+			ExpectedPaths: via130,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			filtered := fetcher.FilterAndRank(paths111To110, test.Filter)
+			assert.ElementsMatch(t, test.ExpectedPaths, filtered)
+		})
+	}
+	t.Run("ranking", func(t *testing.T) {
+		paths := append(append([]*combinator.Path{}, via120...), via130...)
+		for _, p := range paths {
+			p.Metadata = nil
+		}
+		paths[0].Weight = 3
+		paths[1].Weight = 2
+		filtered := fetcher.FilterAndRank(paths, &sciond.PathReqFilter{})
+		assert.Equal(t, []*combinator.Path{paths[1], paths[0]}, filtered)
+
+		paths[0].Weight = 2
+		paths[0].Metadata = &sciond.PathMetadata{Latencies: []uint32{10, 10, 10}}
+		paths[1].Metadata = &sciond.PathMetadata{Latencies: []uint32{10, 0, 10}}
+		filtered = fetcher.FilterAndRank(paths, &sciond.PathReqFilter{})
+		assert.Equal(t, []*combinator.Path{paths[0], paths[1]}, filtered)
+	})
+}
@@ -32,6 +32,7 @@ const (
 	subsystemIFInfo     = "if_info"
 	subsystemSVCInfo    = "service_info"
 	subsystemRevocation = "revocation"
+	subsystemSubscribe  = "path_subscription"
 )
 
 // Revocation sources
@@ -64,6 +65,8 @@ var (
 	IFInfos = newIFInfo()
 	// SVCInfos contains metrics for SVC info requests.
 	SVCInfos = newSVCInfo()
+	// Subscriptions contains metrics for path subscriptions.
+	Subscriptions = newSubscription()
 )
 
 type resultLabel struct {
@@ -183,6 +186,49 @@ func (r Revocation) Start() func(RevocationLabels) {
 	}
 }
 
+// SubscriptionUpdateLabels are the labels for path subscription update metrics.
+type SubscriptionUpdateLabels struct {
+	Result string
+	Reason string
+}
+
+// Labels returns the labels.
+func (l SubscriptionUpdateLabels) Labels() []string {
+	return []string{prom.LabelResult, "reason"}
+}
+
+// Values returns the values for the labels.
+func (l SubscriptionUpdateLabels) Values() []string {
+	return []string{l.Result, l.Reason}
+}
+
+// Subscription contains the metrics for path subscriptions.
+type Subscription struct {
+	active  prometheus.Gauge
+	updates *prometheus.CounterVec
+}
+
+func newSubscription() Subscription {
+	return Subscription{
+		active: prom.NewGauge(Namespace, subsystemSubscribe, "active",
+			"The number of active path subscriptions."),
+		updates: prom.NewCounterVecWithLabels(Namespace, subsystemSubscribe, "updates_total",
+			"The amount of path updates pushed to subscribers.", SubscriptionUpdateLabels{}),
+	}
+}
+
+// Start registers a new subscription and returns a callback that should be
+// called when the subscription ends.
+func (s Subscription) Start() func() {
+	s.active.Inc()
+	return s.active.Dec
+}
+
+// Update returns the counter for pushed path updates.
+func (s Subscription) Update(l SubscriptionUpdateLabels) prometheus.Counter {
+	return s.updates.WithLabelValues(l.Values()...)
+}
+
 // Request is the generic metric for requests.
 type Request struct {
 	count   *prometheus.CounterVec
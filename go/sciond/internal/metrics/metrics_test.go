@@ -24,4 +24,5 @@ import (
 func TestLabels(t *testing.T) {
 	promtest.CheckLabelsStruct(t, metrics.PathRequestLabels{})
 	promtest.CheckLabelsStruct(t, metrics.RevocationLabels{})
+	promtest.CheckLabelsStruct(t, metrics.SubscriptionUpdateLabels{})
 }
@@ -0,0 +1,97 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probing
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/periodic"
+	"github.com/scionproto/scion/go/lib/sciond/pathprobe"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+var _ periodic.Task = (*Prober)(nil)
+
+// Prober is a periodic task that probes the paths tracked in the store. Each
+// run sends at most MaxProbes probes, the paths that were probed least
+// recently are probed first.
+type Prober struct {
+	Store *Store
+	// Local is the local address the probes are sent from.
+	Local snet.Addr
+	// DispPath is the path to the dispatcher socket. If empty, the default
+	// dispatcher is used.
+	DispPath string
+	// MaxProbes is the maximum number of probes sent per run.
+	MaxProbes int
+	// Timeout is the time to wait for the replies to the probes.
+	Timeout time.Duration
+}
+
+func (p *Prober) Name() string {
+	return "sd_path_prober"
+}
+
+func (p *Prober) Run(ctx context.Context) {
+	logger := log.FromCtx(ctx)
+	probes := p.Store.due(time.Now(), p.MaxProbes)
+	if len(probes) == 0 {
+		return
+	}
+	byDst := make(map[addr.IA][]probe)
+	for _, pr := range probes {
+		byDst[pr.dst] = append(byDst[pr.dst], pr)
+	}
+	var wg sync.WaitGroup
+	for dst, group := range byDst {
+		wg.Add(1)
+		go func(dst addr.IA, group []probe) {
+			defer log.LogPanicAndExit()
+			defer wg.Done()
+			if err := p.probe(ctx, dst, group); err != nil {
+				logger.Info("[probing.Prober] Unable to probe paths", "dst", dst, "err", err)
+			}
+		}(dst, group)
+	}
+	wg.Wait()
+	logger.Trace("[probing.Prober] Probed paths", "probes", len(probes))
+}
+
+func (p *Prober) probe(ctx context.Context, dst addr.IA, group []probe) error {
+	ctx, cancelF := context.WithTimeout(ctx, p.Timeout)
+	defer cancelF()
+	paths := make([]snet.Path, 0, len(group))
+	for _, pr := range group {
+		paths = append(paths, pr.path)
+	}
+	prober := pathprobe.Prober{
+		DstIA:    dst,
+		Local:    p.Local,
+		DispPath: p.DispPath,
+	}
+	statuses, err := prober.GetStatuses(ctx, paths)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, pr := range group {
+		p.Store.record(pr.key, statuses[pathprobe.PathKey(pr.path)], now)
+	}
+	return nil
+}
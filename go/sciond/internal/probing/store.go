@@ -0,0 +1,225 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package probing probes the paths that SCIOND hands out and keeps track of
+// their observed round-trip time and loss.
+package probing
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/sciond/pathprobe"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// lossWindow is the number of most recent probes the loss is computed over.
+const lossWindow = 16
+
+// Store keeps track of the paths that SCIOND handed out and of the quality
+// observed when probing them. Paths are identified by their fingerprint. A nil
+// Store tracks nothing.
+type Store struct {
+	maxPaths    int
+	idleTimeout time.Duration
+
+	mu    sync.Mutex
+	paths map[string]*trackedPath
+}
+
+// NewStore creates a store that tracks at most maxPaths paths. Paths that are
+// not handed out again within idleTimeout are dropped.
+func NewStore(maxPaths int, idleTimeout time.Duration) *Store {
+	return &Store{
+		maxPaths:    maxPaths,
+		idleTimeout: idleTimeout,
+		paths:       make(map[string]*trackedPath),
+	}
+}
+
+type trackedPath struct {
+	dst           addr.IA
+	path          snet.Path
+	lastRequested time.Time
+	stats         stats
+}
+
+// Track registers the paths in entries for probing. Already tracked paths are
+// updated with the most recent forwarding path. Paths that do not fit into the
+// store are not tracked.
+func (s *Store) Track(dst addr.IA, entries []sciond.PathReplyEntry, now time.Time) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, entry := range entries {
+		if entry.Path == nil || len(entry.Path.Interfaces) == 0 {
+			// The empty path to the local AS can not be probed.
+			continue
+		}
+		path, err := sciond.PathFromEntry(entry, dst)
+		if err != nil {
+			continue
+		}
+		key := path.Fingerprint()
+		if tp, ok := s.paths[key]; ok {
+			tp.path = path
+			tp.lastRequested = now
+			continue
+		}
+		if len(s.paths) >= s.maxPaths {
+			continue
+		}
+		s.paths[key] = &trackedPath{dst: dst, path: path, lastRequested: now}
+	}
+}
+
+// Annotate sets the observed quality on the entries of paths that were
+// already probed.
+func (s *Store) Annotate(dst addr.IA, entries []sciond.PathReplyEntry) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, entry := range entries {
+		if entry.Path == nil || len(entry.Path.Interfaces) == 0 {
+			continue
+		}
+		path, err := sciond.PathFromEntry(entry, dst)
+		if err != nil {
+			continue
+		}
+		if tp, ok := s.paths[path.Fingerprint()]; ok {
+			entries[i].Quality = tp.stats.quality()
+		}
+	}
+}
+
+// probe is a path that is due for probing.
+type probe struct {
+	key  string
+	dst  addr.IA
+	path snet.Path
+}
+
+// due removes idle and expired paths and returns at most max paths that
+// should be probed next. The paths that were probed least recently come
+// first.
+func (s *Store) due(now time.Time, max int) []probe {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	type candidate struct {
+		probe
+		lastProbe time.Time
+	}
+	var candidates []candidate
+	for key, tp := range s.paths {
+		if now.Sub(tp.lastRequested) > s.idleTimeout || !now.Before(tp.path.Expiry()) {
+			delete(s.paths, key)
+			continue
+		}
+		candidates = append(candidates, candidate{
+			probe:     probe{key: key, dst: tp.dst, path: tp.path.Copy()},
+			lastProbe: tp.stats.lastProbe,
+		})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].lastProbe.Before(candidates[j].lastProbe)
+	})
+	if len(candidates) > max {
+		candidates = candidates[:max]
+	}
+	probes := make([]probe, 0, len(candidates))
+	for _, c := range candidates {
+		probes = append(probes, c.probe)
+	}
+	return probes
+}
+
+// record records the result of probing the path with the given key. Probes
+// with unknown status are ignored.
+func (s *Store) record(key string, status pathprobe.Status, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tp, ok := s.paths[key]
+	if !ok {
+		return
+	}
+	switch status.Status {
+	case pathprobe.StatusAlive:
+		tp.stats.add(true, status.RTT, now)
+	case pathprobe.StatusTimeout, pathprobe.StatusSCMP:
+		tp.stats.add(false, 0, now)
+	}
+}
+
+// stats contains the observed quality of a path. The round-trip time is
+// smoothed as in TCP (RFC 6298).
+type stats struct {
+	srtt      time.Duration
+	rttvar    time.Duration
+	results   []bool
+	next      int
+	lastProbe time.Time
+}
+
+func (s *stats) add(ok bool, rtt time.Duration, now time.Time) {
+	s.lastProbe = now
+	if len(s.results) < lossWindow {
+		s.results = append(s.results, ok)
+	} else {
+		s.results[s.next] = ok
+		s.next = (s.next + 1) % lossWindow
+	}
+	if !ok {
+		return
+	}
+	if s.srtt == 0 {
+		s.srtt = rtt
+		s.rttvar = rtt / 2
+		return
+	}
+	diff := s.srtt - rtt
+	if diff < 0 {
+		diff = -diff
+	}
+	s.rttvar = (3*s.rttvar + diff) / 4
+	s.srtt = (7*s.srtt + rtt) / 8
+}
+
+// quality returns the observed quality, or nil if the path was not probed
+// yet.
+func (s *stats) quality() *sciond.PathQuality {
+	if len(s.results) == 0 {
+		return nil
+	}
+	q := &sciond.PathQuality{
+		Rtt:       uint32(s.srtt / time.Microsecond),
+		RttVar:    uint32(s.rttvar / time.Microsecond),
+		Sent:      uint32(len(s.results)),
+		LastProbe: util.TimeToSecs(s.lastProbe),
+	}
+	for _, ok := range s.results {
+		if !ok {
+			q.Lost++
+		}
+	}
+	return q
+}
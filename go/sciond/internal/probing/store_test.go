@@ -0,0 +1,150 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probing
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/hostinfo"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/sciond/pathprobe"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestStoreTrack(t *testing.T) {
+	now := time.Now()
+	dst := xtest.MustParseIA("1-ff00:0:111")
+	entries := []sciond.PathReplyEntry{
+		newEntry(t, now, 1, 2),
+		newEntry(t, now, 3, 4),
+		newEntry(t, now, 5, 6),
+		{Path: &sciond.FwdPathMeta{}},
+	}
+
+	t.Run("limit paths", func(t *testing.T) {
+		s := NewStore(2, time.Minute)
+		s.Track(dst, entries, now)
+		assert.Len(t, s.due(now, 10), 2)
+	})
+	t.Run("drop idle paths", func(t *testing.T) {
+		s := NewStore(10, time.Minute)
+		s.Track(dst, entries, now)
+		assert.Len(t, s.due(now, 10), 3)
+		assert.Len(t, s.due(now.Add(2*time.Minute), 10), 0)
+	})
+	t.Run("drop expired paths", func(t *testing.T) {
+		s := NewStore(10, 24*time.Hour)
+		s.Track(dst, entries, now)
+		assert.Len(t, s.due(now.Add(2*time.Hour), 10), 0)
+	})
+	t.Run("nil store", func(t *testing.T) {
+		var s *Store
+		s.Track(dst, entries, now)
+		s.Annotate(dst, entries)
+		assert.Nil(t, entries[0].Quality)
+	})
+}
+
+func TestStoreDue(t *testing.T) {
+	now := time.Now()
+	dst := xtest.MustParseIA("1-ff00:0:111")
+	s := NewStore(10, time.Minute)
+	s.Track(dst, []sciond.PathReplyEntry{newEntry(t, now, 1, 2), newEntry(t, now, 3, 4)}, now)
+
+	probes := s.due(now, 1)
+	require.Len(t, probes, 1)
+	s.record(probes[0].key, pathprobe.Status{Status: pathprobe.StatusAlive}, now)
+	next := s.due(now, 1)
+	require.Len(t, next, 1)
+	assert.NotEqual(t, probes[0].key, next[0].key, "least recently probed path first")
+}
+
+func TestStoreAnnotate(t *testing.T) {
+	now := time.Now()
+	dst := xtest.MustParseIA("1-ff00:0:111")
+	s := NewStore(10, time.Minute)
+	entries := []sciond.PathReplyEntry{newEntry(t, now, 1, 2), newEntry(t, now, 3, 4)}
+	s.Track(dst, entries, now)
+
+	probes := s.due(now, 10)
+	require.Len(t, probes, 2)
+	for _, pr := range probes {
+		path := pr.path.(sciond.Path)
+		if path.Interfaces()[0].ID() != 1 {
+			continue
+		}
+		s.record(pr.key, pathprobe.Status{Status: pathprobe.StatusAlive,
+			RTT: 10 * time.Millisecond}, now)
+		s.record(pr.key, pathprobe.Status{Status: pathprobe.StatusTimeout}, now)
+		s.record(pr.key, pathprobe.Status{Status: pathprobe.StatusUnknown}, now)
+	}
+	s.Annotate(dst, entries)
+	require.NotNil(t, entries[0].Quality)
+	assert.Equal(t, 10*time.Millisecond, entries[0].Quality.RTT())
+	assert.Equal(t, 5*time.Millisecond, entries[0].Quality.RTTVar())
+	assert.Equal(t, 0.5, entries[0].Quality.LossRate())
+	assert.Nil(t, entries[1].Quality, "path not probed yet")
+}
+
+func TestStats(t *testing.T) {
+	now := time.Now()
+	var s stats
+	assert.Nil(t, s.quality())
+	s.add(true, 80*time.Millisecond, now)
+	s.add(true, 160*time.Millisecond, now)
+	q := s.quality()
+	assert.Equal(t, 90*time.Millisecond, q.RTT())
+	assert.Equal(t, 50*time.Millisecond, q.RTTVar())
+	for i := 0; i < lossWindow; i++ {
+		s.add(false, 0, now)
+	}
+	q = s.quality()
+	assert.Equal(t, uint32(lossWindow), q.Sent)
+	assert.Equal(t, uint32(lossWindow), q.Lost)
+	assert.Equal(t, 90*time.Millisecond, q.RTT(), "losses do not affect the RTT")
+}
+
+func newEntry(t *testing.T, now time.Time, ifids ...common.IFIDType) sciond.PathReplyEntry {
+	t.Helper()
+	var intfs []sciond.PathInterface
+	for _, ifid := range ifids {
+		intfs = append(intfs, sciond.PathInterface{
+			RawIsdas: xtest.MustParseIA("1-ff00:0:110").IAInt(),
+			IfID:     ifid,
+		})
+	}
+	// A minimal raw path consisting of an info field and a hop field.
+	raw := make(common.RawBytes, spath.InfoFieldLength+spath.HopFieldLength)
+	info := spath.InfoField{ConsDir: true, Hops: 1, ISD: 1}
+	info.Write(raw[:spath.InfoFieldLength])
+	hop := spath.HopField{ConsEgress: ifids[0]}
+	hop.Write(raw[spath.InfoFieldLength:])
+	return sciond.PathReplyEntry{
+		Path: &sciond.FwdPathMeta{
+			FwdPath:    raw,
+			Interfaces: intfs,
+			ExpTime:    util.TimeToSecs(now.Add(time.Hour)),
+		},
+		HostInfo: hostinfo.FromUDPAddr(net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 30041}),
+	}
+}
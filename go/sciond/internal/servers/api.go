@@ -52,6 +52,10 @@ func NewConnHandler(conn net.PacketConn,
 }
 
 func (srv *ConnHandler) Serve() error {
+	// Handlers that outlive a single message (e.g., path subscriptions) are
+	// bound to the lifetime of the connection.
+	ctx, cancelF := context.WithCancel(context.Background())
+	defer cancelF()
 	for {
 		b := make(common.RawBytes, common.MaxMTU)
 		n, address, err := srv.Conn.ReadFrom(b)
@@ -60,12 +64,16 @@ func (srv *ConnHandler) Serve() error {
 		}
 		go func() {
 			defer log.LogPanicAndExit()
-			srv.Handle(b[:n], address)
+			srv.handle(ctx, b[:n], address)
 		}()
 	}
 }
 
 func (srv *ConnHandler) Handle(b common.RawBytes, address net.Addr) {
+	srv.handle(context.Background(), b, address)
+}
+
+func (srv *ConnHandler) handle(ctx context.Context, b common.RawBytes, address net.Addr) {
 	p := &sciond.Pld{}
 	if err := proto.ParseFromReader(p, bytes.NewReader(b)); err != nil {
 		log.Error("capnp error", "err", err)
@@ -76,7 +84,7 @@ func (srv *ConnHandler) Handle(b common.RawBytes, address net.Addr) {
 		log.Error("handler not found for capnp message", "which", p.Which)
 		return
 	}
-	ctx, span := tracing.CtxWith(context.Background(), srv.Logger,
+	ctx, span := tracing.CtxWith(ctx, srv.Logger,
 		fmt.Sprintf("%s.handler", p.Which))
 	defer span.Finish()
 	handler.Handle(ctx, srv.Conn, address, p)
@@ -33,6 +33,7 @@ import (
 	"github.com/scionproto/scion/go/proto"
 	"github.com/scionproto/scion/go/sciond/internal/fetcher"
 	"github.com/scionproto/scion/go/sciond/internal/metrics"
+	"github.com/scionproto/scion/go/sciond/internal/probing"
 )
 
 const (
@@ -56,6 +57,9 @@ type Handler interface {
 // for each PathRequest it receives.
 type PathRequestHandler struct {
 	Fetcher *fetcher.Fetcher
+	// Quality tracks the paths that are handed out for probing and adds their
+	// observed quality to the replies. If nil, paths are not probed.
+	Quality *probing.Store
 }
 
 func (h *PathRequestHandler) Handle(ctx context.Context, conn net.PacketConn, src net.Addr,
@@ -72,6 +76,15 @@ func (h *PathRequestHandler) Handle(ctx context.Context, conn net.PacketConn, sr
 		logger.Error("Unable to get paths", "err", err)
 		labels.Result = segfetcher.ErrToMetricsLabel(err)
 	}
+	if getPathsReply == nil {
+		// The Fetcher does not build a reply for invalid requests.
+		getPathsReply = &sciond.PathReply{ErrorCode: sciond.ErrorInternal}
+	}
+	if err == nil && getPathsReply.ErrorCode == sciond.ErrorOk {
+		dst := pld.PathReq.Dst.IA()
+		h.Quality.Track(dst, getPathsReply.Entries, time.Now())
+		h.Quality.Annotate(dst, getPathsReply.Entries)
+	}
 	// Always reply, as the Fetcher will fill in the relevant error bits of the reply
 	reply := &sciond.Pld{
 		Id:        pld.Id,
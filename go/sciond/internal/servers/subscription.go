@@ -0,0 +1,291 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/revcache"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/proto"
+	"github.com/scionproto/scion/go/sciond/internal/fetcher"
+	"github.com/scionproto/scion/go/sciond/internal/metrics"
+	"github.com/scionproto/scion/go/sciond/internal/probing"
+)
+
+// DefaultSubscriptionCheckInterval is the interval in which path subscriptions
+// look for new paths.
+const DefaultSubscriptionCheckInterval = 5 * time.Second
+
+// PathEvents notifies path subscriptions about events that might change the
+// available paths, e.g., newly inserted revocations. The zero value is ready
+// to use, a nil PathEvents never notifies.
+type PathEvents struct {
+	mu   sync.Mutex
+	subs map[chan struct{}]struct{}
+}
+
+// Notify wakes up all path subscriptions. It never blocks.
+func (e *PathEvents) Notify() {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for c := range e.subs {
+		select {
+		case c <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// subscribe returns a channel that receives a value after Notify is called.
+// Multiple notifications that are not consumed are coalesced. The returned
+// function must be called to unsubscribe.
+func (e *PathEvents) subscribe() (<-chan struct{}, func()) {
+	c := make(chan struct{}, 1)
+	if e == nil {
+		return c, func() {}
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.subs == nil {
+		e.subs = make(map[chan struct{}]struct{})
+	}
+	e.subs[c] = struct{}{}
+	return c, func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		delete(e.subs, c)
+	}
+}
+
+// NewNotifyingRevCache wraps the revocation cache such that events is notified
+// whenever a new revocation is inserted.
+func NewNotifyingRevCache(rc revcache.RevCache, events *PathEvents) revcache.RevCache {
+	return notifyingRevCache{RevCache: rc, events: events}
+}
+
+type notifyingRevCache struct {
+	revcache.RevCache
+	events *PathEvents
+}
+
+func (c notifyingRevCache) Insert(ctx context.Context,
+	rev *path_mgmt.SignedRevInfo) (bool, error) {
+
+	inserted, err := c.RevCache.Insert(ctx, rev)
+	if inserted {
+		c.events.Notify()
+	}
+	return inserted, err
+}
+
+// PathSubscriptionHandler represents the shared global state for the handling
+// of all path subscriptions. For each subscription, the handler pushes the
+// current paths to the client whenever they change. The paths are looked up
+// again when a revocation is inserted, when a path expires and periodically to
+// discover new paths. A subscription ends when the client closes the
+// connection.
+type PathSubscriptionHandler struct {
+	Fetcher *fetcher.Fetcher
+	// Events wakes up the subscriptions, e.g., on new revocations.
+	Events *PathEvents
+	// CheckInterval is the interval in which subscriptions look for new paths.
+	// If zero, DefaultSubscriptionCheckInterval is used.
+	CheckInterval time.Duration
+	// Quality tracks the pushed paths for probing and adds their observed
+	// quality to the updates. If nil, paths are not probed.
+	Quality *probing.Store
+}
+
+func (h *PathSubscriptionHandler) Handle(ctx context.Context, conn net.PacketConn,
+	src net.Addr, pld *sciond.Pld) {
+
+	logger := log.FromCtx(ctx)
+	logger.Debug("[PathSubscriptionHandler] Received subscription", "req", pld.PathSubscribeReq)
+	trigger, unsubscribe := h.Events.subscribe()
+	defer unsubscribe()
+
+	current, err := h.getPaths(ctx, pld.PathSubscribeReq)
+	if err != nil {
+		logger.Error("Unable to get paths", "err", err)
+	}
+	if err := h.push(conn, src, pld.Id, sciond.PathUpdateInitial, current); err != nil {
+		logger.Warn("Unable to reply to client", "client", src, "err", err)
+		return
+	}
+	defer metrics.Subscriptions.Start()()
+
+	interval := h.CheckInterval
+	if interval == 0 {
+		interval = DefaultSubscriptionCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if !waitForChange(ctx, ticker.C, trigger, current) {
+			logger.Debug("[PathSubscriptionHandler] Subscription ended")
+			return
+		}
+		reply, err := h.getPaths(ctx, pld.PathSubscribeReq)
+		if err != nil {
+			logger.Error("Unable to get paths", "err", err)
+			continue
+		}
+		reason, changed := classifyUpdate(current, reply, time.Now())
+		if !changed {
+			continue
+		}
+		if err := h.push(conn, src, pld.Id, reason, reply); err != nil {
+			logger.Warn("Unable to push paths to client", "client", src, "err", err)
+			return
+		}
+		logger.Debug("Pushed paths", "reason", reason, "num_paths", len(reply.Entries),
+			"err_code", reply.ErrorCode)
+		current = reply
+	}
+}
+
+// waitForChange blocks until the paths might have changed. It returns false if
+// the context is done.
+func waitForChange(ctx context.Context, tick <-chan time.Time, trigger <-chan struct{},
+	current *sciond.PathReply) bool {
+
+	var expired <-chan time.Time
+	if next, ok := nextExpiry(current, time.Now()); ok {
+		timer := time.NewTimer(time.Until(next))
+		defer timer.Stop()
+		expired = timer.C
+	}
+	select {
+	case <-ctx.Done():
+		return false
+	case <-tick:
+	case <-trigger:
+	case <-expired:
+	}
+	return true
+}
+
+func (h *PathSubscriptionHandler) getPaths(ctx context.Context,
+	req *sciond.PathReq) (*sciond.PathReply, error) {
+
+	workCtx, workCancelF := context.WithTimeout(ctx, DefaultWorkTimeout)
+	defer workCancelF()
+	reply, err := h.Fetcher.GetPaths(workCtx, req, DefaultEarlyReply, log.FromCtx(ctx))
+	if reply != nil && reply.ErrorCode == sciond.ErrorOk {
+		h.Quality.Track(req.Dst.IA(), reply.Entries, time.Now())
+		h.Quality.Annotate(req.Dst.IA(), reply.Entries)
+	}
+	return reply, err
+}
+
+func (h *PathSubscriptionHandler) push(conn net.PacketConn, src net.Addr, id uint64,
+	reason sciond.PathUpdateReason, reply *sciond.PathReply) error {
+
+	update := &sciond.Pld{
+		Id:    id,
+		Which: proto.SCIONDMsg_Which_pathUpdate,
+		PathUpdate: &sciond.PathUpdate{
+			Reason: reason,
+			Reply:  reply,
+		},
+	}
+	err := sendReply(update, conn, src)
+	labels := metrics.SubscriptionUpdateLabels{Result: metrics.OkSuccess, Reason: reason.String()}
+	if err != nil {
+		labels.Result = metrics.ErrNetwork
+	}
+	metrics.Subscriptions.Update(labels).Inc()
+	return err
+}
+
+// classifyUpdate compares the previously pushed paths with the new paths. It
+// returns whether the paths changed and why. Paths are identified by their
+// interfaces, a path with the same interfaces but a different forwarding path
+// counts as refreshed.
+func classifyUpdate(prev, next *sciond.PathReply,
+	now time.Time) (sciond.PathUpdateReason, bool) {
+
+	oldPaths, newPaths := replyPaths(prev), replyPaths(next)
+	var added, removed, expired bool
+	for key, entry := range newPaths {
+		oldEntry, ok := oldPaths[key]
+		if !ok || string(oldEntry.Path.FwdPath) != string(entry.Path.FwdPath) {
+			added = true
+		}
+	}
+	for key, entry := range oldPaths {
+		if _, ok := newPaths[key]; ok {
+			continue
+		}
+		removed = true
+		if !entry.Path.Expiry().After(now) {
+			expired = true
+		}
+	}
+	switch {
+	case expired:
+		return sciond.PathUpdateExpired, true
+	case added:
+		return sciond.PathUpdateNewPaths, true
+	case removed:
+		return sciond.PathUpdateRevoked, true
+	}
+	return sciond.PathUpdateInitial, false
+}
+
+// replyPaths returns the entries of the reply keyed by their interfaces.
+func replyPaths(reply *sciond.PathReply) map[string]sciond.PathReplyEntry {
+	paths := make(map[string]sciond.PathReplyEntry)
+	if reply == nil || reply.ErrorCode != sciond.ErrorOk {
+		return paths
+	}
+	for _, entry := range reply.Entries {
+		if entry.Path == nil {
+			continue
+		}
+		key := ""
+		for _, intf := range entry.Path.Interfaces {
+			key += intf.String() + " "
+		}
+		paths[key] = entry
+	}
+	return paths
+}
+
+// nextExpiry returns the earliest expiration time after now of the paths in
+// the reply.
+func nextExpiry(reply *sciond.PathReply, now time.Time) (time.Time, bool) {
+	var next time.Time
+	for _, entry := range replyPaths(reply) {
+		if len(entry.Path.Interfaces) == 0 {
+			// The empty path to the local AS never expires.
+			continue
+		}
+		exp := entry.Path.Expiry()
+		if exp.After(now) && (next.IsZero() || exp.Before(next)) {
+			next = exp
+		}
+	}
+	return next, !next.IsZero()
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/scionproto/scion/go/lib/discovery"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/fatal"
+	"github.com/scionproto/scion/go/lib/infra"
 	"github.com/scionproto/scion/go/lib/infra/infraenv"
 	"github.com/scionproto/scion/go/lib/infra/messenger"
 	"github.com/scionproto/scion/go/lib/infra/modules/idiscovery"
@@ -46,6 +47,7 @@ import (
 	"github.com/scionproto/scion/go/proto"
 	"github.com/scionproto/scion/go/sciond/internal/config"
 	"github.com/scionproto/scion/go/sciond/internal/fetcher"
+	"github.com/scionproto/scion/go/sciond/internal/probing"
 	"github.com/scionproto/scion/go/sciond/internal/servers"
 )
 
@@ -84,10 +86,6 @@ func realMain() int {
 		log.Crit("Setup failed", "err", err)
 		return 1
 	}
-	if err := startDiscovery(cfg.Discovery); err != nil {
-		log.Crit("Unable to start topology fetcher", "err", err)
-		return 1
-	}
 	pathDB, revCache, err := pathstorage.NewPathStorage(cfg.SD.PathDB, cfg.SD.RevCache)
 	if err != nil {
 		log.Crit("Unable to initialize path storage", "err", err)
@@ -108,6 +106,10 @@ func realMain() int {
 		log.Crit("Unable to load local TRC", "err", err)
 		return 1
 	}
+	if err := startDiscovery(cfg.Discovery, trustStore.NewVerifier()); err != nil {
+		log.Crit("Unable to start topology fetcher", "err", err)
+		return 1
+	}
 	tracer, trCloser, err := cfg.Tracing.NewTracer(cfg.General.ID)
 	if err != nil {
 		log.Crit("Unable to create tracer", "err", err)
@@ -125,6 +127,8 @@ func realMain() int {
 			Address:  cfg.QUIC.Address,
 			CertFile: cfg.QUIC.CertFile,
 			KeyFile:  cfg.QUIC.KeyFile,
+			UsePKI:   cfg.QUIC.UsePKI,
+			KeyDir:   filepath.Join(cfg.General.ConfigDir, "keys"),
 		},
 		SVCResolutionFraction: cfg.QUIC.ResolutionFraction,
 		TrustStore:            trustStore,
@@ -135,18 +139,36 @@ func realMain() int {
 		log.Crit(infraenv.ErrAppUnableToInitMessenger.Error(), "err", err)
 		return 1
 	}
+	// Path subscriptions are woken up whenever a new revocation is inserted.
+	pathEvents := &servers.PathEvents{}
+	notifyingRevCache := servers.NewNotifyingRevCache(revCache, pathEvents)
+	pathFetcher := fetcher.NewFetcher(
+		msger,
+		pathDB,
+		trustStore,
+		notifyingRevCache,
+		cfg.SD,
+		itopo.Provider(),
+		log.Root(),
+	)
+	var pathQuality *probing.Store
+	if cfg.SD.Probing.Enabled {
+		pathQuality = probing.NewStore(cfg.SD.Probing.MaxPaths,
+			cfg.SD.Probing.IdleTimeout.Duration)
+		prober := periodic.Start(newProber(pathQuality), cfg.SD.Probing.Interval.Duration,
+			cfg.SD.Probing.Interval.Duration)
+		defer prober.Stop()
+	}
 	// Route messages to their correct handlers
 	handlers := servers.HandlerMap{
 		proto.SCIONDMsg_Which_pathReq: &servers.PathRequestHandler{
-			Fetcher: fetcher.NewFetcher(
-				msger,
-				pathDB,
-				trustStore,
-				revCache,
-				cfg.SD,
-				itopo.Provider(),
-				log.Root(),
-			),
+			Fetcher: pathFetcher,
+			Quality: pathQuality,
+		},
+		proto.SCIONDMsg_Which_pathSubscribeReq: &servers.PathSubscriptionHandler{
+			Fetcher: pathFetcher,
+			Events:  pathEvents,
+			Quality: pathQuality,
 		},
 		proto.SCIONDMsg_Which_asInfoReq: &servers.ASInfoRequestHandler{
 			ASInspector: trustStore,
@@ -154,7 +176,7 @@ func realMain() int {
 		proto.SCIONDMsg_Which_ifInfoRequest:      &servers.IFInfoRequestHandler{},
 		proto.SCIONDMsg_Which_serviceInfoRequest: &servers.SVCInfoRequestHandler{},
 		proto.SCIONDMsg_Which_revNotification: &servers.RevNotificationHandler{
-			RevCache:         revCache,
+			RevCache:         notifyingRevCache,
 			VerifierFactory:  trustStore,
 			NextQueryCleaner: segfetcher.NextQueryCleaner{PathDB: pathDB},
 		},
@@ -172,6 +194,12 @@ func realMain() int {
 	unixpacketServer, shutdownF := NewServer("unixpacket", cfg.SD.Unix, handlers, log.Root())
 	defer shutdownF()
 	StartServer("UnixServer", cfg.SD.Unix, unixpacketServer)
+	env.StatusPages{
+		Config:   &cfg,
+		ID:       cfg.General.ID,
+		Features: cfg.Features,
+		Health:   env.HealthChecks(env.TopologyHealth, env.DispatcherHealth("")),
+	}.Register()
 	cfg.Metrics.StartPrometheus()
 	select {
 	case <-fatal.ShutdownChan():
@@ -211,13 +239,29 @@ func setup() error {
 	return cfg.SD.CreateSocketDirs()
 }
 
-func startDiscovery(file idiscovery.Config) error {
+func startDiscovery(file idiscovery.Config, verifier infra.Verifier) error {
 	var err error
 	discRunners, err = idiscovery.StartRunners(file, discovery.Default,
-		idiscovery.TopoHandlers{}, nil, "sd")
+		idiscovery.TopoHandlers{}, verifier, nil, "sd")
 	return err
 }
 
+// newProber creates the prober for the paths tracked in store. The probes are
+// sent from the SCION address of SCIOND on a random port.
+func newProber(store *probing.Store) *probing.Prober {
+	local := cfg.SD.Public.Copy()
+	if cfg.SD.Bind != nil {
+		local = cfg.SD.Bind.Copy()
+	}
+	local.Host.L4 = 0
+	return &probing.Prober{
+		Store:     store,
+		Local:     *local,
+		MaxProbes: cfg.SD.Probing.MaxProbes,
+		Timeout:   cfg.SD.Probing.Timeout.Duration,
+	}
+}
+
 func NewServer(network string, rsockPath string, handlers servers.HandlerMap,
 	logger log.Logger) (*servers.Server, func()) {
 
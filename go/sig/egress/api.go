@@ -16,14 +16,19 @@
 package egress
 
 import (
+	"context"
 	"io"
 
+	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/fatal"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/sig/egress/asmap"
 	"github.com/scionproto/scion/go/sig/egress/iface"
 	"github.com/scionproto/scion/go/sig/egress/reader"
 	"github.com/scionproto/scion/go/sig/internal/config"
+	"github.com/scionproto/scion/go/sig/internal/disp"
+	"github.com/scionproto/scion/go/sig/mgmt"
+	"github.com/scionproto/scion/go/sig/sigcmn"
 )
 
 func Init(tunIO io.ReadWriteCloser) {
@@ -41,3 +46,24 @@ func ReloadConfig(cfg *config.Cfg) bool {
 	log.Info("Config reloaded")
 	return res
 }
+
+// PrefixAnnounceHdlr installs the prefixes announced by remote SIGs.
+func PrefixAnnounceHdlr() {
+	log.Info("PrefixAnnounceHdlr: starting")
+	for rpld := range disp.Dispatcher.PrefixAnnounceC {
+		a, ok := rpld.P.(*mgmt.PrefixAnnounce)
+		if !ok {
+			log.Error("PrefixAnnounceHdlr: non-SIGPrefixAnnounce payload received",
+				"src", rpld.Addr, "type", common.TypeOf(rpld.P), "Id", rpld.Id)
+			continue
+		}
+		ctx, cancelF := context.WithTimeout(context.Background(), sigcmn.VerifyTimeout)
+		err := asmap.Map.Announce(ctx, rpld.Addr.IA, a)
+		cancelF()
+		if err != nil {
+			log.Warn("PrefixAnnounceHdlr: Ignoring prefix announcement", "src", rpld.Addr,
+				"err", err)
+		}
+	}
+	log.Info("PrefixAnnounceHdlr: stopped")
+}
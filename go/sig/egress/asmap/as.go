@@ -16,8 +16,10 @@
 package asmap
 
 import (
+	"context"
 	"fmt"
 	"net"
+	"sort"
 	"sync"
 	"time"
 
@@ -25,19 +27,30 @@ import (
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/ringbuf"
+	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/sig/egress/dispatcher"
 	"github.com/scionproto/scion/go/sig/egress/iface"
 	"github.com/scionproto/scion/go/sig/egress/router"
 	"github.com/scionproto/scion/go/sig/egress/selector"
 	"github.com/scionproto/scion/go/sig/egress/session"
+	"github.com/scionproto/scion/go/sig/egress/siginfo"
 	"github.com/scionproto/scion/go/sig/internal/base"
 	"github.com/scionproto/scion/go/sig/internal/config"
+	"github.com/scionproto/scion/go/sig/internal/encap"
+	"github.com/scionproto/scion/go/sig/mgmt"
+	"github.com/scionproto/scion/go/sig/sigcmn"
 )
 
 const (
 	healthMonitorTick = 5 * time.Second
 )
 
+// AnnounceMaxAge is the maximum age of accepted prefix announcements. The
+// version of an announcement is the time it was signed at, such that replayed
+// announcements are rejected after a restart. If it is zero, the age is not
+// checked.
+var AnnounceMaxAge time.Duration
+
 // ASEntry contains all of the information required to interact with a remote AS.
 type ASEntry struct {
 	sync.RWMutex
@@ -50,6 +63,17 @@ type ASEntry struct {
 	log.Logger
 
 	Session *session.Session
+
+	// announced contains the keys of the networks in Nets that were learned
+	// from prefix announcements of the AS and are not configured.
+	announced map[string]bool
+	// announceAllow lists the networks announced prefixes must be in.
+	announceAllow []*net.IPNet
+	// announceVersion is the version of the last accepted announcement.
+	announceVersion uint64
+	// announceNets contains the allowed networks of the last accepted
+	// announcement.
+	announceNets map[string]*net.IPNet
 }
 
 func newASEntry(ia addr.IA) (*ASEntry, error) {
@@ -58,6 +82,7 @@ func newASEntry(ia addr.IA) (*ASEntry, error) {
 		IA:                ia,
 		IAString:          ia.String(),
 		Nets:              make(map[string]*net.IPNet),
+		announced:         make(map[string]bool),
 		healthMonitorStop: make(chan struct{}),
 	}
 	var err error
@@ -72,19 +97,48 @@ func newASEntry(ia addr.IA) (*ASEntry, error) {
 	return ae, nil
 }
 
-func (ae *ASEntry) ReloadConfig(cfg *config.Cfg, cfgEntry *config.ASEntry) bool {
+// ReloadConfig adds and deletes the networks of the AS in nm according to
+// cfgEntry.
+func (ae *ASEntry) ReloadConfig(cfg *config.Cfg, cfgEntry *config.ASEntry,
+	nm router.NetMapI) bool {
+
 	ae.Lock()
 	defer ae.Unlock()
+	encap.SetPeer(ae.IA, cfgEntry.Encrypt)
+	var sigs []*siginfo.Sig
+	for _, sig := range cfgEntry.Sigs {
+		sigs = append(sigs, &siginfo.Sig{
+			IA:          ae.IA,
+			Host:        addr.HostFromIP(sig.Addr),
+			CtrlL4Port:  int(sig.CtrlPort),
+			EncapL4Port: int(sig.EncapPort),
+		})
+	}
+	ae.Session.SetStaticSigs(sigs)
+	ae.announceAllow = ae.announceAllow[:0]
+	for _, n := range cfgEntry.AnnounceAllow {
+		ae.announceAllow = append(ae.announceAllow, n.IPNet())
+	}
+	// Apply the next announcement again, the allowed and configured networks
+	// might have changed.
+	ae.announceNets = nil
 	// Method calls first to prevent skips due to logical short-circuit
-	s := ae.addNewNets(cfgEntry.Nets)
-	return ae.delOldNets(cfgEntry.Nets) && s
+	s := ae.delOldNets(cfgEntry.Nets, nm)
+	return ae.addNewNets(cfgEntry.Nets, nm) && s
+}
+
+// DelOldNets deletes the networks of the AS from nm that are not in cfgEntry.
+func (ae *ASEntry) DelOldNets(cfgEntry *config.ASEntry, nm router.NetMapI) bool {
+	ae.Lock()
+	defer ae.Unlock()
+	return ae.delOldNets(cfgEntry.Nets, nm)
 }
 
 // addNewNets adds the networks in ipnets that are not currently configured.
-func (ae *ASEntry) addNewNets(ipnets []*config.IPNet) bool {
+func (ae *ASEntry) addNewNets(ipnets []*config.IPNet, nm router.NetMapI) bool {
 	s := true
 	for _, ipnet := range ipnets {
-		err := ae.addNet(ipnet.IPNet())
+		err := ae.addNet(ipnet.IPNet(), nm)
 		if err != nil {
 			ae.Error("Unable to add network", "net", ipnet, "err", err)
 			s = false
@@ -94,16 +148,19 @@ func (ae *ASEntry) addNewNets(ipnets []*config.IPNet) bool {
 }
 
 // delOldNets deletes currently configured networks that are not in ipnets.
-func (ae *ASEntry) delOldNets(ipnets []*config.IPNet) bool {
+func (ae *ASEntry) delOldNets(ipnets []*config.IPNet, nm router.NetMapI) bool {
 	s := true
 Top:
 	for k, v := range ae.Nets {
+		if ae.announced[k] {
+			continue
+		}
 		for _, ipnet := range ipnets {
 			if k == ipnet.IPNet().String() {
 				continue Top
 			}
 		}
-		err := ae.delNet(v)
+		err := ae.delNet(v, nm)
 		if err != nil {
 			ae.Error("Unable to delete network", "net", k, "err", err)
 			s = false
@@ -112,16 +169,19 @@ Top:
 	return s
 }
 
-func (ae *ASEntry) addNet(ipnet *net.IPNet) error {
+func (ae *ASEntry) addNet(ipnet *net.IPNet, nm router.NetMapI) error {
 	if ae.egressRing == nil {
 		// Ensure that the network setup is done
 		ae.setupNet()
 	}
 	key := ipnet.String()
 	if _, ok := ae.Nets[key]; ok {
+		// An announced network that is configured as well is no longer
+		// managed by announcements.
+		delete(ae.announced, key)
 		return nil
 	}
-	if err := router.NetMap.Add(ipnet, ae.IA, ae.egressRing); err != nil {
+	if err := nm.Add(ipnet, ae.IA, ae.egressRing); err != nil {
 		return err
 	}
 	ae.Nets[key] = ipnet
@@ -138,15 +198,16 @@ func (ae *ASEntry) addNet(ipnet *net.IPNet) error {
 	return nil
 }
 
-func (ae *ASEntry) delNet(ipnet *net.IPNet) error {
+func (ae *ASEntry) delNet(ipnet *net.IPNet, nm router.NetMapI) error {
 	key := ipnet.String()
 	if _, ok := ae.Nets[key]; !ok {
 		return common.NewBasicError("DelNet: no network found", nil, "ia", ae.IA, "net", ipnet)
 	}
-	if err := router.NetMap.Delete(ipnet); err != nil {
+	if err := nm.Delete(ipnet); err != nil {
 		return err
 	}
 	delete(ae.Nets, key)
+	delete(ae.announced, key)
 	ae.version++
 	// Generate NetworkChanged event
 	params := base.NetworkChangedParams{
@@ -160,6 +221,117 @@ func (ae *ASEntry) delNet(ipnet *net.IPNet) error {
 	return nil
 }
 
+// verifyAnnounce verifies the prefix announcement of the AS with the
+// certificate chain of the AS from the trust store. It returns the version,
+// and the announced networks that are allowed and those that are not.
+func (ae *ASEntry) verifyAnnounce(ctx context.Context, a *mgmt.PrefixAnnounce) (uint64,
+	[]*net.IPNet, []*net.IPNet, error) {
+
+	if sigcmn.Verifier == nil {
+		return 0, nil, nil, serrors.New("no trust store to verify prefix announcements")
+	}
+	// The verifier rejects signatures of other ASes, the certificate chain is
+	// the one of the AS.
+	p, err := a.Verify(ctx, sigcmn.Verifier.WithIA(ae.IA))
+	if err != nil {
+		return 0, nil, nil, serrors.WrapStr("unable to verify prefix announcement", err)
+	}
+	ae.RLock()
+	defer ae.RUnlock()
+	if !p.IA().Equal(ae.IA) {
+		return 0, nil, nil, serrors.New("prefix announcement for wrong AS", "ia", p.IA())
+	}
+	if p.Version < ae.announceVersion {
+		return 0, nil, nil, serrors.New("outdated prefix announcement",
+			"version", p.Version, "current", ae.announceVersion)
+	}
+	if AnnounceMaxAge != 0 {
+		signed := time.Unix(0, int64(p.Version))
+		if age := time.Since(signed); age > AnnounceMaxAge {
+			return 0, nil, nil, serrors.New("expired prefix announcement",
+				"signed", signed, "age", age, "max_age", AnnounceMaxAge)
+		}
+	}
+	nets, err := p.Nets()
+	if err != nil {
+		return 0, nil, nil, serrors.WrapStr("invalid prefix announcement", err)
+	}
+	var allowed, denied []*net.IPNet
+	for _, n := range nets {
+		if containsNet(ae.announceAllow, n) {
+			allowed = append(allowed, n)
+		} else {
+			denied = append(denied, n)
+		}
+	}
+	return p.Version, allowed, denied, nil
+}
+
+// announceChanged returns whether the announcement with version and nets
+// differs from the last accepted one.
+func (ae *ASEntry) announceChanged(version uint64, nets []*net.IPNet) bool {
+	ae.RLock()
+	defer ae.RUnlock()
+	if version != ae.announceVersion || len(nets) != len(ae.announceNets) {
+		return true
+	}
+	for _, n := range nets {
+		if _, ok := ae.announceNets[n.String()]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// setAnnounced adds the announced networks to nm and deletes the ones that
+// are no longer announced. Configured networks are left untouched.
+func (ae *ASEntry) setAnnounced(version uint64, nets []*net.IPNet, nm router.NetMapI) bool {
+	ae.Lock()
+	defer ae.Unlock()
+	if version < ae.announceVersion {
+		return true
+	}
+	ae.announceVersion = version
+	ae.announceNets = make(map[string]*net.IPNet, len(nets))
+	for _, n := range nets {
+		ae.announceNets[n.String()] = n
+	}
+	s := true
+	for k, v := range ae.Nets {
+		if _, ok := ae.announceNets[k]; !ae.announced[k] || ok {
+			continue
+		}
+		if err := ae.delNet(v, nm); err != nil {
+			ae.Error("Unable to delete announced network", "net", k, "err", err)
+			s = false
+		}
+	}
+	for k, v := range ae.announceNets {
+		if _, ok := ae.Nets[k]; ok {
+			continue
+		}
+		if err := ae.addNet(v, nm); err != nil {
+			ae.Error("Unable to add announced network", "net", k, "err", err)
+			s = false
+			continue
+		}
+		ae.announced[k] = true
+	}
+	return s
+}
+
+// containsNet returns whether n is a subnet of one of the networks.
+func containsNet(networks []*net.IPNet, n *net.IPNet) bool {
+	ones, bits := n.Mask.Size()
+	for _, allowed := range networks {
+		allowedOnes, allowedBits := allowed.Mask.Size()
+		if bits == allowedBits && allowedOnes <= ones && allowed.Contains(n.IP) {
+			return true
+		}
+	}
+	return false
+}
+
 func (ae *ASEntry) monitorHealth() {
 	ticker := time.NewTicker(healthMonitorTick)
 	defer ticker.Stop()
@@ -203,18 +375,77 @@ func (ae *ASEntry) performHealthCheck(prevHealth *bool, prevVersion *uint64) {
 	*prevVersion = ae.version
 }
 
+// Status is a snapshot of the state of a remote AS.
+type Status struct {
+	IA      addr.IA
+	Healthy bool
+	// Encrypted is set if the frames exchanged with the AS are encrypted.
+	Encrypted bool
+	// Nets contains the networks of the AS, sorted by their string
+	// representation.
+	Nets     []NetStatus
+	Sessions []SessionStatus
+}
+
+// NetStatus is a network of a remote AS.
+type NetStatus struct {
+	Net *net.IPNet
+	// Announced is set if the network was learned from a prefix announcement
+	// of the AS, and is not configured.
+	Announced bool
+}
+
+// SessionStatus is a snapshot of the state of a session to a remote AS.
+type SessionStatus struct {
+	ID      mgmt.SessionType
+	Healthy bool
+	// Remote is the remote SIG and path currently used, nil if there is none.
+	Remote *iface.RemoteInfo
+	// Sigs contains the remote SIGs the session fails over to, in the order
+	// they are tried.
+	Sigs []*siginfo.Sig
+	// Paths contains the statistics of the paths the session can use.
+	Paths []iface.PathStats
+}
+
+// Status returns a snapshot of the state of the AS.
+func (ae *ASEntry) Status() Status {
+	ae.RLock()
+	defer ae.RUnlock()
+	s := Status{
+		IA:        ae.IA,
+		Healthy:   ae.checkHealth(),
+		Encrypted: encap.Encrypted(ae.IA),
+		Sessions: []SessionStatus{{
+			ID:      ae.Session.ID(),
+			Healthy: ae.Session.Healthy(),
+			Remote:  ae.Session.Remote().Copy(),
+			Sigs:    ae.Session.Sigs(),
+			Paths:   ae.Session.Paths(),
+		}},
+	}
+	for k, n := range ae.Nets {
+		s.Nets = append(s.Nets, NetStatus{Net: n, Announced: ae.announced[k]})
+	}
+	sort.Slice(s.Nets, func(i, j int) bool {
+		return s.Nets[i].Net.String() < s.Nets[j].Net.String()
+	})
+	return s
+}
+
 func (ae *ASEntry) checkHealth() bool {
 	return ae.Session.Healthy()
 }
 
-func (ae *ASEntry) Cleanup() error {
+// Cleanup stops the AS entry and deletes its networks from nm.
+func (ae *ASEntry) Cleanup(nm router.NetMapI) error {
 	ae.Lock()
 	defer ae.Unlock()
 	// Clean up health monitor
 	ae.healthMonitorStop <- struct{}{}
 	// Clean up NetMap entries
 	for _, v := range ae.Nets {
-		if err := ae.delNet(v); err != nil {
+		if err := ae.delNet(v, nm); err != nil {
 			ae.Error("Error removing networks during cleanup", "err", err)
 		}
 	}
@@ -0,0 +1,154 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package asmap
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/sig/internal/signtest"
+	"github.com/scionproto/scion/go/sig/mgmt"
+	"github.com/scionproto/scion/go/sig/sigcmn"
+)
+
+func TestVerifyAnnounceMaxAge(t *testing.T) {
+	tr := signtest.New(t)
+	defer tr.Close()
+	defer func(v infra.Verifier) { sigcmn.Verifier = v }(sigcmn.Verifier)
+	sigcmn.Verifier = tr.Verifier()
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	signer := tr.Signer(t, ia)
+	ae := &ASEntry{
+		Logger:        log.Root(),
+		IA:            ia,
+		announceAllow: []*net.IPNet{mustParseNet(t, "192.0.2.0/24")},
+	}
+	announce := func(signed time.Time) *mgmt.PrefixAnnounce {
+		a, err := mgmt.NewPrefixAnnounce(mgmt.NewPrefixes(ia, uint64(signed.UnixNano()),
+			[]*net.IPNet{mustParseNet(t, "192.0.2.0/24")}), signer)
+		require.NoError(t, err)
+		return a
+	}
+	defer func(maxAge time.Duration) { AnnounceMaxAge = maxAge }(AnnounceMaxAge)
+	AnnounceMaxAge = 10 * time.Minute
+	ctx := context.Background()
+
+	_, _, _, err := ae.verifyAnnounce(ctx, announce(time.Now().Add(-time.Minute)))
+	assert.NoError(t, err)
+	_, _, _, err = ae.verifyAnnounce(ctx, announce(time.Now().Add(-time.Hour)))
+	assert.Error(t, err, "expired")
+}
+
+func TestVerifyAnnounce(t *testing.T) {
+	tr := signtest.New(t)
+	defer tr.Close()
+	defer func(v infra.Verifier) { sigcmn.Verifier = v }(sigcmn.Verifier)
+	sigcmn.Verifier = tr.Verifier()
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	otherIA := xtest.MustParseIA("1-ff00:0:111")
+	signer, otherSigner := tr.Signer(t, ia), tr.Signer(t, otherIA)
+	ae := &ASEntry{
+		Logger: log.Root(),
+		IA:     ia,
+		announceAllow: []*net.IPNet{
+			mustParseNet(t, "192.0.2.0/24"),
+			mustParseNet(t, "2001:db8::/32"),
+		},
+		announceVersion: 10,
+	}
+	announce := func(ia addr.IA, version uint64, signer infra.Signer,
+		nets ...string) *mgmt.PrefixAnnounce {
+
+		var ipnets []*net.IPNet
+		for _, n := range nets {
+			ipnets = append(ipnets, mustParseNet(t, n))
+		}
+		a, err := mgmt.NewPrefixAnnounce(mgmt.NewPrefixes(ia, version, ipnets), signer)
+		require.NoError(t, err)
+		return a
+	}
+	ctx := context.Background()
+
+	version, allowed, denied, err := ae.verifyAnnounce(ctx, announce(ia, 11, signer,
+		"192.0.2.128/25", "192.0.2.0/24", "192.0.0.0/16", "198.51.100.0/24",
+		"2001:db8:1::/48", "::ffff:192.0.2.0/120"))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(11), version)
+	assert.Equal(t, []string{"192.0.2.128/25", "192.0.2.0/24", "2001:db8:1::/48"},
+		netStrings(allowed))
+	assert.Equal(t, []string{"192.0.0.0/16", "198.51.100.0/24", "192.0.2.0/24"},
+		netStrings(denied))
+
+	_, _, _, err = ae.verifyAnnounce(ctx, announce(ia, 10, signer, "192.0.2.0/24"))
+	assert.NoError(t, err, "same version")
+	_, _, _, err = ae.verifyAnnounce(ctx, announce(ia, 9, signer, "192.0.2.0/24"))
+	assert.Error(t, err, "outdated version")
+	_, _, _, err = ae.verifyAnnounce(ctx, announce(otherIA, 11, signer, "192.0.2.0/24"))
+	assert.Error(t, err, "prefixes of other AS")
+	_, _, _, err = ae.verifyAnnounce(ctx, announce(ia, 11, otherSigner, "192.0.2.0/24"))
+	assert.Error(t, err, "signed by other AS")
+
+	other := signtest.New(t)
+	defer other.Close()
+	_, _, _, err = ae.verifyAnnounce(ctx, announce(ia, 11, other.Signer(t, ia),
+		"192.0.2.0/24"))
+	assert.Error(t, err, "key not in certificate chain")
+	unknown := &ASEntry{Logger: log.Root(), IA: xtest.MustParseIA("1-ff00:0:112")}
+	_, _, _, err = unknown.verifyAnnounce(ctx, announce(unknown.IA, 11,
+		other.Signer(t, unknown.IA), "192.0.2.0/24"))
+	assert.Error(t, err, "certificate chain not in trust store")
+	sigcmn.Verifier = nil
+	_, _, _, err = ae.verifyAnnounce(ctx, announce(ia, 11, signer, "192.0.2.0/24"))
+	assert.Error(t, err, "no trust store")
+}
+
+func TestAnnounceChanged(t *testing.T) {
+	ae := &ASEntry{
+		announceVersion: 10,
+		announceNets: map[string]*net.IPNet{
+			"192.0.2.0/24": mustParseNet(t, "192.0.2.0/24"),
+		},
+	}
+	assert.False(t, ae.announceChanged(10, []*net.IPNet{mustParseNet(t, "192.0.2.0/24")}))
+	assert.True(t, ae.announceChanged(11, []*net.IPNet{mustParseNet(t, "192.0.2.0/24")}))
+	assert.True(t, ae.announceChanged(10, []*net.IPNet{mustParseNet(t, "192.0.2.0/25")}))
+	assert.True(t, ae.announceChanged(10, nil))
+	ae.announceNets = nil
+	assert.True(t, ae.announceChanged(10, []*net.IPNet{mustParseNet(t, "192.0.2.0/24")}),
+		"reset by reload")
+}
+
+func mustParseNet(t *testing.T, s string) *net.IPNet {
+	_, n, err := net.ParseCIDR(s)
+	require.NoError(t, err)
+	return n
+}
+
+func netStrings(nets []*net.IPNet) []string {
+	var s []string
+	for _, n := range nets {
+		s = append(s, n.String())
+	}
+	return s
+}
@@ -17,16 +17,25 @@
 package asmap
 
 import (
+	"context"
+	"sort"
 	"sync"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/sig/egress/router"
 	"github.com/scionproto/scion/go/sig/internal/config"
+	"github.com/scionproto/scion/go/sig/internal/encap"
+	"github.com/scionproto/scion/go/sig/mgmt"
 )
 
 var Map = &ASMap{}
 
+// ErrUnknownAS indicates that a remote AS is not configured.
+var ErrUnknownAS = serrors.New("unknown AS")
+
 // ASMap is not concurrency safe against multiple writers.
 type ASMap sync.Map
 
@@ -60,14 +69,22 @@ func (am *ASMap) Range(f func(key addr.IAInt, value *ASEntry) bool) {
 	})
 }
 
+// ReloadConfig adds and deletes ASes and their networks according to cfg. The
+// changes to the routing table are applied atomically.
 func (am *ASMap) ReloadConfig(cfg *config.Cfg) bool {
-	// Method calls first to prevent skips due to logical short-circuit
-	s := am.addNewIAs(cfg)
-	return am.delOldIAs(cfg) && s
+	var s bool
+	router.NetMap.Update(func(nm router.NetMapI) {
+		// Delete first, such that networks can move between ASes. Method calls
+		// first to prevent skips due to logical short-circuit.
+		s = am.delOldIAs(cfg, nm)
+		s = am.delOldNets(cfg, nm) && s
+		s = am.addNewIAs(cfg, nm) && s
+	})
+	return s
 }
 
 // addNewIAs adds the ASes in cfg that are not currently configured.
-func (am *ASMap) addNewIAs(cfg *config.Cfg) bool {
+func (am *ASMap) addNewIAs(cfg *config.Cfg, nm router.NetMapI) bool {
 	s := true
 	for ia, cfgEntry := range cfg.ASes {
 		log.Info("ReloadConfig: Adding AS...", "ia", ia)
@@ -77,13 +94,25 @@ func (am *ASMap) addNewIAs(cfg *config.Cfg) bool {
 			s = false
 			continue
 		}
-		s = ae.ReloadConfig(cfg, cfgEntry) && s
+		s = ae.ReloadConfig(cfg, cfgEntry, nm) && s
 		log.Info("ReloadConfig: Added AS", "ia", ia)
 	}
 	return s
 }
 
-func (am *ASMap) delOldIAs(cfg *config.Cfg) bool {
+// delOldNets deletes the networks of configured ASes that are not in cfg.
+func (am *ASMap) delOldNets(cfg *config.Cfg, nm router.NetMapI) bool {
+	s := true
+	am.Range(func(iaInt addr.IAInt, as *ASEntry) bool {
+		if cfgEntry, ok := cfg.ASes[iaInt.IA()]; ok {
+			s = as.DelOldNets(cfgEntry, nm) && s
+		}
+		return true
+	})
+	return s
+}
+
+func (am *ASMap) delOldIAs(cfg *config.Cfg, nm router.NetMapI) bool {
 	s := true
 	// Delete all ASes that currently exist but are not in cfg
 	am.Range(func(iaInt addr.IAInt, as *ASEntry) bool {
@@ -91,7 +120,7 @@ func (am *ASMap) delOldIAs(cfg *config.Cfg) bool {
 		if _, ok := cfg.ASes[ia]; !ok {
 			log.Info("ReloadConfig: Deleting AS...", "ia", ia)
 			// Deletion also handles session/tun device cleanup
-			err := am.DelIA(ia)
+			err := am.DelIA(ia, nm)
 			if err != nil {
 				log.Error("ReloadConfig: Deleting AS failed", "err", err)
 				s = false
@@ -104,6 +133,44 @@ func (am *ASMap) delOldIAs(cfg *config.Cfg) bool {
 	return s
 }
 
+// Announce verifies the prefix announcement of the remote IA and installs the
+// announced networks that are allowed by the configuration. Networks that are
+// no longer announced are removed. The changes to the routing table are
+// applied atomically.
+func (am *ASMap) Announce(ctx context.Context, ia addr.IA, a *mgmt.PrefixAnnounce) error {
+	ae := am.ASEntry(ia)
+	if ae == nil {
+		return serrors.New("AS not configured", "ia", ia)
+	}
+	version, nets, denied, err := ae.verifyAnnounce(ctx, a)
+	if err != nil {
+		return err
+	}
+	if !ae.announceChanged(version, nets) {
+		return nil
+	}
+	for _, n := range denied {
+		ae.Warn("Announced network not allowed", "net", n)
+	}
+	var s bool
+	router.NetMap.Update(func(nm router.NetMapI) {
+		// The AS might have been removed by a concurrent reload.
+		if am.ASEntry(ia) != ae {
+			err = serrors.New("AS removed", "ia", ia)
+			return
+		}
+		s = ae.setAnnounced(version, nets, nm)
+	})
+	if err != nil {
+		return err
+	}
+	if !s {
+		return serrors.New("unable to install all announced networks", "ia", ia)
+	}
+	ae.Info("Installed prefix announcement", "version", version, "nets", len(nets))
+	return nil
+}
+
 // AddIA idempotently adds an entry for a remote IA.
 func (am *ASMap) AddIA(ia addr.IA) (*ASEntry, error) {
 	if ia.IsWildcard() {
@@ -122,15 +189,16 @@ func (am *ASMap) AddIA(ia addr.IA) (*ASEntry, error) {
 	return ae, nil
 }
 
-// DelIA removes an entry for a remote IA.
-func (am *ASMap) DelIA(ia addr.IA) error {
+// DelIA removes an entry for a remote IA, and deletes its networks from nm.
+func (am *ASMap) DelIA(ia addr.IA, nm router.NetMapI) error {
 	key := ia.IAInt()
 	ae, ok := am.Load(key)
 	if !ok {
 		return common.NewBasicError("DelIA: No entry found", nil, "ia", ia)
 	}
 	am.Delete(key)
-	return ae.Cleanup()
+	encap.DelPeer(ia)
+	return ae.Cleanup(nm)
 }
 
 // ASEntry returns the entry for the specified remote IA, or nil if not present.
@@ -140,3 +208,24 @@ func (am *ASMap) ASEntry(ia addr.IA) *ASEntry {
 	}
 	return nil
 }
+
+// Status returns a snapshot of the state of the remote ASes, sorted by IA.
+func (am *ASMap) Status() []Status {
+	var s []Status
+	am.Range(func(_ addr.IAInt, ae *ASEntry) bool {
+		s = append(s, ae.Status())
+		return true
+	})
+	sort.Slice(s, func(i, j int) bool { return s[i].IA.IAInt() < s[j].IA.IAInt() })
+	return s
+}
+
+// SwitchPath makes the session to the remote IA switch to the path with key.
+// If key is empty, the best path other than the current one is chosen.
+func (am *ASMap) SwitchPath(ia addr.IA, key string) error {
+	ae := am.ASEntry(ia)
+	if ae == nil {
+		return ErrUnknownAS
+	}
+	return ae.Session.SwitchPath(key)
+}
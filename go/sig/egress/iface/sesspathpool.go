@@ -15,7 +15,9 @@
 package iface
 
 import (
+	"fmt"
 	"math"
+	"sort"
 	"time"
 
 	"github.com/scionproto/scion/go/lib/snet"
@@ -100,6 +102,13 @@ func (spp SessPathPool) Update(aps spathmeta.AppPathSet) {
 // Reply is called when a probe reply arrives.
 // 'sent' is the time when the original probe was sent.
 func (spp SessPathPool) Reply(path *SessPath, sent time.Time) {
+	sp := spp[path.Key()]
+	if sp == nil {
+		return
+	}
+	sp.lastReply = time.Now()
+	sp.rtt = sp.lastReply.Sub(sent)
+	sp.replies++
 }
 
 // Timeout is called when a reply to a probe is not received in time.
@@ -113,6 +122,7 @@ func (spp SessPathPool) Timeout(path *SessPath, sent time.Time) {
 	if sp.failCount < math.MaxInt16 {
 		sp.failCount += 1
 	}
+	sp.timeouts++
 }
 
 func (spp SessPathPool) ExpireFails() {
@@ -123,10 +133,58 @@ func (spp SessPathPool) ExpireFails() {
 	}
 }
 
+// Stats returns a snapshot of the statistics of the paths in the pool, sorted
+// by path key.
+func (spp SessPathPool) Stats() []PathStats {
+	stats := make([]PathStats, 0, len(spp))
+	for _, sp := range spp {
+		stats = append(stats, sp.stats())
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Key < stats[j].Key })
+	return stats
+}
+
 type SessPathStats struct {
 	SessPath  *SessPath
 	lastFail  time.Time
 	failCount uint16
+	// rtt is the round trip time of the last probe reply.
+	rtt       time.Duration
+	lastReply time.Time
+	replies   uint64
+	timeouts  uint64
+}
+
+func (sp *SessPathStats) stats() PathStats {
+	path := sp.SessPath.Path()
+	return PathStats{
+		Key:       sp.SessPath.Key(),
+		Path:      fmt.Sprintf("%s", path),
+		Expiry:    path.Expiry(),
+		MTU:       path.MTU(),
+		RTT:       sp.rtt,
+		LastReply: sp.lastReply,
+		Replies:   sp.replies,
+		Timeouts:  sp.timeouts,
+		FailCount: sp.failCount,
+	}
+}
+
+// PathStats is a snapshot of the statistics of a path in a SessPathPool.
+type PathStats struct {
+	Key    string
+	Path   string
+	Expiry time.Time
+	MTU    uint16
+	// RTT is the round trip time of the last probe reply, zero if there was
+	// none.
+	RTT       time.Duration
+	LastReply time.Time
+	// Replies and Timeouts are the number of probe replies and timeouts.
+	Replies  uint64
+	Timeouts uint64
+	// FailCount is the decaying number of failures used to rank the path.
+	FailCount uint16
 }
 
 func newSessPathStats(key string, path snet.Path) *SessPathStats {
@@ -25,18 +25,26 @@ import (
 	"github.com/scionproto/scion/go/lib/ringbuf"
 )
 
-var NetMap NetMapI = &Networks{}
+var NetMap NetMapI = NewTable()
 
 type NetMapI interface {
 	Add(*net.IPNet, addr.IA, *ringbuf.Ring) error
 	Delete(*net.IPNet) error
 	Lookup(net.IP) (addr.IA, *ringbuf.Ring)
+	// Update calls the function with a staging copy of the map and
+	// atomically replaces the map with the copy once the function returns.
+	Update(func(NetMapI))
 }
 
+var _ NetMapI = (*Networks)(nil)
+
 // Networks is an unordered mapping of non-overlapping IP allocations to ASes. It is
-// concurrency safe. It is intended to be a stand-in until we have a a proper
-// mapping type, as the lookup is O(n) for the number of networks it contains.
+// concurrency safe. The lookup is O(n) for the number of networks it contains, use
+// Table for large numbers of networks.
 type Networks struct {
+	// writeMtx serializes all writers.
+	writeMtx sync.Mutex
+	// m protects nets against concurrent reads and writes.
 	m    sync.RWMutex
 	nets []*network
 }
@@ -49,6 +57,8 @@ func (ns *Networks) Add(ipnet *net.IPNet, ia addr.IA, ring *ringbuf.Ring) error
 		return common.NewBasicError("Networks.Add(): ringBuf.Ring must not be nil", nil, "ia", ia)
 	}
 	cnet := newCanonNet(ipnet)
+	ns.writeMtx.Lock()
+	defer ns.writeMtx.Unlock()
 	ns.m.Lock()
 	defer ns.m.Unlock()
 	newNet := &network{cnet, ia, ring}
@@ -64,6 +74,8 @@ func (ns *Networks) Add(ipnet *net.IPNet, ia addr.IA, ring *ringbuf.Ring) error
 
 func (ns *Networks) Delete(ipnet *net.IPNet) error {
 	cnet := newCanonNet(ipnet)
+	ns.writeMtx.Lock()
+	defer ns.writeMtx.Unlock()
 	ns.m.Lock()
 	defer ns.m.Unlock()
 	idx := ns.getIdxL(cnet)
@@ -90,6 +102,19 @@ func (ns *Networks) Lookup(ip net.IP) (addr.IA, *ringbuf.Ring) {
 	return addr.IA{}, nil
 }
 
+// Update calls f with a copy of the networks and replaces the networks with
+// the copy once f returns. Other writers are blocked until the update
+// completes, lookups are not.
+func (ns *Networks) Update(f func(NetMapI)) {
+	ns.writeMtx.Lock()
+	defer ns.writeMtx.Unlock()
+	staged := &Networks{nets: append([]*network(nil), ns.nets...)}
+	f(staged)
+	ns.m.Lock()
+	ns.nets = staged.nets
+	ns.m.Unlock()
+}
+
 func (ns *Networks) getIdxL(cnet *canonNet) int {
 	for i, n := range ns.nets {
 		if n.net.Equal(cnet) {
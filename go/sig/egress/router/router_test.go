@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"net"
 	"testing"
+	"time"
 
 	. "github.com/smartystreets/goconvey/convey"
 
@@ -189,6 +190,30 @@ func Test_Networks_Lookup(t *testing.T) {
 	})
 }
 
+func Test_Networks_Update(t *testing.T) {
+	Convey("Networks.Update() keeps concurrent changes", t, func() {
+		nets := defNetworks(t)
+		added := make(chan error, 1)
+		nets.Update(func(staged NetMapI) {
+			go func() {
+				added <- nets.Add(parseNet(t, "198.51.100.0/24"), iaA, &ringbuf.Ring{})
+			}()
+			select {
+			case <-added:
+				SoMsg("Concurrent add should wait for the update", true, ShouldBeFalse)
+			case <-time.After(20 * time.Millisecond):
+			}
+			err := staged.Add(parseNet(t, "203.0.113.0/24"), iaB, &ringbuf.Ring{})
+			SoMsg("Staged add should succeed", err, ShouldBeNil)
+		})
+		SoMsg("Concurrent add should succeed", <-added, ShouldBeNil)
+		ia, _ := nets.Lookup(net.ParseIP("198.51.100.1"))
+		SoMsg("Concurrently added network should be present", ia, ShouldResemble, iaA)
+		ia, _ = nets.Lookup(net.ParseIP("203.0.113.1"))
+		SoMsg("Staged network should be present", ia, ShouldResemble, iaB)
+	})
+}
+
 func Test_ipNet_Equal(t *testing.T) {
 	var testCases = []struct {
 		netA string
@@ -0,0 +1,299 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net"
+	"sync"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ringbuf"
+)
+
+var _ NetMapI = (*Table)(nil)
+
+// Table is a longest-prefix-match routing table of IP allocations to ASes.
+// Networks may overlap, a lookup returns the AS of the most specific network
+// containing the address. IPv4 and IPv6 networks are stored in separate
+// path-compressed binary radix tries, such that a lookup takes at most 32
+// (resp. 128) steps independent of the number of networks.
+//
+// Table is concurrency safe. Lookups only block while a change is written,
+// Update prepares the changes on a copy and swaps it in at once.
+type Table struct {
+	// writeMtx serializes all writers.
+	writeMtx sync.Mutex
+	// m protects the tries against concurrent reads and writes.
+	m  sync.RWMutex
+	v4 *node
+	v6 *node
+}
+
+// NewTable creates an empty table.
+func NewTable() *Table {
+	return &Table{}
+}
+
+func (t *Table) Add(ipnet *net.IPNet, ia addr.IA, ring *ringbuf.Ring) error {
+	if ia.IsWildcard() {
+		return common.NewBasicError("Table.Add(): Illegal wildcard remote AS", nil, "ia", ia)
+	}
+	if ring == nil {
+		return common.NewBasicError("Table.Add(): ringBuf.Ring must not be nil", nil, "ia", ia)
+	}
+	key, bits, v4, ok := prefixKey(ipnet)
+	if !ok {
+		return common.NewBasicError("Table.Add(): Invalid network", nil, "net", ipnet)
+	}
+	t.writeMtx.Lock()
+	defer t.writeMtx.Unlock()
+	t.m.Lock()
+	defer t.m.Unlock()
+	nw := &network{newCanonNet(ipnet), ia, ring}
+	if existing := insert(t.root(v4), key, bits, nw); existing != nil {
+		return common.NewBasicError("Table.Add(): Network already present", nil,
+			"net", ipnet, "existing", existing.ia)
+	}
+	return nil
+}
+
+func (t *Table) Delete(ipnet *net.IPNet) error {
+	key, bits, v4, ok := prefixKey(ipnet)
+	if !ok {
+		return common.NewBasicError("Table.Delete(): Invalid network", nil, "net", ipnet)
+	}
+	t.writeMtx.Lock()
+	defer t.writeMtx.Unlock()
+	t.m.Lock()
+	defer t.m.Unlock()
+	if !remove(t.root(v4), key, bits) {
+		return common.NewBasicError("Table.Delete(): IPNet entry not present", nil, "net", ipnet)
+	}
+	return nil
+}
+
+func (t *Table) Lookup(ip net.IP) (addr.IA, *ringbuf.Ring) {
+	key, v4 := ipKey(ip)
+	if key == nil {
+		return addr.IA{}, nil
+	}
+	t.m.RLock()
+	defer t.m.RUnlock()
+	n := t.v6
+	if v4 {
+		n = t.v4
+	}
+	if nw := lookup(n, key); nw != nil {
+		return nw.ia, nw.ring
+	}
+	return addr.IA{}, nil
+}
+
+// Update calls f with a copy of the table and replaces the content of the
+// table with the copy once f returns. Concurrent lookups either see the
+// table before or after all the changes made by f.
+func (t *Table) Update(f func(NetMapI)) {
+	t.writeMtx.Lock()
+	defer t.writeMtx.Unlock()
+	staged := &Table{v4: t.v4.clone(), v6: t.v6.clone()}
+	f(staged)
+	t.m.Lock()
+	t.v4, t.v6 = staged.v4, staged.v6
+	t.m.Unlock()
+}
+
+func (t *Table) root(v4 bool) **node {
+	if v4 {
+		return &t.v4
+	}
+	return &t.v6
+}
+
+// node is a node of a path-compressed binary trie. The key of a node is the
+// masked prefix of length bits. Nodes without network are only kept if they
+// have two children.
+type node struct {
+	key      []byte
+	bits     int
+	network  *network
+	children [2]*node
+}
+
+// insert adds the network for prefix key/bits to the trie. If the prefix is
+// already present, the existing network is returned and the trie is not
+// modified.
+func insert(n **node, key []byte, bits int, nw *network) *network {
+	for {
+		cur := *n
+		if cur == nil {
+			*n = &node{key: key, bits: bits, network: nw}
+			return nil
+		}
+		shared := commonPrefixLen(cur.key, key, minInt(cur.bits, bits))
+		if shared < cur.bits {
+			// The new prefix branches off above cur, split the edge.
+			split := &node{key: maskKey(key, shared), bits: shared}
+			split.children[bitAt(cur.key, shared)] = cur
+			if shared == bits {
+				split.network = nw
+			} else {
+				split.children[bitAt(key, shared)] = &node{key: key, bits: bits, network: nw}
+			}
+			*n = split
+			return nil
+		}
+		if cur.bits == bits {
+			if cur.network != nil {
+				return cur.network
+			}
+			cur.network = nw
+			return nil
+		}
+		n = &cur.children[bitAt(key, cur.bits)]
+	}
+}
+
+// remove deletes the network for prefix key/bits from the trie. It returns
+// false if the prefix is not present.
+func remove(n **node, key []byte, bits int) bool {
+	var parent **node
+	for {
+		cur := *n
+		if cur == nil || cur.bits > bits || commonPrefixLen(cur.key, key, cur.bits) < cur.bits {
+			return false
+		}
+		if cur.bits == bits {
+			if cur.network == nil {
+				return false
+			}
+			cur.network = nil
+			compact(n)
+			if parent != nil {
+				compact(parent)
+			}
+			return true
+		}
+		parent = n
+		n = &cur.children[bitAt(key, cur.bits)]
+	}
+}
+
+// compact removes the node n points to if it has no network and less than two
+// children.
+func compact(n **node) {
+	cur := *n
+	if cur.network != nil {
+		return
+	}
+	switch {
+	case cur.children[0] == nil:
+		*n = cur.children[1]
+	case cur.children[1] == nil:
+		*n = cur.children[0]
+	}
+}
+
+// lookup returns the network of the longest prefix in the trie that contains
+// key, or nil if there is none.
+func lookup(n *node, key []byte) *network {
+	var best *network
+	for n != nil && commonPrefixLen(n.key, key, n.bits) == n.bits {
+		if n.network != nil {
+			best = n.network
+		}
+		if n.bits == len(key)*8 {
+			break
+		}
+		n = n.children[bitAt(key, n.bits)]
+	}
+	return best
+}
+
+func (n *node) clone() *node {
+	if n == nil {
+		return nil
+	}
+	return &node{
+		key:      n.key,
+		bits:     n.bits,
+		network:  n.network,
+		children: [2]*node{n.children[0].clone(), n.children[1].clone()},
+	}
+}
+
+// prefixKey returns the masked prefix of ipnet, the prefix length, and
+// whether it is an IPv4 prefix. It returns false if ipnet is not a valid
+// prefix.
+func prefixKey(ipnet *net.IPNet) ([]byte, int, bool, bool) {
+	ones, bits := ipnet.Mask.Size()
+	var ip net.IP
+	switch bits {
+	case 8 * net.IPv4len:
+		ip = ipnet.IP.To4()
+	case 8 * net.IPv6len:
+		ip = ipnet.IP.To16()
+	}
+	if ip == nil {
+		return nil, 0, false, false
+	}
+	return maskKey(ip, ones), ones, len(ip) == net.IPv4len, true
+}
+
+// ipKey returns the key to look up ip, and whether it is an IPv4 address.
+func ipKey(ip net.IP) ([]byte, bool) {
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4, true
+	}
+	return ip.To16(), false
+}
+
+// maskKey returns a copy of key with all bits after the first bits cleared.
+func maskKey(key []byte, bits int) []byte {
+	masked := make([]byte, len(key))
+	full := bits / 8
+	copy(masked, key[:full])
+	if rem := bits % 8; rem != 0 {
+		masked[full] = key[full] & ^byte(0xff>>uint(rem))
+	}
+	return masked
+}
+
+// commonPrefixLen returns the number of leading bits a and b have in common,
+// up to max.
+func commonPrefixLen(a, b []byte, max int) int {
+	for i := 0; i*8 < max; i++ {
+		if x := a[i] ^ b[i]; x != 0 {
+			l := i * 8
+			for x&0x80 == 0 {
+				x <<= 1
+				l++
+			}
+			return minInt(l, max)
+		}
+	}
+	return max
+}
+
+func bitAt(key []byte, i int) int {
+	return int(key[i/8]>>(7-uint(i%8))) & 1
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,263 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ringbuf"
+)
+
+var iaC = addr.IA{I: 1, A: 0xff0000000002}
+
+func TestTableAdd(t *testing.T) {
+	testCases := map[string]struct {
+		nets []string
+		ok   bool
+	}{
+		"disjoint": {
+			nets: []string{"192.0.2.0/24", "198.51.100.0/24", "2001:db8::/48"},
+			ok:   true,
+		},
+		"overlapping": {
+			nets: []string{"192.0.2.0/24", "192.0.2.0/25", "0.0.0.0/0", "2001:db8::/32",
+				"2001:db8::/48"},
+			ok: true,
+		},
+		"duplicate after canonicalisation": {
+			nets: []string{"192.0.2.0/24", "192.0.2.1/24"},
+		},
+		"duplicate IPv6": {
+			nets: []string{"2001:db8::/48", "2001:db8::1/48"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			table := NewTable()
+			var err error
+			for _, n := range tc.nets {
+				if err = table.Add(parseNet(t, n), iaA, &ringbuf.Ring{}); err != nil {
+					break
+				}
+			}
+			if tc.ok {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+	t.Run("invalid arguments", func(t *testing.T) {
+		table := NewTable()
+		assert.Error(t, table.Add(parseNet(t, "192.0.2.0/24"), addr.IA{}, &ringbuf.Ring{}))
+		assert.Error(t, table.Add(parseNet(t, "192.0.2.0/24"), iaA, nil))
+		invalid := &net.IPNet{IP: net.ParseIP("192.0.2.0"), Mask: net.IPMask{0xff, 0, 0xff, 0}}
+		assert.Error(t, table.Add(invalid, iaA, &ringbuf.Ring{}))
+	})
+}
+
+func TestTableLookup(t *testing.T) {
+	table := NewTable()
+	for ia, nets := range map[addr.IA][]string{
+		iaA: {"0.0.0.0/0", "192.0.2.0/24", "2001:db8::/32"},
+		iaB: {"192.0.2.128/25", "192.0.2.255/32", "2001:db8:1::/48"},
+		iaC: {"192.0.2.192/26", "2001:db8:1:1::/64"},
+	} {
+		for _, n := range nets {
+			require.NoError(t, table.Add(parseNet(t, n), ia, &ringbuf.Ring{}))
+		}
+	}
+	testCases := map[string]addr.IA{
+		"198.51.100.1":        iaA,
+		"192.0.2.1":           iaA,
+		"192.0.2.127":         iaA,
+		"192.0.2.128":         iaB,
+		"192.0.2.191":         iaB,
+		"192.0.2.192":         iaC,
+		"192.0.2.254":         iaC,
+		"192.0.2.255":         iaB,
+		"2001:db8::1":         iaA,
+		"2001:db8:1::1":       iaB,
+		"2001:db8:1:1::1":     iaC,
+		"2001:db8:1:2::1":     iaB,
+		"2001:db9::1":         {},
+		"::ffff:192.0.2.1":    iaA,
+		"::ffff:192.0.2.193":  iaC,
+		"2001:db8:ffff::ffff": iaA,
+	}
+	for ip, expected := range testCases {
+		t.Run(ip, func(t *testing.T) {
+			ia, ring := table.Lookup(net.ParseIP(ip))
+			assert.Equal(t, expected, ia)
+			assert.Equal(t, expected.IsZero(), ring == nil)
+		})
+	}
+}
+
+func TestTableDelete(t *testing.T) {
+	table := NewTable()
+	nets := []string{"192.0.2.0/24", "192.0.2.0/25", "192.0.2.128/25", "192.0.2.64/26",
+		"2001:db8::/32"}
+	for _, n := range nets {
+		require.NoError(t, table.Add(parseNet(t, n), iaA, &ringbuf.Ring{}))
+	}
+	assert.Error(t, table.Delete(parseNet(t, "192.0.2.0/23")), "not present")
+	assert.Error(t, table.Delete(parseNet(t, "192.0.2.0/27")), "not present")
+	assert.Error(t, table.Delete(parseNet(t, "2001:db8::/48")), "not present")
+
+	require.NoError(t, table.Delete(parseNet(t, "192.0.2.0/25")))
+	assert.Error(t, table.Delete(parseNet(t, "192.0.2.0/25")), "already deleted")
+	ia, _ := table.Lookup(net.ParseIP("192.0.2.1"))
+	assert.Equal(t, iaA, ia, "covered by /24")
+	ia, _ = table.Lookup(net.ParseIP("192.0.2.65"))
+	assert.Equal(t, iaA, ia, "covered by /26")
+
+	for _, n := range []string{"192.0.2.0/24", "192.0.2.128/25", "192.0.2.64/26",
+		"2001:db8::/32"} {
+		require.NoError(t, table.Delete(parseNet(t, n)))
+	}
+	assert.Nil(t, table.v4, "trie is compacted")
+	assert.Nil(t, table.v6, "trie is compacted")
+}
+
+func TestTableUpdate(t *testing.T) {
+	table := NewTable()
+	require.NoError(t, table.Add(parseNet(t, "192.0.2.0/24"), iaA, &ringbuf.Ring{}))
+	table.Update(func(staged NetMapI) {
+		// Move the network to another AS. The change is not visible until
+		// the update completes.
+		require.NoError(t, staged.Delete(parseNet(t, "192.0.2.0/24")))
+		require.NoError(t, staged.Add(parseNet(t, "192.0.2.0/24"), iaB, &ringbuf.Ring{}))
+		ia, _ := table.Lookup(net.ParseIP("192.0.2.1"))
+		assert.Equal(t, iaA, ia)
+	})
+	ia, _ := table.Lookup(net.ParseIP("192.0.2.1"))
+	assert.Equal(t, iaB, ia)
+}
+
+// TestTableRandom compares the table to a brute force longest prefix match.
+func TestTableRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	nets := randomNets(r, 500)
+	ias := make([]addr.IA, len(nets))
+	table := NewTable()
+	for i, n := range nets {
+		ias[i] = addr.IA{I: 1, A: addr.AS(i + 1)}
+		require.NoError(t, table.Add(n, ias[i], &ringbuf.Ring{}))
+	}
+	check := func() {
+		for i := 0; i < 2000; i++ {
+			ip := make(net.IP, net.IPv4len)
+			binary.BigEndian.PutUint32(ip, r.Uint32()&0x00ffffff|0x0a000000)
+			expected, best := addr.IA{}, -1
+			for j, n := range nets {
+				if ones, _ := n.Mask.Size(); n.Contains(ip) && ones > best {
+					expected, best = ias[j], ones
+				}
+			}
+			ia, _ := table.Lookup(ip)
+			require.Equal(t, expected, ia, "ip %s", ip)
+		}
+	}
+	check()
+	for len(nets) > 250 {
+		i := r.Intn(len(nets))
+		require.NoError(t, table.Delete(nets[i]))
+		nets = append(nets[:i], nets[i+1:]...)
+		ias = append(ias[:i], ias[i+1:]...)
+	}
+	check()
+}
+
+func BenchmarkTableLookup(b *testing.B) {
+	benchmarkLookup(b, NewTable())
+}
+
+func BenchmarkNetworksLookup(b *testing.B) {
+	benchmarkLookup(b, &Networks{})
+}
+
+func BenchmarkTableUpdate(b *testing.B) {
+	table := NewTable()
+	nets := randomDisjointNets(rand.New(rand.NewSource(1)), 5000)
+	for _, n := range nets {
+		if err := table.Add(n, iaA, &ringbuf.Ring{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		n := nets[i%len(nets)]
+		table.Update(func(staged NetMapI) {
+			staged.Delete(n)
+			staged.Add(n, iaB, &ringbuf.Ring{})
+		})
+	}
+}
+
+func benchmarkLookup(b *testing.B, m NetMapI) {
+	r := rand.New(rand.NewSource(1))
+	// Networks does not support overlapping prefixes.
+	nets := randomDisjointNets(r, 5000)
+	for i, n := range nets {
+		if err := m.Add(n, addr.IA{I: 1, A: addr.AS(i%200 + 1)}, &ringbuf.Ring{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+	ips := make([]net.IP, 1024)
+	for i := range ips {
+		ips[i] = make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ips[i], r.Uint32()&0x00ffffff|0x0a000000)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Lookup(ips[i%len(ips)])
+	}
+}
+
+// randomNets returns n random, possibly overlapping, networks in 10.0.0.0/8.
+func randomNets(r *rand.Rand, n int) []*net.IPNet {
+	seen := make(map[string]bool)
+	var nets []*net.IPNet
+	for len(nets) < n {
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, r.Uint32()&0x00ffffff|0x0a000000)
+		ipnet := &net.IPNet{IP: ip, Mask: net.CIDRMask(8+r.Intn(25), 32)}
+		ipnet.IP = ipnet.IP.Mask(ipnet.Mask)
+		if seen[ipnet.String()] {
+			continue
+		}
+		seen[ipnet.String()] = true
+		nets = append(nets, ipnet)
+	}
+	return nets
+}
+
+// randomDisjointNets returns n disjoint /24 networks in 10.0.0.0/8.
+func randomDisjointNets(r *rand.Rand, n int) []*net.IPNet {
+	var nets []*net.IPNet
+	for _, i := range r.Perm(1 << 16)[:n] {
+		ip := net.IP{10, byte(i >> 8), byte(i), 0}
+		nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(24, 32)})
+	}
+	return nets
+}
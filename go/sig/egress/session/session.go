@@ -28,9 +28,11 @@ import (
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/pktdisp"
 	"github.com/scionproto/scion/go/lib/ringbuf"
+	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/spath/spathmeta"
 	"github.com/scionproto/scion/go/sig/egress/iface"
+	"github.com/scionproto/scion/go/sig/egress/siginfo"
 	"github.com/scionproto/scion/go/sig/egress/worker"
 	"github.com/scionproto/scion/go/sig/internal/pathmgr"
 	"github.com/scionproto/scion/go/sig/mgmt"
@@ -39,6 +41,15 @@ import (
 
 var _ iface.Session = (*Session)(nil)
 
+var (
+	// ErrUnknownPath indicates that a path is not in the path pool of the
+	// session.
+	ErrUnknownPath = serrors.New("unknown path")
+	// ErrSwitchPending indicates that a path switch was requested already and
+	// is not processed yet.
+	ErrSwitchPending = serrors.New("path switch pending")
+)
+
 // Session contains a pool of paths to the remote AS, metrics about those paths,
 // as well as maintaining the currently favoured path and remote SIG to use.
 type Session struct {
@@ -51,7 +62,16 @@ type Session struct {
 	// FIXME: Use AtomicRemoteInfo instead
 	currRemote atomic.Value
 	// FIXME: Use AtomicBool instead.
-	healthy        atomic.Value
+	healthy atomic.Value
+	// staticSigs contains the configured SIGs of the remote AS.
+	staticSigs atomic.Value
+	// sigs contains the snapshot of the remote SIGs the session monitor
+	// fails over to.
+	sigs atomic.Value
+	// paths contains the snapshot of the path statistics of the session
+	// monitor.
+	paths          atomic.Value
+	switchPathC    chan string
 	ring           *ringbuf.Ring
 	conn           snet.Conn
 	sessMonStop    chan struct{}
@@ -73,6 +93,10 @@ func NewSession(dstIA addr.IA, sessId mgmt.SessionType, logger log.Logger,
 	}
 	s.currRemote.Store((*iface.RemoteInfo)(nil))
 	s.healthy.Store(false)
+	s.paths.Store([]iface.PathStats(nil))
+	s.switchPathC = make(chan string, 1)
+	s.staticSigs.Store([]*siginfo.Sig(nil))
+	s.sigs.Store([]*siginfo.Sig(nil))
 	s.ring = ringbuf.New(64, nil, fmt.Sprintf("egress_%s_%s", dstIA, sessId))
 	// Not using a fixed local port, as this is for outgoing data only.
 	s.conn, err = sigcmn.Network.ListenSCION("udp4",
@@ -148,6 +172,45 @@ func (s *Session) Healthy() bool {
 	return s.healthy.Load().(bool)
 }
 
+// Paths returns the statistics of the paths the session monitor chooses the
+// path of the session from.
+func (s *Session) Paths() []iface.PathStats {
+	return s.paths.Load().([]iface.PathStats)
+}
+
+// SwitchPath makes the session switch to the path with key. If key is empty,
+// the best path other than the current one is chosen. The switch is applied
+// asynchronously by the session monitor.
+func (s *Session) SwitchPath(key string) error {
+	if key != "" {
+		var found bool
+		for _, p := range s.Paths() {
+			found = found || p.Key == key
+		}
+		if !found {
+			return ErrUnknownPath
+		}
+	}
+	select {
+	case s.switchPathC <- key:
+		return nil
+	default:
+		return ErrSwitchPending
+	}
+}
+
+// SetStaticSigs sets the configured SIGs of the remote AS. They are used if no
+// remote SIG is discovered via SVC resolution.
+func (s *Session) SetStaticSigs(sigs []*siginfo.Sig) {
+	s.staticSigs.Store(sigs)
+}
+
+// Sigs returns the remote SIGs the session monitor fails over to, in the order
+// they are tried. Discovered SIGs precede the configured ones.
+func (s *Session) Sigs() []*siginfo.Sig {
+	return s.sigs.Load().([]*siginfo.Sig)
+}
+
 func (s *Session) PathPool() iface.PathPool {
 	return s.pool
 }
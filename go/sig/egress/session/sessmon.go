@@ -16,6 +16,7 @@
 package session
 
 import (
+	"context"
 	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
@@ -23,9 +24,12 @@ import (
 	"github.com/scionproto/scion/go/lib/ctrl"
 	"github.com/scionproto/scion/go/lib/infra"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/proto"
 	"github.com/scionproto/scion/go/sig/egress/iface"
 	"github.com/scionproto/scion/go/sig/egress/siginfo"
+	"github.com/scionproto/scion/go/sig/internal/announce"
 	"github.com/scionproto/scion/go/sig/internal/disp"
+	"github.com/scionproto/scion/go/sig/internal/encap"
 	"github.com/scionproto/scion/go/sig/internal/metrics"
 	"github.com/scionproto/scion/go/sig/mgmt"
 	"github.com/scionproto/scion/go/sig/sigcmn"
@@ -37,6 +41,21 @@ const (
 	tout          = 1 * time.Second
 	writeTout     = 100 * time.Millisecond
 	pathExpiryLen = 10 * time.Second
+	// How often the local prefixes are announced to the remote SIG.
+	announceLen = 5 * time.Second
+	// How often the frame key is renegotiated if frames are encrypted.
+	rekeyLen = 10 * time.Minute
+	// How many frames are sealed with a key before it is renegotiated.
+	rekeyFrames = 1 << 32
+	// How long a key exchange is retried before it is restarted.
+	keyExchangeTout = 5 * time.Second
+	// How often the remote SIGs are discovered via SVC resolution.
+	resolveLen = time.Minute
+	// How long after the last discovery the remote SIGs are discovered again
+	// if the remote SIG times out.
+	resolveRetryLen = 5 * time.Second
+	// How long a discovery of the remote SIGs may take.
+	resolveTout = time.Second
 )
 
 // sessMonitor is responsible for monitoring a session, polling remote SIGs, and switching
@@ -59,6 +78,27 @@ type sessMonitor struct {
 	updateMsgId mgmt.MsgIdType
 	// the last time a PollRep was received.
 	lastReply time.Time
+	// the pending key exchange, if any, and the remote SIG it is sent to.
+	hs    *encap.Handshake
+	hsSig *siginfo.Sig
+	// the id of the pending key exchange request, and the last time it was sent.
+	hsMsgId mgmt.MsgIdType
+	hsSent  time.Time
+	// the id of the last frame key negotiated, and the remote SIG it was
+	// negotiated with.
+	keyId  uint8
+	keySig *siginfo.Sig
+	// the remote SIGs discovered via SVC resolution, and the index of the
+	// remote SIG that is tried next if the remote SIG times out.
+	discovered []*siginfo.Sig
+	sigIdx     int
+	// set if the remote SIG in smRemote has not replied yet.
+	sigPending bool
+	// the results of discoveries, whether one is pending, and the last time
+	// one was started.
+	resolveC    chan []*siginfo.Sig
+	resolving   bool
+	lastResolve time.Time
 }
 
 func newSessMonitor(sess *Session) *sessMonitor {
@@ -70,6 +110,7 @@ func newSessMonitor(sess *Session) *sessMonitor {
 		sess:         sess,
 		pool:         sess.pool,
 		sessPathPool: iface.NewSessPathPool(),
+		resolveC:     make(chan []*siginfo.Sig, 1),
 	}
 }
 
@@ -80,17 +121,20 @@ func (sm *sessMonitor) run() {
 	defer reqTick.Stop()
 	pathExpiryTick := time.NewTicker(pathExpiryLen)
 	defer pathExpiryTick.Stop()
+	announceTick := time.NewTicker(announceLen)
+	defer announceTick.Stop()
 	// Register with SIG ctrl dispatcher
 	regc := make(disp.RegPldChan, 1)
 	disp.Dispatcher.Register(disp.RegPollRep,
 		disp.MkRegPollKey(sm.sess.IA(), sm.sess.SessId, 0), regc)
+	keyc := make(disp.RegPldChan, 1)
+	disp.Dispatcher.Register(disp.RegKeyExchangeRep,
+		disp.MkRegPollKey(sm.sess.IA(), sm.sess.SessId, 0), keyc)
 	sm.lastReply = time.Now()
 	// Start by querying for the remote SIG instance.
+	sm.publishSigs()
 	sm.smRemote = &iface.RemoteInfo{
-		Sig: &siginfo.Sig{
-			IA:   sm.sess.IA(),
-			Host: addr.SvcSIG,
-		},
+		Sig:      sm.nextSig(),
 		SessPath: sm.sessPathPool.Get(""),
 	}
 Top:
@@ -100,12 +144,26 @@ Top:
 			break Top
 		case <-reqTick.C:
 			sm.updatePaths()
+			sm.publishSigs()
 			sm.updateRemote()
+			sm.resolve(resolveLen)
 			sm.sendReq()
+			sm.updateKey()
+			sm.publishPaths()
 		case rpld := <-regc:
 			sm.handleRep(rpld)
+			sm.publishPaths()
+		case rpld := <-keyc:
+			sm.handleKeyRep(rpld)
+		case sigs := <-sm.resolveC:
+			sm.handleResolve(sigs)
+		case key := <-sm.sess.switchPathC:
+			sm.switchPath(key)
 		case <-pathExpiryTick.C:
 			sm.sessPathPool.ExpireFails()
+			sm.publishPaths()
+		case <-announceTick.C:
+			sm.sendAnnounce()
 		}
 	}
 	err := disp.Dispatcher.Unregister(disp.RegPollRep, disp.MkRegPollKey(sm.sess.IA(),
@@ -113,6 +171,11 @@ Top:
 	if err != nil {
 		log.Error("sessMonitor: unable to unregister from ctrl dispatcher", "err", err)
 	}
+	err = disp.Dispatcher.Unregister(disp.RegKeyExchangeRep, disp.MkRegPollKey(sm.sess.IA(),
+		sm.sess.SessId, 0))
+	if err != nil {
+		log.Error("sessMonitor: unable to unregister from ctrl dispatcher", "err", err)
+	}
 	sm.Info("sessMonitor: stopped")
 }
 
@@ -157,11 +220,12 @@ func (sm *sessMonitor) updateRemote() {
 			// checking for the path.
 			sm.sessPathPool.Timeout(sm.smRemote.SessPath, sm.updateMsgId.Time())
 		}
-		// Start monitoring new path and discover a new SIG.
-		sm.smRemote.Sig.Host = addr.SvcSIG
+		// Start monitoring new path and try the next remote SIG.
+		sm.smRemote.Sig = sm.nextSig()
 		sm.smRemote.SessPath = sm.getNewPath(sm.smRemote.SessPath, "timeout")
+		sm.resolve(resolveRetryLen)
 		// XXX(roosd): The session's remote SIG will remain the same until the
-		// monitor discovers a remote SIG.
+		// new remote SIG replies.
 		sm.updateSessSnap()
 		sm.Info("sessMonitor: New remote", "remote", sm.smRemote)
 		return
@@ -210,18 +274,21 @@ func (sm *sessMonitor) updateRemote() {
 }
 
 // updateSessSnap updates the remote snapshot in the session. If the new remote
-// SIG host is an SVC address, the previous host of the session is kept.
+// SIG host is an SVC address, or the new remote SIG has not replied yet, the
+// previous host of the session is kept.
 func (sm *sessMonitor) updateSessSnap() {
 	// Copy the remote to avoid capturing the object in the session.
 	remote := sm.smRemote.Copy()
 	// XXX(roosd): Data traffic should never be sent to a SVC address if avoidable.
-	if remote.Sig.Host.Equal(addr.SvcSIG) {
+	if remote.Sig.Host.Equal(addr.SvcSIG) || sm.sigPending {
 		old := sm.sess.Remote()
-		// If the previous remote is not set, do not set the snapshot.
-		if old == nil {
+		switch {
+		case old != nil:
+			remote.Sig = old.Sig
+		case remote.Sig.Host.Equal(addr.SvcSIG):
+			// If the previous remote is not set, do not set the snapshot.
 			return
 		}
-		remote.Sig = old.Sig
 	}
 	sm.sess.currRemote.Store(remote)
 	if remote.SessPath != nil {
@@ -238,7 +305,13 @@ func (sm *sessMonitor) getNewPath(old *iface.SessPath, reason string) *iface.Ses
 	} else {
 		res = sm.sessPathPool.Get(old.Key())
 	}
-	// If the path has changed, report it to Prometheus.
+	sm.reportPathSwitch(old, res, reason)
+	return res
+}
+
+// reportPathSwitch reports the switch from path old to res to Prometheus, if
+// the path has changed.
+func (sm *sessMonitor) reportPathSwitch(old, res *iface.SessPath, reason string) {
 	var report bool
 	if old == nil || res == nil {
 		report = old != res
@@ -249,7 +322,123 @@ func (sm *sessMonitor) getNewPath(old *iface.SessPath, reason string) *iface.Ses
 		metrics.SessionPathSwitched.WithLabelValues(sm.sess.IA().String(),
 			sm.sess.SessId.String(), reason).Inc()
 	}
-	return res
+}
+
+// switchPath switches the session to the path with key, or to the best path
+// other than the current one if key is empty. The remote SIG is kept.
+func (sm *sessMonitor) switchPath(key string) {
+	path := sm.sessPathPool.GetByKey(key)
+	if key == "" {
+		path = sm.getNewPath(sm.smRemote.SessPath, "forced")
+	} else if path == nil {
+		sm.Info("sessMonitor: Requested path not available", "key", key)
+		return
+	} else {
+		sm.reportPathSwitch(sm.smRemote.SessPath, path, "forced")
+	}
+	sm.smRemote.SessPath = path
+	sm.updateSessSnap()
+	sm.Info("sessMonitor: Forced path switch", "remote", sm.smRemote)
+}
+
+// nextSig returns the next remote SIG to try. The discovered and configured
+// SIGs are tried in turn, followed by an anycast to SvcSIG. The anycast also
+// reaches remote SIGs that do not support SVC resolution.
+func (sm *sessMonitor) nextSig() *siginfo.Sig {
+	sm.sigPending = true
+	sigs := sm.sess.Sigs()
+	i := sm.sigIdx % (len(sigs) + 1)
+	sm.sigIdx++
+	if i == len(sigs) {
+		return &siginfo.Sig{IA: sm.sess.IA(), Host: addr.SvcSIG}
+	}
+	return sigs[i].Copy()
+}
+
+// resolve discovers the remote SIGs via SVC resolution over the path of the
+// monitor, unless a discovery is pending or the last one was started less
+// than minAge ago. The result is handled by handleResolve.
+func (sm *sessMonitor) resolve(minAge time.Duration) {
+	if sm.resolving || sm.smRemote.SessPath == nil || time.Since(sm.lastResolve) < minAge {
+		return
+	}
+	sm.resolving, sm.lastResolve = true, time.Now()
+	path := sm.smRemote.SessPath.Path()
+	go func() {
+		defer log.LogPanicAndExit()
+		ctx, cancelF := context.WithTimeout(context.Background(), resolveTout)
+		defer cancelF()
+		sigs, err := siginfo.Resolve(ctx, sigcmn.Resolver, path)
+		if err != nil {
+			sm.Debug("sessMonitor: Unable to discover remote SIGs", "err", err)
+		}
+		sm.resolveC <- sigs
+	}()
+}
+
+// handleResolve handles the result of a discovery. If remote SIGs were
+// discovered, they replace the previously discovered ones, and the monitor
+// immediately switches to them if the current remote SIG has not replied yet.
+func (sm *sessMonitor) handleResolve(sigs []*siginfo.Sig) {
+	sm.resolving = false
+	if len(sigs) == 0 {
+		metrics.SessionSigDiscoveries.WithLabelValues(sm.sess.IA().String(),
+			sm.sess.SessId.String(), "err").Inc()
+		return
+	}
+	metrics.SessionSigDiscoveries.WithLabelValues(sm.sess.IA().String(),
+		sm.sess.SessId.String(), "ok").Inc()
+	if !sigsEqual(sm.discovered, sigs) {
+		sm.Info("sessMonitor: Discovered remote SIGs", "sigs", sigs)
+	}
+	sm.discovered = sigs
+	sm.publishSigs()
+	if sm.sigPending {
+		sm.sigIdx = 0
+		sm.smRemote.Sig = sm.nextSig()
+		sm.Info("sessMonitor: New remote", "remote", sm.smRemote)
+	}
+}
+
+// publishSigs publishes the remote SIGs the monitor fails over to. The
+// discovered SIGs precede the configured ones, duplicates are removed.
+func (sm *sessMonitor) publishSigs() {
+	var sigs []*siginfo.Sig
+	static := sm.sess.staticSigs.Load().([]*siginfo.Sig)
+	for _, candidates := range [][]*siginfo.Sig{sm.discovered, static} {
+		for _, sig := range candidates {
+			if !containsSig(sigs, sig) {
+				sigs = append(sigs, sig)
+			}
+		}
+	}
+	sm.sess.sigs.Store(sigs)
+}
+
+func containsSig(sigs []*siginfo.Sig, sig *siginfo.Sig) bool {
+	for _, s := range sigs {
+		if s.Equal(sig) {
+			return true
+		}
+	}
+	return false
+}
+
+func sigsEqual(a, b []*siginfo.Sig) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// publishPaths publishes the statistics of the paths in the session.
+func (sm *sessMonitor) publishPaths() {
+	sm.sess.paths.Store(sm.sessPathPool.Stats())
 }
 
 func (sm *sessMonitor) sendReq() {
@@ -257,7 +446,80 @@ func (sm *sessMonitor) sendReq() {
 		return
 	}
 	sm.updateMsgId = mgmt.MsgIdType(time.Now().UnixNano())
-	spld, err := mgmt.NewPld(sm.updateMsgId, mgmt.NewPollReq(sigcmn.MgmtAddr, sm.sess.SessId))
+	sm.send(sm.updateMsgId, mgmt.NewPollReq(sigcmn.MgmtAddr, sm.sess.SessId), sm.smRemote)
+	metrics.SessionProbes.WithLabelValues(sm.sess.IA().String(), sm.sess.SessId.String()).Inc()
+}
+
+// sendAnnounce sends the announcement of the local prefixes to the remote SIG
+// the session currently uses.
+func (sm *sessMonitor) sendAnnounce() {
+	a := announce.Current()
+	if a == nil || !sm.sess.Healthy() {
+		return
+	}
+	remote := sm.sess.Remote()
+	if remote == nil || remote.SessPath == nil {
+		return
+	}
+	sm.send(mgmt.MsgIdType(time.Now().UnixNano()), a, remote)
+}
+
+// updateKey negotiates a new frame key with the remote SIG the session
+// currently uses, if frames are encrypted and the current key is missing or
+// due for renegotiation. Pending key exchanges are retried until they time
+// out.
+func (sm *sessMonitor) updateKey() {
+	ia := sm.sess.IA()
+	if !encap.Encrypted(ia) {
+		sm.hs = nil
+		return
+	}
+	remote := sm.sess.Remote()
+	if remote == nil || remote.SessPath == nil {
+		return
+	}
+	now := time.Now()
+	switch {
+	case sm.hs == nil:
+		// The key is only valid for the remote SIG it was negotiated with.
+		key := encap.Egress.Current(ia, remote.Sig.Host, sm.sess.SessId)
+		if key != nil && remote.Sig.Equal(sm.keySig) &&
+			now.Sub(key.Created()) < rekeyLen && key.Sealed() < rekeyFrames {
+			return
+		}
+	case !remote.Sig.Equal(sm.hsSig):
+		sm.Info("sessMonitor: Remote changed during key exchange", "keyId", sm.keyId)
+	case now.Sub(sm.hs.Created()) >= keyExchangeTout:
+		sm.Info("sessMonitor: Key exchange timed out", "keyId", sm.keyId)
+	default:
+		// The request or the reply might have been lost.
+		if now.Sub(sm.hsSent) >= tout {
+			sm.sendKeyReq(remote)
+		}
+		return
+	}
+	sm.keyId++
+	hs, err := encap.NewHandshake(sigcmn.IA, ia, sm.sess.SessId, sm.keyId, sigcmn.MgmtAddr,
+		sigcmn.Signer)
+	if err != nil {
+		sm.Error("sessMonitor: Unable to start key exchange", "err", err)
+		sm.hs = nil
+		return
+	}
+	sm.hs, sm.hsSig = hs, remote.Sig
+	sm.hsMsgId = mgmt.MsgIdType(now.UnixNano())
+	sm.sendKeyReq(remote)
+}
+
+func (sm *sessMonitor) sendKeyReq(remote *iface.RemoteInfo) {
+	sm.hsSent = time.Now()
+	sm.send(sm.hsMsgId, sm.hs.Req, remote)
+}
+
+// send sends the SIG ctrl payload containing u to the remote SIG on the path
+// of the remote.
+func (sm *sessMonitor) send(id mgmt.MsgIdType, u proto.Cerealizable, remote *iface.RemoteInfo) {
+	spld, err := mgmt.NewPld(id, u)
 	if err != nil {
 		sm.Error("sessMonitor: Error creating SIGCtrl payload", "err", err)
 		return
@@ -277,9 +539,9 @@ func (sm *sessMonitor) sendReq() {
 		sm.Error("sessMonitor: Error packing signed Ctrl payload", "err", err)
 		return
 	}
-	raddr := sm.smRemote.Sig.CtrlSnetAddr()
-	raddr.Path = sm.smRemote.SessPath.Path().Path()
-	raddr.NextHop = sm.smRemote.SessPath.Path().OverlayNextHop()
+	raddr := remote.Sig.CtrlSnetAddr()
+	raddr.Path = remote.SessPath.Path().Path()
+	raddr.NextHop = remote.SessPath.Path().OverlayNextHop()
 	// XXX(kormat): if this blocks, both the sessMon and egress worker
 	// goroutines will block. Can't just use SetWriteDeadline, as both
 	// goroutines write to it.
@@ -287,7 +549,6 @@ func (sm *sessMonitor) sendReq() {
 	if err != nil {
 		sm.Error("sessMonitor: Error sending signed Ctrl payload", "err", err)
 	}
-	metrics.SessionProbes.WithLabelValues(sm.sess.IA().String(), sm.sess.SessId.String()).Inc()
 }
 
 func (sm *sessMonitor) handleRep(rpld *disp.RegPld) {
@@ -314,6 +575,7 @@ func (sm *sessMonitor) handleRep(rpld *disp.RegPld) {
 	// the last poll we sent.
 	if sm.updateMsgId == rpld.Id {
 		sm.lastReply = time.Now()
+		sm.sigPending = false
 		// Update sessmon's remote.
 		sm.smRemote.Sig = &siginfo.Sig{
 			IA:          sm.smRemote.Sig.IA,
@@ -343,6 +605,31 @@ func (sm *sessMonitor) handleRep(rpld *disp.RegPld) {
 	}
 }
 
+func (sm *sessMonitor) handleKeyRep(rpld *disp.RegPld) {
+	rep, ok := rpld.P.(*mgmt.KeyExchangeRep)
+	if !ok {
+		sm.Error("sessMonitor: non-SIGKeyExchangeRep payload received",
+			"src", rpld.Addr, "type", common.TypeOf(rpld.P), "pld", rpld.P)
+		return
+	}
+	if sm.hs == nil || sm.hsMsgId != rpld.Id {
+		sm.Info("sessMonitor: Reply to an old key exchange received", "reply", rpld.Id)
+		return
+	}
+	ctx, cancelF := context.WithTimeout(context.Background(), sigcmn.VerifyTimeout)
+	defer cancelF()
+	key, err := sm.hs.Finish(ctx, rep, sigcmn.Verifier)
+	if err != nil {
+		sm.Error("sessMonitor: Invalid key exchange reply", "src", rpld.Addr, "err", err)
+		return
+	}
+	sm.hs, sm.keySig = nil, sm.hsSig
+	encap.Egress.Install(sm.sess.IA(), sm.hsSig.Host, sm.sess.SessId, key)
+	sm.Info("sessMonitor: Installed frame key", "keyId", key.ID)
+	metrics.SessionKeyExchanges.WithLabelValues(sm.sess.IA().String(),
+		sm.sess.SessId.String()).Inc()
+}
+
 func (sm *sessMonitor) setHealth(healthy bool) {
 	sm.sess.healthy.Store(healthy)
 	var healthVal float64
@@ -0,0 +1,90 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/sig/egress/iface"
+	"github.com/scionproto/scion/go/sig/egress/siginfo"
+)
+
+func newTestMonitor(ia addr.IA) *sessMonitor {
+	s := &Session{ia: ia}
+	s.currRemote.Store((*iface.RemoteInfo)(nil))
+	s.staticSigs.Store([]*siginfo.Sig(nil))
+	s.sigs.Store([]*siginfo.Sig(nil))
+	return &sessMonitor{sess: s}
+}
+
+func testSig(ia addr.IA, host string) *siginfo.Sig {
+	return &siginfo.Sig{IA: ia, Host: addr.HostFromIPStr(host),
+		CtrlL4Port: 30256, EncapL4Port: 30056}
+}
+
+func TestNextSig(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	a, b, c := testSig(ia, "192.0.2.1"), testSig(ia, "192.0.2.2"), testSig(ia, "192.0.2.3")
+	anycast := &siginfo.Sig{IA: ia, Host: addr.SvcSIG}
+
+	t.Run("anycast without SIGs", func(t *testing.T) {
+		sm := newTestMonitor(ia)
+		sm.publishSigs()
+		assert.Empty(t, sm.sess.Sigs())
+		for i := 0; i < 2; i++ {
+			assert.True(t, anycast.Equal(sm.nextSig()))
+		}
+		assert.True(t, sm.sigPending)
+	})
+	t.Run("discovered SIGs precede static SIGs", func(t *testing.T) {
+		sm := newTestMonitor(ia)
+		sm.sess.SetStaticSigs([]*siginfo.Sig{a, b})
+		sm.discovered = []*siginfo.Sig{b, c}
+		sm.publishSigs()
+		assert.Equal(t, []*siginfo.Sig{b, c, a}, sm.sess.Sigs())
+		for _, expected := range []*siginfo.Sig{b, c, a, anycast, b} {
+			sig := sm.nextSig()
+			assert.True(t, expected.Equal(sig), "expected %s, got %s", expected, sig)
+		}
+	})
+}
+
+func TestUpdateSessSnapPendingSig(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	a, b := testSig(ia, "192.0.2.1"), testSig(ia, "192.0.2.2")
+
+	sm := newTestMonitor(ia)
+	sm.smRemote = &iface.RemoteInfo{Sig: &siginfo.Sig{IA: ia, Host: addr.SvcSIG}}
+	sm.sigPending = true
+	sm.updateSessSnap()
+	assert.Nil(t, sm.sess.Remote(), "anycast must not be used for data")
+
+	// Without a previous remote SIG, a pending one is used right away.
+	sm.smRemote.Sig = a
+	sm.updateSessSnap()
+	assert.True(t, a.Equal(sm.sess.Remote().Sig))
+
+	// Otherwise, the previous one is kept until the pending one replies.
+	sm.smRemote.Sig = b
+	sm.updateSessSnap()
+	assert.True(t, a.Equal(sm.sess.Remote().Sig))
+	sm.sigPending = false
+	sm.updateSessSnap()
+	assert.True(t, b.Equal(sm.sess.Remote().Sig))
+}
@@ -0,0 +1,113 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package siginfo
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/svc"
+)
+
+// EncapTransport is the transport key of the encapsulation address in the SVC
+// resolution replies of SIGs. The ctrl address is the UDP transport.
+const EncapTransport svc.Transport = "SIG_ENCAP"
+
+// BuildReply constructs the SVC resolution reply of a SIG with the ctrl and
+// encapsulation addresses.
+func BuildReply(host addr.HostAddr, ctrlPort, encapPort uint16) *svc.Reply {
+	ip := host.IP().String()
+	return &svc.Reply{
+		Transports: map[svc.Transport]string{
+			svc.UDP:        net.JoinHostPort(ip, strconv.Itoa(int(ctrlPort))),
+			EncapTransport: net.JoinHostPort(ip, strconv.Itoa(int(encapPort))),
+		},
+	}
+}
+
+// FromReply parses the SVC resolution reply of a SIG in AS ia.
+func FromReply(ia addr.IA, r *svc.Reply) (*Sig, error) {
+	ctrlIP, ctrlPort, err := parseTransport(r, svc.UDP)
+	if err != nil {
+		return nil, err
+	}
+	encapIP, encapPort, err := parseTransport(r, EncapTransport)
+	if err != nil {
+		return nil, err
+	}
+	if !ctrlIP.Equal(encapIP) {
+		return nil, common.NewBasicError("Ctrl and encap addresses differ", nil,
+			"ctrl", ctrlIP, "encap", encapIP)
+	}
+	return &Sig{
+		IA:          ia,
+		Host:        addr.HostFromIP(ctrlIP),
+		CtrlL4Port:  ctrlPort,
+		EncapL4Port: encapPort,
+	}, nil
+}
+
+func parseTransport(r *svc.Reply, t svc.Transport) (net.IP, int, error) {
+	raw, ok := r.Transports[t]
+	if !ok {
+		return nil, 0, common.NewBasicError("Transport missing", nil, "transport", t)
+	}
+	host, port, err := net.SplitHostPort(raw)
+	if err != nil {
+		return nil, 0, common.NewBasicError("Invalid transport address", err,
+			"transport", t, "addr", raw)
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil, 0, common.NewBasicError("Invalid transport IP", nil,
+			"transport", t, "addr", raw)
+	}
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil || p == 0 {
+		return nil, 0, common.NewBasicError("Invalid transport port", err,
+			"transport", t, "addr", raw)
+	}
+	return ip, int(p), nil
+}
+
+// Resolve discovers the SIGs in the AS terminating the path via SVC
+// resolution. Invalid replies are skipped. If no SIG is discovered, an error
+// is returned.
+func Resolve(ctx context.Context, r svc.MultiResolver, p snet.Path) ([]*Sig, error) {
+	replies, err := r.LookupSVCAll(ctx, p, addr.SvcSIG)
+	if err != nil {
+		return nil, err
+	}
+	var sigs []*Sig
+	for _, reply := range replies {
+		sig, err := FromReply(p.Destination(), reply)
+		if err != nil {
+			log.Debug("Ignoring invalid SIG resolution reply", "ia", p.Destination(),
+				"err", err)
+			continue
+		}
+		sigs = append(sigs, sig)
+	}
+	if len(sigs) == 0 {
+		return nil, common.NewBasicError("No valid SIG resolution reply", nil,
+			"ia", p.Destination(), "replies", len(replies))
+	}
+	return sigs, nil
+}
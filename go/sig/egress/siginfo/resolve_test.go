@@ -0,0 +1,145 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package siginfo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/mock_snet"
+	"github.com/scionproto/scion/go/lib/svc"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+type staticResolver struct {
+	svc     addr.HostSVC
+	replies []*svc.Reply
+	err     error
+}
+
+func (r *staticResolver) LookupSVCAll(_ context.Context, _ snet.Path,
+	svc addr.HostSVC) ([]*svc.Reply, error) {
+
+	r.svc = svc
+	return r.replies, r.err
+}
+
+func TestFromReply(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	tests := map[string]struct {
+		Transports map[svc.Transport]string
+		Expected   *Sig
+	}{
+		"valid": {
+			Transports: map[svc.Transport]string{
+				svc.UDP:        "192.0.2.1:30256",
+				EncapTransport: "192.0.2.1:30056",
+			},
+			Expected: &Sig{IA: ia, Host: addr.HostFromIPStr("192.0.2.1"),
+				CtrlL4Port: 30256, EncapL4Port: 30056},
+		},
+		"valid ipv6": {
+			Transports: map[svc.Transport]string{
+				svc.UDP:        "[2001:db8::1]:30256",
+				EncapTransport: "[2001:db8::1]:30056",
+			},
+			Expected: &Sig{IA: ia, Host: addr.HostFromIPStr("2001:db8::1"),
+				CtrlL4Port: 30256, EncapL4Port: 30056},
+		},
+		"no encap": {
+			Transports: map[svc.Transport]string{svc.UDP: "192.0.2.1:30256"},
+		},
+		"different hosts": {
+			Transports: map[svc.Transport]string{
+				svc.UDP:        "192.0.2.1:30256",
+				EncapTransport: "192.0.2.2:30056",
+			},
+		},
+		"invalid ip": {
+			Transports: map[svc.Transport]string{
+				svc.UDP:        "sig:30256",
+				EncapTransport: "sig:30056",
+			},
+		},
+		"invalid port": {
+			Transports: map[svc.Transport]string{
+				svc.UDP:        "192.0.2.1:0",
+				EncapTransport: "192.0.2.1:30056",
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sig, err := FromReply(ia, &svc.Reply{Transports: test.Transports})
+			if test.Expected == nil {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, test.Expected.Equal(sig), "expected %s, got %s", test.Expected, sig)
+		})
+	}
+}
+
+func TestBuildReply(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	expected := &Sig{IA: ia, Host: addr.HostFromIPStr("2001:db8::1"),
+		CtrlL4Port: 30256, EncapL4Port: 30056}
+	sig, err := FromReply(ia, BuildReply(expected.Host, 30256, 30056))
+	require.NoError(t, err)
+	assert.True(t, expected.Equal(sig), "expected %s, got %s", expected, sig)
+}
+
+func TestResolve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	path := mock_snet.NewMockPath(ctrl)
+	path.EXPECT().Destination().Return(ia).AnyTimes()
+
+	t.Run("invalid replies are skipped", func(t *testing.T) {
+		r := &staticResolver{replies: []*svc.Reply{
+			BuildReply(addr.HostFromIPStr("192.0.2.1"), 30256, 30056),
+			{Transports: map[svc.Transport]string{svc.UDP: "192.0.2.2:30256"}},
+			BuildReply(addr.HostFromIPStr("192.0.2.3"), 40256, 40056),
+		}}
+		sigs, err := Resolve(context.Background(), r, path)
+		require.NoError(t, err)
+		assert.Equal(t, addr.SvcSIG, r.svc)
+		require.Len(t, sigs, 2)
+		assert.Equal(t, "1-ff00:0:110,[192.0.2.1]:30256:30056", sigs[0].String())
+		assert.Equal(t, "1-ff00:0:110,[192.0.2.3]:40256:40056", sigs[1].String())
+	})
+	t.Run("no valid reply", func(t *testing.T) {
+		r := &staticResolver{replies: []*svc.Reply{
+			{Transports: map[svc.Transport]string{svc.UDP: "192.0.2.2:30256"}},
+		}}
+		_, err := Resolve(context.Background(), r, path)
+		assert.Error(t, err)
+	})
+	t.Run("lookup error", func(t *testing.T) {
+		r := &staticResolver{err: errors.New("timeout")}
+		_, err := Resolve(context.Background(), r, path)
+		assert.Error(t, err)
+	})
+}
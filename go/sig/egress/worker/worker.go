@@ -19,6 +19,7 @@ package worker
 import (
 	"time"
 
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/l4"
 	"github.com/scionproto/scion/go/lib/log"
@@ -28,6 +29,7 @@ import (
 	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/sig/egress/iface"
 	"github.com/scionproto/scion/go/sig/egress/siginfo"
+	"github.com/scionproto/scion/go/sig/internal/encap"
 	"github.com/scionproto/scion/go/sig/internal/metrics"
 	"github.com/scionproto/scion/go/sig/mgmt"
 	"github.com/scionproto/scion/go/sig/sigcmn"
@@ -67,6 +69,10 @@ type worker struct {
 	currSig       *siginfo.Sig
 	currPathEntry snet.Path
 	frameSentCtrs metrics.CtrPair
+	// seal is set if the current frame must be sealed, sealed is the buffer
+	// the sealed frame is written to.
+	seal   bool
+	sealed common.RawBytes
 
 	epoch uint16
 	seq   uint32
@@ -92,7 +98,8 @@ func NewWorker(sess iface.Session, writer SCIONWriter, ignoreAddress bool,
 			Pkts:  metrics.FramesSent.WithLabelValues(sess.IA().String(), sess.ID().String()),
 			Bytes: metrics.FrameBytesSent.WithLabelValues(sess.IA().String(), sess.ID().String()),
 		},
-		pkts: make(ringbuf.EntryList, 0, iface.EgressBufPkts),
+		pkts:   make(ringbuf.EntryList, 0, iface.EgressBufPkts),
+		sealed: make(common.RawBytes, 0, common.MaxMTU),
 	}
 }
 
@@ -203,7 +210,25 @@ func (w *worker) write(f *frame) error {
 	}
 
 	f.writeHdr(w.sess.ID(), w.epoch, seq)
-	bytesWritten, err := w.writer.WriteToSCION(f.raw(), snetAddr)
+	raw := f.raw()
+	if w.seal {
+		var host addr.HostAddr
+		if w.currSig != nil {
+			host = w.currSig.Host
+		}
+		key := encap.Egress.Current(w.sess.IA(), host, w.sess.ID())
+		if key == nil {
+			// Frames are never sent in the clear if encryption is required.
+			metrics.FramesDropped.WithLabelValues(w.iaString, "no_key").Inc()
+			return nil
+		}
+		var err error
+		if raw, err = key.Seal(w.sealed[:0], w.sess.ID(), raw); err != nil {
+			metrics.FramesDropped.WithLabelValues(w.iaString, "seal_failed").Inc()
+			return common.NewBasicError("Unable to seal frame", err)
+		}
+	}
+	bytesWritten, err := w.writer.WriteToSCION(raw, snetAddr)
 	if err != nil {
 		return common.NewBasicError("Egress write error", err)
 	}
@@ -230,8 +255,13 @@ func (w *worker) resetFrame(f *frame) {
 			pathLen = uint16(len(w.currPathEntry.Path().Raw))
 		}
 	}
+	mtu -= spkt.CmnHdrLen + addrLen + pathLen + l4.UDPLen
+	w.seal = encap.Encrypted(w.sess.IA())
+	if w.seal {
+		mtu -= encap.Overhead
+	}
 	// FIXME(kormat): to do this properly, need to account for any ext headers.
-	f.reset(mtu - spkt.CmnHdrLen - addrLen - pathLen - l4.UDPLen)
+	f.reset(mtu)
 }
 
 type frame struct {
@@ -21,6 +21,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
@@ -30,6 +31,7 @@ import (
 	"github.com/scionproto/scion/go/sig/egress/iface"
 	"github.com/scionproto/scion/go/sig/egress/iface/mock_iface"
 	"github.com/scionproto/scion/go/sig/egress/worker/mock_worker"
+	"github.com/scionproto/scion/go/sig/internal/encap"
 	"github.com/scionproto/scion/go/sig/mgmt"
 )
 
@@ -173,3 +175,31 @@ func TestParsing(t *testing.T) {
 		tester.Run()
 	})
 }
+
+func TestSealing(t *testing.T) {
+	iface.Init()
+	ia, _ := addr.IAFromString("1-ff00:0:300")
+	secret := make(common.RawBytes, encap.KeyLen)
+	egressKey, err := encap.NewKey(1, secret)
+	require.NoError(t, err)
+	ingressKey, err := encap.NewKey(1, secret)
+	require.NoError(t, err)
+	encap.SetPeer(ia, true)
+	encap.Egress.Install(ia, nil, 0, egressKey)
+	defer encap.DelPeer(ia)
+
+	tester := NewWorkerTester(t)
+	defer tester.Finish()
+	tester.SendPacket([]byte{1, 2, 3})
+	tester.writer.EXPECT().WriteToSCION(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(frame []byte, address *snet.Addr) (int, error) {
+			tester.ring.Close()
+			assert.True(t, encap.IsSealed(frame))
+			plain, err := ingressKey.Open(frame)
+			require.NoError(t, err)
+			assert.True(t, MatchFrame([]byte{0, 0, 0, 0, 0, 0, 0, 1,
+				0, 3, 1, 2, 3}).Matches([]byte(plain)))
+			return len(frame), nil
+		})
+	tester.Run()
+}
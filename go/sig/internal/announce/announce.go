@@ -0,0 +1,98 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package announce maintains the signed announcement of the prefixes served
+// by the local SIG. The session monitors periodically send it to the remote
+// SIGs, which install the prefixes in their routing tables.
+package announce
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/sig/internal/config"
+	"github.com/scionproto/scion/go/sig/mgmt"
+)
+
+// RefreshInterval is the interval at which the announcement is signed again
+// with a new version. Remote SIGs reject announcements that are older than
+// their maximum age, which must be larger than the interval.
+const RefreshInterval = time.Minute
+
+var (
+	ia     addr.IA
+	signer infra.Signer
+
+	mtx sync.Mutex
+	// nets are the announced prefixes.
+	nets    []*net.IPNet
+	current *mgmt.PrefixAnnounce
+	signed  time.Time
+)
+
+// Init sets the signer announcements are signed with. If s is nil, no
+// prefixes are announced.
+func Init(localIA addr.IA, s infra.Signer) {
+	ia = localIA
+	signer = s
+}
+
+// Update signs the prefixes listed in cfg. The announcement is versioned with
+// the current time, such that it supersedes all previous announcements, also
+// those sent before a restart.
+func Update(cfg *config.Cfg) error {
+	if signer == nil {
+		return nil
+	}
+	announced := make([]*net.IPNet, 0, len(cfg.Announce))
+	for _, n := range cfg.Announce {
+		announced = append(announced, n.IPNet())
+	}
+	mtx.Lock()
+	defer mtx.Unlock()
+	if err := sign(announced); err != nil {
+		return err
+	}
+	nets = announced
+	return nil
+}
+
+// Current returns the current announcement, or nil if the local SIG does not
+// announce any prefixes. The announcement is signed again if it is older than
+// RefreshInterval.
+func Current() *mgmt.PrefixAnnounce {
+	mtx.Lock()
+	defer mtx.Unlock()
+	if current != nil && time.Since(signed) >= RefreshInterval {
+		if err := sign(nets); err != nil {
+			log.Error("Unable to refresh prefix announcement", "err", err)
+		}
+	}
+	return current
+}
+
+func sign(announced []*net.IPNet) error {
+	now := time.Now()
+	a, err := mgmt.NewPrefixAnnounce(
+		mgmt.NewPrefixes(ia, uint64(now.UnixNano()), announced), signer)
+	if err != nil {
+		return err
+	}
+	current, signed = a, now
+	return nil
+}
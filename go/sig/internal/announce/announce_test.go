@@ -0,0 +1,76 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package announce
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/sig/internal/config"
+	"github.com/scionproto/scion/go/sig/internal/signtest"
+)
+
+func TestUpdate(t *testing.T) {
+	tr := signtest.New(t)
+	defer tr.Close()
+	local := xtest.MustParseIA("1-ff00:0:110")
+	verifier := tr.Verifier().WithIA(local)
+	ctx := context.Background()
+
+	Init(local, nil)
+	require.NoError(t, Update(&config.Cfg{}))
+	assert.Nil(t, Current(), "announcing disabled")
+
+	Init(local, tr.Signer(t, local))
+	cfg := &config.Cfg{Announce: []*config.IPNet{
+		{IP: net.IP{192, 0, 2, 0}, Mask: net.CIDRMask(24, 32)},
+		{IP: net.ParseIP("2001:db8::"), Mask: net.CIDRMask(48, 128)},
+	}}
+	require.NoError(t, Update(cfg))
+	first := Current()
+	require.NotNil(t, first)
+	p, err := first.Verify(ctx, verifier)
+	require.NoError(t, err)
+	assert.Equal(t, local, p.IA())
+	nets, err := p.Nets()
+	require.NoError(t, err)
+	require.Len(t, nets, 2)
+	assert.Equal(t, "192.0.2.0/24", nets[0].String())
+	assert.Equal(t, "2001:db8::/48", nets[1].String())
+
+	_, err = first.Verify(ctx, tr.Verifier().WithIA(xtest.MustParseIA("1-ff00:0:111")))
+	assert.Error(t, err, "wrong AS")
+
+	require.NoError(t, Update(cfg))
+	p2, err := Current().Verify(ctx, verifier)
+	require.NoError(t, err)
+	assert.True(t, p2.Version > p.Version, "version increases")
+
+	// Outdated announcements are signed again with the same prefixes.
+	mtx.Lock()
+	signed = signed.Add(-RefreshInterval)
+	mtx.Unlock()
+	p3, err := Current().Verify(ctx, verifier)
+	require.NoError(t, err)
+	assert.True(t, p3.Version > p2.Version, "version increases")
+	nets, err = p3.Nets()
+	require.NoError(t, err)
+	assert.Len(t, nets, 2)
+}
@@ -0,0 +1,99 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package base
+
+import (
+	"context"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/proto"
+	"github.com/scionproto/scion/go/sig/internal/disp"
+	"github.com/scionproto/scion/go/sig/internal/encap"
+	"github.com/scionproto/scion/go/sig/mgmt"
+	"github.com/scionproto/scion/go/sig/sigcmn"
+)
+
+// KeyExchangeReqHdlr answers the key exchange requests of remote SIGs, and
+// installs the negotiated keys the frames received from them are opened with.
+func KeyExchangeReqHdlr() {
+	log.Info("KeyExchangeReqHdlr: starting")
+	r := &encap.Responder{
+		IA:       sigcmn.IA,
+		Addr:     sigcmn.MgmtAddr,
+		Signer:   sigcmn.Signer,
+		Verifier: sigcmn.Verifier,
+		Store:    encap.Ingress,
+	}
+	for rpld := range disp.Dispatcher.KeyExchangeReqC {
+		req, ok := rpld.P.(*mgmt.KeyExchangeReq)
+		if !ok {
+			log.Error("KeyExchangeReqHdlr: non-SIGKeyExchangeReq payload received",
+				"src", rpld.Addr, "type", common.TypeOf(rpld.P), "Id", rpld.Id)
+			continue
+		}
+		if !encap.IsPeer(rpld.Addr.IA) {
+			log.Warn("KeyExchangeReqHdlr: Ignoring key exchange request of unknown AS",
+				"src", rpld.Addr)
+			continue
+		}
+		ctx, cancelF := context.WithTimeout(context.Background(), sigcmn.VerifyTimeout)
+		rep, share, err := r.Respond(ctx, rpld.Addr.IA, req)
+		cancelF()
+		if err != nil {
+			log.Warn("KeyExchangeReqHdlr: Ignoring key exchange request", "src", rpld.Addr,
+				"err", err)
+			continue
+		}
+		sigCtrlAddr := &snet.Addr{
+			IA:      rpld.Addr.IA,
+			Host:    addr.AppAddrFromUDP(share.Addr.Ctrl.UDP()),
+			Path:    rpld.Addr.Path,
+			NextHop: snet.CopyUDPAddr(rpld.Addr.NextHop),
+		}
+		if err := sendCtrl(rpld.Id, rep, sigCtrlAddr); err != nil {
+			log.Error("KeyExchangeReqHdlr: Error sending reply", "dest", sigCtrlAddr,
+				"err", err)
+		}
+	}
+	log.Info("KeyExchangeReqHdlr: stopped")
+}
+
+// sendCtrl sends the SIG ctrl payload containing u to dst on the ctrl
+// connection.
+func sendCtrl(id mgmt.MsgIdType, u proto.Cerealizable, dst *snet.Addr) error {
+	spld, err := mgmt.NewPld(id, u)
+	if err != nil {
+		return common.NewBasicError("Error creating SIGCtrl payload", err)
+	}
+	cpld, err := ctrl.NewPld(spld, nil)
+	if err != nil {
+		return common.NewBasicError("Error creating Ctrl payload", err)
+	}
+	scpld, err := cpld.SignedPld(infra.NullSigner)
+	if err != nil {
+		return common.NewBasicError("Error creating signed Ctrl payload", err)
+	}
+	raw, err := scpld.PackPld()
+	if err != nil {
+		return common.NewBasicError("Error packing signed Ctrl payload", err)
+	}
+	_, err = sigcmn.CtrlConn.WriteToSCION(raw, dst)
+	return err
+}
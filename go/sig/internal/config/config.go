@@ -19,6 +19,7 @@ package config
 import (
 	"encoding/json"
 	"io/ioutil"
+	"net"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
@@ -28,6 +29,9 @@ import (
 type Cfg struct {
 	ASes          map[addr.IA]*ASEntry
 	ConfigVersion uint64
+	// Announce lists the local prefixes that are announced to the SIGs of
+	// the remote ASes.
+	Announce []*IPNet `json:",omitempty"`
 }
 
 // Load a JSON config file from path and parse it into a Cfg struct.
@@ -40,9 +44,38 @@ func LoadFromFile(path string) (*Cfg, error) {
 	if err := json.Unmarshal(b, cfg); err != nil {
 		return nil, common.NewBasicError("Unable to parse SIG config", err)
 	}
+	for ia, e := range cfg.ASes {
+		for _, sig := range e.Sigs {
+			if sig.Addr == nil || sig.CtrlPort == 0 || sig.EncapPort == 0 {
+				return nil, common.NewBasicError("Incomplete static SIG", nil,
+					"ia", ia, "addr", sig.Addr, "ctrl_port", sig.CtrlPort,
+					"encap_port", sig.EncapPort)
+			}
+		}
+	}
 	return cfg, nil
 }
 
 type ASEntry struct {
 	Nets []*IPNet
+	// AnnounceAllow lists the networks the remote AS may announce prefixes
+	// in. Announced prefixes that are not contained in one of them are
+	// dropped. Announcements are verified with the certificate chain of the
+	// remote AS.
+	AnnounceAllow []*IPNet `json:",omitempty"`
+	// Encrypt requires the frames exchanged with the remote AS to be
+	// encrypted. Frames are never sent or accepted in the clear. The key
+	// exchanges are verified with the certificate chain of the remote AS.
+	Encrypt bool `json:",omitempty"`
+	// Sigs lists static SIGs of the remote AS. The SIGs of the remote AS
+	// are discovered via SVC resolution, the static SIGs are used as a
+	// fallback if the discovery fails.
+	Sigs []*SIG `json:",omitempty"`
+}
+
+// SIG is the address of a SIG in a remote AS.
+type SIG struct {
+	Addr      net.IP
+	CtrlPort  uint16
+	EncapPort uint16
 }
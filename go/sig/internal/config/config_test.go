@@ -16,11 +16,13 @@ package config
 
 import (
 	"flag"
+	"io/ioutil"
 	"net"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/xtest"
@@ -71,6 +73,86 @@ func TestLoadFromFile(t *testing.T) {
 				ConfigVersion: 9001,
 			},
 		},
+		{
+			Name:     "announce",
+			FileName: "02-announce",
+			Config: Cfg{
+				ASes: map[addr.IA]*ASEntry{
+					xtest.MustParseIA("1-ff00:0:1"): {
+						Nets: []*IPNet{
+							{
+								IP:   net.IP{192, 0, 2, 0},
+								Mask: net.CIDRMask(24, 8*net.IPv4len),
+							},
+						},
+						AnnounceAllow: []*IPNet{
+							{
+								IP:   net.IP{198, 51, 100, 0},
+								Mask: net.CIDRMask(24, 8*net.IPv4len),
+							},
+							{
+								IP:   net.ParseIP("2001:DB8:1::"),
+								Mask: net.CIDRMask(48, 8*net.IPv6len),
+							},
+						},
+					},
+				},
+				ConfigVersion: 9002,
+				Announce: []*IPNet{
+					{
+						IP:   net.IP{203, 0, 113, 0},
+						Mask: net.CIDRMask(24, 8*net.IPv4len),
+					},
+				},
+			},
+		},
+		{
+			Name:     "encrypt",
+			FileName: "03-encrypt",
+			Config: Cfg{
+				ASes: map[addr.IA]*ASEntry{
+					xtest.MustParseIA("1-ff00:0:1"): {
+						Nets: []*IPNet{
+							{
+								IP:   net.IP{192, 0, 2, 0},
+								Mask: net.CIDRMask(24, 8*net.IPv4len),
+							},
+						},
+						Encrypt: true,
+					},
+				},
+				ConfigVersion: 9003,
+			},
+		},
+		{
+			Name:     "sigs",
+			FileName: "04-sigs",
+			Config: Cfg{
+				ASes: map[addr.IA]*ASEntry{
+					xtest.MustParseIA("1-ff00:0:1"): {
+						Nets: []*IPNet{
+							{
+								IP:   net.IP{192, 0, 2, 0},
+								Mask: net.CIDRMask(24, 8*net.IPv4len),
+							},
+						},
+						Sigs: []*SIG{
+							{
+								Addr:      net.ParseIP("198.51.100.1"),
+								CtrlPort:  30256,
+								EncapPort: 30056,
+							},
+							{
+								Addr:      net.ParseIP("2001:db8::1"),
+								CtrlPort:  30256,
+								EncapPort: 30056,
+							},
+						},
+					},
+				},
+				ConfigVersion: 9004,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -86,6 +168,25 @@ func TestLoadFromFile(t *testing.T) {
 	}
 }
 
+func TestLoadFromFileInvalid(t *testing.T) {
+	tests := map[string]string{
+		"sig no addr": `{"ASes": {"1-ff00:0:1": {"Sigs": [` +
+			`{"CtrlPort": 30256, "EncapPort": 30056}]}}}`,
+		"sig no encap port": `{"ASes": {"1-ff00:0:1": {"Sigs": [` +
+			`{"Addr": "198.51.100.1", "CtrlPort": 30256}]}}}`,
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir, cleanF := xtest.MustTempDir("", "sig-config")
+			defer cleanF()
+			file := filepath.Join(dir, "sig.json")
+			require.NoError(t, ioutil.WriteFile(file, []byte(content), 0644))
+			_, err := LoadFromFile(file)
+			assert.Error(t, err)
+		})
+	}
+}
+
 func TestIPNetUnmarshalJSON(t *testing.T) {
 	tests := []struct {
 		Name  string
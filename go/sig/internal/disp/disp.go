@@ -40,12 +40,15 @@ type RegType int
 
 const (
 	RegPollRep RegType = iota
+	RegKeyExchangeRep
 )
 
 func (rt RegType) String() string {
 	switch rt {
 	case RegPollRep:
 		return "RegPollRep"
+	case RegKeyExchangeRep:
+		return "RegKeyExchangeRep"
 	}
 	return fmt.Sprintf("UNKNOWN (%d)", rt)
 }
@@ -67,12 +70,20 @@ type dispRegistry struct {
 	sync.RWMutex
 	PollReqC RegPldChan
 	pollRep  map[RegPollKey]RegPldChan
+	// PrefixAnnounceC receives the prefix announcements of remote SIGs.
+	PrefixAnnounceC RegPldChan
+	// KeyExchangeReqC receives the key exchange requests of remote SIGs.
+	KeyExchangeReqC RegPldChan
+	keyExchangeRep  map[RegPollKey]RegPldChan
 }
 
 func newDispReg() *dispRegistry {
 	return &dispRegistry{
-		PollReqC: make(RegPldChan, 16),
-		pollRep:  make(map[RegPollKey]RegPldChan),
+		PollReqC:        make(RegPldChan, 16),
+		pollRep:         make(map[RegPollKey]RegPldChan),
+		PrefixAnnounceC: make(RegPldChan, 16),
+		KeyExchangeReqC: make(RegPldChan, 16),
+		keyExchangeRep:  make(map[RegPollKey]RegPldChan),
 	}
 }
 
@@ -82,6 +93,8 @@ func (dm *dispRegistry) Register(regType RegType, key RegPollKey, c RegPldChan)
 	switch regType {
 	case RegPollRep:
 		dm.pollRep[key] = c
+	case RegKeyExchangeRep:
+		dm.keyExchangeRep[key] = c
 	default:
 		return common.NewBasicError("Register: Unsupported dispatcher RegType", nil, "v", regType)
 	}
@@ -94,6 +107,8 @@ func (dm *dispRegistry) Unregister(regType RegType, key RegPollKey) error {
 	switch regType {
 	case RegPollRep:
 		delete(dm.pollRep, key)
+	case RegKeyExchangeRep:
+		delete(dm.keyExchangeRep, key)
 	default:
 		return common.NewBasicError("Unregister: Unsupported dispatcher RegType", nil, "v", regType)
 	}
@@ -124,6 +139,24 @@ func (dm *dispRegistry) sigCtrl(pld *mgmt.Pld, addr *snet.Addr) {
 			return
 		}
 		entry <- regPld
+	case *mgmt.PrefixAnnounce:
+		dm.PrefixAnnounceC <- &RegPld{Id: msgId, P: pld, Addr: addr}
+	case *mgmt.KeyExchangeReq:
+		dm.KeyExchangeReqC <- &RegPld{Id: msgId, P: pld, Addr: addr}
+	case *mgmt.KeyExchangeRep:
+		// The key share is only parsed to find the session, it is verified by
+		// the session monitor.
+		share, err := mgmt.NewKeyShareFromRaw(pld.Blob)
+		if err != nil {
+			log.Error("Unparsable SIG KeyExchangeRep received", "src", addr, "err", err)
+			return
+		}
+		entry, ok := dm.keyExchangeRep[MkRegPollKey(addr.IA, share.Session, msgId)]
+		if !ok {
+			log.Warn("Unexpected SIG KeyExchangeRep received", "src", addr, "pld", pld)
+			return
+		}
+		entry <- &RegPld{Id: msgId, P: pld, Addr: addr}
 	default:
 		log.Error("Unsupported ctrl payload type", common.TypeOf(pld), "src", addr)
 	}
@@ -0,0 +1,257 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encap
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"io"
+	"time"
+
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/sig/mgmt"
+)
+
+const (
+	// MaxSignSkew is the maximum difference between the signature time of a
+	// key exchange and the local time.
+	MaxSignSkew = time.Minute
+
+	pubKeyLen = 32
+)
+
+// kdfInfo is the HKDF info prefix of frame keys.
+var kdfInfo = []byte("SIG frame key")
+
+// Handshake is the key exchange of the initiator, the SIG sending the frames
+// of a session. The initiator sends Req to the responder, and derives the key
+// from the reply with Finish.
+type Handshake struct {
+	// Req is the signed key exchange request.
+	Req *mgmt.KeyExchangeReq
+
+	local   addr.IA
+	remote  addr.IA
+	sessId  mgmt.SessionType
+	keyId   uint8
+	priv    [32]byte
+	pub     [32]byte
+	created time.Time
+}
+
+// NewHandshake starts the key exchange for key keyId of the session with the
+// remote AS. The request is signed with signer, replies are sent to ctrlAddr.
+func NewHandshake(local, remote addr.IA, sessId mgmt.SessionType, keyId uint8,
+	ctrlAddr *mgmt.Addr, signer infra.Signer) (*Handshake, error) {
+
+	h := &Handshake{
+		local:   local,
+		remote:  remote,
+		sessId:  sessId,
+		keyId:   keyId,
+		created: time.Now(),
+	}
+	if err := genKeyPair(&h.priv, &h.pub); err != nil {
+		return nil, err
+	}
+	share := &mgmt.KeyShare{
+		RawIA:   local.IAInt(),
+		Session: sessId,
+		KeyId:   keyId,
+		PubKey:  append(common.RawBytes(nil), h.pub[:]...),
+		Addr:    ctrlAddr,
+	}
+	var err error
+	if h.Req, err = mgmt.NewKeyExchangeReq(share, signer); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Created returns the time the handshake was started at.
+func (h *Handshake) Created() time.Time {
+	return h.created
+}
+
+// Finish verifies the reply of the responder with the certificate chain of
+// the remote AS, and derives the frame key.
+func (h *Handshake) Finish(ctx context.Context, rep *mgmt.KeyExchangeRep,
+	verifier infra.Verifier) (*Key, error) {
+
+	share, err := verify(ctx, rep.KeyExchange, verifier, h.remote)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case !share.IA().Equal(h.remote):
+		return nil, serrors.New("reply from wrong IA", "expected", h.remote,
+			"actual", share.IA())
+	case share.Session != h.sessId || share.KeyId != h.keyId:
+		return nil, serrors.New("reply for wrong key", "session", share.Session,
+			"keyId", share.KeyId)
+	case !bytes.Equal(share.PeerPubKey, h.pub[:]):
+		return nil, serrors.New("reply to different request")
+	}
+	return deriveKey(&h.priv, share.PubKey, h.pub[:], share.PubKey, h.local, h.remote,
+		h.sessId, h.keyId)
+}
+
+// Responder answers the key exchange requests of the initiators. It installs
+// the derived keys in its store. Responder is not concurrency safe.
+type Responder struct {
+	// IA is the local IA.
+	IA addr.IA
+	// Addr is the local control address.
+	Addr *mgmt.Addr
+	// Signer signs the replies with the local AS signing key.
+	Signer infra.Signer
+	// Verifier verifies the requests with the certificate chains of the
+	// remote ASes.
+	Verifier infra.Verifier
+	// Store holds the keys of the frames received from the initiators.
+	Store *Store
+
+	// replies contains the last reply per session with a remote SIG, such
+	// that retransmitted requests are answered without replacing the key.
+	replies map[storeKey]*reply
+}
+
+type reply struct {
+	keyId  uint8
+	pubKey common.RawBytes
+	rep    *mgmt.KeyExchangeRep
+}
+
+// Respond verifies the request of the initiator in AS src with the
+// certificate chain of src. It installs the derived key for the host of the signed
+// control address of the initiator, and returns the reply and the key share
+// of the initiator.
+func (r *Responder) Respond(ctx context.Context, src addr.IA,
+	req *mgmt.KeyExchangeReq) (*mgmt.KeyExchangeRep, *mgmt.KeyShare, error) {
+
+	if r.Signer == nil {
+		return nil, nil, serrors.New("no signing key")
+	}
+	share, err := verify(ctx, req.KeyExchange, r.Verifier, src)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !share.IA().Equal(src) {
+		return nil, nil, serrors.New("request from wrong IA", "src", src, "ia", share.IA())
+	}
+	if share.Addr == nil || share.Addr.Ctrl == nil || share.Addr.Ctrl.Host() == nil {
+		return nil, nil, serrors.New("request without control address")
+	}
+	host := share.Addr.Ctrl.Host()
+	sk := newStoreKey(src, host, share.Session)
+	if last, ok := r.replies[sk]; ok && last.keyId == share.KeyId &&
+		bytes.Equal(last.pubKey, share.PubKey) {
+		return last.rep, share, nil
+	}
+	var priv, pub [32]byte
+	if err := genKeyPair(&priv, &pub); err != nil {
+		return nil, nil, err
+	}
+	key, err := deriveKey(&priv, share.PubKey, share.PubKey, pub[:], src, r.IA,
+		share.Session, share.KeyId)
+	if err != nil {
+		return nil, nil, err
+	}
+	rep, err := mgmt.NewKeyExchangeRep(&mgmt.KeyShare{
+		RawIA:      r.IA.IAInt(),
+		Session:    share.Session,
+		KeyId:      share.KeyId,
+		PubKey:     append(common.RawBytes(nil), pub[:]...),
+		PeerPubKey: share.PubKey,
+		Addr:       r.Addr,
+	}, r.Signer)
+	if err != nil {
+		return nil, nil, err
+	}
+	r.Store.Install(src, host, share.Session, key)
+	if r.replies == nil {
+		r.replies = make(map[storeKey]*reply)
+	}
+	r.replies[sk] = &reply{keyId: share.KeyId, pubKey: share.PubKey, rep: rep}
+	return rep, share, nil
+}
+
+// verify verifies that the key exchange is signed by the AS ia, checks its
+// signature time, and returns the key share.
+func verify(ctx context.Context, e *mgmt.KeyExchange, verifier infra.Verifier,
+	ia addr.IA) (*mgmt.KeyShare, error) {
+
+	if e == nil {
+		return nil, serrors.New("empty key exchange")
+	}
+	if verifier == nil {
+		return nil, serrors.New("no verifier")
+	}
+	share, err := e.Verify(ctx, verifier.WithIA(ia))
+	if err != nil {
+		return nil, serrors.WrapStr("unable to verify key exchange", err)
+	}
+	if skew := time.Since(e.Time()); skew > MaxSignSkew || skew < -MaxSignSkew {
+		return nil, serrors.New("key exchange signature time out of range",
+			"time", e.Time())
+	}
+	if len(share.PubKey) != pubKeyLen {
+		return nil, serrors.New("invalid public key length", "len", len(share.PubKey))
+	}
+	return share, nil
+}
+
+func genKeyPair(priv, pub *[32]byte) error {
+	if _, err := io.ReadFull(rand.Reader, priv[:]); err != nil {
+		return serrors.WrapStr("unable to generate ephemeral key", err)
+	}
+	curve25519.ScalarBaseMult(pub, priv)
+	return nil
+}
+
+// deriveKey derives the frame key from the X25519 shared secret of priv and
+// peerPub. The public keys of both parties and the identity of the key are
+// bound to it.
+func deriveKey(priv *[32]byte, peerPub, initPub, respPub common.RawBytes,
+	initIA, respIA addr.IA, sessId mgmt.SessionType, keyId uint8) (*Key, error) {
+
+	var peer, shared [32]byte
+	copy(peer[:], peerPub)
+	curve25519.ScalarMult(&shared, priv, &peer)
+	if subtle.ConstantTimeCompare(shared[:], make([]byte, len(shared))) == 1 {
+		return nil, serrors.New("low order public key")
+	}
+	salt := append(append(make([]byte, 0, 2*pubKeyLen), initPub...), respPub...)
+	info := make([]byte, len(kdfInfo)+2*addr.IABytes+2)
+	copy(info, kdfInfo)
+	initIA.Write(info[len(kdfInfo):])
+	respIA.Write(info[len(kdfInfo)+addr.IABytes:])
+	info[len(info)-2] = uint8(sessId)
+	info[len(info)-1] = keyId
+	secret := make([]byte, KeyLen)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, shared[:], salt, info), secret); err != nil {
+		return nil, err
+	}
+	return NewKey(keyId, secret)
+}
@@ -0,0 +1,165 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encap
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/sig/internal/signtest"
+	"github.com/scionproto/scion/go/sig/mgmt"
+)
+
+var (
+	iaInit   = xtest.MustParseIA("1-ff00:0:110")
+	iaResp   = xtest.MustParseIA("1-ff00:0:111")
+	hostInit = mustAddr(1).Ctrl.Host()
+)
+
+func TestHandshake(t *testing.T) {
+	tr := signtest.New(t)
+	defer tr.Close()
+	initSigner, respSigner := tr.Signer(t, iaInit), tr.Signer(t, iaResp)
+	r := newResponder(respSigner, tr.Verifier())
+	ctx := context.Background()
+
+	h, err := NewHandshake(iaInit, iaResp, 2, 5, mustAddr(1), initSigner)
+	require.NoError(t, err)
+	rep, share, err := r.Respond(ctx, iaInit, h.Req)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(30256), share.Addr.Ctrl.Port, "reply address")
+	egress, err := h.Finish(ctx, rep, tr.Verifier())
+	require.NoError(t, err)
+	ingress := r.Store.Get(iaInit, hostInit, 2, 5)
+	require.NotNil(t, ingress)
+
+	sealed, err := egress.Seal(nil, 2, common.RawBytes("frame"))
+	require.NoError(t, err)
+	plain, err := ingress.Open(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, common.RawBytes("frame"), plain)
+
+	t.Run("retransmitted request", func(t *testing.T) {
+		rep2, _, err := r.Respond(ctx, iaInit, h.Req)
+		require.NoError(t, err)
+		assert.Equal(t, rep, rep2)
+		assert.Same(t, ingress, r.Store.Current(iaInit, hostInit, 2), "key not replaced")
+	})
+	t.Run("other host in same session", func(t *testing.T) {
+		other, err := NewHandshake(iaInit, iaResp, 2, 5, mustAddr(3), initSigner)
+		require.NoError(t, err)
+		rep2, _, err := r.Respond(ctx, iaInit, other.Req)
+		require.NoError(t, err)
+		assert.NotEqual(t, rep, rep2, "reply of other host")
+		key, err := other.Finish(ctx, rep2, tr.Verifier())
+		require.NoError(t, err)
+		assert.NotNil(t, r.Store.Get(iaInit, mustAddr(3).Ctrl.Host(), 2, 5))
+		assert.Same(t, ingress, r.Store.Current(iaInit, hostInit, 2), "key not replaced")
+		sealed, err := key.Seal(nil, 2, common.RawBytes("frame"))
+		require.NoError(t, err)
+		_, err = ingress.Open(sealed)
+		assert.Error(t, err, "opened with key of other host")
+	})
+	t.Run("signed by other AS", func(t *testing.T) {
+		forged, err := NewHandshake(iaInit, iaResp, 2, 5, mustAddr(1), respSigner)
+		require.NoError(t, err)
+		_, _, err = r.Respond(ctx, iaInit, forged.Req)
+		assert.Error(t, err)
+		forger := newResponder(initSigner, tr.Verifier())
+		forgedRep, _, err := forger.Respond(ctx, iaInit, h.Req)
+		require.NoError(t, err)
+		_, err = h.Finish(ctx, forgedRep, tr.Verifier())
+		assert.Error(t, err)
+	})
+	t.Run("key not in certificate chain", func(t *testing.T) {
+		other := signtest.New(t)
+		defer other.Close()
+		forged, err := NewHandshake(iaInit, iaResp, 2, 5, mustAddr(1),
+			other.Signer(t, iaInit))
+		require.NoError(t, err)
+		_, _, err = r.Respond(ctx, iaInit, forged.Req)
+		assert.Error(t, err)
+	})
+	t.Run("wrong source IA", func(t *testing.T) {
+		_, _, err := r.Respond(ctx, iaResp, h.Req)
+		assert.Error(t, err)
+	})
+	t.Run("reply to other request", func(t *testing.T) {
+		other, err := NewHandshake(iaInit, iaResp, 2, 5, mustAddr(1), initSigner)
+		require.NoError(t, err)
+		_, err = other.Finish(ctx, rep, tr.Verifier())
+		assert.Error(t, err)
+	})
+	t.Run("reply from wrong IA", func(t *testing.T) {
+		h, err := NewHandshake(iaInit, iaInit, 2, 6, mustAddr(1), initSigner)
+		require.NoError(t, err)
+		rep, _, err := r.Respond(ctx, iaInit, h.Req)
+		require.NoError(t, err)
+		_, err = h.Finish(ctx, rep, tr.Verifier())
+		assert.Error(t, err)
+	})
+	t.Run("low order public key", func(t *testing.T) {
+		share := &mgmt.KeyShare{RawIA: iaInit.IAInt(), KeyId: 7, PubKey: make([]byte, 32),
+			Addr: mustAddr(1)}
+		req, err := mgmt.NewKeyExchangeReq(share, initSigner)
+		require.NoError(t, err)
+		_, _, err = r.Respond(ctx, iaInit, req)
+		assert.Error(t, err)
+	})
+}
+
+func TestHandshakeRekey(t *testing.T) {
+	tr := signtest.New(t)
+	defer tr.Close()
+	initSigner, respSigner := tr.Signer(t, iaInit), tr.Signer(t, iaResp)
+	r := newResponder(respSigner, tr.Verifier())
+	ctx := context.Background()
+	var keys []*Key
+	for id := uint8(1); id <= 2; id++ {
+		h, err := NewHandshake(iaInit, iaResp, 0, id, mustAddr(1), initSigner)
+		require.NoError(t, err)
+		rep, _, err := r.Respond(ctx, iaInit, h.Req)
+		require.NoError(t, err)
+		k, err := h.Finish(ctx, rep, tr.Verifier())
+		require.NoError(t, err)
+		keys = append(keys, k)
+	}
+	// Frames sealed with the previous key are still accepted.
+	for _, k := range keys {
+		sealed, err := k.Seal(nil, 0, common.RawBytes("frame"))
+		require.NoError(t, err)
+		ingress := r.Store.Get(iaInit, hostInit, 0, k.ID)
+		require.NotNil(t, ingress)
+		_, err = ingress.Open(sealed)
+		assert.NoError(t, err)
+	}
+}
+
+func newResponder(signer infra.Signer, verifier infra.Verifier) *Responder {
+	return &Responder{IA: iaResp, Addr: mustAddr(2), Signer: signer, Verifier: verifier,
+		Store: NewStore()}
+}
+
+func mustAddr(host byte) *mgmt.Addr {
+	return mgmt.NewAddr(addr.HostFromIP(net.IPv4(192, 0, 2, host)), 30256, 30056)
+}
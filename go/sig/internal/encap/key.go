@@ -0,0 +1,204 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package encap implements the optional authenticated encryption of the
+// frames exchanged between SIGs.
+//
+// A sealed frame is used instead of a plain SIG frame if the frames of a
+// session are encrypted. The sealed flag (S) distinguishes sealed frames from
+// plain SIG frames. The key id selects the key of the session, the counter is
+// strictly increasing per key. The header is followed by the plain SIG frame,
+// including its header, encrypted with AES-256-GCM. The header is
+// authenticated as additional data, and prefixed with 4 zero bytes it forms
+// the nonce.
+//
+//	0B       1        2        3        4        5        6        7
+//	+--------+--------+--------+--------+--------+--------+--------+--------+
+//	|S|SessId| Key Id |                      Counter                        |
+//	+--------+--------+--------+--------+--------+--------+--------+--------+
+//
+// Keys are unidirectional. They are negotiated by the session monitor of the
+// sending SIG with an ephemeral X25519 key exchange signed with the AS signing
+// keys, see Handshake.
+package encap
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/sig/mgmt"
+)
+
+const (
+	// HdrLen is the length of the sealed frame header.
+	HdrLen = 8
+	// Overhead is the number of bytes a sealed frame is longer than the
+	// plain frame.
+	Overhead = HdrLen + 16
+	// SealedFlag is set in the first byte of sealed frames.
+	SealedFlag = 0x80
+	// MaxSessId is the highest session id that can be sealed.
+	MaxSessId = SealedFlag - 1
+	// MaxCounter is the highest counter a key seals a frame with.
+	MaxCounter = 1<<48 - 1
+	// KeyLen is the length of the frame keys.
+	KeyLen = 32
+
+	// replayWindow is the number of counters below the highest counter
+	// received that are still accepted if they were not received before.
+	replayWindow = 64
+)
+
+var (
+	// ErrAuth indicates that a sealed frame failed authentication.
+	ErrAuth = serrors.New("authentication failed")
+	// ErrReplay indicates that a sealed frame was replayed or is too old.
+	ErrReplay = serrors.New("replayed frame")
+	// ErrKeyExhausted indicates that a key sealed the maximum number of
+	// frames.
+	ErrKeyExhausted = serrors.New("key exhausted")
+	// ErrMalformed indicates that a sealed frame is too short.
+	ErrMalformed = serrors.New("malformed frame")
+)
+
+// IsSealed returns whether the frame in b is sealed.
+func IsSealed(b common.RawBytes) bool {
+	return len(b) > 0 && b[0]&SealedFlag != 0
+}
+
+// ParseHdr parses the header of the sealed frame in b.
+func ParseHdr(b common.RawBytes) (mgmt.SessionType, uint8, uint64, error) {
+	if len(b) < Overhead {
+		return 0, 0, 0, ErrMalformed
+	}
+	return mgmt.SessionType(b[0] &^ SealedFlag), b[1], common.Order.UintN(b[2:HdrLen], 6), nil
+}
+
+// Key is a frame key of a session. Seal and Open are not concurrency safe;
+// keys are used by a single egress worker or the ingress dispatcher.
+type Key struct {
+	// ID is the key id carried in the header of sealed frames.
+	ID      uint8
+	created time.Time
+	aead    cipher.AEAD
+	// counter is the counter of the next sealed frame, accessed atomically.
+	counter uint64
+	// top is the highest counter received, window has bit i set if counter
+	// top-i was received.
+	top    uint64
+	window uint64
+}
+
+// NewKey creates the key with id from the secret of length KeyLen.
+func NewKey(id uint8, secret common.RawBytes) (*Key, error) {
+	if len(secret) != KeyLen {
+		return nil, serrors.New("invalid key length", "len", len(secret))
+	}
+	block, err := aes.NewCipher(secret)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Key{ID: id, created: time.Now(), aead: aead}, nil
+}
+
+// Seal appends the sealed frame to dst and returns the updated slice. dst
+// must not overlap with frame.
+func (k *Key) Seal(dst common.RawBytes, sessId mgmt.SessionType,
+	frame common.RawBytes) (common.RawBytes, error) {
+
+	if sessId > MaxSessId {
+		return nil, serrors.New("session id not sealable", "sessId", sessId)
+	}
+	counter := atomic.AddUint64(&k.counter, 1) - 1
+	if counter > MaxCounter {
+		return nil, ErrKeyExhausted
+	}
+	var nonce [12]byte
+	hdr := nonce[12-HdrLen:]
+	hdr[0] = uint8(sessId) | SealedFlag
+	hdr[1] = k.ID
+	common.Order.PutUintN(hdr[2:], counter, 6)
+	dst = append(dst, hdr...)
+	return k.aead.Seal(dst, nonce[:], frame, hdr), nil
+}
+
+// Open authenticates and decrypts the sealed frame in b in place, and returns
+// the plain frame. The returned slice shares the storage of b, starting
+// HdrLen bytes into it.
+func (k *Key) Open(b common.RawBytes) (common.RawBytes, error) {
+	_, _, counter, err := ParseHdr(b)
+	if err != nil {
+		return nil, err
+	}
+	if !k.fresh(counter) {
+		return nil, ErrReplay
+	}
+	var nonce [12]byte
+	copy(nonce[12-HdrLen:], b[:HdrLen])
+	plain, err := k.aead.Open(b[HdrLen:HdrLen], nonce[:], b[HdrLen:], b[:HdrLen])
+	if err != nil {
+		return nil, ErrAuth
+	}
+	k.markReceived(counter)
+	return plain, nil
+}
+
+// Created returns the time the key was created at.
+func (k *Key) Created() time.Time {
+	return k.created
+}
+
+// Sealed returns the number of frames sealed with the key.
+func (k *Key) Sealed() uint64 {
+	return atomic.LoadUint64(&k.counter)
+}
+
+// fresh returns whether counter was not received before, and is within the
+// replay window.
+func (k *Key) fresh(counter uint64) bool {
+	if counter > k.top || k.window == 0 {
+		return true
+	}
+	diff := k.top - counter
+	if diff >= replayWindow {
+		return false
+	}
+	return k.window&(1<<diff) == 0
+}
+
+func (k *Key) markReceived(counter uint64) {
+	switch {
+	case k.window == 0:
+		k.top, k.window = counter, 1
+	case counter > k.top:
+		shift := counter - k.top
+		if shift >= replayWindow {
+			k.window = 0
+		} else {
+			k.window <<= shift
+		}
+		k.top = counter
+		k.window |= 1
+	default:
+		k.window |= 1 << (k.top - counter)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encap
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestSealOpen(t *testing.T) {
+	egress, ingress := mustKeyPair(t, 7)
+	frame := common.RawBytes("\x03\x00\x01\x00\x00\x01\x00\x01payload")
+	sealed, err := egress.Seal(nil, 3, frame)
+	require.NoError(t, err)
+	assert.Len(t, sealed, len(frame)+Overhead)
+	assert.True(t, IsSealed(sealed))
+	sessId, keyId, counter, err := ParseHdr(sealed)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, sessId)
+	assert.EqualValues(t, 7, keyId)
+	assert.EqualValues(t, 0, counter)
+	assert.NotContains(t, string(sealed), "payload")
+
+	plain, err := ingress.Open(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, frame, plain)
+	assert.False(t, IsSealed(plain))
+
+	_, err = egress.Seal(nil, MaxSessId+1, frame)
+	assert.Error(t, err, "session id not sealable")
+	_, _, _, err = ParseHdr(sealed[:Overhead-1])
+	assert.True(t, errors.Is(err, ErrMalformed))
+}
+
+func TestOpenTampered(t *testing.T) {
+	for name, offset := range map[string]int{"header": 1, "counter": 7, "payload": HdrLen + 2,
+		"tag": -1} {
+		t.Run(name, func(t *testing.T) {
+			egress, ingress := mustKeyPair(t, 1)
+			sealed, err := egress.Seal(nil, 0, common.RawBytes("some frame"))
+			require.NoError(t, err)
+			if offset < 0 {
+				offset += len(sealed)
+			}
+			sealed[offset] ^= 0x01
+			_, err = ingress.Open(sealed)
+			assert.True(t, errors.Is(err, ErrAuth), "%v", err)
+		})
+	}
+}
+
+func TestOpenReplay(t *testing.T) {
+	egress, ingress := mustKeyPair(t, 1)
+	var frames []common.RawBytes
+	for i := 0; i < 2*replayWindow; i++ {
+		sealed, err := egress.Seal(nil, 0, common.RawBytes("frame"))
+		require.NoError(t, err)
+		frames = append(frames, sealed)
+	}
+	open := func(i int) error {
+		// Open decrypts in place, keep the sealed frame for later attempts.
+		_, err := ingress.Open(append(common.RawBytes(nil), frames[i]...))
+		return err
+	}
+	require.NoError(t, open(10))
+	assert.True(t, errors.Is(open(10), ErrReplay), "duplicate")
+	require.NoError(t, open(5), "reordered within window")
+	assert.True(t, errors.Is(open(5), ErrReplay), "duplicate reordered")
+	require.NoError(t, open(10+replayWindow-1))
+	require.NoError(t, open(11), "lowest counter in window")
+	assert.True(t, errors.Is(open(9), ErrReplay), "below window")
+	require.NoError(t, open(2*replayWindow-1))
+	assert.True(t, errors.Is(open(2*replayWindow-1), ErrReplay), "duplicate")
+
+	// A forged frame with a high counter must not advance the window.
+	forged := append(common.RawBytes(nil), frames[len(frames)-1]...)
+	common.Order.PutUintN(forged[2:HdrLen], MaxCounter, 6)
+	_, err := ingress.Open(forged)
+	assert.True(t, errors.Is(err, ErrAuth))
+	require.NoError(t, open(2*replayWindow-2))
+}
+
+func TestStore(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	host := addr.HostFromIPStr("192.0.2.1")
+	s := NewStore()
+	assert.Nil(t, s.Current(ia, host, 0))
+	k1, _ := mustKeyPair(t, 1)
+	k2, _ := mustKeyPair(t, 2)
+	k3, _ := mustKeyPair(t, 3)
+	s.Install(ia, host, 0, k1)
+	assert.Equal(t, k1, s.Current(ia, host, 0))
+	assert.Nil(t, s.Current(ia, host, 1), "other session")
+	assert.Nil(t, s.Current(ia, addr.HostFromIPStr("192.0.2.2"), 0), "other host")
+	assert.Nil(t, s.Current(ia, nil, 0), "no host")
+	s.Install(ia, host, 0, k2)
+	assert.Equal(t, k2, s.Current(ia, host, 0))
+	assert.Equal(t, k1, s.Get(ia, host, 0, 1), "previous key")
+	s.Install(ia, host, 0, k3)
+	assert.Nil(t, s.Get(ia, host, 0, 1), "replaced key")
+	assert.Equal(t, k2, s.Get(ia, host, 0, 2))
+	assert.Equal(t, k3, s.Get(ia, host, 0, 3))
+	s.Install(ia, addr.HostFromIPStr("192.0.2.2"), 0, k1)
+	assert.Equal(t, k3, s.Current(ia, host, 0), "key of other host")
+	s.Delete(ia)
+	assert.Nil(t, s.Current(ia, host, 0))
+	assert.Nil(t, s.Current(ia, addr.HostFromIPStr("192.0.2.2"), 0))
+}
+
+// mustKeyPair returns two instances of the same key, one for sealing and one
+// for opening.
+func mustKeyPair(t *testing.T, id uint8) (*Key, *Key) {
+	secret := make(common.RawBytes, KeyLen)
+	secret[0] = id
+	egress, err := NewKey(id, secret)
+	require.NoError(t, err)
+	ingress, err := NewKey(id, secret)
+	require.NoError(t, err)
+	return egress, ingress
+}
@@ -0,0 +1,152 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encap
+
+import (
+	"sync"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/sig/mgmt"
+)
+
+var (
+	// Egress holds the keys frames sent to remote ASes are sealed with.
+	Egress = NewStore()
+	// Ingress holds the keys frames received from remote ASes are opened
+	// with.
+	Ingress = NewStore()
+
+	peersMtx sync.RWMutex
+	// peers contains whether the frames exchanged with the remote ASes must
+	// be encrypted.
+	peers = make(map[addr.IAInt]bool)
+)
+
+// SetPeer adds the remote AS, such that it can exchange keys, and sets
+// whether the frames exchanged with it must be encrypted.
+func SetPeer(ia addr.IA, encrypt bool) {
+	peersMtx.Lock()
+	defer peersMtx.Unlock()
+	peers[ia.IAInt()] = encrypt
+}
+
+// DelPeer removes the remote AS and its keys.
+func DelPeer(ia addr.IA) {
+	peersMtx.Lock()
+	delete(peers, ia.IAInt())
+	peersMtx.Unlock()
+	Egress.Delete(ia)
+	Ingress.Delete(ia)
+}
+
+// IsPeer returns whether the remote AS can exchange keys.
+func IsPeer(ia addr.IA) bool {
+	peersMtx.RLock()
+	defer peersMtx.RUnlock()
+	_, ok := peers[ia.IAInt()]
+	return ok
+}
+
+// Encrypted returns whether the frames exchanged with the remote AS must be
+// encrypted.
+func Encrypted(ia addr.IA) bool {
+	peersMtx.RLock()
+	defer peersMtx.RUnlock()
+	return peers[ia.IAInt()]
+}
+
+// Store holds the keys of the sessions with remote SIGs. Sessions are
+// identified by the remote AS, the host of the remote SIG and the session ID,
+// the same way ingress frames are dispatched. For each session, it holds the
+// current key and the key it replaced, such that frames sealed before a
+// rekeying can still be opened.
+type Store struct {
+	mtx  sync.RWMutex
+	keys map[storeKey]*keyPair
+}
+
+type storeKey struct {
+	ia     addr.IAInt
+	host   string
+	sessId mgmt.SessionType
+}
+
+func newStoreKey(ia addr.IA, host addr.HostAddr, sessId mgmt.SessionType) storeKey {
+	sk := storeKey{ia: ia.IAInt(), sessId: sessId}
+	if host != nil {
+		sk.host = host.String()
+	}
+	return sk
+}
+
+type keyPair struct {
+	curr, prev *Key
+}
+
+func NewStore() *Store {
+	return &Store{keys: make(map[storeKey]*keyPair)}
+}
+
+// Install makes key the current key of the session with the remote SIG.
+func (s *Store) Install(ia addr.IA, host addr.HostAddr, sessId mgmt.SessionType, key *Key) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	sk := newStoreKey(ia, host, sessId)
+	kp, ok := s.keys[sk]
+	if !ok {
+		s.keys[sk] = &keyPair{curr: key}
+		return
+	}
+	kp.prev, kp.curr = kp.curr, key
+}
+
+// Current returns the current key of the session with the remote SIG, or nil
+// if there is none.
+func (s *Store) Current(ia addr.IA, host addr.HostAddr, sessId mgmt.SessionType) *Key {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	if kp, ok := s.keys[newStoreKey(ia, host, sessId)]; ok {
+		return kp.curr
+	}
+	return nil
+}
+
+// Get returns the key with id of the session with the remote SIG, or nil if
+// there is none.
+func (s *Store) Get(ia addr.IA, host addr.HostAddr, sessId mgmt.SessionType, id uint8) *Key {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	kp, ok := s.keys[newStoreKey(ia, host, sessId)]
+	switch {
+	case !ok:
+		return nil
+	case kp.curr.ID == id:
+		return kp.curr
+	case kp.prev != nil && kp.prev.ID == id:
+		return kp.prev
+	}
+	return nil
+}
+
+// Delete removes the keys of all sessions with the SIGs of the remote AS.
+func (s *Store) Delete(ia addr.IA) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for sk := range s.keys {
+		if sk.ia == ia.IAInt() {
+			delete(s.keys, sk)
+		}
+	}
+}
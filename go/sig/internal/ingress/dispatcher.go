@@ -16,6 +16,7 @@
 package ingress
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"time"
@@ -26,6 +27,7 @@ import (
 	"github.com/scionproto/scion/go/lib/ringbuf"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/sig/internal/encap"
 	"github.com/scionproto/scion/go/sig/internal/metrics"
 	"github.com/scionproto/scion/go/sig/mgmt"
 	"github.com/scionproto/scion/go/sig/sigcmn"
@@ -81,11 +83,12 @@ func (d *Dispatcher) read() error {
 					return common.NewBasicError("Problems speaking to dispatcher", err)
 				}
 				frame.Release()
-			} else {
-				frame.frameLen = read
+			} else if d.open(frame, read, src) {
 				frame.sessId = mgmt.SessionType((frame.raw[0]))
 				d.updateMetrics(src.IA.IAInt(), frame.sessId, read)
 				d.dispatch(frame, src)
+			} else {
+				frame.Release()
 			}
 			// Clear FrameBuf reference
 			frames[i] = nil
@@ -97,6 +100,43 @@ func (d *Dispatcher) read() error {
 	}
 }
 
+// open sets the length of the frame of read bytes. Sealed frames are opened
+// in place. It returns false if the frame must be dropped, because it cannot
+// be opened, or because frames from the source AS must be encrypted and the
+// frame is not.
+func (d *Dispatcher) open(frame *FrameBuf, read int, src *snet.Addr) bool {
+	raw := frame.raw[:read]
+	if !encap.IsSealed(raw) {
+		if encap.Encrypted(src.IA) {
+			metrics.FramesDropped.WithLabelValues(src.IA.String(), "cleartext").Inc()
+			return false
+		}
+		frame.frameLen = read
+		return true
+	}
+	sessId, keyId, _, err := encap.ParseHdr(raw)
+	if err != nil {
+		metrics.FramesDropped.WithLabelValues(src.IA.String(), "malformed").Inc()
+		return false
+	}
+	key := encap.Ingress.Get(src.IA, src.Host.L3, sessId, keyId)
+	if key == nil {
+		metrics.FramesDropped.WithLabelValues(src.IA.String(), "unknown_key").Inc()
+		return false
+	}
+	plain, err := key.Open(raw)
+	switch {
+	case errors.Is(err, encap.ErrReplay):
+		metrics.FramesDropped.WithLabelValues(src.IA.String(), "replay").Inc()
+		return false
+	case err != nil:
+		metrics.FramesDropped.WithLabelValues(src.IA.String(), "auth_failed").Inc()
+		return false
+	}
+	frame.frameLen = copy(frame.raw, plain)
+	return true
+}
+
 // dispatch dispatches a frame to the corresponding worker, spawning one if none
 // exist yet. Dispatching is done based on source ISD-AS -> source host Addr -> Sess Id.
 func (d *Dispatcher) dispatch(frame *FrameBuf, src *snet.Addr) {
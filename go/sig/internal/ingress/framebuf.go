@@ -17,12 +17,14 @@ package ingress
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/ringbuf"
 	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/sig/mgmt"
+	"github.com/scionproto/scion/go/sig/sigcmn"
 )
 
 const (
@@ -30,6 +32,8 @@ const (
 	frameBufCap = 65535
 	// freeFramesCap is the number of preallocated Framebuf objects.
 	freeFramesCap = 1024
+	// pktLenSize is the size of the length preceding each packet in a frame.
+	pktLenSize = 2
 )
 
 var (
@@ -76,6 +80,8 @@ type FrameBuf struct {
 	raw common.RawBytes
 	// The sender object for the frame.
 	snd sender
+	// Time the frame was inserted into a reassembly list.
+	arrival time.Time
 }
 
 func newFrameBuf() *FrameBuf {
@@ -96,6 +102,7 @@ func (fb *FrameBuf) Reset() {
 	fb.completePktsProcessed = false
 	fb.pktLen = 0
 	fb.snd = nil
+	fb.arrival = time.Time{}
 }
 
 // Release reset the FrameBuf and releases it back to the ringbuf (if set).
@@ -107,6 +114,31 @@ func (fb *FrameBuf) Release() {
 	freeFrames.Write(ringbuf.EntryList{fb}, true)
 }
 
+// parseHdr parses the SIG frame header and validates it against the frame
+// length. It returns the epoch of the frame.
+func (fb *FrameBuf) parseHdr() (int, error) {
+	if fb.frameLen < sigcmn.SIGHdrSize || fb.frameLen > len(fb.raw) {
+		return 0, common.NewBasicError("Invalid frame length", nil, "len", fb.frameLen)
+	}
+	epoch := int(common.Order.Uint16(fb.raw[1:3]))
+	index := int(common.Order.Uint16(fb.raw[6:8]))
+	// The first packet must start after the header, and its length must be
+	// within the frame.
+	if index != 0 && index*8+pktLenSize > fb.frameLen {
+		return 0, common.NewBasicError("Packet index out of range", nil,
+			"index", index, "len", fb.frameLen)
+	}
+	fb.seqNr = int(common.Order.UintN(fb.raw[3:6], 3))
+	fb.index = index
+	// If index == 1 then we can be sure that there is no fragment at the beginning
+	// of the frame.
+	fb.fragNProcessed = index == 1
+	// If index == 0 then we can be sure that there are no complete packets in this
+	// frame.
+	fb.completePktsProcessed = index == 0
+	return epoch, nil
+}
+
 // ProcessCompletePkts write all complete packets in the frame to the wire and
 // sets the correct metadata in case there is a fragment at the end of the frame.
 func (fb *FrameBuf) ProcessCompletePkts() {
@@ -115,17 +147,25 @@ func (fb *FrameBuf) ProcessCompletePkts() {
 		return
 	}
 	offset := fb.index * 8
-	var pktLen int
 	for offset < fb.frameLen {
-		pktLen = int(common.Order.Uint16(fb.raw[offset : offset+2]))
-		offset += 2
+		if offset+pktLenSize > fb.frameLen {
+			log.Error("Truncated packet length in frame", "frame", fb.String())
+			break
+		}
+		pktLen := int(common.Order.Uint16(fb.raw[offset : offset+pktLenSize]))
+		offset += pktLenSize
+		if pktLen == 0 {
+			log.Error("Empty packet in frame", "frame", fb.String())
+			break
+		}
 		rawPkt := fb.raw[offset:fb.frameLen]
 		if len(rawPkt) < pktLen {
+			// There is an incomplete packet at the end of the frame.
+			fb.frag0Start = offset
+			fb.pktLen = pktLen
 			break
 		}
 		// We got everything for the packet. Write it out to the wire.
-		//log.Debug("ProcessCompletePkts: directly write pkt", "seqNr", fb.seqNr,
-		//	"offset", offset, "len", pktLen)
 		if err := fb.snd.send(rawPkt[:pktLen]); err != nil {
 			log.Error("Unable to send packet", "err", err)
 		}
@@ -133,11 +173,6 @@ func (fb *FrameBuf) ProcessCompletePkts() {
 		// Packet always starts at 8-byte boundary.
 		offset += util.CalcPadding(offset, 8)
 	}
-	if offset < fb.frameLen {
-		// There is an incomplete packet at the end of the frame.
-		fb.frag0Start = offset
-		fb.pktLen = pktLen
-	}
 	fb.completePktsProcessed = true
 	fb.frag0Processed = fb.frag0Start == 0
 }
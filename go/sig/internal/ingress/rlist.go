@@ -18,6 +18,8 @@ import (
 	"bytes"
 	"container/list"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/log"
@@ -25,29 +27,59 @@ import (
 	"github.com/scionproto/scion/go/sig/sigcmn"
 )
 
+const (
+	// reorderWindow is the number of sequence numbers a frame may arrive ahead
+	// of a missing frame. Frames further ahead give up the missing frames.
+	reorderWindow = 16
+	// reassemblyTimeout is how long frames wait for missing frames before the
+	// missing frames are given up.
+	reassemblyTimeout = time.Second
+)
+
 // ReassemblyList is used to keep a doubly linked list of SIG frames that are
 // outstanding for reassembly. The frames kept in the reassambly list sorted by
 // their sequence numbers. There is always one reassembly list per epoch to
 // ensure that sequence numbers are monotonically increasing.
+//
+// Frames are reassembled in the order of their sequence numbers. Frames that
+// arrive ahead of a missing frame wait in the reorder buffer, until the
+// missing frame arrives, the window of reorderWindow sequence numbers is
+// exceeded, or reassemblyTimeout expires. The packets completely contained in
+// such frames are sent immediately.
 type ReassemblyList struct {
 	epoch             int
 	capacity          int
 	snd               sender
 	markedForDeletion bool
-	entries           *list.List
-	buf               *bytes.Buffer
+	// entries contains the frames of the packet being reassembled, without
+	// gaps in their sequence numbers.
+	entries *list.List
+	// pending contains the frames that arrived ahead of a missing frame,
+	// sorted by their sequence numbers.
+	pending []*FrameBuf
+	// next is the sequence number of the next frame to reassemble, -1 before
+	// the first frame.
+	next int
+	// lastInsert is the time the last frame was inserted at.
+	lastInsert time.Time
+	buf        *bytes.Buffer
+	// ia is the remote IA used to label the drop metrics.
+	ia string
 }
 
 // NewReassemblyList returns a ReassemblyList object for the given epoch and with
-// given maximum capacity.
-func NewReassemblyList(epoch int, capacity int, s sender) *ReassemblyList {
+// given maximum capacity. Dropped frames are accounted to the remote IA ia.
+func NewReassemblyList(epoch int, capacity int, s sender, ia string) *ReassemblyList {
 	list := &ReassemblyList{
 		epoch:             epoch,
 		capacity:          capacity,
 		snd:               s,
 		markedForDeletion: false,
 		entries:           list.New(),
+		pending:           make([]*FrameBuf, 0, reorderWindow),
+		next:              -1,
 		buf:               bytes.NewBuffer(make(common.RawBytes, 0, frameBufCap)),
+		ia:                ia,
 	}
 	return list
 }
@@ -57,45 +89,46 @@ func NewReassemblyList(epoch int, capacity int, s sender) *ReassemblyList {
 // that involve the newly added frame. Completely processed frames get removed from the
 // list and released to the pool of frame buffers.
 func (l *ReassemblyList) Insert(frame *FrameBuf) {
-	// If this is the first frame, write all complete packets to the wire and
-	// add the frame to the reassembly list if it contains a fragment at the end.
-	if l.entries.Len() == 0 {
-		l.insertFirst(frame)
-		return
+	now := time.Now()
+	frame.arrival = now
+	l.lastInsert = now
+	l.expire(now)
+	if l.next < 0 {
+		l.next = frame.seqNr
 	}
-	first := l.entries.Front()
-	firstFrame := first.Value.(*FrameBuf)
-	// Check whether frame is too old.
-	if frame.seqNr < firstFrame.seqNr {
+	switch {
+	case frame.seqNr < l.next:
+		// The frame is a duplicate, or arrived after it was given up.
 		metrics.FramesTooOld.Inc()
-		frame.Release()
+		l.drop(frame, "too_old")
 		return
-	}
-	last := l.entries.Back()
-	lastFrame := last.Value.(*FrameBuf)
-	// Check if the frame is a duplicate.
-	if frame.seqNr >= firstFrame.seqNr && frame.seqNr <= lastFrame.seqNr {
-		log.Error("Received duplicate frame.", "epoch", l.epoch, "seqNr", frame.seqNr,
-			"currentOldest", firstFrame.seqNr, "currentNewest", lastFrame.seqNr)
-		metrics.FramesDuplicated.Inc()
-		frame.Release()
+	case frame.seqNr > l.next:
+		if !l.buffer(frame) {
+			return
+		}
+		for len(l.pending) > 0 && l.pending[len(l.pending)-1].seqNr-l.next >= reorderWindow {
+			l.skip("reorder_window")
+		}
 		return
 	}
-	// If there is a gap between this frame and the last in the reassembly list,
-	// remove all packets from the reassembly list and only add this frame.
-	if frame.seqNr > lastFrame.seqNr+1 {
-		log.Info(fmt.Sprintf("Detected dropped frame(s). Discarding %d frames.", l.entries.Len()),
-			"epoch", l.epoch, "segNr", frame.seqNr, "currentNewest", lastFrame.seqNr)
-		metrics.FrameDiscardEvents.Inc()
-		metrics.FramesDiscarded.Add(float64(frame.seqNr - lastFrame.seqNr - 1))
-		l.removeAll()
+	l.process(frame)
+	l.processPending()
+}
+
+// process adds the frame with the next sequence number to the frames being
+// reassembled.
+func (l *ReassemblyList) process(frame *FrameBuf) {
+	l.next = frame.seqNr + 1
+	// If this is the first frame, write all complete packets to the wire and
+	// add the frame to the reassembly list if it contains a fragment at the end.
+	if l.entries.Len() == 0 {
 		l.insertFirst(frame)
 		return
 	}
 	// Check if we have capacity.
 	if l.entries.Len() == l.capacity {
 		log.Warn("Reassembly list reached maximum capacity", "epoch", l.epoch, "cap", l.capacity)
-		l.removeAll()
+		l.dropEntries("capacity")
 		l.insertFirst(frame)
 		return
 	}
@@ -103,6 +136,77 @@ func (l *ReassemblyList) Insert(frame *FrameBuf) {
 	l.tryReassemble()
 }
 
+// processPending processes the frames of the reorder buffer that follow the
+// processed frames without a gap.
+func (l *ReassemblyList) processPending() {
+	for len(l.pending) > 0 && l.pending[0].seqNr == l.next {
+		frame := l.pending[0]
+		l.popPending()
+		l.process(frame)
+	}
+}
+
+// buffer adds the frame that arrived ahead of a missing frame to the reorder
+// buffer, and sends the packets completely contained in it. It returns false
+// if the frame is a duplicate.
+func (l *ReassemblyList) buffer(frame *FrameBuf) bool {
+	i := sort.Search(len(l.pending), func(i int) bool {
+		return l.pending[i].seqNr >= frame.seqNr
+	})
+	if i < len(l.pending) && l.pending[i].seqNr == frame.seqNr {
+		metrics.FramesDuplicated.Inc()
+		l.drop(frame, "duplicate")
+		return false
+	}
+	frame.ProcessCompletePkts()
+	l.pending = append(l.pending, nil)
+	copy(l.pending[i+1:], l.pending[i:])
+	l.pending[i] = frame
+	return true
+}
+
+func (l *ReassemblyList) popPending() {
+	copy(l.pending, l.pending[1:])
+	l.pending[len(l.pending)-1] = nil
+	l.pending = l.pending[:len(l.pending)-1]
+}
+
+// skip gives up the frames missing before the first frame of the reorder
+// buffer. The packet being reassembled cannot be completed and is discarded.
+func (l *ReassemblyList) skip(reason string) {
+	first := l.pending[0]
+	log.Info(fmt.Sprintf("Detected dropped frame(s). Discarding %d frames.", l.entries.Len()),
+		"epoch", l.epoch, "seqNr", first.seqNr, "next", l.next, "reason", reason)
+	metrics.FrameDiscardEvents.Inc()
+	metrics.FramesDiscarded.Add(float64(first.seqNr - l.next))
+	l.dropEntries(reason)
+	l.next = first.seqNr
+	l.processPending()
+}
+
+// expire gives up missing frames and incomplete packets that have been
+// waiting for at least reassemblyTimeout.
+func (l *ReassemblyList) expire(now time.Time) {
+	for len(l.pending) > 0 && now.Sub(l.oldestPending()) >= reassemblyTimeout {
+		l.skip("timeout")
+	}
+	if l.entries.Len() > 0 &&
+		now.Sub(l.entries.Front().Value.(*FrameBuf).arrival) >= reassemblyTimeout {
+
+		l.dropEntries("timeout")
+	}
+}
+
+func (l *ReassemblyList) oldestPending() time.Time {
+	oldest := l.pending[0].arrival
+	for _, frame := range l.pending[1:] {
+		if frame.arrival.Before(oldest) {
+			oldest = frame.arrival
+		}
+	}
+	return oldest
+}
+
 // insertFirst handles the case when the reassembly list is empty and a frame needs
 // to be inserted.
 func (l *ReassemblyList) insertFirst(frame *FrameBuf) {
@@ -126,7 +230,7 @@ func (l *ReassemblyList) tryReassemble() {
 		log.Error("First frame in reassembly list does not contain a packet start.",
 			"frame", startFrame.String())
 		// Safest to remove all frames in the list.
-		l.removeAll()
+		l.dropEntries("framing_error")
 		return
 	}
 	bytes := startFrame.frameLen - startFrame.frag0Start
@@ -154,7 +258,13 @@ func (l *ReassemblyList) tryReassemble() {
 	if canReassemble {
 		l.collectAndWrite()
 	} else if framingError {
-		l.removeBefore(l.entries.Back())
+		// The packet cannot be completed. The last frame still contains the
+		// start of the following packets.
+		last := l.entries.Back()
+		frame := last.Value.(*FrameBuf)
+		l.entries.Remove(last)
+		l.dropEntries("framing_error")
+		l.insertFirst(frame)
 	}
 }
 
@@ -202,10 +312,6 @@ func (l *ReassemblyList) removeEntry(e *list.Element) {
 	l.entries.Remove(e)
 }
 
-func (l *ReassemblyList) removeOldest() {
-	l.removeEntry(l.entries.Front())
-}
-
 func (l *ReassemblyList) removeProcessed() {
 	var next *list.Element
 	for e := l.entries.Front(); e != nil; e = next {
@@ -217,18 +323,35 @@ func (l *ReassemblyList) removeProcessed() {
 	}
 }
 
-func (l *ReassemblyList) removeAll() {
-	l.removeBefore(nil)
+// drop releases the frame that is not reassembled, and accounts it to the
+// drop reason.
+func (l *ReassemblyList) drop(frame *FrameBuf, reason string) {
+	metrics.FramesDropped.WithLabelValues(l.ia, reason).Inc()
+	frame.Release()
 }
 
-func (l *ReassemblyList) removeBefore(ele *list.Element) {
+// dropEntries releases the frames of the packet being reassembled.
+func (l *ReassemblyList) dropEntries(reason string) {
+	if n := l.entries.Len(); n > 0 {
+		metrics.FramesDropped.WithLabelValues(l.ia, reason).Add(float64(n))
+	}
 	var next *list.Element
-	for e := l.entries.Front(); e != ele; e = next {
+	for e := l.entries.Front(); e != nil; e = next {
 		next = e.Next()
 		l.removeEntry(e)
 	}
 }
 
+// dropAll releases all frames held by the reassembly list.
+func (l *ReassemblyList) dropAll(reason string) {
+	l.dropEntries(reason)
+	for len(l.pending) > 0 {
+		frame := l.pending[0]
+		l.popPending()
+		l.drop(frame, reason)
+	}
+}
+
 func intMin(x, y int) int {
 	if x <= y {
 		return x
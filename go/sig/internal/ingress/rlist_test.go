@@ -0,0 +1,357 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/sig/sigcmn"
+)
+
+// iterations is the number of random streams checked per property.
+const iterations = 200
+
+// recordingTun records copies of the packets written to it.
+type recordingTun struct {
+	MockTun
+}
+
+func (rt *recordingTun) Write(p []byte) (int, error) {
+	rt.packets = append(rt.packets, append([]byte(nil), p...))
+	return len(p), nil
+}
+
+func newTestWorker(t *testing.T) (*Worker, *recordingTun) {
+	remote, err := snet.AddrFromString("1-ff00:0:300,[192.168.1.1]:80")
+	require.NoError(t, err)
+	rt := &recordingTun{}
+	return NewWorker(remote, 1, rt), rt
+}
+
+// encodeFrames encapsulates the packets into frames of at most mtu bytes in
+// the same way as the egress worker.
+func encodeFrames(epoch int, pkts [][]byte, mtu int) [][]byte {
+	var frames [][]byte
+	var seq, idx int
+	b := make([]byte, mtu)
+	offset := sigcmn.SIGHdrSize
+	flush := func() {
+		b[0] = 1
+		common.Order.PutUint16(b[1:3], uint16(epoch))
+		common.Order.PutUintN(b[3:6], uint64(seq), 3)
+		common.Order.PutUint16(b[6:8], uint16(idx))
+		frames = append(frames, append([]byte(nil), b[:offset]...))
+		seq++
+		idx = 0
+		offset = sigcmn.SIGHdrSize
+	}
+	for _, pkt := range pkts {
+		offset += util.CalcPadding(offset, 8)
+		if idx == 0 {
+			idx = offset / 8
+		}
+		common.Order.PutUint16(b[offset:], uint16(len(pkt)))
+		offset += pktLenSize
+		for rest := pkt; ; {
+			copied := copy(b[offset:], rest)
+			offset += copied
+			rest = rest[copied:]
+			if len(b)-offset < 16 {
+				flush()
+			}
+			if len(rest) == 0 {
+				break
+			}
+		}
+	}
+	if offset > sigcmn.SIGHdrSize {
+		flush()
+	}
+	return frames
+}
+
+// randPkts returns unique packets of random length.
+func randPkts(r *rand.Rand, n int) [][]byte {
+	pkts := make([][]byte, n)
+	for i := range pkts {
+		pkts[i] = make([]byte, 4+r.Intn(3000))
+		r.Read(pkts[i])
+		common.Order.PutUint32(pkts[i], uint32(i))
+	}
+	return pkts
+}
+
+// reorder moves each frame, except for the first, by less than half of the
+// reorder window.
+func reorder(r *rand.Rand, frames [][]byte) [][]byte {
+	keys := make([]int, len(frames))
+	for i := 1; i < len(frames); i++ {
+		keys[i] = i + r.Intn(reorderWindow/2)
+	}
+	res := append([][]byte(nil), frames...)
+	sort.Sort(byKey{keys: keys, frames: res})
+	return res
+}
+
+type byKey struct {
+	keys   []int
+	frames [][]byte
+}
+
+func (b byKey) Len() int           { return len(b.keys) }
+func (b byKey) Less(i, j int) bool { return b.keys[i] < b.keys[j] }
+func (b byKey) Swap(i, j int) {
+	b.keys[i], b.keys[j] = b.keys[j], b.keys[i]
+	b.frames[i], b.frames[j] = b.frames[j], b.frames[i]
+}
+
+// duplicate repeats random frames shortly after they were sent.
+func duplicate(r *rand.Rand, frames [][]byte) [][]byte {
+	var res [][]byte
+	for i, f := range frames {
+		res = append(res, f)
+		if r.Intn(10) == 0 {
+			res = append(res, frames[i-r.Intn(intMin(i+1, 4))])
+		}
+	}
+	return res
+}
+
+// lose drops random frames.
+func lose(r *rand.Rand, frames [][]byte) [][]byte {
+	var res [][]byte
+	for _, f := range frames {
+		if r.Intn(10) != 0 {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+func sendFrames(t *testing.T, w *Worker, frames [][]byte) {
+	for _, f := range frames {
+		SendFrame(t, w, f)
+		assert.True(t, heldFrames(w) <= maxRlists*(reassemblyListCap+reorderWindow))
+	}
+}
+
+// heldFrames returns the number of frames held by the reassembly lists of w.
+func heldFrames(w *Worker) int {
+	var n int
+	for _, rlist := range w.rlists {
+		n += rlist.entries.Len() + len(rlist.pending)
+	}
+	return n
+}
+
+// releaseAll gives up all frames held by w.
+func releaseAll(w *Worker) {
+	for epoch, rlist := range w.rlists {
+		delete(w.rlists, epoch)
+		rlist.dropAll("worker_stopped")
+	}
+}
+
+func TestReassemblyProperties(t *testing.T) {
+	tests := map[string]struct {
+		transform func(*rand.Rand, [][]byte) [][]byte
+		// complete is set if all packets must be delivered.
+		complete bool
+	}{
+		"in order": {
+			transform: func(_ *rand.Rand, f [][]byte) [][]byte { return f },
+			complete:  true,
+		},
+		"reordered within window": {
+			transform: reorder,
+			complete:  true,
+		},
+		"reordered with duplicates": {
+			transform: func(r *rand.Rand, f [][]byte) [][]byte {
+				return duplicate(r, reorder(r, f))
+			},
+			complete: true,
+		},
+		"lost, reordered and duplicated": {
+			transform: func(r *rand.Rand, f [][]byte) [][]byte {
+				return duplicate(r, reorder(r, lose(r, f)))
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < iterations; i++ {
+				r := rand.New(rand.NewSource(int64(i)))
+				pkts := randPkts(r, 1+r.Intn(20))
+				frames := encodeFrames(1, pkts, 64+r.Intn(1400))
+				w, rt := newTestWorker(t)
+				sendFrames(t, w, test.transform(r, frames))
+				// Give up all frames still waiting for missing frames.
+				for _, rlist := range w.rlists {
+					rlist.expire(time.Now().Add(reassemblyTimeout))
+				}
+				releaseAll(w)
+
+				msg := fmt.Sprintf("seed %d", i)
+				delivered := make(map[uint32]bool)
+				for _, pkt := range rt.packets {
+					require.True(t, len(pkt) >= 4, msg)
+					id := common.Order.Uint32(pkt)
+					require.True(t, int(id) < len(pkts), msg)
+					assert.Equal(t, pkts[id], pkt, msg)
+					assert.False(t, delivered[id], "duplicate packet %d, %s", id, msg)
+					delivered[id] = true
+				}
+				if test.complete {
+					assert.Len(t, delivered, len(pkts), msg)
+				}
+			}
+		})
+	}
+}
+
+func TestReassemblyGarbage(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	w, _ := newTestWorker(t)
+	for i := 0; i < 100*iterations; i++ {
+		raw := make([]byte, r.Intn(100))
+		r.Read(raw)
+		if len(raw) >= sigcmn.SIGHdrSize {
+			// Keep the epoch and sequence numbers in a small range, such that
+			// the frames are processed by the same reassembly lists.
+			common.Order.PutUint16(raw[1:3], uint16(r.Intn(3)))
+			common.Order.PutUintN(raw[3:6], uint64(i/4+r.Intn(2*reorderWindow)), 3)
+			common.Order.PutUint16(raw[6:8], uint16(r.Intn(len(raw)/8+2)))
+		}
+		sendFrames(t, w, [][]byte{raw})
+	}
+	releaseAll(w)
+}
+
+func TestParseHdr(t *testing.T) {
+	tests := map[string]struct {
+		raw   []byte
+		len   int
+		valid bool
+	}{
+		"short": {
+			raw: []byte{1, 0, 1, 0, 0, 1, 0},
+		},
+		"header only": {
+			raw:   []byte{1, 0, 1, 0, 0, 1, 0, 0},
+			valid: true,
+		},
+		"length beyond buffer": {
+			raw: []byte{1, 0, 1, 0, 0, 1, 0, 0},
+			len: frameBufCap + 1,
+		},
+		"index beyond frame": {
+			raw: []byte{1, 0, 1, 0, 0, 1, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0},
+		},
+		"index without packet length": {
+			raw: []byte{1, 0, 1, 0, 0, 1, 0, 1, 0},
+		},
+		"index at packet length": {
+			raw:   []byte{1, 0, 1, 0, 0, 1, 0, 1, 0, 1},
+			valid: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fb := newFrameBuf()
+			fb.frameLen = copy(fb.raw, test.raw)
+			if test.len != 0 {
+				fb.frameLen = test.len
+			}
+			epoch, err := fb.parseHdr()
+			if !test.valid {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 1, epoch)
+			assert.Equal(t, 1, fb.seqNr)
+		})
+	}
+}
+
+func TestProcessCompletePktsMalformed(t *testing.T) {
+	w, rt := newTestWorker(t)
+	// A packet followed by an empty packet and a truncated packet length.
+	SendFrame(t, w, []byte{1, 0, 1, 0, 0, 1, 0, 1,
+		0, 3, 101, 102, 103, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0})
+	SendFrame(t, w, []byte{1, 0, 1, 0, 0, 2, 0, 1,
+		0, 3, 101, 102, 103, 0, 0, 0,
+		0})
+	assert.Equal(t, [][]byte{{101, 102, 103}, {101, 102, 103}}, rt.packets)
+	assert.Equal(t, 0, heldFrames(w))
+}
+
+func TestReassemblyListBounds(t *testing.T) {
+	t.Run("epochs", func(t *testing.T) {
+		w, _ := newTestWorker(t)
+		for epoch := 0; epoch < 10; epoch++ {
+			// A frame with an incomplete packet, that is held for reassembly.
+			SendFrame(t, w, []byte{1, 0, byte(epoch), 0, 0, 1, 0, 1,
+				0, 16, 51, 52, 53, 54, 55, 56})
+			assert.True(t, len(w.rlists) <= maxRlists)
+		}
+		assert.Equal(t, maxRlists, heldFrames(w))
+		releaseAll(w)
+	})
+	t.Run("reorder window", func(t *testing.T) {
+		w, rt := newTestWorker(t)
+		SendFrame(t, w, []byte{1, 0, 1, 0, 0, 1, 0, 1,
+			0, 16, 51, 52, 53, 54, 55, 56})
+		// Frames ahead of the missing frame 2, the packet of frame 1 cannot be
+		// completed anymore.
+		for seq := 3; seq < 3+reorderWindow; seq++ {
+			SendFrame(t, w, []byte{1, 0, 1, 0, 0, byte(seq), 0, 0,
+				0, 0, 0, 0, 0, 0, 0, 0})
+		}
+		rlist := w.rlists[1]
+		assert.Equal(t, 0, rlist.entries.Len())
+		assert.True(t, len(rlist.pending) < reorderWindow)
+		assert.Empty(t, rt.packets)
+		releaseAll(w)
+	})
+	t.Run("timeout", func(t *testing.T) {
+		w, rt := newTestWorker(t)
+		SendFrame(t, w, []byte{1, 0, 1, 0, 0, 1, 0, 1,
+			0, 16, 51, 52, 53, 54, 55, 56})
+		SendFrame(t, w, []byte{1, 0, 1, 0, 0, 3, 0, 1,
+			0, 3, 101, 102, 103, 0, 0, 0,
+			0, 9, 61, 62, 63, 64, 65, 66})
+		rlist := w.rlists[1]
+		assert.Equal(t, 1, rlist.entries.Len())
+		assert.Len(t, rlist.pending, 1)
+		rlist.expire(time.Now().Add(reassemblyTimeout))
+		// Frame 2 is given up, and both incomplete packets time out.
+		assert.Equal(t, 4, rlist.next)
+		assert.Equal(t, 0, heldFrames(w))
+		assert.Equal(t, [][]byte{{101, 102, 103}}, rt.packets)
+	})
+}
@@ -33,6 +33,10 @@ const (
 	reassemblyListCap = 100
 	// rlistCleanUpInterval is the interval between clean up of outdated reassembly lists.
 	rlistCleanUpInterval = 1 * time.Second
+	// maxRlists is the maximum number of reassembly lists, i.e., concurrent
+	// epochs, per worker. Together with reassemblyListCap and reorderWindow, it
+	// bounds the frames held per remote SIG and session.
+	maxRlists = 2
 )
 
 type sender interface {
@@ -49,6 +53,7 @@ type Worker struct {
 	markedForCleanup bool
 	sentCtrs         metrics.CtrPair
 	tunIO            io.ReadWriteCloser
+	iaString         string
 }
 
 func NewWorker(remote *snet.Addr, sessId mgmt.SessionType, tunIO io.ReadWriteCloser) *Worker {
@@ -64,7 +69,8 @@ func NewWorker(remote *snet.Addr, sessId mgmt.SessionType, tunIO io.ReadWriteClo
 			Bytes: metrics.PktBytesSent.WithLabelValues(remote.IA.String(),
 				sessId.String()),
 		},
-		tunIO: tunIO,
+		tunIO:    tunIO,
+		iaString: remote.IA.String(),
 	}
 	return worker
 }
@@ -95,6 +101,10 @@ func (w *Worker) Run() {
 			lastCleanup = time.Now()
 		}
 	}
+	for epoch, rlist := range w.rlists {
+		delete(w.rlists, epoch)
+		rlist.dropAll("worker_stopped")
+	}
 	w.Info("IngressWorker stopping")
 }
 
@@ -102,20 +112,14 @@ func (w *Worker) Run() {
 // packets to the wire and then adding the frame to the corresponding reassembly
 // list if needed.
 func (w *Worker) processFrame(frame *FrameBuf) {
-	epoch := int(common.Order.Uint16(frame.raw[1:3]))
-	seqNr := int(common.Order.UintN(frame.raw[3:6], 3))
-	index := int(common.Order.Uint16(frame.raw[6:8]))
-	frame.seqNr = seqNr
-	frame.index = index
+	epoch, err := frame.parseHdr()
+	if err != nil {
+		w.Debug("Dropping malformed frame", "err", err)
+		metrics.FramesDropped.WithLabelValues(w.iaString, "malformed").Inc()
+		frame.Release()
+		return
+	}
 	frame.snd = w
-	//w.Debug("Received Frame", "seqNr", seqNr, "index", index, "epoch", epoch,
-	//	"len", frame.frameLen)
-	// If index == 1 then we can be sure that there is no fragment at the beginning
-	// of the frame.
-	frame.fragNProcessed = index == 1
-	// If index == 0 then we can be sure that there are no complete packets in this
-	// frame.
-	frame.completePktsProcessed = index == 0
 	// Add to frame buf reassembly list.
 	rlist := w.getRlist(epoch)
 	rlist.Insert(frame)
@@ -124,14 +128,31 @@ func (w *Worker) processFrame(frame *FrameBuf) {
 func (w *Worker) getRlist(epoch int) *ReassemblyList {
 	rlist, ok := w.rlists[epoch]
 	if !ok {
-		rlist = NewReassemblyList(epoch, reassemblyListCap, w)
+		if len(w.rlists) >= maxRlists {
+			w.evictRlist()
+		}
+		rlist = NewReassemblyList(epoch, reassemblyListCap, w, w.iaString)
 		w.rlists[epoch] = rlist
 	}
 	rlist.markedForDeletion = false
 	return rlist
 }
 
+// evictRlist removes the least recently used reassembly list.
+func (w *Worker) evictRlist() {
+	var lru *ReassemblyList
+	for _, rlist := range w.rlists {
+		if lru == nil || rlist.lastInsert.Before(lru.lastInsert) {
+			lru = rlist
+		}
+	}
+	w.Debug("Evicting reassembly list", "epoch", lru.epoch)
+	delete(w.rlists, lru.epoch)
+	lru.dropAll("epoch_evicted")
+}
+
 func (w *Worker) cleanup() {
+	now := time.Now()
 	for epoch := range w.rlists {
 		rlist := w.rlists[epoch]
 		if rlist.markedForDeletion {
@@ -141,9 +162,11 @@ func (w *Worker) cleanup() {
 			delete(w.rlists, epoch)
 			go func() {
 				defer log.LogPanicAndExit()
-				rlist.removeAll()
+				rlist.dropAll("epoch_expired")
 			}()
 		} else {
+			// Give up frames that have been waiting for too long.
+			rlist.expire(now)
 			// Mark the reassembly list for deletion. If it is not accessed between now
 			// and the next cleanup interval, it will be removed.
 			rlist.markedForDeletion = true
@@ -47,6 +47,7 @@ var (
 	FramesDiscarded       prometheus.Counter
 	FramesTooOld          prometheus.Counter
 	FramesDuplicated      prometheus.Counter
+	FramesDropped         *prometheus.CounterVec
 	SessionTimedOut       *prometheus.CounterVec
 	SessionPathSwitched   *prometheus.CounterVec
 	SessionOldPollReplies *prometheus.CounterVec
@@ -57,6 +58,8 @@ var (
 	SessionMTU            *prometheus.GaugeVec
 	SessionHealth         *prometheus.GaugeVec
 	SessionRemoteSwitched *prometheus.CounterVec
+	SessionKeyExchanges   *prometheus.CounterVec
+	SessionSigDiscoveries *prometheus.CounterVec
 
 	EgressRxQueueFull *prometheus.CounterVec
 )
@@ -97,6 +100,9 @@ func init() {
 	FramesDiscarded = newC("frames_discarded_total", "Number of frames discarded.")
 	FramesTooOld = newC("frames_too_old_total", "Number of frames that are too old.")
 	FramesDuplicated = newC("frames_duplicated_total", "Number of duplicate frames.")
+	FramesDropped = newCVec("frames_dropped_total",
+		"Number of frames dropped, by reason.",
+		[]string{"IA", "reason"})
 	SessionTimedOut = newCVec("session_timeout", "Number of pollreq timeouts", iaLabels)
 	SessionPathSwitched = newCVec("session_switch_path", "Number of path switches",
 		append(iaLabels, "reason"))
@@ -113,6 +119,10 @@ func init() {
 		iaLabels)
 	SessionRemoteSwitched = newCVec("session_switch_remote",
 		"Number of times the remote has changed.", iaLabels)
+	SessionKeyExchanges = newCVec("session_key_exchanges",
+		"Number of completed frame key exchanges", iaLabels)
+	SessionSigDiscoveries = newCVec("session_sig_discoveries",
+		"Number of remote SIG discoveries via SVC resolution", append(iaLabels, "result"))
 
 	EgressRxQueueFull = newCVec("egress_recv_queue_full_total",
 		"Egress packets dropped due to full queues.", []string{"IA"})
@@ -18,11 +18,14 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/config"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/truststorage"
+	"github.com/scionproto/scion/go/lib/util"
 )
 
 const (
@@ -30,6 +33,10 @@ const (
 	DefaultEncapPort   = 30056
 	DefaultTunName     = "sig"
 	DefaultTunRTableId = 11
+	DefaultStatusAddr  = "127.0.0.1:30257"
+	// DefaultAnnounceMaxAge is the default maximum age of accepted prefix
+	// announcements.
+	DefaultAnnounceMaxAge = 10 * time.Minute
 )
 
 var _ config.Config = (*Config)(nil)
@@ -39,7 +46,12 @@ type Config struct {
 	Logging  env.Logging
 	Metrics  env.Metrics
 	Sciond   env.SciondClient `toml:"sd_client"`
-	Sig      SigConf
+	// TrustDB is the trust database the certificate chains of remote ASes
+	// are looked up in, missing chains are not fetched. Prefix announcements
+	// and key exchanges of remote SIGs are only accepted if a connection is
+	// set, e.g., to the trust database of the local SCION daemon.
+	TrustDB truststorage.TrustDBConf
+	Sig     SigConf
 }
 
 func (cfg *Config) InitDefaults() {
@@ -48,18 +60,27 @@ func (cfg *Config) InitDefaults() {
 		&cfg.Logging,
 		&cfg.Metrics,
 		&cfg.Sciond,
+		&cfg.TrustDB,
 		&cfg.Sig,
 	)
 }
 
 func (cfg *Config) Validate() error {
-	return config.ValidateAll(
+	err := config.ValidateAll(
 		&cfg.Features,
 		&cfg.Logging,
 		&cfg.Metrics,
 		&cfg.Sciond,
+		&cfg.TrustDB,
 		&cfg.Sig,
 	)
+	if err != nil {
+		return err
+	}
+	if cfg.Sig.SigningKey != "" && cfg.TrustDB.Connection() == "" {
+		return serrors.New("SigningKey requires a TrustDB connection")
+	}
+	return nil
 }
 
 func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
@@ -68,6 +89,7 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 		&cfg.Logging,
 		&cfg.Metrics,
 		&cfg.Sciond,
+		&cfg.TrustDB,
 		&cfg.Sig,
 	)
 }
@@ -102,6 +124,25 @@ type SigConf struct {
 	SrcIP4 net.IP
 	// IPv6 source address hint to put into routing table.
 	SrcIP6 net.IP
+	// SigningKey is the file containing the base64 encoded ed25519 seed of
+	// the AS signing key. Announcements of the local prefixes and key
+	// exchanges are signed with it. If it is not set, no prefixes are
+	// announced and frames are not encrypted. It requires CertsDir and a
+	// TrustDB connection. (default "")
+	SigningKey string
+	// CertsDir is the directory containing the TRC and the certificate chain
+	// of the local AS, which are loaded into the trust database. Remote SIGs
+	// verify the signatures with the chain. (default "")
+	CertsDir string
+	// AnnounceMaxAge is the maximum age of accepted prefix announcements of
+	// remote SIGs. Announcements are signed again every minute, older ones
+	// are rejected, such that replayed announcements are not installed.
+	// (default DefaultAnnounceMaxAge)
+	AnnounceMaxAge util.DurWrap
+	// StatusAddr is the address the status and control API listens on. The
+	// API shows the remote ASes with their sessions and paths, and allows to
+	// switch paths and reload the SIG config. (default DefaultStatusAddr)
+	StatusAddr string
 }
 
 // InitDefaults sets the default values to unset values.
@@ -118,6 +159,12 @@ func (cfg *SigConf) InitDefaults() {
 	if cfg.TunRTableId == 0 {
 		cfg.TunRTableId = DefaultTunRTableId
 	}
+	if cfg.StatusAddr == "" {
+		cfg.StatusAddr = DefaultStatusAddr
+	}
+	if cfg.AnnounceMaxAge.Duration == 0 {
+		cfg.AnnounceMaxAge.Duration = DefaultAnnounceMaxAge
+	}
 }
 
 // Validate validate the config and returns an error if a value is not valid.
@@ -137,6 +184,13 @@ func (cfg *SigConf) Validate() error {
 	if cfg.IP.IsUnspecified() {
 		return serrors.New("IP must be set")
 	}
+	if cfg.SigningKey != "" && cfg.CertsDir == "" {
+		return serrors.New("SigningKey requires CertsDir")
+	}
+	if cfg.AnnounceMaxAge.Duration < 2*time.Minute {
+		return serrors.New("AnnounceMaxAge must be at least 2m",
+			"actual", cfg.AnnounceMaxAge)
+	}
 	return nil
 }
 
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/scionproto/scion/go/lib/env/envtest"
+	"github.com/scionproto/scion/go/lib/truststorage/truststoragetest"
 	"github.com/scionproto/scion/go/lib/xtest"
 )
 
@@ -40,6 +41,7 @@ func TestConfigSample(t *testing.T) {
 
 func InitTestConfig(cfg *Config) {
 	envtest.InitTest(nil, &cfg.Logging, &cfg.Metrics, nil, &cfg.Sciond)
+	truststoragetest.InitTestConfig(&cfg.TrustDB)
 	InitTestSigConf(&cfg.Sig)
 }
 
@@ -49,6 +51,7 @@ func InitTestSigConf(cfg *SigConf) {
 
 func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 	envtest.CheckTest(t, nil, &cfg.Logging, &cfg.Metrics, nil, &cfg.Sciond, id)
+	truststoragetest.CheckTestConfig(t, &cfg.TrustDB, id)
 	CheckTestSigConf(t, &cfg.Sig, id)
 }
 
@@ -62,4 +65,8 @@ func CheckTestSigConf(t *testing.T, cfg *SigConf, id string) {
 	assert.Empty(t, cfg.Dispatcher)
 	assert.Equal(t, DefaultTunName, cfg.Tun)
 	assert.Equal(t, DefaultTunRTableId, cfg.TunRTableId)
+	assert.Empty(t, cfg.SigningKey)
+	assert.Empty(t, cfg.CertsDir)
+	assert.Equal(t, DefaultAnnounceMaxAge, cfg.AnnounceMaxAge.Duration)
+	assert.Equal(t, DefaultStatusAddr, cfg.StatusAddr)
 }
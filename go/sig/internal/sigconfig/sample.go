@@ -43,4 +43,25 @@ Tun = "sig"
 
 # Id of the routing table. (default 11)
 TunRTableId = 11
+
+# File containing the base64 encoded ed25519 seed of the AS signing key that
+# announcements of the local prefixes and key exchanges are signed with. If it
+# is not set, no prefixes are announced and frames are not encrypted. It
+# requires CertsDir and a trustDB connection. (default "")
+SigningKey = ""
+
+# Directory containing the TRC and the certificate chain of the local AS, which
+# are loaded into the trust database. Remote SIGs verify the signatures with the
+# chain. (default "")
+CertsDir = ""
+
+# Maximum age of accepted prefix announcements of remote SIGs. Announcements
+# are signed again every minute, older ones are rejected, such that replayed
+# announcements are not installed. Must be at least 2m. (default 10m)
+AnnounceMaxAge = "10m"
+
+# Address the status and control API listens on. The API shows the remote ASes
+# with their sessions and paths, and allows to switch paths and reload the SIG
+# config. (default "127.0.0.1:30257")
+StatusAddr = "127.0.0.1:30257"
 `
@@ -0,0 +1,252 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sighttp implements the status and control API of the SIG.
+//
+// The API serves JSON and has the following endpoints:
+//
+//	GET  /ases:                  List the remote ASes with their networks,
+//	                             sessions and paths.
+//	GET  /ases/<ia>:             Show the remote AS ia.
+//	POST /ases/<ia>/switchpath:  Switch the session to ia to the path with the
+//	                             hex encoded key in the path query parameter,
+//	                             or to the best other path if it is not set.
+//	POST /reload:                Reload the SIG config.
+package sighttp
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/sig/egress/asmap"
+	"github.com/scionproto/scion/go/sig/egress/session"
+)
+
+// ASMap contains the remote ASes the API operates on.
+type ASMap interface {
+	// Status returns the state of the remote ASes.
+	Status() []asmap.Status
+	// SwitchPath makes the session to the remote AS ia switch to the path
+	// with key, or to the best other path if key is empty.
+	SwitchPath(ia addr.IA, key string) error
+}
+
+// NewHandler creates the handler of the API. Reload is called to reload the
+// SIG config, and returns whether it succeeded.
+func NewHandler(m ASMap, reload func() bool) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/ases", &asesHandler{m: m})
+	mux.Handle("/ases/", &asHandler{m: m})
+	mux.Handle("/reload", &reloadHandler{reload: reload})
+	return mux
+}
+
+type asesHandler struct {
+	m ASMap
+}
+
+func (h *asesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	ases := []asInfo{}
+	for _, s := range h.m.Status() {
+		ases = append(ases, newASInfo(s))
+	}
+	writeJSON(w, ases)
+}
+
+type asHandler struct {
+	m ASMap
+}
+
+func (h *asHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/ases/"), "/")
+	ia, err := addr.IAFromString(parts[0])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid IA: %s", err), http.StatusBadRequest)
+		return
+	}
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		h.get(w, ia)
+	case len(parts) == 2 && parts[1] == "switchpath" && r.Method == http.MethodPost:
+		h.switchPath(w, r, ia)
+	case len(parts) <= 2:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *asHandler) get(w http.ResponseWriter, ia addr.IA) {
+	for _, s := range h.m.Status() {
+		if s.IA.Equal(ia) {
+			writeJSON(w, newASInfo(s))
+			return
+		}
+	}
+	http.Error(w, "unknown AS", http.StatusNotFound)
+}
+
+func (h *asHandler) switchPath(w http.ResponseWriter, r *http.Request, ia addr.IA) {
+	key, err := hex.DecodeString(r.URL.Query().Get("path"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid path key: %s", err), http.StatusBadRequest)
+		return
+	}
+	switch err := h.m.SwitchPath(ia, string(key)); {
+	case errors.Is(err, asmap.ErrUnknownAS), errors.Is(err, session.ErrUnknownPath):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, session.ErrSwitchPending):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	default:
+		log.Info("[sighttp] Path switch requested", "ia", ia, "path", hex.EncodeToString(key))
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+type reloadHandler struct {
+	reload func() bool
+}
+
+func (h *reloadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	log.Info("[sighttp] Config reload requested")
+	if !h.reload() {
+		http.Error(w, "reload failed, see log for details", http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprintln(w, "reloaded")
+}
+
+type asInfo struct {
+	IA        string
+	Healthy   bool
+	Encrypted bool
+	Nets      []netInfo
+	Sessions  []sessionInfo
+}
+
+type netInfo struct {
+	Net       string
+	Announced bool
+}
+
+type sessionInfo struct {
+	ID      uint8
+	Healthy bool
+	// RemoteSIG is the remote SIG the session sends to.
+	RemoteSIG string `json:",omitempty"`
+	// SIGs lists the remote SIGs the session fails over to, discovered ones
+	// first.
+	SIGs  []string
+	Paths []pathInfo
+}
+
+type pathInfo struct {
+	// Key is the hex encoded key of the path.
+	Key  string
+	Path string
+	// Current is set if the session sends over the path.
+	Current   bool
+	Expiry    time.Time
+	MTU       uint16
+	RTT       string     `json:",omitempty"`
+	LastReply *time.Time `json:",omitempty"`
+	Replies   uint64
+	Timeouts  uint64
+	FailCount uint16
+}
+
+func newASInfo(s asmap.Status) asInfo {
+	info := asInfo{
+		IA:        s.IA.String(),
+		Healthy:   s.Healthy,
+		Encrypted: s.Encrypted,
+		Nets:      []netInfo{},
+		Sessions:  []sessionInfo{},
+	}
+	for _, n := range s.Nets {
+		info.Nets = append(info.Nets, netInfo{Net: n.Net.String(), Announced: n.Announced})
+	}
+	for _, sess := range s.Sessions {
+		info.Sessions = append(info.Sessions, newSessionInfo(sess))
+	}
+	return info
+}
+
+func newSessionInfo(s asmap.SessionStatus) sessionInfo {
+	info := sessionInfo{
+		ID:      uint8(s.ID),
+		Healthy: s.Healthy,
+		SIGs:    []string{},
+		Paths:   []pathInfo{},
+	}
+	for _, sig := range s.Sigs {
+		info.SIGs = append(info.SIGs, sig.String())
+	}
+	var current string
+	if s.Remote != nil {
+		if s.Remote.Sig != nil {
+			info.RemoteSIG = s.Remote.Sig.String()
+		}
+		if s.Remote.SessPath != nil {
+			current = s.Remote.SessPath.Key()
+		}
+	}
+	for _, p := range s.Paths {
+		pi := pathInfo{
+			Key:       hex.EncodeToString([]byte(p.Key)),
+			Path:      p.Path,
+			Current:   p.Key == current,
+			Expiry:    p.Expiry,
+			MTU:       p.MTU,
+			Replies:   p.Replies,
+			Timeouts:  p.Timeouts,
+			FailCount: p.FailCount,
+		}
+		if p.Replies > 0 {
+			lastReply := p.LastReply
+			pi.RTT = p.RTT.String()
+			pi.LastReply = &lastReply
+		}
+		info.Paths = append(info.Paths, pi)
+	}
+	return info
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	raw, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		log.Error("[sighttp] Unable to encode response", "err", err)
+		http.Error(w, "unable to encode response", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(raw)
+}
@@ -0,0 +1,183 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sighttp
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/sig/egress/asmap"
+	"github.com/scionproto/scion/go/sig/egress/iface"
+	"github.com/scionproto/scion/go/sig/egress/session"
+	"github.com/scionproto/scion/go/sig/egress/siginfo"
+)
+
+type fakeASMap struct {
+	status   []asmap.Status
+	switched map[addr.IA]string
+}
+
+func (m *fakeASMap) Status() []asmap.Status {
+	return m.status
+}
+
+func (m *fakeASMap) SwitchPath(ia addr.IA, key string) error {
+	for _, s := range m.status {
+		if !s.IA.Equal(ia) {
+			continue
+		}
+		if key != "" && key != "\x01\x02" {
+			return session.ErrUnknownPath
+		}
+		if _, ok := m.switched[ia]; ok {
+			return session.ErrSwitchPending
+		}
+		m.switched[ia] = key
+		return nil
+	}
+	return asmap.ErrUnknownAS
+}
+
+func newFakeASMap(t *testing.T) *fakeASMap {
+	_, n, err := net.ParseCIDR("192.0.2.0/24")
+	require.NoError(t, err)
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	return &fakeASMap{
+		status: []asmap.Status{{
+			IA:      ia,
+			Healthy: true,
+			Nets:    []asmap.NetStatus{{Net: n, Announced: true}},
+			Sessions: []asmap.SessionStatus{{
+				Healthy: true,
+				Remote: &iface.RemoteInfo{
+					Sig: &siginfo.Sig{IA: ia, Host: addr.HostFromIPStr("192.0.2.1"),
+						CtrlL4Port: 30256, EncapL4Port: 30056},
+					SessPath: iface.NewSessPath("\x01\x02", nil),
+				},
+				Sigs: []*siginfo.Sig{
+					{IA: ia, Host: addr.HostFromIPStr("192.0.2.1"),
+						CtrlL4Port: 30256, EncapL4Port: 30056},
+					{IA: ia, Host: addr.HostFromIPStr("192.0.2.2"),
+						CtrlL4Port: 30256, EncapL4Port: 30056},
+				},
+				Paths: []iface.PathStats{
+					{Key: "\x01\x02", Path: "path1", MTU: 1280, RTT: 20 * time.Millisecond,
+						LastReply: time.Now(), Replies: 3},
+					{Key: "\x03", Path: "path2", Timeouts: 2, FailCount: 2},
+				},
+			}},
+		}},
+		switched: make(map[addr.IA]string),
+	}
+}
+
+func TestASes(t *testing.T) {
+	h := NewHandler(newFakeASMap(t), nil)
+	tests := map[string]struct {
+		Method       string
+		Path         string
+		ExpectedCode int
+	}{
+		"list":        {Method: http.MethodGet, Path: "/ases", ExpectedCode: http.StatusOK},
+		"list post":   {Method: http.MethodPost, Path: "/ases", ExpectedCode: 405},
+		"get":         {Method: http.MethodGet, Path: "/ases/1-ff00:0:110", ExpectedCode: 200},
+		"get unknown": {Method: http.MethodGet, Path: "/ases/1-ff00:0:111", ExpectedCode: 404},
+		"get invalid": {Method: http.MethodGet, Path: "/ases/1-invalid", ExpectedCode: 400},
+		"get subpath": {Method: http.MethodGet, Path: "/ases/1-ff00:0:110/a/b", ExpectedCode: 404},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(test.Method, test.Path, nil))
+			assert.Equal(t, test.ExpectedCode, w.Code)
+		})
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ases", nil))
+	var ases []asInfo
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &ases))
+	require.Len(t, ases, 1)
+	as := ases[0]
+	assert.Equal(t, "1-ff00:0:110", as.IA)
+	assert.Equal(t, []netInfo{{Net: "192.0.2.0/24", Announced: true}}, as.Nets)
+	require.Len(t, as.Sessions, 1)
+	sess := as.Sessions[0]
+	assert.Equal(t, "1-ff00:0:110,[192.0.2.1]:30256:30056", sess.RemoteSIG)
+	assert.Equal(t, []string{"1-ff00:0:110,[192.0.2.1]:30256:30056",
+		"1-ff00:0:110,[192.0.2.2]:30256:30056"}, sess.SIGs)
+	require.Len(t, sess.Paths, 2)
+	assert.Equal(t, "0102", sess.Paths[0].Key)
+	assert.True(t, sess.Paths[0].Current)
+	assert.Equal(t, "20ms", sess.Paths[0].RTT)
+	assert.NotNil(t, sess.Paths[0].LastReply)
+	assert.Equal(t, "03", sess.Paths[1].Key)
+	assert.False(t, sess.Paths[1].Current)
+	assert.Empty(t, sess.Paths[1].RTT)
+	assert.Nil(t, sess.Paths[1].LastReply)
+	assert.Equal(t, uint64(2), sess.Paths[1].Timeouts)
+}
+
+func TestSwitchPath(t *testing.T) {
+	m := newFakeASMap(t)
+	h := NewHandler(m, nil)
+	tests := []struct {
+		Name         string
+		Method       string
+		Path         string
+		ExpectedCode int
+	}{
+		{"get", http.MethodGet, "/ases/1-ff00:0:110/switchpath", 405},
+		{"unknown AS", http.MethodPost, "/ases/1-ff00:0:111/switchpath", 404},
+		{"invalid key", http.MethodPost, "/ases/1-ff00:0:110/switchpath?path=x", 400},
+		{"unknown key", http.MethodPost, "/ases/1-ff00:0:110/switchpath?path=03ff", 404},
+		{"key", http.MethodPost, "/ases/1-ff00:0:110/switchpath?path=0102", 202},
+		{"pending", http.MethodPost, "/ases/1-ff00:0:110/switchpath", 409},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(test.Method, test.Path, nil))
+		assert.Equal(t, test.ExpectedCode, w.Code, test.Name)
+	}
+	assert.Equal(t, map[addr.IA]string{xtest.MustParseIA("1-ff00:0:110"): "\x01\x02"},
+		m.switched)
+}
+
+func TestReload(t *testing.T) {
+	var ok bool
+	h := NewHandler(newFakeASMap(t), func() bool { return ok })
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/reload", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/reload", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	ok = true
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/reload", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,110 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package signtest provides signers and a verifier backed by a trust store for
+// the tests of the SIG.
+package signtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust/trustdb"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust/trustdb/trustdbsqlite"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// Trust holds the certificate chains of the ASes signers are created for in
+// an in-memory trust database.
+type Trust struct {
+	db    trustdb.TrustDB
+	store *trust.Store
+}
+
+// New creates a Trust without any certificate chains. The caller must close
+// it.
+func New(t *testing.T) *Trust {
+	t.Helper()
+	db, err := trustdbsqlite.New(":memory:")
+	require.NoError(t, err)
+	return &Trust{
+		db:    db,
+		store: trust.NewStore(db, addr.IA{}, trust.Config{}, log.Root()),
+	}
+}
+
+// Signer creates an AS signing key for ia, inserts a certificate chain with its
+// verification key, and returns a signer that signs with the key. It must be
+// called at most once per AS.
+func (tr *Trust) Signer(t *testing.T, ia addr.IA) infra.Signer {
+	t.Helper()
+	pub, priv, err := scrypto.GenKeyPair(scrypto.Ed25519)
+	require.NoError(t, err)
+	issPub, issPriv, err := scrypto.GenKeyPair(scrypto.Ed25519)
+	require.NoError(t, err)
+	now := time.Now()
+	newCert := func(signKey common.RawBytes, canIssue bool) *cert.Certificate {
+		c := &cert.Certificate{
+			CanIssue:       canIssue,
+			EncAlgorithm:   scrypto.Curve25519xSalsa20Poly1305,
+			ExpirationTime: util.TimeToSecs(now.Add(time.Hour)),
+			Issuer:         ia,
+			IssuingTime:    util.TimeToSecs(now),
+			SignAlgorithm:  scrypto.Ed25519,
+			Subject:        ia,
+			SubjectEncKey:  make(common.RawBytes, scrypto.NaClBoxKeySize),
+			SubjectSignKey: signKey,
+			TRCVersion:     1,
+			Version:        1,
+		}
+		require.NoError(t, c.Sign(issPriv, scrypto.Ed25519))
+		return c
+	}
+	chain := &cert.Chain{
+		Leaf:   newCert(pub, false),
+		Issuer: newCert(issPub, true),
+	}
+	_, err = tr.db.InsertChain(context.Background(), chain)
+	require.NoError(t, err)
+	signer, err := trust.NewBasicSigner(priv, infra.SignerMeta{
+		Src:     ctrl.SignSrcDef{IA: ia, ChainVer: 1, TRCVer: 1},
+		ExpTime: now.Add(time.Hour),
+		Algo:    scrypto.Ed25519,
+	})
+	require.NoError(t, err)
+	return signer
+}
+
+// Verifier returns a verifier that verifies signatures with the certificate
+// chains in the trust database. Chains that are not in the database are not
+// fetched from the network.
+func (tr *Trust) Verifier() infra.Verifier {
+	return tr.store.NewVerifier()
+}
+
+// Close closes the trust database.
+func (tr *Trust) Close() {
+	tr.db.Close()
+}
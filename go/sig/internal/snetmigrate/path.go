@@ -56,6 +56,10 @@ func (p *emptyPath) Expiry() time.Time {
 	return time.Time{}
 }
 
+func (p *emptyPath) Metadata() *snet.PathMetadata {
+	return nil
+}
+
 func (p *emptyPath) Copy() snet.Path {
 	return &emptyPath{
 		source: p.source,
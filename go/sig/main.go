@@ -16,13 +16,17 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"io"
+	"net/http"
 	_ "net/http/pprof"
 	"os"
 	"os/user"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/syndtr/gocapability/capability"
@@ -30,22 +34,35 @@ import (
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/fatal"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust/trustdb"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/prom"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/sig/egress"
+	"github.com/scionproto/scion/go/sig/egress/asmap"
+	"github.com/scionproto/scion/go/sig/internal/announce"
 	"github.com/scionproto/scion/go/sig/internal/base"
 	"github.com/scionproto/scion/go/sig/internal/config"
 	"github.com/scionproto/scion/go/sig/internal/disp"
 	"github.com/scionproto/scion/go/sig/internal/ingress"
 	"github.com/scionproto/scion/go/sig/internal/metrics"
 	"github.com/scionproto/scion/go/sig/internal/sigconfig"
+	"github.com/scionproto/scion/go/sig/internal/sighttp"
 	"github.com/scionproto/scion/go/sig/internal/xnet"
 	"github.com/scionproto/scion/go/sig/sigcmn"
 )
 
+const (
+	// shutdownWaitTimeout is how long the status server is given to finish
+	// open requests on shutdown.
+	shutdownWaitTimeout = 5 * time.Second
+)
+
 var (
 	cfg sigconfig.Config
+	// reloadMtx serializes config reloads, which are triggered by SIGHUP and
+	// the status API.
+	reloadMtx sync.Mutex
 )
 
 func init() {
@@ -80,10 +97,20 @@ func realMain() int {
 		log.Crit("Unable to create & configure TUN device", "err", err)
 		return 1
 	}
-	if err := sigcmn.Init(cfg.Sig, cfg.Sciond); err != nil {
+	var trustDB trustdb.TrustDB
+	if cfg.TrustDB.Connection() != "" {
+		if trustDB, err = cfg.TrustDB.New(); err != nil {
+			log.Crit("Unable to initialize trustDB", "err", err)
+			return 1
+		}
+		defer trustDB.Close()
+	}
+	if err := sigcmn.Init(cfg.Sig, cfg.Sciond, trustDB); err != nil {
 		log.Crit("Error during initialization", "err", err)
 		return 1
 	}
+	announce.Init(cfg.Sig.IA, sigcmn.Signer)
+	asmap.AnnounceMaxAge = cfg.Sig.AnnounceMaxAge.Duration
 	env.SetupEnv(
 		func() {
 			success := loadConfig(cfg.Sig.SIGConfig)
@@ -102,8 +129,40 @@ func realMain() int {
 		defer log.LogPanicAndExit()
 		base.PollReqHdlr()
 	}()
+	// Negotiate the keys of encrypted frames with other SIGs.
+	go func() {
+		defer log.LogPanicAndExit()
+		base.KeyExchangeReqHdlr()
+	}()
+	// Install the prefixes announced by other SIGs.
+	go func() {
+		defer log.LogPanicAndExit()
+		egress.PrefixAnnounceHdlr()
+	}()
 	egress.Init(tunIO)
 	ingress.Init(tunIO)
+	statusSrv := &http.Server{
+		Addr: cfg.Sig.StatusAddr,
+		Handler: sighttp.NewHandler(asmap.Map, func() bool {
+			return loadConfig(cfg.Sig.SIGConfig)
+		}),
+	}
+	defer shutdown(statusSrv)
+	go func() {
+		defer log.LogPanicAndExit()
+		log.Info("Starting status API", "addr", cfg.Sig.StatusAddr)
+		if err := statusSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			fatal.Fatal(common.NewBasicError("Status API ListenAndServe error", err))
+		}
+	}()
+	env.StatusPages{
+		Config:   &cfg,
+		ID:       cfg.Sig.ID,
+		IA:       cfg.Sig.IA,
+		Features: cfg.Features,
+		Health: env.HealthChecks(env.DispatcherHealth(cfg.Sig.Dispatcher),
+			env.SciondHealth(cfg.Sciond.Path)),
+	}.Register()
 	cfg.Metrics.StartPrometheus()
 	select {
 	case <-fatal.ShutdownChan():
@@ -124,6 +183,7 @@ func setupBasic() error {
 		return err
 	}
 	prom.ExportElementID(cfg.Sig.ID)
+	log.SetIA(cfg.Sig.IA.String())
 	return env.LogAppStarted("SIG", cfg.Sig.ID)
 }
 
@@ -190,16 +250,34 @@ func checkPerms() error {
 	return nil
 }
 
+func shutdown(server *http.Server) {
+	ctx, cancelF := context.WithTimeout(context.Background(), shutdownWaitTimeout)
+	defer cancelF()
+	server.Shutdown(ctx)
+}
+
 func loadConfig(path string) bool {
+	reloadMtx.Lock()
+	defer reloadMtx.Unlock()
 	cfg, err := config.LoadFromFile(path)
 	if err != nil {
 		log.Error("loadConfig: Failed", "err", err)
 		return false
 	}
+	for ia, e := range cfg.ASes {
+		if e.Encrypt && sigcmn.Signer == nil {
+			log.Error("loadConfig: Encryption requires a signing key", "ia", ia)
+			return false
+		}
+	}
 	ok := egress.ReloadConfig(cfg)
 	if !ok {
 		return false
 	}
+	if err := announce.Update(cfg); err != nil {
+		log.Error("loadConfig: Unable to update prefix announcement", "err", err)
+		return false
+	}
 	atomic.StoreUint64(&metrics.ConfigVersion, cfg.ConfigVersion)
 	return true
 }
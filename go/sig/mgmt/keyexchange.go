@@ -0,0 +1,134 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgmt
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ proto.Cerealizable = (*KeyShare)(nil)
+
+// KeyShare is the ephemeral key share a SIG contributes to the key of a
+// session.
+type KeyShare struct {
+	RawIA   addr.IAInt `capnp:"ia"`
+	Session SessionType
+	KeyId   uint8
+	// PubKey is the ephemeral X25519 public key of the sender.
+	PubKey common.RawBytes
+	// PeerPubKey is the ephemeral X25519 public key of the initiator. It is
+	// only set in replies, and binds the reply to the request.
+	PeerPubKey common.RawBytes
+	// Addr is the control address of the sender.
+	Addr *Addr
+}
+
+// NewKeyShareFromRaw parses the packed key share in b.
+func NewKeyShareFromRaw(b common.RawBytes) (*KeyShare, error) {
+	k := &KeyShare{}
+	return k, proto.ParseFromRaw(k, b)
+}
+
+func (k *KeyShare) IA() addr.IA {
+	return k.RawIA.IA()
+}
+
+func (k *KeyShare) ProtoId() proto.ProtoIdType {
+	return proto.SIGKeyShare_TypeID
+}
+
+func (k *KeyShare) String() string {
+	return fmt.Sprintf("IA: %s Session: %s KeyId: %d PubKey: %s PeerPubKey: %s Addr: %s",
+		k.IA(), k.Session, k.KeyId, k.PubKey, k.PeerPubKey, k.Addr)
+}
+
+var _ proto.Cerealizable = (*KeyExchange)(nil)
+
+// KeyExchange is a KeyShare signed with the AS signing key of the sender.
+type KeyExchange struct {
+	Blob common.RawBytes
+	Sign *proto.SignS
+}
+
+func newKeyExchange(k *KeyShare, signer Signer) (*KeyExchange, error) {
+	blob, err := proto.PackRoot(k)
+	if err != nil {
+		return nil, err
+	}
+	sign, err := signer.Sign(blob)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to sign key share", err)
+	}
+	return &KeyExchange{Blob: blob, Sign: sign}, nil
+}
+
+// Verify verifies the signature with verifier and returns the key share.
+func (e *KeyExchange) Verify(ctx context.Context, verifier Verifier) (*KeyShare, error) {
+	if err := verifier.Verify(ctx, e.Blob, e.Sign); err != nil {
+		return nil, err
+	}
+	return NewKeyShareFromRaw(e.Blob)
+}
+
+// Time returns the time the key share was signed at.
+func (e *KeyExchange) Time() time.Time {
+	if e.Sign == nil {
+		return time.Time{}
+	}
+	return e.Sign.Time()
+}
+
+func (e *KeyExchange) ProtoId() proto.ProtoIdType {
+	return proto.SIGKeyExchange_TypeID
+}
+
+func (e *KeyExchange) String() string {
+	k, err := NewKeyShareFromRaw(e.Blob)
+	if err != nil {
+		return fmt.Sprintf("Unparsable blob: %s Sign: %s", e.Blob, e.Sign)
+	}
+	return fmt.Sprintf("%s Sign: %s", k, e.Sign)
+}
+
+type KeyExchangeReq struct {
+	*KeyExchange
+}
+
+func NewKeyExchangeReq(k *KeyShare, signer Signer) (*KeyExchangeReq, error) {
+	e, err := newKeyExchange(k, signer)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyExchangeReq{e}, nil
+}
+
+type KeyExchangeRep struct {
+	*KeyExchange
+}
+
+func NewKeyExchangeRep(k *KeyShare, signer Signer) (*KeyExchangeRep, error) {
+	e, err := newKeyExchange(k, signer)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyExchangeRep{e}, nil
+}
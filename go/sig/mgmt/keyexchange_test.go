@@ -0,0 +1,80 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgmt_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+	"github.com/scionproto/scion/go/sig/internal/signtest"
+	"github.com/scionproto/scion/go/sig/mgmt"
+)
+
+func TestKeyExchangeRoundTrip(t *testing.T) {
+	tr := signtest.New(t)
+	defer tr.Close()
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	share := &mgmt.KeyShare{
+		RawIA:      ia.IAInt(),
+		Session:    3,
+		KeyId:      7,
+		PubKey:     make([]byte, 32),
+		PeerPubKey: make([]byte, 32),
+		Addr:       mgmt.NewAddr(addr.HostFromIP(net.IPv4(192, 0, 2, 1)), 30256, 30056),
+	}
+	share.PubKey[0], share.PeerPubKey[0] = 1, 2
+	rep, err := mgmt.NewKeyExchangeRep(share, tr.Signer(t, ia))
+	require.NoError(t, err)
+
+	spld, err := mgmt.NewPld(1, rep)
+	require.NoError(t, err)
+	cpld, err := ctrl.NewPld(spld, nil)
+	require.NoError(t, err)
+	raw, err := proto.PackRoot(cpld)
+	require.NoError(t, err)
+	parsed, err := ctrl.NewPldFromRaw(raw)
+	require.NoError(t, err)
+	u, err := parsed.Union()
+	require.NoError(t, err)
+	u, err = u.(*mgmt.Pld).Union()
+	require.NoError(t, err)
+	received, ok := u.(*mgmt.KeyExchangeRep)
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now(), received.Time(), 2*time.Second)
+
+	k, err := received.Verify(context.Background(), tr.Verifier())
+	require.NoError(t, err)
+	assert.Equal(t, share.IA(), k.IA())
+	assert.Equal(t, share.Session, k.Session)
+	assert.Equal(t, share.KeyId, k.KeyId)
+	assert.Equal(t, share.PubKey, k.PubKey)
+	assert.Equal(t, share.PeerPubKey, k.PeerPubKey)
+	require.NotNil(t, k.Addr)
+	assert.Equal(t, share.Addr.EncapPort, k.Addr.EncapPort)
+	assert.Equal(t, share.Addr.Ctrl.Port, k.Addr.Ctrl.Port)
+
+	received.Blob[len(received.Blob)-1] ^= 0xff
+	_, err = received.Verify(context.Background(), tr.Verifier())
+	assert.Error(t, err, "tampered blob")
+}
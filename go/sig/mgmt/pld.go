@@ -35,9 +35,12 @@ func (m MsgIdType) Time() time.Time {
 
 // union represents the contents of the unnamed capnp union.
 type union struct {
-	Which   proto.SIGCtrl_Which
-	PollReq *PollReq
-	PollRep *PollRep
+	Which          proto.SIGCtrl_Which
+	PollReq        *PollReq
+	PollRep        *PollRep
+	PrefixAnnounce *PrefixAnnounce
+	KeyExchangeReq *KeyExchangeReq
+	KeyExchangeRep *KeyExchangeRep
 }
 
 func (u *union) set(c proto.Cerealizable) error {
@@ -48,6 +51,15 @@ func (u *union) set(c proto.Cerealizable) error {
 	case *PollRep:
 		u.Which = proto.SIGCtrl_Which_pollRep
 		u.PollRep = p
+	case *PrefixAnnounce:
+		u.Which = proto.SIGCtrl_Which_prefixAnnounce
+		u.PrefixAnnounce = p
+	case *KeyExchangeReq:
+		u.Which = proto.SIGCtrl_Which_keyExchangeReq
+		u.KeyExchangeReq = p
+	case *KeyExchangeRep:
+		u.Which = proto.SIGCtrl_Which_keyExchangeRep
+		u.KeyExchangeRep = p
 	default:
 		return common.NewBasicError("Unsupported SIG ctrl union type (set)", nil,
 			"type", common.TypeOf(c))
@@ -61,6 +73,12 @@ func (u *union) get() (proto.Cerealizable, error) {
 		return u.PollReq, nil
 	case proto.SIGCtrl_Which_pollRep:
 		return u.PollRep, nil
+	case proto.SIGCtrl_Which_prefixAnnounce:
+		return u.PrefixAnnounce, nil
+	case proto.SIGCtrl_Which_keyExchangeReq:
+		return u.KeyExchangeReq, nil
+	case proto.SIGCtrl_Which_keyExchangeRep:
+		return u.KeyExchangeRep, nil
 	}
 	return nil, common.NewBasicError("Unsupported SIG ctrl union type (get)", nil,
 		"type", u.Which)
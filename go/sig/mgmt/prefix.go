@@ -0,0 +1,173 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgmt
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ proto.Cerealizable = (*Prefixes)(nil)
+
+// Prefixes is the set of IP prefixes served by the SIGs of an AS.
+type Prefixes struct {
+	RawIA addr.IAInt `capnp:"ia"`
+	// Version orders the announcements of an AS. Higher versions supersede
+	// lower ones.
+	Version  uint64
+	Prefixes []*Prefix
+}
+
+// NewPrefixes creates the announced prefixes of ia from nets.
+func NewPrefixes(ia addr.IA, version uint64, nets []*net.IPNet) *Prefixes {
+	p := &Prefixes{RawIA: ia.IAInt(), Version: version}
+	for _, n := range nets {
+		p.Prefixes = append(p.Prefixes, NewPrefix(n))
+	}
+	return p
+}
+
+// NewPrefixesFromRaw parses the packed prefixes in b.
+func NewPrefixesFromRaw(b common.RawBytes) (*Prefixes, error) {
+	p := &Prefixes{}
+	return p, proto.ParseFromRaw(p, b)
+}
+
+func (p *Prefixes) IA() addr.IA {
+	return p.RawIA.IA()
+}
+
+// Nets returns the announced prefixes as networks. It returns an error if any
+// of the prefixes is invalid.
+func (p *Prefixes) Nets() ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(p.Prefixes))
+	for _, pfx := range p.Prefixes {
+		n, err := pfx.IPNet()
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+func (p *Prefixes) ProtoId() proto.ProtoIdType {
+	return proto.SIGPrefixes_TypeID
+}
+
+func (p *Prefixes) String() string {
+	pfxs := make([]string, 0, len(p.Prefixes))
+	for _, pfx := range p.Prefixes {
+		pfxs = append(pfxs, pfx.String())
+	}
+	return fmt.Sprintf("IA: %s Version: %d Prefixes: [%s]", p.IA(), p.Version,
+		strings.Join(pfxs, " "))
+}
+
+var _ proto.Cerealizable = (*Prefix)(nil)
+
+// Prefix is an IP prefix in canonical form.
+type Prefix struct {
+	IP  common.RawBytes `capnp:"ip"`
+	Len uint8
+}
+
+func NewPrefix(n *net.IPNet) *Prefix {
+	ones, bits := n.Mask.Size()
+	ip := n.IP.To16()
+	if bits == 8*net.IPv4len {
+		ip = n.IP.To4()
+	}
+	return &Prefix{IP: common.RawBytes(ip.Mask(n.Mask)), Len: uint8(ones)}
+}
+
+// IPNet returns the prefix as network. It returns an error if the prefix is
+// not a valid IPv4 or IPv6 prefix, or if host bits are set.
+func (p *Prefix) IPNet() (*net.IPNet, error) {
+	if len(p.IP) != net.IPv4len && len(p.IP) != net.IPv6len {
+		return nil, serrors.New("invalid prefix address length", "len", len(p.IP))
+	}
+	if int(p.Len) > 8*len(p.IP) {
+		return nil, serrors.New("invalid prefix length", "len", p.Len)
+	}
+	n := &net.IPNet{
+		IP:   append(net.IP(nil), p.IP...),
+		Mask: net.CIDRMask(int(p.Len), 8*len(p.IP)),
+	}
+	if !n.IP.Equal(n.IP.Mask(n.Mask)) {
+		return nil, serrors.New("prefix is not canonical", "prefix", n)
+	}
+	return n, nil
+}
+
+func (p *Prefix) ProtoId() proto.ProtoIdType {
+	return proto.SIGPrefix_TypeID
+}
+
+func (p *Prefix) String() string {
+	return fmt.Sprintf("%s/%d", net.IP(p.IP), p.Len)
+}
+
+var _ proto.Cerealizable = (*PrefixAnnounce)(nil)
+
+// PrefixAnnounce is a Prefixes announcement signed with the AS signing key of
+// the announcing AS.
+type PrefixAnnounce struct {
+	Blob common.RawBytes
+	Sign *proto.SignS
+}
+
+// NewPrefixAnnounce packs p and signs it with signer.
+func NewPrefixAnnounce(p *Prefixes, signer Signer) (*PrefixAnnounce, error) {
+	blob, err := proto.PackRoot(p)
+	if err != nil {
+		return nil, err
+	}
+	sign, err := signer.Sign(blob)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to sign prefixes", err)
+	}
+	return &PrefixAnnounce{Blob: blob, Sign: sign}, nil
+}
+
+// Verify verifies the signature with verifier and returns the announced
+// prefixes.
+func (a *PrefixAnnounce) Verify(ctx context.Context,
+	verifier Verifier) (*Prefixes, error) {
+
+	if err := verifier.Verify(ctx, a.Blob, a.Sign); err != nil {
+		return nil, err
+	}
+	return NewPrefixesFromRaw(a.Blob)
+}
+
+func (a *PrefixAnnounce) ProtoId() proto.ProtoIdType {
+	return proto.SIGPrefixAnnounce_TypeID
+}
+
+func (a *PrefixAnnounce) String() string {
+	p, err := NewPrefixesFromRaw(a.Blob)
+	if err != nil {
+		return fmt.Sprintf("Unparsable blob: %s Sign: %s", a.Blob, a.Sign)
+	}
+	return fmt.Sprintf("%s Sign: %s", p, a.Sign)
+}
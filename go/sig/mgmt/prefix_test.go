@@ -0,0 +1,100 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgmt_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/ctrl"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+	"github.com/scionproto/scion/go/sig/internal/signtest"
+	"github.com/scionproto/scion/go/sig/mgmt"
+)
+
+func TestPrefixAnnounceRoundTrip(t *testing.T) {
+	tr := signtest.New(t)
+	defer tr.Close()
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	_, n4, _ := net.ParseCIDR("192.0.2.0/24")
+	_, n6, _ := net.ParseCIDR("2001:db8::/48")
+	a, err := mgmt.NewPrefixAnnounce(mgmt.NewPrefixes(ia, 42, []*net.IPNet{n4, n6}),
+		tr.Signer(t, ia))
+	require.NoError(t, err)
+
+	spld, err := mgmt.NewPld(1, a)
+	require.NoError(t, err)
+	cpld, err := ctrl.NewPld(spld, nil)
+	require.NoError(t, err)
+	raw, err := proto.PackRoot(cpld)
+	require.NoError(t, err)
+	parsed, err := ctrl.NewPldFromRaw(raw)
+	require.NoError(t, err)
+	u, err := parsed.Union()
+	require.NoError(t, err)
+	u, err = u.(*mgmt.Pld).Union()
+	require.NoError(t, err)
+	received, ok := u.(*mgmt.PrefixAnnounce)
+	require.True(t, ok)
+
+	p, err := received.Verify(context.Background(), tr.Verifier())
+	require.NoError(t, err)
+	assert.Equal(t, ia, p.IA())
+	assert.Equal(t, uint64(42), p.Version)
+	nets, err := p.Nets()
+	require.NoError(t, err)
+	assert.Equal(t, []*net.IPNet{n4, n6}, nets)
+
+	received.Blob[len(received.Blob)-1] ^= 0xff
+	_, err = received.Verify(context.Background(), tr.Verifier())
+	assert.Error(t, err, "tampered blob")
+
+	other, err := mgmt.NewPrefixAnnounce(mgmt.NewPrefixes(ia, 42, nil),
+		tr.Signer(t, xtest.MustParseIA("1-ff00:0:111")))
+	require.NoError(t, err)
+	_, err = other.Verify(context.Background(), tr.Verifier().WithIA(ia))
+	assert.Error(t, err, "signed by other AS")
+}
+
+func TestPrefixIPNet(t *testing.T) {
+	testCases := map[string]struct {
+		prefix   mgmt.Prefix
+		expected string
+	}{
+		"IPv4":            {mgmt.Prefix{IP: []byte{192, 0, 2, 0}, Len: 24}, "192.0.2.0/24"},
+		"IPv4 host":       {mgmt.Prefix{IP: []byte{192, 0, 2, 1}, Len: 32}, "192.0.2.1/32"},
+		"IPv6":            {mgmt.Prefix{IP: []byte(net.ParseIP("2001:db8::")), Len: 32}, "2001:db8::/32"},
+		"default":         {mgmt.Prefix{IP: make([]byte, 4), Len: 0}, "0.0.0.0/0"},
+		"host bits set":   {mgmt.Prefix{IP: []byte{192, 0, 2, 1}, Len: 24}, ""},
+		"length too long": {mgmt.Prefix{IP: []byte{192, 0, 2, 0}, Len: 33}, ""},
+		"invalid address": {mgmt.Prefix{IP: []byte{192, 0, 2}, Len: 24}, ""},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			n, err := tc.prefix.IPNet()
+			if tc.expected == "" {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, n.String())
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgmt
+
+import (
+	"context"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/proto"
+)
+
+// Signer signs prefix announcements and key exchanges with the AS signing key
+// of the local AS. It is implemented by infra.Signer, which cannot be used
+// directly since package ctrl depends on this package.
+type Signer interface {
+	Sign(msg common.RawBytes) (*proto.SignS, error)
+}
+
+// Verifier verifies the signatures of prefix announcements and key exchanges
+// of remote ASes. It is implemented by infra.Verifier.
+type Verifier interface {
+	Verify(ctx context.Context, msg common.RawBytes, sign *proto.SignS) error
+}
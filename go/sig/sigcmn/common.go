@@ -16,15 +16,27 @@
 package sigcmn
 
 import (
+	"bytes"
+	"context"
 	"net"
 	"time"
 
+	"golang.org/x/crypto/ed25519"
+
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/infraenv"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust/trustdb"
+	"github.com/scionproto/scion/go/lib/keyconf"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/scrypto"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/lib/svc"
+	"github.com/scionproto/scion/go/sig/egress/siginfo"
 	"github.com/scionproto/scion/go/sig/internal/pathmgr"
 	"github.com/scionproto/scion/go/sig/internal/sigconfig"
 	"github.com/scionproto/scion/go/sig/internal/snetmigrate"
@@ -34,6 +46,9 @@ import (
 const (
 	MaxPort    = (1 << 16) - 1
 	SIGHdrSize = 8
+	// VerifyTimeout is the timeout of verifying a signature of a remote SIG,
+	// which looks up the certificate chain in the trust database.
+	VerifyTimeout = time.Second
 )
 
 var (
@@ -50,13 +65,29 @@ var (
 	CtrlConn  snet.Conn
 	MgmtAddr  *mgmt.Addr
 	encapPort uint16
+	// Signer signs prefix announcements and key exchanges with the AS signing
+	// key. It is nil if no signing key is configured.
+	Signer infra.Signer
+	// Verifier verifies the prefix announcements and key exchanges of remote
+	// SIGs with the certificate chains of their ASes in the trust store. It
+	// is nil if no trust database is configured.
+	Verifier infra.Verifier
+	// Resolver discovers the SIGs of remote ASes via SVC resolution.
+	Resolver svc.MultiResolver
 )
 
-func Init(cfg sigconfig.SigConf, sdCfg env.SciondClient) error {
+// Init initializes the SIG. The trust store is backed by trustDB, it is not
+// set up if trustDB is nil.
+func Init(cfg sigconfig.SigConf, sdCfg env.SciondClient, trustDB trustdb.TrustDB) error {
 	IA = cfg.IA
 	Host = addr.HostFromIP(cfg.IP)
 	MgmtAddr = mgmt.NewAddr(Host, cfg.CtrlPort, cfg.EncapPort)
 	encapPort = cfg.EncapPort
+	if trustDB != nil {
+		if err := initTrust(cfg, trustDB); err != nil {
+			return err
+		}
+	}
 
 	ds := reliable.NewDispatcherService(cfg.Dispatcher)
 	// TODO(karampok). To be kept until https://github.com/scionproto/scion/issues/3377
@@ -81,7 +112,24 @@ func Init(cfg sigconfig.SigConf, sdCfg env.SciondClient) error {
 	if err != nil {
 		return common.NewBasicError("Error creating local SCION Network context", err)
 	}
-	conn, err := network.ListenSCIONWithBindSVC("udp4",
+	// The ctrl conn answers SVC resolution requests, such that remote SIGs
+	// can discover this SIG.
+	reply := &bytes.Buffer{}
+	err = siginfo.BuildReply(Host, cfg.CtrlPort, cfg.EncapPort).SerializeTo(reply)
+	if err != nil {
+		return common.NewBasicError("Unable to build SVC resolution reply", err)
+	}
+	ctrlNetwork := snet.NewCustomNetworkWithPR(cfg.IA, svc.NewMulticastResolverPacketDispatcher(
+		&snet.DefaultPacketDispatcherService{
+			Dispatcher:  ds,
+			SCMPHandler: snet.NewSCMPHandler(resolver),
+		},
+		&infraenv.LegacyForwardingHandler{
+			BaseHandler:     &svc.BaseHandler{Message: reply.Bytes()},
+			ExpectedPayload: infraenv.ResolutionRequestPayload,
+		},
+	))
+	conn, err := ctrlNetwork.ListenSCIONWithBindSVC("udp4",
 		&snet.Addr{IA: IA, Host: &addr.AppAddr{L3: Host, L4: cfg.CtrlPort}},
 		nil, addr.SvcSIG, 0)
 	if err != nil {
@@ -91,10 +139,57 @@ func Init(cfg sigconfig.SigConf, sdCfg env.SciondClient) error {
 	CtrlConn = conn
 	Network = network
 	PathMgr = resolver
+	Resolver = &svc.Resolver{
+		LocalIA:     IA,
+		ConnFactory: &snet.DefaultPacketDispatcherService{Dispatcher: ds},
+		Machine:     snet.LocalMachine{InterfaceIP: cfg.IP},
+		Payload:     infraenv.ResolutionRequestPayload,
+	}
 
 	return nil
 }
 
+// initTrust sets up the verifier and, if a signing key is configured, the
+// signer. The trust store has no messenger, the certificate chains of remote
+// ASes are only looked up in trustDB.
+func initTrust(cfg sigconfig.SigConf, trustDB trustdb.TrustDB) error {
+	store := trust.NewStore(trustDB, IA, trust.Config{MustHaveLocalChain: true}, log.Root())
+	Verifier = store.NewVerifier()
+	if cfg.SigningKey == "" {
+		return nil
+	}
+	key, err := keyconf.LoadKey(cfg.SigningKey, scrypto.Ed25519)
+	if err != nil {
+		return common.NewBasicError("Unable to load signing key", err, "file", cfg.SigningKey)
+	}
+	if err := store.LoadAuthoritativeCrypto(cfg.CertsDir); err != nil {
+		return common.NewBasicError("Unable to load local TRC and certificate chain", err,
+			"dir", cfg.CertsDir)
+	}
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+	meta, err := trust.CreateSignMeta(ctx, IA, trustDB)
+	if err != nil {
+		return err
+	}
+	opts := infra.ChainOpts{TrustStoreOpts: infra.TrustStoreOpts{LocalOnly: true}}
+	chain, err := store.GetChain(ctx, IA, meta.Src.ChainVer, opts)
+	if err != nil {
+		return common.NewBasicError("Unable to get local certificate chain", err)
+	}
+	pub := ed25519.PrivateKey(key).Public().(ed25519.PublicKey)
+	if !bytes.Equal(pub, chain.Leaf.SubjectSignKey) {
+		return common.NewBasicError("Signing key does not match certificate chain", nil,
+			"file", cfg.SigningKey, "chain_version", meta.Src.ChainVer)
+	}
+	signer, err := trust.NewBasicSigner(key, meta)
+	if err != nil {
+		return common.NewBasicError("Unable to create signer", err)
+	}
+	Signer = signer
+	return nil
+}
+
 func EncapSnetAddr() *snet.Addr {
 	return &snet.Addr{IA: IA, Host: &addr.AppAddr{L3: Host, L4: uint16(encapPort)}}
 }
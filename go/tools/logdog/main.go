@@ -22,30 +22,48 @@
 // foo/bar/br1-ff00_0_311-1.log turns into the prefix br1-ff00_0_311-1.
 // The prefix is only printed once for blocks coming from the same file. The
 // timestamp format of the output is the same as the input format, i.e. ISO8601
-// with a space instead of "T".
+// with a space instead of "T". With -format json, each entry is written as a
+// JSON object per line instead.
+//
+// The log files are expected to be sorted by timestamp. They are merged while
+// they are read, such that only one entry per file is kept in memory. Entries
+// can be filtered by level, time range, service, debug ID, substring and
+// regular expression. With -follow, the files are followed for new entries,
+// similar to tail -f.
 //
 // Limitations:
-// - All the logs are kept in memory prior to output. Processing terabytes of
-//   logs is thus not recommended.
 // - The tool does not care about stdin
+// - In follow mode, the last entry of a file is only printed once the next
+//   entry is written to the file, since entries can span multiple lines.
 // - The tool tries to keep going in the face of errors, but will emit messages
 //   to stderr when doing so.
 
 package main
 
 import (
+	"bufio"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/log/logparse"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	fmtHuman = "human"
+	fmtJSON  = "json"
 )
 
 var (
@@ -62,6 +80,22 @@ var (
 			}, ",")))
 	containsFlag = flag.String("contains", "",
 		"A string that must be contained in a log line to be included in the output.")
+	sinceFlag = flag.String("since", "",
+		"Only include entries at or after this time. Either a timestamp in the log format or"+
+			" RFC 3339, or a duration before now, e.g. 10m.")
+	untilFlag = flag.String("until", "",
+		"Only include entries at or before this time. Same format as -since.")
+	serviceFlag = flag.String("service", "",
+		"Comma-separated list of prefixes. Only include entries whose file name or service"+
+			" starts with any of them, e.g. cs,br1-ff00_0_110.")
+	regexFlag = flag.String("regex", "",
+		"A regular expression that must match a log line to be included in the output.")
+	debugIDFlag = flag.String("debug-id", "",
+		"Only include entries with this debug ID.")
+	followFlag = flag.Bool("follow", false,
+		"Keep running and output new entries as they are appended to the files.")
+	formatFlag = flag.String("format", fmtHuman,
+		fmt.Sprintf("The output format, any of [%s,%s].", fmtHuman, fmtJSON))
 )
 
 func main() {
@@ -72,37 +106,60 @@ func main() {
 		os.Exit(0)
 	}
 
-	f, err := filterFromFlags()
+	now := time.Now()
+	since, err := parseTime(*sinceFlag, now)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to parse -since: %s\n", err)
+		os.Exit(1)
+	}
+	until, err := parseTime(*untilFlag, now)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to parse -until: %s\n", err)
+		os.Exit(1)
+	}
+	f, err := filterFromFlags(since, until)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to create filters: %s\n", err)
 		os.Exit(1)
 	}
-	maxENameLen := 0
-	// Read in all files
-	for _, fn := range flag.Args() {
-		fileEntries := entriesFromFile(fn, f)
-		entries = append(entries, fileEntries...)
-		eNameLen := len(fnToEName(fn))
-		if eNameLen > maxENameLen {
-			maxENameLen = eNameLen
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+	var emit func(logparse.LogEntry)
+	switch *formatFlag {
+	case fmtHuman:
+		maxENameLen := 0
+		for _, fn := range flag.Args() {
+			if eNameLen := len(fnToEName(fn)); eNameLen > maxENameLen {
+				maxENameLen = eNameLen
+			}
 		}
+		emit = humanPrinter(w, maxENameLen)
+	case fmtJSON:
+		emit = jsonPrinter(w)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown output format: %s\n", *formatFlag)
+		os.Exit(1)
 	}
-	indent := strings.Repeat(" ", maxENameLen+3)
-	fmtL := "[%-" + strconv.Itoa(maxENameLen) + "s] %s"
-	// Sort by timestamp and output
-	sort.Sort(entries)
-	lastelement := ""
-	for _, entry := range entries {
-		if entry.Element == lastelement {
-			fmt.Printf("%s%s", indent, fmtEntry(entry, indent))
-		} else {
-			lastelement = entry.Element
-			fmt.Printf(fmtL, entry.Element, fmtEntry(entry, indent))
+	if *followFlag {
+		printEntry := emit
+		emit = func(e logparse.LogEntry) {
+			printEntry(e)
+			w.Flush()
 		}
 	}
+	var srcs []*source
+	for _, fn := range flag.Args() {
+		src, err := newSource(fn, f, until, *followFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Could not open file %s: %s\n", fn, err)
+			continue
+		}
+		srcs = append(srcs, src)
+	}
+	merge(srcs, emit)
 }
 
-func filterFromFlags() (Filter, error) {
+func filterFromFlags(since, until time.Time) (Filter, error) {
 	var filters Filters
 	logLevel, err := log.LvlFromString(*logLevelFlag)
 	if err != nil {
@@ -110,29 +167,108 @@ func filterFromFlags() (Filter, error) {
 	}
 	filters = append(filters, MinLevel(logLevel))
 	filters = append(filters, Contains(*containsFlag))
+	if !since.IsZero() || !until.IsZero() {
+		filters = append(filters, TimeRange(since, until))
+	}
+	if *serviceFlag != "" {
+		filters = append(filters, ServicePrefix(strings.Split(*serviceFlag, ",")...))
+	}
+	if *regexFlag != "" {
+		re, err := regexp.Compile(*regexFlag)
+		if err != nil {
+			return filters, err
+		}
+		filters = append(filters, Regex(re))
+	}
+	if *debugIDFlag != "" {
+		filters = append(filters, DebugID(*debugIDFlag))
+	}
 	return filters, nil
 }
 
+// parseTime parses a timestamp in the log format or RFC 3339, or a duration
+// before now. The empty string results in the zero time.
+func parseTime(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{common.TimeFmt, time.RFC3339Nano} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, serrors.New("Invalid time", "time", s)
+}
+
+// humanPrinter returns a function that writes entries in the human readable
+// format, prefixed with the element padded to eNameLen.
+func humanPrinter(w io.Writer, eNameLen int) func(logparse.LogEntry) {
+	indent := strings.Repeat(" ", eNameLen+3)
+	fmtL := "[%-" + strconv.Itoa(eNameLen) + "s] %s"
+	lastelement := ""
+	return func(entry logparse.LogEntry) {
+		if entry.Element == lastelement {
+			fmt.Fprintf(w, "%s%s", indent, fmtEntry(entry, indent))
+		} else {
+			lastelement = entry.Element
+			fmt.Fprintf(w, fmtL, entry.Element, fmtEntry(entry, indent))
+		}
+	}
+}
+
 func fmtEntry(l logparse.LogEntry, indent string) string {
 	return fmt.Sprintf("%s [%s] %s\n", l.Timestamp.Format(common.TimeFmt), l.Level,
 		strings.Join(l.Lines, "\n"+indent))
 }
 
-type LogEntries []logparse.LogEntry
-
-var entries LogEntries
-
-// Implement interface for sort.Sort()
-func (e LogEntries) Len() int {
-	return len(e)
+// jsonPrinter returns a function that writes entries as JSON objects, one per
+// line. The keys are ts, level, element and msg, followed by the fields of
+// entries in the JSON format, sorted by key. For entries in the human readable
+// format, msg contains all lines of the entry.
+func jsonPrinter(w io.Writer) func(logparse.LogEntry) {
+	return func(entry logparse.LogEntry) {
+		msg := entry.Msg
+		if entry.Fields == nil {
+			msg = strings.Join(entry.Lines, "\n")
+		}
+		b := &strings.Builder{}
+		b.WriteByte('{')
+		writeJSONField(b, log.KeyTime, entry.Timestamp.Format(common.TimeFmt), true)
+		writeJSONField(b, log.KeyLevel, entry.Level.String(), false)
+		writeJSONField(b, "element", entry.Element, false)
+		writeJSONField(b, log.KeyMsg, msg, false)
+		keys := make([]string, 0, len(entry.Fields))
+		for k := range entry.Fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			key := k
+			if key == "element" {
+				key = "ctx_" + key
+			}
+			writeJSONField(b, key, entry.Fields[k], false)
+		}
+		b.WriteString("}\n")
+		io.WriteString(w, b.String())
+	}
 }
 
-func (e LogEntries) Less(i, j int) bool {
-	return e[i].Timestamp.UnixNano() < e[j].Timestamp.UnixNano()
+func writeJSONField(b *strings.Builder, k, v string, first bool) {
+	if !first {
+		b.WriteByte(',')
+	}
+	// Marshaling strings never fails.
+	raw, _ := json.Marshal(k)
+	b.Write(raw)
+	b.WriteByte(':')
+	raw, _ = json.Marshal(v)
+	b.Write(raw)
 }
 
-func (e LogEntries) Swap(i, j int) { e[i], e[j] = e[j], e[i] }
-
 // Turn a path name like "foo/bar/logs/br1-ff00_0_311-1.log" into "br1-ff00_0_311-1"
 // Note that sthis also strips the suffix, no matter its contents, i.e. it will
 // strip .log, .DEBUG, .INFO etc., basically anything after (and including) the
@@ -147,24 +283,6 @@ func printUsage() {
 	flag.PrintDefaults()
 }
 
-// entriesFromFile gets all log entries from the file with a given minimum
-// level.
-func entriesFromFile(fn string, filter Filter) LogEntries {
-	var entries LogEntries
-	f, err := os.Open(fn)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Could not open file %s: %s\n", fn, err)
-		return entries // empty slice
-	}
-	defer f.Close()
-	logparse.ParseFrom(f, fn, fnToEName(fn), func(e logparse.LogEntry) {
-		if filter.Keep(e) {
-			entries = append(entries, e)
-		}
-	})
-	return entries
-}
-
 // Filter filters log entries. If keep returns true an entry is kept, otherwise
 // it is dropped.
 type Filter interface {
@@ -209,3 +327,52 @@ func Contains(s string) Filter {
 		return false
 	})
 }
+
+// TimeRange returns a filter that checks that an entry is in the time range
+// from since to until, both inclusive. Zero bounds are ignored.
+func TimeRange(since, until time.Time) Filter {
+	return FilterFunc(func(e logparse.LogEntry) bool {
+		if !since.IsZero() && e.Timestamp.Before(since) {
+			return false
+		}
+		return until.IsZero() || !e.Timestamp.After(until)
+	})
+}
+
+// ServicePrefix returns a filter that checks that the element or the service
+// of an entry starts with any of the prefixes.
+func ServicePrefix(prefixes ...string) Filter {
+	return FilterFunc(func(e logparse.LogEntry) bool {
+		for _, p := range prefixes {
+			if strings.HasPrefix(e.Element, p) || strings.HasPrefix(e.Fields[log.KeyService], p) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Regex returns a filter that checks that re matches a line of the entry.
+func Regex(re *regexp.Regexp) Filter {
+	return FilterFunc(func(e logparse.LogEntry) bool {
+		for _, l := range e.Lines {
+			if re.MatchString(l) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// DebugID returns a filter that checks that an entry has the debug ID id. For
+// entries in the human readable format, the context of the lines is searched
+// for the debug ID.
+func DebugID(id string) Filter {
+	re := regexp.MustCompile(`(^|\s)` + regexp.QuoteMeta(log.KeyDebugID+"="+id) + `(\s|$)`)
+	return FilterFunc(func(e logparse.LogEntry) bool {
+		if e.Fields != nil {
+			return e.Fields[log.KeyDebugID] == id
+		}
+		return Regex(re).Keep(e)
+	})
+}
@@ -15,10 +15,15 @@
 package main
 
 import (
+	"bytes"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/log/logparse"
 )
@@ -107,3 +112,156 @@ func TestContainsFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeRangeFilter(t *testing.T) {
+	ts := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		Since time.Time
+		Until time.Time
+		Keep  assert.BoolAssertionFunc
+	}{
+		"No bounds keeps entry": {
+			Keep: assert.True,
+		},
+		"Bounds are inclusive": {
+			Since: ts,
+			Until: ts,
+			Keep:  assert.True,
+		},
+		"Entry before since is removed": {
+			Since: ts.Add(time.Second),
+			Keep:  assert.False,
+		},
+		"Entry after until is removed": {
+			Until: ts.Add(-time.Second),
+			Keep:  assert.False,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := TimeRange(test.Since, test.Until)
+			test.Keep(t, f.Keep(logparse.LogEntry{Timestamp: ts}))
+		})
+	}
+}
+
+func TestServicePrefixFilter(t *testing.T) {
+	tests := map[string]struct {
+		Entry logparse.LogEntry
+		Keep  assert.BoolAssertionFunc
+	}{
+		"Element with prefix is kept": {
+			Entry: logparse.LogEntry{Element: "br1-ff00_0_110-1"},
+			Keep:  assert.True,
+		},
+		"Service with prefix is kept": {
+			Entry: logparse.LogEntry{Element: "out",
+				Fields: map[string]string{log.KeyService: "cs1-ff00:0:110-1"}},
+			Keep: assert.True,
+		},
+		"Entry without prefix is removed": {
+			Entry: logparse.LogEntry{Element: "sd1-ff00_0_110",
+				Fields: map[string]string{log.KeyService: "sd1-ff00:0:110"}},
+			Keep: assert.False,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := ServicePrefix("br1", "cs")
+			test.Keep(t, f.Keep(test.Entry))
+		})
+	}
+}
+
+func TestRegexFilter(t *testing.T) {
+	f := Regex(regexp.MustCompile(`ia=1-ff00:0:11\d`))
+	assert.True(t, f.Keep(logparse.LogEntry{Lines: []string{"foo", "bar ia=1-ff00:0:112"}}))
+	assert.False(t, f.Keep(logparse.LogEntry{Lines: []string{"foo ia=1-ff00:0:120"}}))
+}
+
+func TestDebugIDFilter(t *testing.T) {
+	tests := map[string]struct {
+		Entry logparse.LogEntry
+		Keep  assert.BoolAssertionFunc
+	}{
+		"Human entry with debug ID is kept": {
+			Entry: logparse.LogEntry{Lines: []string{"Request debug_id=0a1b err=nil"}},
+			Keep:  assert.True,
+		},
+		"Human entry with longer debug ID is removed": {
+			Entry: logparse.LogEntry{Lines: []string{"Request debug_id=0a1b2c"}},
+			Keep:  assert.False,
+		},
+		"JSON entry with debug ID is kept": {
+			Entry: logparse.LogEntry{Lines: []string{"Request debug_id=0a1b"},
+				Fields: map[string]string{log.KeyDebugID: "0a1b"}},
+			Keep: assert.True,
+		},
+		"JSON entry with debug ID in message is removed": {
+			Entry: logparse.LogEntry{Lines: []string{"Request debug_id=0a1b"},
+				Fields: map[string]string{}},
+			Keep: assert.False,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := DebugID("0a1b")
+			test.Keep(t, f.Keep(test.Entry))
+		})
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		Input    string
+		Expected time.Time
+		Err      bool
+	}{
+		"empty": {},
+		"duration": {
+			Input:    "10m",
+			Expected: now.Add(-10 * time.Minute),
+		},
+		"log format": {
+			Input:    "2020-01-01 11:00:00.000000+0000",
+			Expected: now.Add(-time.Hour),
+		},
+		"RFC 3339": {
+			Input:    "2020-01-01T11:00:00Z",
+			Expected: now.Add(-time.Hour),
+		},
+		"invalid": {
+			Input: "yesterday",
+			Err:   true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ts, err := parseTime(test.Input, now)
+			if test.Err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, test.Expected.Equal(ts), "expected %s, got %s", test.Expected, ts)
+		})
+	}
+}
+
+func TestJSONPrinter(t *testing.T) {
+	ts := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	buf := &bytes.Buffer{}
+	p := jsonPrinter(buf)
+	p(logparse.LogEntry{Timestamp: ts, Element: "br1", Level: log.LvlInfo,
+		Lines: []string{"Multi", "> line"}})
+	p(logparse.LogEntry{Timestamp: ts, Element: "cs1", Level: log.LvlError,
+		Lines: []string{"Failed element=x err=y"}, Msg: "Failed",
+		Fields: map[string]string{"err": "y", "element": "x"}})
+	stamp := ts.Format(common.TimeFmt)
+	assert.Equal(t,
+		`{"ts":"`+stamp+`","level":"INFO","element":"br1","msg":"Multi\n\u003e line"}`+"\n"+
+			`{"ts":"`+stamp+`","level":"EROR","element":"cs1","msg":"Failed",`+
+			`"ctx_element":"x","err":"y"}`+"\n",
+		buf.String())
+}
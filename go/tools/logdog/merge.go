@@ -0,0 +1,208 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"container/heap"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/log/logparse"
+)
+
+const (
+	// pollInterval is the interval in which followed files are checked for
+	// new data.
+	pollInterval = 200 * time.Millisecond
+	// entryBuffer is the number of parsed entries that are buffered per file.
+	entryBuffer = 64
+)
+
+// source is a log file that is parsed in the background. The entries of the
+// file are expected to be sorted by timestamp.
+type source struct {
+	entries chan logparse.LogEntry
+	reader  *sourceReader
+}
+
+// newSource starts parsing the log file fn in the background. Entries that are
+// not kept by the filter are dropped. Since the file is sorted, parsing stops
+// at the first entry after until, unless until is zero. If follow is set, the
+// file is followed for new entries.
+func newSource(fn string, filter Filter, until time.Time, follow bool) (*source, error) {
+	f, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+	s := &source{
+		entries: make(chan logparse.LogEntry, entryBuffer),
+		reader:  &sourceReader{r: f, follow: follow, stop: make(chan struct{})},
+	}
+	go func() {
+		defer close(s.entries)
+		defer f.Close()
+		logparse.ParseFrom(s.reader, fn, fnToEName(fn), func(e logparse.LogEntry) {
+			if !until.IsZero() && e.Timestamp.After(until) {
+				s.reader.Stop()
+				return
+			}
+			if filter.Keep(e) {
+				s.entries <- e
+			}
+		})
+	}()
+	return s, nil
+}
+
+// next returns the next entry of the source. If the source is exhausted, ok
+// is false. If the file of the source is followed and there is no new data,
+// next does not block but returns with idle set.
+func (s *source) next() (e logparse.LogEntry, ok, idle bool) {
+	if !s.reader.follow {
+		e, ok = <-s.entries
+		return e, ok, false
+	}
+	for {
+		select {
+		case e, ok = <-s.entries:
+			return e, ok, false
+		default:
+		}
+		if s.reader.Idle() {
+			return e, false, true
+		}
+		select {
+		case e, ok = <-s.entries:
+			return e, ok, false
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// sourceReader reads a log file. If follow is set, it waits for the file to
+// grow instead of returning io.EOF at the end of the file. Once stopped, it
+// returns io.EOF.
+type sourceReader struct {
+	r      io.Reader
+	follow bool
+	stop   chan struct{}
+	once   sync.Once
+	idle   int32
+}
+
+func (r *sourceReader) Read(p []byte) (int, error) {
+	for {
+		select {
+		case <-r.stop:
+			return 0, io.EOF
+		default:
+		}
+		n, err := r.r.Read(p)
+		if n > 0 || err != io.EOF || !r.follow {
+			atomic.StoreInt32(&r.idle, 0)
+			return n, err
+		}
+		atomic.StoreInt32(&r.idle, 1)
+		select {
+		case <-r.stop:
+			return 0, io.EOF
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// Stop stops reading. It is safe to call Stop multiple times.
+func (r *sourceReader) Stop() {
+	r.once.Do(func() { close(r.stop) })
+}
+
+// Idle returns whether the reader waits for the file to grow.
+func (r *sourceReader) Idle() bool {
+	return atomic.LoadInt32(&r.idle) == 1
+}
+
+// merge merges the entries of the sources by timestamp and passes them to
+// emit. It returns once all sources are exhausted. Only the head of each
+// source is kept in memory.
+//
+// Followed sources that wait for their file to grow do not hold back the
+// entries of the other sources. Entries they produce later are emitted as they
+// arrive, even if older entries of other sources were emitted already.
+func merge(srcs []*source, emit func(logparse.LogEntry)) {
+	h := &entryHeap{}
+	pending := make([]int, 0, len(srcs))
+	for i := range srcs {
+		pending = append(pending, i)
+	}
+	for len(pending) > 0 || h.Len() > 0 {
+		var idle []int
+		for _, i := range pending {
+			e, ok, isIdle := srcs[i].next()
+			switch {
+			case isIdle:
+				idle = append(idle, i)
+			case ok:
+				heap.Push(h, head{entry: e, src: i})
+			}
+		}
+		pending = idle
+		if h.Len() == 0 {
+			if len(pending) > 0 {
+				time.Sleep(pollInterval)
+			}
+			continue
+		}
+		hd := heap.Pop(h).(head)
+		emit(hd.entry)
+		pending = append(pending, hd.src)
+	}
+}
+
+// head is the next entry of a source.
+type head struct {
+	entry logparse.LogEntry
+	src   int
+}
+
+// entryHeap is a min-heap of the heads of the sources, ordered by timestamp.
+// Ties are broken by the order of the sources.
+type entryHeap []head
+
+func (h entryHeap) Len() int {
+	return len(h)
+}
+
+func (h entryHeap) Less(i, j int) bool {
+	if !h[i].entry.Timestamp.Equal(h[j].entry.Timestamp) {
+		return h[i].entry.Timestamp.Before(h[j].entry.Timestamp)
+	}
+	return h[i].src < h[j].src
+}
+
+func (h entryHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *entryHeap) Push(x interface{}) {
+	*h = append(*h, x.(head))
+}
+
+func (h *entryHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
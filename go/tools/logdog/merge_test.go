@@ -0,0 +1,143 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log/logparse"
+)
+
+var base = time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// line returns a log line at base plus the given seconds.
+func line(sec int, msg string) string {
+	ts := base.Add(time.Duration(sec) * time.Second)
+	return ts.Format(common.TimeFmt) + " [INFO] " + msg + "\n"
+}
+
+func writeLog(t *testing.T, dir, name string, lines ...string) string {
+	fn := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(fn, []byte(strings.Join(lines, "")), 0644))
+	return fn
+}
+
+func messages(entries []logparse.LogEntry) []string {
+	var msgs []string
+	for _, e := range entries {
+		msgs = append(msgs, e.Element+": "+strings.Join(e.Lines, "|"))
+	}
+	return msgs
+}
+
+func TestMerge(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logdog")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	a := writeLog(t, dir, "a.log", line(0, "a0"), line(2, "a2"), "> cont\n", line(4, "a4"))
+	b := writeLog(t, dir, "b.log", line(1, "b1"), line(2, "b2"), line(5, "b5"))
+	c := writeLog(t, dir, "c.log")
+
+	t.Run("entries are merged by timestamp", func(t *testing.T) {
+		var entries []logparse.LogEntry
+		merge(mustSources(t, Filters{}, time.Time{}, a, b, c), func(e logparse.LogEntry) {
+			entries = append(entries, e)
+		})
+		assert.Equal(t, []string{"a: a0", "b: b1", "a: a2|> cont", "b: b2", "a: a4", "b: b5"},
+			messages(entries))
+	})
+	t.Run("filter and until are applied", func(t *testing.T) {
+		var entries []logparse.LogEntry
+		f := Filters{Contains("2")}
+		merge(mustSources(t, f, base.Add(2*time.Second), a, b), func(e logparse.LogEntry) {
+			entries = append(entries, e)
+		})
+		assert.Equal(t, []string{"a: a2|> cont", "b: b2"}, messages(entries))
+	})
+}
+
+func TestMergeFollow(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logdog")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	a := writeLog(t, dir, "a.log", line(0, "a0"), line(3, "a3"))
+	b := writeLog(t, dir, "b.log")
+	srcs := make([]*source, 0, 2)
+	for _, fn := range []string{a, b} {
+		src, err := newSource(fn, Filters{}, time.Time{}, true)
+		require.NoError(t, err)
+		srcs = append(srcs, src)
+	}
+	entries := make(chan logparse.LogEntry, 10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		merge(srcs, func(e logparse.LogEntry) { entries <- e })
+	}()
+	next := func() string {
+		select {
+		case e := <-entries:
+			return messages([]logparse.LogEntry{e})[0]
+		case <-time.After(5 * time.Second):
+			return "timeout"
+		}
+	}
+
+	// The idle file b does not hold back the entries of a.
+	assert.Equal(t, "a: a0", next())
+	f, err := os.OpenFile(b, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	defer f.Close()
+	_, err = f.WriteString(line(1, "b1") + line(2, "b2"))
+	require.NoError(t, err)
+	assert.Equal(t, "b: b1", next())
+
+	for _, src := range srcs {
+		src.reader.Stop()
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("merge did not return after the sources were stopped")
+	}
+	close(entries)
+	var rest []logparse.LogEntry
+	for e := range entries {
+		rest = append(rest, e)
+	}
+	// The order of the remaining entries depends on when the sources stopped.
+	assert.ElementsMatch(t, []string{"b: b2", "a: a3"}, messages(rest))
+}
+
+func mustSources(t *testing.T, f Filter, until time.Time, fns ...string) []*source {
+	var srcs []*source
+	for _, fn := range fns {
+		src, err := newSource(fn, f, until, false)
+		require.NoError(t, err)
+		srcs = append(srcs, src)
+	}
+	return srcs
+}
@@ -0,0 +1,210 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// Packet types of the bandwidth test protocol.
+const (
+	// pktData is a test packet sent from the client to the server.
+	pktData uint8 = iota + 1
+	// pktFin ends the test. Its sequence number contains the number of data
+	// packets the client sent.
+	pktFin
+	// pktReport is the reply of the server to a fin packet. Its payload is the
+	// JSON encoded ServerReport.
+	pktReport
+)
+
+// hdrLen is the length of the header of every test packet.
+const hdrLen = 17
+
+// windowSize is the number of sequence numbers up to the highest received one
+// for which the receiver detects duplicates. Data packets with a lower
+// sequence number are late, data packets with a sequence number at least
+// windowSize above the highest received one are ignored.
+const windowSize = 1 << 16
+
+// header is the header of every test packet. The header is followed by
+// padding for data packets and by the report for report packets.
+type header struct {
+	Type uint8
+	// Session identifies the test run of a client.
+	Session uint32
+	// Seq is the sequence number of a data packet or the number of sent data
+	// packets in a fin packet.
+	Seq uint32
+	// Timestamp is the time the packet was sent.
+	Timestamp time.Time
+}
+
+func (h header) write(b []byte) {
+	b[0] = h.Type
+	binary.BigEndian.PutUint32(b[1:5], h.Session)
+	binary.BigEndian.PutUint32(b[5:9], h.Seq)
+	binary.BigEndian.PutUint64(b[9:17], uint64(h.Timestamp.UnixNano()))
+}
+
+func parseHeader(b []byte) (header, error) {
+	if len(b) < hdrLen {
+		return header{}, serrors.New("packet too short", "len", len(b), "min", hdrLen)
+	}
+	h := header{
+		Type:      b[0],
+		Session:   binary.BigEndian.Uint32(b[1:5]),
+		Seq:       binary.BigEndian.Uint32(b[5:9]),
+		Timestamp: time.Unix(0, int64(binary.BigEndian.Uint64(b[9:17]))),
+	}
+	if h.Type < pktData || h.Type > pktReport {
+		return header{}, serrors.New("unknown packet type", "type", h.Type)
+	}
+	return h, nil
+}
+
+// ServerReport contains the statistics measured by the server for the data
+// packets of a test run.
+type ServerReport struct {
+	// Received is the number of unique data packets received.
+	Received uint64 `json:"received"`
+	// Duplicates is the number of data packets received more than once.
+	Duplicates uint64 `json:"duplicates"`
+	// Reordered is the number of data packets that arrived after a packet
+	// with a higher sequence number.
+	Reordered uint64 `json:"reordered"`
+	// Late is the number of data packets that arrived too late to be checked
+	// for duplicates. They are not counted as received.
+	Late uint64 `json:"late"`
+	// Lost is the number of data packets sent but not received.
+	Lost uint64 `json:"lost"`
+	// LossRate is the fraction of sent data packets that were lost.
+	LossRate float64 `json:"loss_rate"`
+	// Bytes is the number of payload bytes received.
+	Bytes uint64 `json:"bytes"`
+	// Duration is the time between the first and the last received data
+	// packet in seconds.
+	Duration float64 `json:"duration_s"`
+	// Throughput is the received payload bandwidth in bits per second.
+	Throughput float64 `json:"throughput_bps"`
+	// Jitter is the interarrival jitter as defined in RFC 3550 in
+	// milliseconds.
+	Jitter float64 `json:"jitter_ms"`
+}
+
+// receiver measures the statistics of the data packets of a test run.
+type receiver struct {
+	received   uint64
+	duplicates uint64
+	reordered  uint64
+	late       uint64
+	bytes      uint64
+	first      time.Time
+	last       time.Time
+	maxSeq     uint32
+	// seen is a bitmap of the received sequence numbers in the window. The
+	// bit of sequence number seq is at position seq % windowSize.
+	seen [windowSize / 64]uint64
+	// jitter is the smoothed interarrival jitter in nanoseconds.
+	jitter      float64
+	lastTransit time.Duration
+}
+
+// add records the data packet with the given header and size that arrived
+// at time now.
+func (r *receiver) add(h header, size int, now time.Time) {
+	switch {
+	case uint64(h.Seq) >= uint64(r.maxSeq)+windowSize:
+		return
+	case uint64(h.Seq)+windowSize <= uint64(r.maxSeq):
+		r.late++
+		return
+	case r.isSeen(h.Seq):
+		r.duplicates++
+		return
+	}
+	if h.Seq > r.maxSeq {
+		r.advance(h.Seq)
+	}
+	r.markSeen(h.Seq)
+	transit := now.Sub(h.Timestamp)
+	if r.received == 0 {
+		r.first = now
+	} else {
+		if h.Seq < r.maxSeq {
+			r.reordered++
+		}
+		d := float64(transit - r.lastTransit)
+		if d < 0 {
+			d = -d
+		}
+		r.jitter += (d - r.jitter) / 16
+	}
+	r.lastTransit = transit
+	r.last = now
+	r.received++
+	r.bytes += uint64(size)
+}
+
+func (r *receiver) isSeen(seq uint32) bool {
+	i := seq % windowSize
+	return r.seen[i/64]&(1<<(i%64)) != 0
+}
+
+func (r *receiver) markSeen(seq uint32) {
+	i := seq % windowSize
+	r.seen[i/64] |= 1 << (i % 64)
+}
+
+// advance moves the window up to seq. The bits of the sequence numbers that
+// drop out of the window are cleared.
+func (r *receiver) advance(seq uint32) {
+	if seq-r.maxSeq >= windowSize {
+		r.seen = [windowSize / 64]uint64{}
+	} else {
+		for s := r.maxSeq + 1; s != seq+1; s++ {
+			i := s % windowSize
+			r.seen[i/64] &^= 1 << (i % 64)
+		}
+	}
+	r.maxSeq = seq
+}
+
+// report returns the statistics given that the client sent sent data
+// packets.
+func (r *receiver) report(sent uint32) ServerReport {
+	rep := ServerReport{
+		Received:   r.received,
+		Duplicates: r.duplicates,
+		Reordered:  r.reordered,
+		Late:       r.late,
+		Bytes:      r.bytes,
+		Jitter:     r.jitter / float64(time.Millisecond),
+	}
+	if uint64(sent) > r.received {
+		rep.Lost = uint64(sent) - r.received
+	}
+	if sent > 0 {
+		rep.LossRate = float64(rep.Lost) / float64(sent)
+	}
+	if d := r.last.Sub(r.first); d > 0 {
+		rep.Duration = d.Seconds()
+		rep.Throughput = float64(r.bytes*8) / d.Seconds()
+	}
+	return rep
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeader(t *testing.T) {
+	h := header{Type: pktFin, Session: 42, Seq: 1000, Timestamp: time.Unix(0, 1234567890)}
+	b := make([]byte, hdrLen)
+	h.write(b)
+	parsed, err := parseHeader(b)
+	require.NoError(t, err)
+	assert.Equal(t, h.Type, parsed.Type)
+	assert.Equal(t, h.Session, parsed.Session)
+	assert.Equal(t, h.Seq, parsed.Seq)
+	assert.True(t, h.Timestamp.Equal(parsed.Timestamp))
+
+	_, err = parseHeader(b[:hdrLen-1])
+	assert.Error(t, err, "short packet")
+	b[0] = 0
+	_, err = parseHeader(b)
+	assert.Error(t, err, "unknown type")
+}
+
+func TestReceiver(t *testing.T) {
+	start := time.Now()
+	sendAt := func(seq uint32) header {
+		return header{Type: pktData, Seq: seq,
+			Timestamp: start.Add(time.Duration(seq) * time.Millisecond)}
+	}
+	var r receiver
+	// Packets 0, 1, 3, 2, 3 arrive with a constant transit time of 10ms,
+	// packet 4 is lost.
+	for _, seq := range []uint32{0, 1, 3, 2, 3} {
+		r.add(sendAt(seq), 100, sendAt(seq).Timestamp.Add(10*time.Millisecond))
+	}
+	rep := r.report(5)
+	assert.Equal(t, uint64(4), rep.Received)
+	assert.Equal(t, uint64(1), rep.Duplicates)
+	assert.Equal(t, uint64(1), rep.Reordered)
+	assert.Equal(t, uint64(1), rep.Lost)
+	assert.Equal(t, 0.2, rep.LossRate)
+	assert.Equal(t, uint64(400), rep.Bytes)
+	assert.Equal(t, 0.0, rep.Jitter)
+	// The last unique packet (seq 2) arrived 2ms after the first one.
+	assert.InDelta(t, 0.002, rep.Duration, 1e-9)
+	assert.InDelta(t, 400*8/0.002, rep.Throughput, 1e-3)
+}
+
+func TestReceiverJitter(t *testing.T) {
+	start := time.Now()
+	var r receiver
+	r.add(header{Type: pktData, Seq: 0, Timestamp: start}, 100, start)
+	r.add(header{Type: pktData, Seq: 1, Timestamp: start}, 100, start.Add(16*time.Millisecond))
+	assert.Equal(t, 1.0, r.report(2).Jitter)
+}
+
+func TestReceiverWindow(t *testing.T) {
+	start := time.Now()
+	data := func(seq uint32) header {
+		return header{Type: pktData, Seq: seq, Timestamp: start}
+	}
+	var r receiver
+	// Sequence numbers far above the highest received one are ignored.
+	r.add(data(0xFFFFFFFF), 100, start)
+	r.add(data(windowSize), 100, start)
+	assert.Equal(t, ServerReport{}, r.report(0))
+
+	r.add(data(0), 100, start)
+	r.add(data(1), 100, start)
+	r.add(data(windowSize-1), 100, start)
+	r.add(data(2*windowSize-2), 100, start)
+	// Packet 0 dropped out of the window.
+	r.add(data(0), 100, start)
+	// Packet windowSize-1 is still in the window.
+	r.add(data(windowSize-1), 100, start)
+	// Packet windowSize+1 uses the bit of packet 1, which was cleared when
+	// the window moved past packet 1.
+	r.add(data(windowSize+1), 100, start)
+	rep := r.report(2*windowSize - 1)
+	assert.Equal(t, uint64(5), rep.Received)
+	assert.Equal(t, uint64(1), rep.Late)
+	assert.Equal(t, uint64(1), rep.Duplicates)
+	assert.Equal(t, uint64(1), rep.Reordered)
+	assert.Equal(t, uint64(2*windowSize-6), rep.Lost)
+}
+
+func TestServerSessionLimit(t *testing.T) {
+	now := time.Now()
+	s := &server{sessions: make(map[sessionKey]*session)}
+	for i := 0; i < maxSessions; i++ {
+		require.NotNil(t, s.session(sessionKey{src: "client", id: uint32(i)}, now))
+	}
+	assert.Nil(t, s.session(sessionKey{src: "client", id: maxSessions}, now))
+	assert.NotNil(t, s.session(sessionKey{src: "client", id: 0}, now))
+
+	s.expire(now.Add(2 * sessionTimeout))
+	assert.NotNil(t, s.session(sessionKey{src: "client", id: maxSessions}, now))
+}
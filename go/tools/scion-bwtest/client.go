@@ -0,0 +1,206 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathpol"
+	sd "github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// finInterval is the interval in which the client repeats the fin packet
+// until it receives the report of the server.
+const finInterval = 200 * time.Millisecond
+
+// Report is the result of a test run as printed by the client.
+type Report struct {
+	// Remote is the address of the server.
+	Remote string `json:"remote"`
+	// Path is the path the test packets were sent on. It is empty if the
+	// server is in the local AS.
+	Path string `json:"path,omitempty"`
+	// PacketSize is the payload size of the test packets in bytes.
+	PacketSize int `json:"packet_size"`
+	// TargetRate is the configured send rate in packets per second.
+	TargetRate int `json:"target_rate_pps"`
+	// Duration is the time the client spent sending in seconds.
+	Duration float64 `json:"duration_s"`
+	// Sent is the number of data packets sent.
+	Sent uint32 `json:"sent"`
+	// SendThroughput is the sent payload bandwidth in bits per second.
+	SendThroughput float64 `json:"send_throughput_bps"`
+	// Server contains the statistics measured by the server.
+	Server *ServerReport `json:"server,omitempty"`
+}
+
+type client struct {
+	network *snet.SCIONNetwork
+	sdConn  sd.Connector
+}
+
+func (c client) run() (*Report, error) {
+	report := &Report{
+		Remote:     remote.String(),
+		PacketSize: *size,
+		TargetRate: *rate,
+	}
+	if !remote.IA.Equal(local.IA) {
+		path, err := c.choosePath()
+		if err != nil {
+			return nil, err
+		}
+		remote.Path = path.Path()
+		remote.NextHop = path.OverlayNextHop()
+		report.Path = fmt.Sprintf("%s", path)
+		log.Info("Using path", "path", path)
+	}
+	conn, err := c.network.ListenSCION("udp4", &local, 0)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to listen", err)
+	}
+	defer conn.Close()
+	session := rand.New(rand.NewSource(time.Now().UnixNano())).Uint32()
+	log.Info("Starting test", "remote", remote.String(), "session", session,
+		"size", *size, "rate", *rate, "duration", *duration)
+	sent, elapsed, err := send(conn, session)
+	if err != nil {
+		return nil, err
+	}
+	report.Sent = sent
+	report.Duration = elapsed.Seconds()
+	if elapsed > 0 {
+		report.SendThroughput = float64(uint64(sent)*uint64(*size)*8) / elapsed.Seconds()
+	}
+	if report.Server, err = finish(conn, session, sent); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// choosePath returns the first path to the remote AS that satisfies the path
+// policy.
+func (c client) choosePath() (snet.Path, error) {
+	var policy *pathpol.Policy
+	if *policyFile != "" {
+		var err error
+		if policy, err = pathpol.PolicyFromFile(*policyFile); err != nil {
+			return nil, serrors.WrapStr("unable to load path policy", err,
+				"file", *policyFile)
+		}
+	}
+	ctx, cancelF := context.WithTimeout(context.Background(), *timeout)
+	defer cancelF()
+	paths, err := c.sdConn.PathsWithFilter(ctx, remote.IA, local.IA, 0,
+		sd.PathReqFlags{}, &sd.PathReqFilter{Policy: policy})
+	if err != nil {
+		return nil, serrors.WrapStr("unable to retrieve paths", err)
+	}
+	if len(paths) == 0 {
+		return nil, serrors.New("no path found", "remote", remote.IA)
+	}
+	return paths[0], nil
+}
+
+// send sends data packets at the configured rate for the configured duration.
+// It returns the number of packets sent and the time spent sending.
+func send(conn snet.Conn, session uint32) (uint32, time.Duration, error) {
+	buf := make([]byte, *size)
+	start := time.Now()
+	var seq uint32
+	for {
+		elapsed := time.Since(start)
+		if elapsed >= *duration {
+			return seq, elapsed, nil
+		}
+		// Send all packets that are due, this keeps the average rate even if
+		// the sleep below overshoots.
+		due := uint32(elapsed.Seconds()*float64(*rate)) + 1
+		for ; seq < due; seq++ {
+			h := header{Type: pktData, Session: session, Seq: seq, Timestamp: time.Now()}
+			h.write(buf)
+			if _, err := conn.WriteToSCION(buf, &remote); err != nil {
+				return seq, time.Since(start), serrors.WrapStr("unable to send", err)
+			}
+		}
+		next := time.Duration(float64(seq) / float64(*rate) * float64(time.Second))
+		time.Sleep(time.Until(start.Add(next)))
+	}
+}
+
+// finish repeats the fin packet until the server replies with its report or
+// the timeout expires.
+func finish(conn snet.Conn, session, sent uint32) (*ServerReport, error) {
+	fin := make([]byte, hdrLen)
+	buf := make([]byte, common.MaxMTU)
+	deadline := time.Now().Add(*timeout)
+	for time.Now().Before(deadline) {
+		h := header{Type: pktFin, Session: session, Seq: sent, Timestamp: time.Now()}
+		h.write(fin)
+		if _, err := conn.WriteToSCION(fin, &remote); err != nil {
+			return nil, serrors.WrapStr("unable to send fin", err)
+		}
+		wait := time.Now().Add(finInterval)
+		if wait.After(deadline) {
+			wait = deadline
+		}
+		if err := conn.SetReadDeadline(wait); err != nil {
+			return nil, err
+		}
+		report, err := readReport(conn, buf, session)
+		if err != nil {
+			return nil, err
+		}
+		if report != nil {
+			return report, nil
+		}
+	}
+	return nil, serrors.New("no report received", "timeout", *timeout)
+}
+
+// readReport reads packets until it receives the report for the session. It
+// returns nil and no error if the read deadline expires.
+func readReport(conn snet.Conn, buf []byte, session uint32) (*ServerReport, error) {
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			if common.IsTimeoutErr(err) {
+				return nil, nil
+			}
+			if _, ok := err.(*snet.OpError); ok {
+				log.Debug("SCMP error while waiting for report", "err", err)
+				continue
+			}
+			return nil, serrors.WrapStr("unable to read report", err)
+		}
+		h, err := parseHeader(buf[:n])
+		if err != nil || h.Type != pktReport || h.Session != session {
+			continue
+		}
+		var report ServerReport
+		if err := json.Unmarshal(buf[hdrLen:n], &report); err != nil {
+			return nil, serrors.WrapStr("unable to parse report", err)
+		}
+		return &report, nil
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Bandwidth test application for SCION. The client sends test packets at a
+// configurable size and rate over a chosen path, the server measures the
+// one-way throughput, loss, jitter and reordering and reports them back to the
+// client, which prints a JSON report.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/log"
+	sd "github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/sock/reliable"
+)
+
+const (
+	ModeServer = "server"
+	ModeClient = "client"
+
+	DefaultDuration   = 10 * time.Second
+	DefaultTimeout    = 2 * time.Second
+	DefaultPacketSize = 1000
+	DefaultRate       = 1000
+)
+
+var (
+	local, remote snet.Addr
+
+	dispatcher   = flag.String("dispatcher", "", "Path to dispatcher socket")
+	duration     = flag.Duration("duration", DefaultDuration, "Duration of the test (client)")
+	mode         = flag.String("mode", ModeClient, "Run in "+ModeClient+" or "+ModeServer+" mode")
+	out          = flag.String("out", "", "Write the JSON report to this file instead of stdout")
+	policyFile   = flag.String("policy", "", "Path to a JSON path policy file to choose the path")
+	rate         = flag.Int("rate", DefaultRate, "Packets sent per second (client)")
+	sciond       = flag.String("sciond", "", "Path to sciond socket")
+	sciondFromIA = flag.Bool("sciondFromIA", false, "SCIOND socket path from IA address:ISD-AS")
+	size         = flag.Int("size", DefaultPacketSize, "Payload size of the test packets in bytes")
+	timeout      = flag.Duration("timeout", DefaultTimeout, "Timeout for the server report")
+	version      = flag.Bool("version", false, "Output version information and exit.")
+)
+
+func init() {
+	flag.Var((*snet.Addr)(&local), "local", "(Mandatory) address to listen on")
+	flag.Var((*snet.Addr)(&remote), "remote", "(Mandatory for clients) address to connect to")
+}
+
+func main() {
+	os.Setenv("TZ", "UTC")
+	log.AddLogConsFlags()
+	validateFlags()
+	if err := log.SetupFromFlags(""); err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %s", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+	defer log.LogPanicAndExit()
+	network, sdConn := initNetwork()
+	switch *mode {
+	case ModeClient:
+		report, err := client{network: network, sdConn: sdConn}.run()
+		if err != nil {
+			LogFatal("Bandwidth test failed", "err", err)
+		}
+		if err := writeReport(report); err != nil {
+			LogFatal("Unable to write report", "err", err)
+		}
+	case ModeServer:
+		if err := (&server{network: network}).run(); err != nil {
+			LogFatal("Server failed", "err", err)
+		}
+	}
+}
+
+func validateFlags() {
+	flag.Parse()
+	if *version {
+		fmt.Print(env.VersionInfo())
+		os.Exit(0)
+	}
+	if *mode != ModeClient && *mode != ModeServer {
+		LogFatal("Unknown mode, must be either '" + ModeClient + "' or '" + ModeServer + "'")
+	}
+	if *mode == ModeClient {
+		if remote.Host == nil {
+			LogFatal("Missing remote address")
+		}
+		if remote.Host.L4 == 0 {
+			LogFatal("Invalid remote port", "remote port", remote.Host.L4)
+		}
+		if *size < hdrLen {
+			LogFatal("Packet size too small", "min", hdrLen, "actual", *size)
+		}
+		if *rate <= 0 {
+			LogFatal("Rate must be positive", "actual", *rate)
+		}
+		if *duration <= 0 {
+			LogFatal("Duration must be positive", "actual", *duration)
+		}
+	}
+	if local.Host == nil {
+		LogFatal("Missing local address")
+	}
+	if *sciondFromIA {
+		if *sciond != "" {
+			LogFatal("Only one of -sciond or -sciondFromIA can be specified")
+		}
+		if local.IA.IsZero() {
+			LogFatal("-local flag is missing")
+		}
+		*sciond = sd.GetDefaultSCIONDPath(&local.IA)
+	} else if *sciond == "" {
+		*sciond = sd.GetDefaultSCIONDPath(nil)
+	}
+}
+
+func LogFatal(msg string, a ...interface{}) {
+	log.Crit(msg, a...)
+	os.Exit(1)
+}
+
+func initNetwork() (*snet.SCIONNetwork, sd.Connector) {
+	ds := reliable.NewDispatcherService(*dispatcher)
+	sciondConn, err := sd.NewService(*sciond).Connect(context.Background())
+	if err != nil {
+		LogFatal("Unable to initialize SCION network", "err", err)
+	}
+	network := snet.NewNetworkWithPR(local.IA, ds, sd.Querier{
+		Connector: sciondConn,
+		IA:        local.IA,
+	}, sd.RevHandler{Connector: sciondConn})
+	return network, sciondConn
+}
+
+func writeReport(report *Report) error {
+	raw, err := json.MarshalIndent(report, "", "    ")
+	if err != nil {
+		return err
+	}
+	raw = append(raw, '\n')
+	if *out == "" {
+		_, err := os.Stdout.Write(raw)
+		return err
+	}
+	return ioutil.WriteFile(*out, raw, 0644)
+}
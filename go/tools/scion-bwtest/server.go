@@ -0,0 +1,144 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// sessionTimeout is the time after which the server forgets a test run from
+// which it received no packets.
+const sessionTimeout = time.Minute
+
+// maxSessions is the maximum number of concurrent test runs. Packets of new
+// test runs are ignored while the server has maxSessions sessions.
+const maxSessions = 64
+
+type sessionKey struct {
+	src string
+	id  uint32
+}
+
+type session struct {
+	receiver receiver
+	lastSeen time.Time
+}
+
+type server struct {
+	network    *snet.SCIONNetwork
+	sessions   map[sessionKey]*session
+	lastExpiry time.Time
+}
+
+func (s *server) run() error {
+	conn, err := s.network.ListenSCION("udp4", &local, 0)
+	if err != nil {
+		return serrors.WrapStr("unable to listen", err)
+	}
+	defer conn.Close()
+	log.Info("Listening", "local", conn.LocalAddr())
+	s.sessions = make(map[sessionKey]*session)
+	buf := make([]byte, common.MaxMTU)
+	for {
+		n, from, err := conn.ReadFromSCION(buf)
+		if err != nil {
+			if _, ok := err.(*snet.OpError); ok {
+				log.Debug("SCMP error", "err", err)
+				continue
+			}
+			return serrors.WrapStr("unable to read", err)
+		}
+		now := time.Now()
+		s.handle(conn, buf[:n], from, now)
+		s.expire(now)
+	}
+}
+
+func (s *server) handle(conn snet.Conn, pkt []byte, from *snet.Addr, now time.Time) {
+	h, err := parseHeader(pkt)
+	if err != nil {
+		log.Debug("Ignoring invalid packet", "src", from, "err", err)
+		return
+	}
+	key := sessionKey{src: from.String(), id: h.Session}
+	if h.Type == pktReport {
+		return
+	}
+	sess := s.session(key, now)
+	if sess == nil {
+		log.Debug("Ignoring packet, too many sessions", "src", from, "session", h.Session)
+		return
+	}
+	switch h.Type {
+	case pktData:
+		sess.receiver.add(h, len(pkt), now)
+	case pktFin:
+		report := sess.receiver.report(h.Seq)
+		if err := sendReport(conn, from, h.Session, report); err != nil {
+			log.Info("Unable to send report", "dst", from, "err", err)
+			return
+		}
+		log.Info("Test finished", "src", from, "session", h.Session, "sent", h.Seq,
+			"received", report.Received, "lost", report.Lost)
+	}
+}
+
+// session returns the session for key. A new session is created if the key is
+// unknown. It returns nil if the maximum number of sessions is reached.
+func (s *server) session(key sessionKey, now time.Time) *session {
+	sess, ok := s.sessions[key]
+	if !ok {
+		if len(s.sessions) >= maxSessions {
+			return nil
+		}
+		log.Info("Test started", "src", key.src, "session", key.id)
+		sess = &session{}
+		s.sessions[key] = sess
+	}
+	sess.lastSeen = now
+	return sess
+}
+
+// expire removes the sessions that were idle for longer than the session
+// timeout.
+func (s *server) expire(now time.Time) {
+	if now.Sub(s.lastExpiry) < sessionTimeout {
+		return
+	}
+	s.lastExpiry = now
+	for key, sess := range s.sessions {
+		if now.Sub(sess.lastSeen) > sessionTimeout {
+			delete(s.sessions, key)
+		}
+	}
+}
+
+func sendReport(conn snet.Conn, dst *snet.Addr, id uint32, report ServerReport) error {
+	raw, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	pkt := make([]byte, hdrLen+len(raw))
+	header{Type: pktReport, Session: id, Timestamp: time.Now()}.write(pkt)
+	copy(pkt[hdrLen:], raw)
+	_, err = conn.WriteToSCION(pkt, dst)
+	return err
+}
@@ -41,6 +41,7 @@ import (
 const (
 	DefaultInterval = 1 * time.Second
 	DefaultTimeout  = 2 * time.Second
+	DefaultProbes   = 3
 	MaxEchoes       = 1 << 16
 )
 
@@ -56,6 +57,8 @@ var (
 	Count       uint
 	Interactive bool
 	Interval    time.Duration
+	JSON        bool
+	Probes      uint
 	Timeout     time.Duration
 	Local       snet.Addr
 	Remote      snet.Addr
@@ -76,6 +79,8 @@ func init() {
 	flag.DurationVar(&Interval, "interval", DefaultInterval, "time between packets (echo only)")
 	flag.DurationVar(&Timeout, "timeout", DefaultTimeout, "timeout per packet")
 	flag.UintVar(&Count, "c", 0, "Total number of packet to send (echo only). Maximum value 65535")
+	flag.UintVar(&Probes, "probes", DefaultProbes, "number of probes per hop (traceroute only)")
+	flag.BoolVar(&JSON, "json", false, "output the results as JSON (traceroute only)")
 	flag.Var((*snet.Addr)(&Local), "local", "(Mandatory) address to listen on")
 	flag.Var((*snet.Addr)(&Remote), "remote", "(Mandatory for clients) address to connect to")
 	flag.Var((*snet.Addr)(&Bind), "bind", "address to bind to, if running behind NAT")
@@ -139,6 +144,9 @@ func ValidateFlags() {
 	if Count > uint(zero-1) {
 		Fatal("Maximum count value is %d", zero-1)
 	}
+	if Probes == 0 {
+		Fatal("Number of probes must be positive")
+	}
 }
 
 func NewSCMPPkt(t scmp.Type, info scmp.Info, ext common.Extension) *spkt.ScnPkt {
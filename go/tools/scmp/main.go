@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/sciond"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
@@ -40,6 +41,7 @@ var (
 	dispatcher   = flag.String("dispatcher", reliable.DefaultDispPath, "Path to dispatcher socket")
 	sciondFromIA = flag.Bool("sciondFromIA", false, "SCIOND socket path from IA address:ISD-AS")
 	refresh      = flag.Bool("refresh", false, "Set refresh flag for SCIOND path request")
+	policyFile   = flag.String("policy", "", "Path to a JSON path policy file to filter paths")
 	sdConn       sciond.Connector
 	version      = flag.Bool("version", false, "Output version information and exit.")
 )
@@ -90,7 +92,9 @@ func main() {
 	} else {
 		cmn.Mtu = setLocalMtu()
 	}
-	fmt.Printf("Using path:\n  %s\n", pathStr)
+	if !cmn.JSON {
+		fmt.Printf("Using path:\n  %s\n", pathStr)
+	}
 
 	ret := doCommand(cmd)
 	os.Exit(ret)
@@ -126,6 +130,16 @@ func choosePath() snet.Path {
 	if len(paths) == 0 {
 		cmn.Fatal("No paths available to remote destination")
 	}
+	if *policyFile != "" {
+		policy, err := pathpol.PolicyFromFile(*policyFile)
+		if err != nil {
+			cmn.Fatal("Failed to load path policy: %v\n", err)
+		}
+		paths = snet.FilterPaths(paths, policy)
+		if len(paths) == 0 {
+			cmn.Fatal("No paths to remote destination match the path policy")
+		}
+	}
 	if cmn.Interactive {
 		fmt.Printf("Available paths to %v\n", cmn.Remote.IA)
 		for i := range paths {
@@ -141,7 +155,7 @@ func choosePath() snet.Path {
 				break
 			}
 			fmt.Fprintf(os.Stderr, "ERROR: Invalid path index, valid indices range: [0, %v]\n",
-				len(paths))
+				len(paths)-1)
 		}
 	}
 	return paths[pathIndex]
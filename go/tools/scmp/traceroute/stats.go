@@ -0,0 +1,168 @@
+// Copyright 2019 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traceroute
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+// HopStats contains the probe results for a single hop, i.e., a single border
+// router interface or the destination host.
+type HopStats struct {
+	// Index is the 1-based position of the hop on the path.
+	Index int
+	// IA is the AS of the replying host. It is the zero value if no probe was
+	// answered.
+	IA addr.IA
+	// Host is the address of the replying host.
+	Host addr.HostAddr
+	// IfID is the interface that replied. It is zero for the destination.
+	IfID common.IFIDType
+	// Sent is the number of probes sent to this hop.
+	Sent uint
+	// RTTs contains the round trip times of the answered probes.
+	RTTs []time.Duration
+}
+
+// Add records the reply to a probe.
+func (s *HopStats) Add(ia addr.IA, host addr.HostAddr, ifID common.IFIDType,
+	rtt time.Duration) {
+
+	s.IA, s.Host, s.IfID = ia, host, ifID
+	s.RTTs = append(s.RTTs, rtt)
+}
+
+// Loss returns the fraction of unanswered probes.
+func (s *HopStats) Loss() float64 {
+	if s.Sent == 0 {
+		return 0
+	}
+	return float64(s.Sent-uint(len(s.RTTs))) / float64(s.Sent)
+}
+
+// RTT returns the minimum, average, maximum and (population) standard
+// deviation of the round trip times. All values are zero if no probe was
+// answered.
+func (s *HopStats) RTT() (min, avg, max, stddev time.Duration) {
+	if len(s.RTTs) == 0 {
+		return 0, 0, 0, 0
+	}
+	min, max = s.RTTs[0], s.RTTs[0]
+	var sum float64
+	for _, rtt := range s.RTTs {
+		if rtt < min {
+			min = rtt
+		}
+		if rtt > max {
+			max = rtt
+		}
+		sum += float64(rtt)
+	}
+	mean := sum / float64(len(s.RTTs))
+	var sqDiff float64
+	for _, rtt := range s.RTTs {
+		sqDiff += (float64(rtt) - mean) * (float64(rtt) - mean)
+	}
+	stddev = time.Duration(math.Sqrt(sqDiff / float64(len(s.RTTs))))
+	return min, time.Duration(mean), max, stddev
+}
+
+// String returns a single line summary of the hop.
+func (s *HopStats) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d ", s.Index)
+	switch {
+	case len(s.RTTs) == 0:
+		fmt.Fprintf(&b, " *")
+	case s.IfID == 0:
+		fmt.Fprintf(&b, " %s,[%s]", s.IA, s.Host)
+	default:
+		fmt.Fprintf(&b, " %s,[%s] IfID=%d", s.IA, s.Host, s.IfID)
+	}
+	min, avg, max, stddev := s.RTT()
+	fmt.Fprintf(&b, "  rtt min/avg/max/mdev = %s/%s/%s/%s", min.Round(time.Microsecond),
+		avg.Round(time.Microsecond), max.Round(time.Microsecond),
+		stddev.Round(time.Microsecond))
+	fmt.Fprintf(&b, "  loss = %.0f%% (%d/%d)", s.Loss()*100, len(s.RTTs), s.Sent)
+	return b.String()
+}
+
+// Result is the result of a traceroute run.
+type Result struct {
+	// Path is the description of the path that was traced. It is empty for
+	// AS-local destinations.
+	Path string
+	// Hops contains the statistics for every probed hop in path order.
+	Hops []*HopStats
+}
+
+type jsonHop struct {
+	Hop      int     `json:"hop"`
+	IA       string  `json:"isd_as,omitempty"`
+	Host     string  `json:"host,omitempty"`
+	IfID     uint64  `json:"ifid,omitempty"`
+	Sent     uint    `json:"sent"`
+	Received int     `json:"received"`
+	Loss     float64 `json:"loss"`
+	MinMs    float64 `json:"rtt_min_ms"`
+	AvgMs    float64 `json:"rtt_avg_ms"`
+	MaxMs    float64 `json:"rtt_max_ms"`
+	StdDevMs float64 `json:"rtt_stddev_ms"`
+}
+
+type jsonResult struct {
+	Path string    `json:"path,omitempty"`
+	Hops []jsonHop `json:"hops"`
+}
+
+// WriteJSON writes the result as JSON object to w. Round trip times are
+// reported in milliseconds.
+func (r *Result) WriteJSON(w io.Writer) error {
+	res := jsonResult{Path: r.Path, Hops: make([]jsonHop, 0, len(r.Hops))}
+	for _, s := range r.Hops {
+		min, avg, max, stddev := s.RTT()
+		hop := jsonHop{
+			Hop:      s.Index,
+			IfID:     uint64(s.IfID),
+			Sent:     s.Sent,
+			Received: len(s.RTTs),
+			Loss:     s.Loss(),
+			MinMs:    ms(min),
+			AvgMs:    ms(avg),
+			MaxMs:    ms(max),
+			StdDevMs: ms(stddev),
+		}
+		if len(s.RTTs) != 0 {
+			hop.IA = s.IA.String()
+			hop.Host = s.Host.String()
+		}
+		res.Hops = append(res.Hops, hop)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	return enc.Encode(res)
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
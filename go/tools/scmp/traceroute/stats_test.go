@@ -0,0 +1,89 @@
+// Copyright 2019 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traceroute
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestHopStats(t *testing.T) {
+	t.Run("no replies", func(t *testing.T) {
+		s := &HopStats{Index: 1, Sent: 3}
+		min, avg, max, stddev := s.RTT()
+		assert.Zero(t, min)
+		assert.Zero(t, avg)
+		assert.Zero(t, max)
+		assert.Zero(t, stddev)
+		assert.Equal(t, 1.0, s.Loss())
+	})
+	t.Run("replies", func(t *testing.T) {
+		s := &HopStats{Index: 1, Sent: 5}
+		ia := xtest.MustParseIA("1-ff00:0:110")
+		host := addr.HostFromIP(net.IPv4(127, 0, 0, 1))
+		for _, ms := range []time.Duration{2, 4, 4, 6} {
+			s.Add(ia, host, 41, ms*time.Millisecond)
+		}
+		min, avg, max, stddev := s.RTT()
+		assert.Equal(t, 2*time.Millisecond, min)
+		assert.Equal(t, 4*time.Millisecond, avg)
+		assert.Equal(t, 6*time.Millisecond, max)
+		assert.InDelta(t, float64(1414213*time.Nanosecond), float64(stddev), 1000)
+		assert.InDelta(t, 0.2, s.Loss(), 1e-9)
+	})
+}
+
+func TestResultWriteJSON(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	host := addr.HostFromIP(net.IPv4(127, 0, 0, 1))
+	hop := &HopStats{Index: 1, Sent: 2}
+	hop.Add(ia, host, 41, 2*time.Millisecond)
+	res := &Result{
+		Path: "path",
+		Hops: []*HopStats{hop, {Index: 2, Sent: 2}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, res.WriteJSON(&buf))
+	var decoded jsonResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	expected := jsonResult{
+		Path: "path",
+		Hops: []jsonHop{
+			{
+				Hop:      1,
+				IA:       "1-ff00:0:110",
+				Host:     "127.0.0.1",
+				IfID:     41,
+				Sent:     2,
+				Received: 1,
+				Loss:     0.5,
+				MinMs:    2,
+				AvgMs:    2,
+				MaxMs:    2,
+			},
+			{Hop: 2, Sent: 2, Loss: 1},
+		},
+	}
+	assert.Equal(t, expected, decoded)
+}
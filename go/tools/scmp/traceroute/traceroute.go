@@ -17,6 +17,7 @@ package traceroute
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"time"
 
@@ -30,8 +31,6 @@ import (
 	"github.com/scionproto/scion/go/tools/scmp/cmn"
 )
 
-const pkts_per_hop uint = 3
-
 var (
 	id uint64
 )
@@ -40,109 +39,111 @@ func Run() {
 	var hopOff uint8
 	var ext common.Extension
 	var path *spath.Path
-	var total uint = 1
+	var hops = 1
 
 	cmn.SetupSignals(nil)
+	res := &Result{}
 	if cmn.PathEntry != nil {
 		path = cmn.PathEntry.Path()
-		total += uint(len(cmn.PathEntry.Interfaces()))
+		hops += len(cmn.PathEntry.Interfaces())
 		hopOff = hopPktOff(path.HopOff)
 		ext = &layers.ExtnSCMP{Error: false, HopByHop: true}
+		res.Path = fmt.Sprintf("%s", cmn.PathEntry)
 	}
 	id = cmn.Rand()
 	info := &scmp.InfoTraceRoute{Id: id, HopOff: hopOff}
 	pkt := cmn.NewSCMPPkt(scmp.T_G_TraceRouteRequest, info, ext)
-	total *= pkts_per_hop
 	b := make(common.RawBytes, cmn.Mtu)
 	nhAddr := cmn.NextHopAddr()
-	for {
-		var now time.Time
-		var rtt time.Duration
-		var pktRecv *spkt.ScnPkt
-		var scmpHdr *scmp.Hdr
-		var infoRecv *scmp.InfoTraceRoute
+	err := trace(res, hops, pkt, info, path, b, nhAddr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+	}
+	if cmn.JSON {
+		if err := res.WriteJSON(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: Unable to write JSON: %v\n", err)
+		}
+	}
+}
 
-		ts := time.Now()
-		cmn.UpdatePktTS(pkt, ts)
-		// Serialize packet to internal buffer
-		pktLen, err := hpkt.WriteScnPkt(pkt, b)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "ERROR: Unable to serialize SCION packet %v\n", err)
-			break
+// trace sends cmn.Probes probes to each hop and appends the statistics to
+// res. It stops at the first error that is not caused by a lost probe.
+func trace(res *Result, hops int, pkt *spkt.ScnPkt, info *scmp.InfoTraceRoute,
+	path *spath.Path, b common.RawBytes, nhAddr net.Addr) error {
+
+	for i := 0; i < hops; i++ {
+		hop := &HopStats{Index: i + 1}
+		res.Hops = append(res.Hops, hop)
+		for j := uint(0); j < cmn.Probes; j++ {
+			if err := probe(hop, pkt, b, nhAddr); err != nil {
+				return err
+			}
 		}
-		// Send packet
-		written, err := cmn.Conn.WriteTo(b[:pktLen], nhAddr)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "ERROR: Unable to write %v\n", err)
-			break
-		} else if written != pktLen {
-			fmt.Fprintf(os.Stderr, "ERROR: Wrote incomplete message. written=%d, expected=%d\n",
-				len(b), written)
-			break
+		if !cmn.JSON {
+			fmt.Println(hop)
+		}
+		if i < hops-1 {
+			updateHopField(pkt, info, path, i == hops-2)
 		}
-		cmn.Stats.Sent += 1
-		// Receive packet with timeout
-		cmn.Conn.SetReadDeadline(ts.Add(cmn.Timeout))
+	}
+	return nil
+}
+
+// probe sends a single probe and waits for the matching reply. Timeouts,
+// unparsable and invalid replies are recorded as loss.
+func probe(hop *HopStats, pkt *spkt.ScnPkt, b common.RawBytes, nhAddr net.Addr) error {
+	ts := time.Now()
+	cmn.UpdatePktTS(pkt, ts)
+	sentTs := pkt.L4.(*scmp.Hdr).Timestamp
+	// Serialize packet to internal buffer
+	pktLen, err := hpkt.WriteScnPkt(pkt, b)
+	if err != nil {
+		return common.NewBasicError("Unable to serialize SCION packet", err)
+	}
+	// Send packet
+	written, err := cmn.Conn.WriteTo(b[:pktLen], nhAddr)
+	if err != nil {
+		return common.NewBasicError("Unable to write", err)
+	} else if written != pktLen {
+		return common.NewBasicError("Wrote incomplete message", nil,
+			"written", written, "expected", pktLen)
+	}
+	cmn.Stats.Sent += 1
+	hop.Sent += 1
+	// Receive packet with timeout
+	cmn.Conn.SetReadDeadline(ts.Add(cmn.Timeout))
+	for {
 		pktLen, err = cmn.Conn.Read(b)
 		if err != nil {
 			if common.IsTimeoutErr(err) {
-				rtt = cmn.Timeout + 1
-				goto next
-			} else {
-				fmt.Fprintf(os.Stderr, "ERROR: Unable to read: %v\n", err)
-				break
+				return nil
 			}
+			return common.NewBasicError("Unable to read", err)
 		}
-		now = time.Now()
-		cmn.Stats.Recv += 1
+		now := time.Now()
 		// Parse packet
-		pktRecv = &spkt.ScnPkt{}
-		err = hpkt.ParseScnPkt(pktRecv, b[:pktLen])
-		if err != nil {
+		pktRecv := &spkt.ScnPkt{}
+		if err := hpkt.ParseScnPkt(pktRecv, b[:pktLen]); err != nil {
 			fmt.Fprintf(os.Stderr, "ERROR: SCION packet parse error: %v\n", err)
 			continue
 		}
 		// Validate packet
-		scmpHdr, infoRecv, err = validate(pktRecv, cmn.PathEntry)
+		scmpHdr, infoRecv, err := validate(pktRecv, cmn.PathEntry)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "ERROR: SCMP validation error: %v\n", err)
 			continue
 		}
-		// Calculate return time
-		rtt = now.Sub(scmpHdr.Time()).Round(time.Microsecond)
-	next:
-		prettyPrint(pktRecv, infoRecv, rtt)
-		// More packets?
-		if cmn.Stats.Sent == total {
-			break
+		if scmpHdr.Timestamp != sentTs {
+			// Late reply to a previous probe, it has already been counted as lost.
+			continue
 		}
-		updateHopField(pkt, info, path, total)
-	}
-}
-
-var hop_printed bool = false
-
-func prettyPrint(pkt *spkt.ScnPkt, info *scmp.InfoTraceRoute, rtt time.Duration) {
-	var str string
-	if (cmn.Stats.Sent-1)%pkts_per_hop == 0 {
-		fmt.Printf("%d ", cmn.Stats.Sent/pkts_per_hop)
-	}
-	if rtt > cmn.Timeout {
-		fmt.Printf(" *")
-	} else {
-		if !hop_printed {
-			hop_printed = true
-			if info.HopOff == 0 {
-				str = fmt.Sprintf("%s,[%s]  ", pkt.SrcIA, pkt.SrcHost)
-			} else {
-				str = fmt.Sprintf("%s,[%s] IfID=%d  ", pkt.SrcIA, pkt.SrcHost, info.IfID)
-			}
+		cmn.Stats.Recv += 1
+		var ifID common.IFIDType
+		if infoRecv.HopOff != 0 {
+			ifID = infoRecv.IfID
 		}
-		fmt.Printf(" %s%s", str, rtt)
-	}
-	if cmn.Stats.Sent%pkts_per_hop == 0 {
-		hop_printed = false
-		fmt.Println()
+		hop.Add(pktRecv.SrcIA, pktRecv.SrcHost, ifID, now.Sub(scmpHdr.Time()))
+		return nil
 	}
 }
 
@@ -152,12 +153,11 @@ func hopPktOff(offset int) uint8 {
 	return uint8(off / common.LineLen)
 }
 
-func updateHopField(pkt *spkt.ScnPkt, info *scmp.InfoTraceRoute, path *spath.Path, total uint) {
-	if cmn.Stats.Sent%pkts_per_hop != 0 {
-		return
-	}
+// updateHopField points the probe to the next hop. last indicates that the
+// next hop is the destination.
+func updateHopField(pkt *spkt.ScnPkt, info *scmp.InfoTraceRoute, path *spath.Path, last bool) {
 	info.HopOff = 0
-	if path != nil && cmn.Stats.Sent < total-pkts_per_hop {
+	if path != nil && !last {
 		if !info.In { // Egress
 			// Inc path
 			path.IncOffsets()
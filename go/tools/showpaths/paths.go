@@ -91,6 +91,9 @@ func main() {
 		if *status {
 			fmt.Printf(" Status: %s", pathStatuses[pathprobe.PathKey(path)])
 		}
+		if p, ok := path.(sciond.Path); ok && p.Quality() != nil {
+			fmt.Printf(" Quality: %s", p.Quality())
+		}
 		fmt.Printf("\n")
 	}
 }